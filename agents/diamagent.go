@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -57,6 +58,11 @@ func NewDiameterAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 		raa:     make(map[string]chan *diam.Message),
 		dpa:     make(map[string]chan *diam.Message),
 		peers:   make(map[string]diam.Conn),
+		ans:     make(map[diamAnsKey]chan *diam.Message),
+		relayed: make(map[uint32]*diamRelayedReq),
+
+		peerConns:   make([]diam.Conn, len(cgrCfg.DiameterAgentCfg().Peers)),
+		hopByHopIDs: make(map[diam.Conn]uint32),
 	}
 	srv, err := birpc.NewServiceWithMethodsRename(da, utils.AgentV1, true, func(oldFn string) (newFn string) {
 		return strings.TrimPrefix(oldFn, "V1")
@@ -101,6 +107,15 @@ type DiameterAgent struct {
 	peers    map[string]diam.Conn // peer index by OriginHost;OriginRealm
 	dpaLck   sync.RWMutex
 	dpa      map[string]chan *diam.Message
+	ansLck   sync.Mutex
+	ans      map[diamAnsKey]chan *diam.Message // answers awaited by SendRequest
+	relayLck sync.Mutex
+	relayed  map[uint32]*diamRelayedReq // relayed requests waiting for answer, indexed by the forwarded Hop-by-Hop-Id
+
+	peerConnsLck sync.RWMutex
+	peerConns    []diam.Conn // connections towards the configured peers, nil while disconnected
+	hopByHopLck  sync.Mutex
+	hopByHopIDs  map[diam.Conn]uint32 // last Hop-by-Hop-Id sent on each connection
 
 	ctx *context.Context
 }
@@ -114,16 +129,28 @@ func (da *DiameterAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 		Handler: da.handlers(),
 		Dict:    nil,
 	}
+	isTLS := srv.Network == utils.TLSNoCaps
+	if isTLS { // TLS over TCP (RFC 6733 section 13)
+		srv.Network = utils.TCP
+	}
 	// used to control the server state
 	var lsn net.Listener
 	if lsn, err = diam.MultistreamListen(utils.FirstNonEmpty(srv.Network, utils.TCP),
 		utils.FirstNonEmpty(srv.Addr, ":3868")); err != nil {
 		return
 	}
+	if isTLS {
+		lsn = tls.NewListener(lsn, &tls.Config{
+			GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+				return da.cgrCfg.TLSCfg().TLSConfig(true)
+			},
+		})
+	}
 	errChan := make(chan error)
 	go func() {
 		errChan <- srv.Serve(lsn)
 	}()
+	da.connectPeers(stopChan)
 	select {
 	case err = <-errChan:
 		return
@@ -134,13 +161,7 @@ func (da *DiameterAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 
 // Creates the message handlers
 func (da *DiameterAgent) handlers() diam.Handler {
-	settings := &sm.Settings{
-		OriginHost:       datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginHost),
-		OriginRealm:      datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginRealm),
-		VendorID:         datatype.Unsigned32(da.cgrCfg.DiameterAgentCfg().VendorID),
-		ProductName:      datatype.UTF8String(da.cgrCfg.DiameterAgentCfg().ProductName),
-		FirmwareRevision: datatype.Unsigned32(utils.DiameterFirmwareRevision),
-	}
+	settings := da.smSettings()
 	hosts := disectDiamListen(da.cgrCfg.DiameterAgentCfg().Listen)
 	if len(hosts) == 0 {
 		interfaces, err := net.Interfaces()
@@ -163,8 +184,41 @@ func (da *DiameterAgent) handlers() diam.Handler {
 	for i, host := range hosts {
 		settings.HostIPAddresses[i] = datatype.Address(host)
 	}
+	dSM := da.newStateMachine(settings)
+	go da.handleConns(dSM.HandshakeNotify())
+	// the CER is checked before reaching the state machine which does the capabilities exchange
+	return diam.HandlerFunc(func(c diam.Conn, m *diam.Message) {
+		if m.Header.CommandCode == diam.CapabilitiesExchange &&
+			m.Header.CommandFlags&diam.RequestFlag != 0 {
+			if err := da.checkPeer(c, m); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> rejecting peer <%s>: %v",
+					utils.DiameterAgent, c.RemoteAddr(), err))
+				cea := diamErrMsg(m, diam.UnknownPeer, err.Error())
+				cea.NewAVP(avp.OriginHost, avp.Mbit, 0, settings.OriginHost)
+				cea.NewAVP(avp.OriginRealm, avp.Mbit, 0, settings.OriginRealm)
+				writeOnConn(c, cea)
+				c.Close()
+				return
+			}
+		}
+		dSM.ServeDIAM(c, m)
+	})
+}
+
+// smSettings returns the identity of the agent used within the capabilities exchange
+func (da *DiameterAgent) smSettings() *sm.Settings {
+	return &sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginHost),
+		OriginRealm:      datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginRealm),
+		VendorID:         datatype.Unsigned32(da.cgrCfg.DiameterAgentCfg().VendorID),
+		ProductName:      datatype.UTF8String(da.cgrCfg.DiameterAgentCfg().ProductName),
+		FirmwareRevision: datatype.Unsigned32(utils.DiameterFirmwareRevision),
+	}
+}
 
-	dSM := sm.New(settings)
+// newStateMachine creates a state machine routing the messages towards the agent handlers
+func (da *DiameterAgent) newStateMachine(settings *sm.Settings) (dSM *sm.StateMachine) {
+	dSM = sm.New(settings)
 	if da.cgrCfg.DiameterAgentCfg().SyncedConnReqs {
 		dSM.HandleFunc(all, da.handleMessage)
 		dSM.HandleFunc(raa, da.handleRAA)
@@ -174,17 +228,75 @@ func (da *DiameterAgent) handlers() diam.Handler {
 		dSM.HandleFunc(raa, func(c diam.Conn, m *diam.Message) { go da.handleRAA(c, m) })
 		dSM.HandleFunc(dpa, func(c diam.Conn, m *diam.Message) { go da.handleDPA(c, m) })
 	}
-	go da.handleConns(dSM.HandshakeNotify())
 	go func() {
 		for err := range dSM.ErrorReports() {
 			utils.Logger.Err(fmt.Sprintf("<%s> sm error: %v", utils.DiameterAgent, err))
 		}
 	}()
-	return dSM
+	return
+}
+
+// checkPeer verifies the Origin-Host and Origin-Realm of the CER against the allowed peers
+// and, for the TLS connections, against the identity within the peer certificate
+func (da *DiameterAgent) checkPeer(c diam.Conn, m *diam.Message) (err error) {
+	var originHost, originRealm string
+	if originHost, err = diamIdentityAVP(m, avp.OriginHost); err != nil {
+		return
+	}
+	if originRealm, err = diamIdentityAVP(m, avp.OriginRealm); err != nil {
+		return
+	}
+	if !diamPeerAllowed(da.cgrCfg.DiameterAgentCfg().AllowedPeers, originHost, originRealm) {
+		return fmt.Errorf("peer %s not allowed",
+			utils.ConcatenatedKey(originHost, originRealm))
+	}
+	tlsState := c.TLS()
+	if tlsState == nil {
+		return
+	}
+	if len(tlsState.PeerCertificates) == 0 {
+		return fmt.Errorf("no certificate presented by %s", originHost)
+	}
+	if err = tlsState.PeerCertificates[0].VerifyHostname(originHost); err != nil {
+		return fmt.Errorf("Origin-Host not matching the certificate: %v", err)
+	}
+	return
+}
+
+// diamIdentityAVP returns the value of a DiameterIdentity AVP out of the message
+func diamIdentityAVP(m *diam.Message, code uint32) (string, error) {
+	a, err := m.FindAVP(code, 0)
+	if err != nil {
+		return utils.EmptyString, err
+	}
+	id, canCast := a.Data.(datatype.DiameterIdentity)
+	if !canCast {
+		return utils.EmptyString, fmt.Errorf("invalid DiameterIdentity AVP with code %d", code)
+	}
+	return string(id), nil
+}
+
+// diamPeerAllowed checks the peer against the list of allowed peers in the form <origin_host[:origin_realm]>
+func diamPeerAllowed(allowedPeers []string, originHost, originRealm string) bool {
+	if len(allowedPeers) == 0 {
+		return true
+	}
+	for _, peer := range allowedPeers {
+		host, realm, _ := strings.Cut(peer, utils.ConcatenatedKeySep)
+		if (host == utils.MetaAny || host == originHost) &&
+			(realm == utils.EmptyString || realm == utils.MetaAny || realm == originRealm) {
+			return true
+		}
+	}
+	return false
 }
 
 // handleALL is the handler of all messages coming in via Diameter
 func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 &&
+		(da.handleAnswer(c, m) || da.relayAnswer(m)) {
+		return
+	}
 	dApp, err := m.Dictionary().App(m.Header.ApplicationID)
 	if err != nil {
 		utils.Logger.Err(fmt.Sprintf("<%s> decoding app: %d, err: %s",
@@ -222,6 +334,11 @@ func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
 		defer da.caps.Deallocate()
 	}
 
+	if route := da.matchRoute(m); route != nil {
+		da.relayRequest(c, m, route, diamDP, reqVars)
+		return
+	}
+
 	// cache message for ASR
 	if da.cgrCfg.DiameterAgentCfg().ASRTemplate != "" ||
 		da.cgrCfg.DiameterAgentCfg().RARTemplate != "" {
@@ -244,27 +361,32 @@ func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
 
 	cgrRplyNM := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{}}
 	opts := utils.MapStorage{}
-	rply := utils.NewOrderedNavigableMap() // share it among different processors
+	rply := utils.NewOrderedNavigableMap()         // share it among different processors
+	extraDP := make(map[string]utils.DataProvider) // the *peer_answer is available to the next processors
 	var processed bool
 	for _, reqProcessor := range da.cgrCfg.DiameterAgentCfg().RequestProcessors {
+		agReq := NewAgentRequest(
+			diamDP, reqVars, cgrRplyNM, rply,
+			opts, reqProcessor.Tenant,
+			da.cgrCfg.GeneralCfg().DefaultTenant,
+			utils.FirstNonEmpty(
+				reqProcessor.Timezone,
+				da.cgrCfg.GeneralCfg().DefaultTimezone,
+			),
+			da.filterS, extraDP)
 		var lclProcessed bool
 		lclProcessed, err = processRequest(
 			da.ctx,
 			reqProcessor,
-			NewAgentRequest(
-				diamDP, reqVars, cgrRplyNM, rply,
-				opts, reqProcessor.Tenant,
-				da.cgrCfg.GeneralCfg().DefaultTenant,
-				utils.FirstNonEmpty(
-					reqProcessor.Timezone,
-					da.cgrCfg.GeneralCfg().DefaultTimezone,
-				),
-				da.filterS, nil),
+			agReq,
 			utils.DiameterAgent, da.connMgr,
 			da.cgrCfg.DiameterAgentCfg().SessionSConns,
 			da.filterS)
 		if lclProcessed {
 			processed = lclProcessed
+			if err == nil && reqProcessor.Flags.Has(utils.MetaPeerRequest) {
+				err = da.sendPeerRequest(reqProcessor, m, agReq)
+			}
 		}
 		if err != nil ||
 			(lclProcessed && !reqProcessor.Flags.GetBool(utils.MetaContinue)) {
@@ -297,6 +419,37 @@ func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
 	writeOnConn(c, a)
 }
 
+// sendPeerRequest originates the request built out of the *peer_request template of the processor
+// towards the configured peers, the answer being available to the next processors as *peer_answer
+func (da *DiameterAgent) sendPeerRequest(reqProcessor *config.RequestProcessor,
+	m *diam.Message, agReq *AgentRequest) (err error) {
+	tplID := reqProcessor.Flags.ParamValue(utils.MetaPeerRequest)
+	tpl, has := da.cgrCfg.TemplatesCfg()[tplID]
+	if !has {
+		return fmt.Errorf("%w: template %s", utils.ErrNotFound, tplID)
+	}
+	if err = agReq.SetFields(tpl); err != nil {
+		return
+	}
+	peerReq := diam.NewMessage(m.Header.CommandCode,
+		m.Header.CommandFlags&(diam.RequestFlag|diam.ProxiableFlag),
+		m.Header.ApplicationID, 0, 0, m.Dictionary())
+	if err = updateDiamMsgFromNavMap(peerReq, agReq.diamreq, agReq.Timezone); err != nil {
+		return
+	}
+	if reqProcessor.Flags.Has(utils.MetaLog) {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> LOG, processorID: %s, peer request: %s",
+				utils.DiameterAgent, reqProcessor.ID, peerReq))
+	}
+	var a *diam.Message
+	if a, err = da.SendRequest(peerReq, da.cgrCfg.GeneralCfg().ReplyTimeout); err != nil {
+		return fmt.Errorf("sending the peer request failed: %w", err)
+	}
+	agReq.ExtraDP[utils.MetaPeerAnswer] = newDADataProvider(nil, a)
+	return
+}
+
 // V1DisconnectSession is part of the sessions.BiRPClient
 func (da *DiameterAgent) V1DisconnectSession(ctx *context.Context, cgrEv utils.CGREvent, reply *string) (err error) {
 	ssID, has := cgrEv.Event[utils.OriginID]
@@ -349,7 +502,7 @@ func (da *DiameterAgent) sendASR(originID string, reply *string) (err error) {
 				utils.DiameterAgent, originID, err.Error()))
 		return utils.ErrServerError
 	}
-	if err = writeOnConn(dmd.c, m); err != nil {
+	if err = writeOnConn(da.peerConn(dmd.c), m); err != nil {
 		return utils.ErrServerError
 	}
 	*reply = utils.OK
@@ -405,7 +558,7 @@ func (da *DiameterAgent) V1AlterSession(ctx *context.Context, cgrEv utils.CGREve
 		delete(da.raa, originID)
 		da.raaLck.Unlock()
 	}()
-	if err = writeOnConn(dmd.c, m); err != nil {
+	if err = writeOnConn(da.peerConn(dmd.c), m); err != nil {
 		return utils.ErrServerError
 	}
 	select {
@@ -432,6 +585,9 @@ func (da *DiameterAgent) V1AlterSession(ctx *context.Context, cgrEv utils.CGREve
 
 // handleRAA is used to handle all Re-Authorize Answers that are received
 func (da *DiameterAgent) handleRAA(c diam.Conn, m *diam.Message) {
+	if da.relayAnswer(m) {
+		return
+	}
 	avp, err := m.FindAVP(avp.SessionID, dict.UndefinedVendorID)
 	if err != nil {
 		return
@@ -450,22 +606,44 @@ func (da *DiameterAgent) handleRAA(c diam.Conn, m *diam.Message) {
 }
 
 // handleConns is used to handle all conns that are connected to the agent
-// it register the connection so it can be used to send a DPR
 func (da *DiameterAgent) handleConns(peers <-chan diam.Conn) {
 	for c := range peers {
-		meta, _ := smpeer.FromContext(c.Context())
-		key := string(meta.OriginHost + utils.ConcatenatedKeySep + meta.OriginRealm)
+		da.registerPeerConn(c)
+	}
+}
+
+// registerPeerConn registers the connection so it can be used to send a DPR
+func (da *DiameterAgent) registerPeerConn(c diam.Conn) {
+	meta, _ := smpeer.FromContext(c.Context())
+	key := string(meta.OriginHost + utils.ConcatenatedKeySep + meta.OriginRealm)
+	da.peersLck.Lock()
+	da.peers[key] = c // store in peers table
+	da.peersLck.Unlock()
+	go func() {
+		// wait for disconnect notification
+		<-c.(diam.CloseNotifier).CloseNotify()
 		da.peersLck.Lock()
-		da.peers[key] = c // store in peers table
-		da.peersLck.Unlock()
-		go func(c diam.Conn, key string) {
-			// wait for disconnect notification
-			<-c.(diam.CloseNotifier).CloseNotify()
-			da.peersLck.Lock()
+		if da.peers[key] == c { // the peer could have reconnected in the meantime
 			delete(da.peers, key) // remove from peers table
-			da.peersLck.Unlock()
-		}(c, key)
+		}
+		da.peersLck.Unlock()
+	}()
+}
+
+// peerConn returns the current connection of the peer behind c,
+// which is different once the peer reconnected in the meantime
+func (da *DiameterAgent) peerConn(c diam.Conn) diam.Conn {
+	meta, has := smpeer.FromContext(c.Context())
+	if !has {
+		return c
+	}
+	key := string(meta.OriginHost + utils.ConcatenatedKeySep + meta.OriginRealm)
+	da.peersLck.Lock()
+	defer da.peersLck.Unlock()
+	if pc, has := da.peers[key]; has {
+		return pc
 	}
+	return c
 }
 
 // handleDPA is used to handle all DisconnectPeer Answers that are received
@@ -18,6 +18,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"crypto/tls"
+	"net"
 	"reflect"
 	"strings"
 	"testing"
@@ -31,6 +33,9 @@ import (
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
 	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
 )
 
 func TestDAsSessionSClientIface(t *testing.T) {
@@ -580,3 +585,102 @@ func TestV1DisconnectPeer(t *testing.T) {
 		t.Errorf("Expected ErrNotFound, got: %v", err)
 	}
 }
+
+func TestDiamPeerAllowed(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowedPeers []string
+		host         string
+		realm        string
+		exp          bool
+	}{
+		{"no restrictions", nil, "peer1", "cgrates.org", true},
+		{"host match", []string{"peer1"}, "peer1", "cgrates.org", true},
+		{"host and realm match", []string{"peer1:cgrates.org"}, "peer1", "cgrates.org", true},
+		{"realm mismatch", []string{"peer1:itsyscom.com"}, "peer1", "cgrates.org", false},
+		{"host mismatch", []string{"peer2", "peer3:cgrates.org"}, "peer1", "cgrates.org", false},
+		{"any host in realm", []string{"*any:cgrates.org"}, "peer1", "cgrates.org", true},
+		{"any host other realm", []string{"*any:itsyscom.com"}, "peer1", "cgrates.org", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rcv := diamPeerAllowed(tt.allowedPeers, tt.host, tt.realm); rcv != tt.exp {
+				t.Errorf("expected %v, received %v", tt.exp, rcv)
+			}
+		})
+	}
+}
+
+// sendDiamTLSCER opens a new TLS connection and returns the Result-Code of the CEA
+func sendDiamTLSCER(t *testing.T, addr string, tlsCfg *tls.Config, originHost string) uint32 {
+	var conn *tls.Conn
+	var err error
+	for i := 0; i < 50; i++ { // wait for the listener
+		if conn, err = tls.Dial(utils.TCP, addr, tlsCfg); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(time.Second))
+	cer := diam.NewRequest(diam.CapabilitiesExchange, 0, dict.Default)
+	cer.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(originHost))
+	cer.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("cgrates.org"))
+	cer.NewAVP(avp.HostIPAddress, avp.Mbit, 0, datatype.Address(net.ParseIP("127.0.0.1")))
+	cer.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(0))
+	cer.NewAVP(avp.ProductName, 0, 0, datatype.UTF8String("CGRateS"))
+	cer.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(4))
+	if _, err = cer.WriteTo(conn); err != nil {
+		t.Fatal(err)
+	}
+	cea, err := diam.ReadMessage(conn, dict.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsltCode, err := cea.FindAVP(avp.ResultCode, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uint32(rsltCode.Data.(datatype.Unsigned32))
+}
+
+func TestDiamAgentTLSPeerCheck(t *testing.T) {
+	ln, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().ListenNet = utils.TLSNoCaps
+	cfg.DiameterAgentCfg().Listen = addr
+	cfg.DiameterAgentCfg().DictionariesPath = utils.EmptyString
+	cfg.DiameterAgentCfg().AllowedPeers = []string{"localhost:cgrates.org", "client2"}
+	*cfg.TLSCfg() = *newTestTLSCfg(t)
+	da, err := NewDiameterAgent(cfg, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopChan := make(chan struct{})
+	go da.ListenAndServe(stopChan)
+	defer close(stopChan)
+	clntCfg, err := cfg.TLSCfg().TLSConfig(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rcv := sendDiamTLSCER(t, addr, clntCfg, "localhost"); rcv != diam.Success {
+		t.Errorf("expected Result-Code %d, received %d", diam.Success, rcv)
+	}
+	// not in the allowed peers
+	if rcv := sendDiamTLSCER(t, addr, clntCfg, "client1"); rcv != diam.UnknownPeer {
+		t.Errorf("expected Result-Code %d, received %d", diam.UnknownPeer, rcv)
+	}
+	// allowed but not matching the certificate
+	if rcv := sendDiamTLSCER(t, addr, clntCfg, "client2"); rcv != diam.UnknownPeer {
+		t.Errorf("expected Result-Code %d, received %d", diam.UnknownPeer, rcv)
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+)
+
+// connectPeers starts maintaining the connections towards the configured peers
+func (da *DiameterAgent) connectPeers(stopChan <-chan struct{}) {
+	for i, peer := range da.cgrCfg.DiameterAgentCfg().Peers {
+		go da.maintainPeerConn(i, peer, stopChan)
+	}
+}
+
+// maintainPeerConn keeps the connection towards the peer open,
+// reconnecting with exponential back-off once it is lost
+func (da *DiameterAgent) maintainPeerConn(idx int, peer *config.DiameterPeer, stopChan <-chan struct{}) {
+	cli := da.newPeerClient(peer)
+	delay := da.cgrCfg.DiameterAgentCfg().ReconnectInterval
+	for {
+		c, err := da.dialPeer(cli, peer)
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> cannot connect to peer <%s> at <%s>: %v, retrying in %s",
+				utils.DiameterAgent, peer.ID, peer.Address, err, delay))
+			select {
+			case <-stopChan:
+				return
+			case <-time.After(delay):
+			}
+			delay = nextReconnectDelay(delay, da.cgrCfg.DiameterAgentCfg().MaxReconnectInterval)
+			continue
+		}
+		utils.Logger.Info(fmt.Sprintf("<%s> connected to peer <%s> at <%s>",
+			utils.DiameterAgent, peer.ID, peer.Address))
+		delay = da.cgrCfg.DiameterAgentCfg().ReconnectInterval
+		da.registerPeerConn(c)
+		da.setPeerConn(idx, c)
+		select {
+		case <-c.(diam.CloseNotifier).CloseNotify():
+			da.setPeerConn(idx, nil)
+			utils.Logger.Warning(fmt.Sprintf("<%s> lost connection to peer <%s> at <%s>",
+				utils.DiameterAgent, peer.ID, peer.Address))
+		case <-stopChan:
+			da.setPeerConn(idx, nil)
+			c.Close()
+			return
+		}
+	}
+}
+
+// newPeerClient creates the client sending the CER and the DWRs towards the peer
+// each peer has its own state machine since the handshake is tracked within it
+func (da *DiameterAgent) newPeerClient(peer *config.DiameterPeer) *sm.Client {
+	authAppIDs := peer.AuthApplicationIDs
+	if len(authAppIDs) == 0 && len(peer.AcctApplicationIDs) == 0 {
+		authAppIDs = []int{diam.CHARGING_CONTROL_APP_ID} // RFC 4006
+	}
+	return &sm.Client{
+		Handler:            da.newStateMachine(da.smSettings()),
+		MaxRetransmits:     3,
+		RetransmitInterval: time.Second,
+		EnableWatchdog:     true,
+		WatchdogInterval:   da.cgrCfg.DiameterAgentCfg().WatchdogInterval,
+		AuthApplicationID:  diamAppIDAVPs(avp.AuthApplicationID, authAppIDs),
+		AcctApplicationID:  diamAppIDAVPs(avp.AcctApplicationID, peer.AcctApplicationIDs),
+	}
+}
+
+// dialPeer opens the connection towards the peer and does the capabilities exchange
+func (da *DiameterAgent) dialPeer(cli *sm.Client, peer *config.DiameterPeer) (diam.Conn, error) {
+	connTimeout := da.cgrCfg.GeneralCfg().ConnectTimeout
+	if peer.Network != utils.TLSNoCaps {
+		return cli.DialExt(peer.Network, peer.Address, connTimeout, nil)
+	}
+	tlsCfg, err := da.cgrCfg.TLSCfg().TLSConfig(false)
+	if err != nil {
+		return nil, err
+	}
+	rw, err := tls.DialWithDialer(&net.Dialer{Timeout: connTimeout}, utils.TCP, peer.Address, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	return cli.NewConn(rw, peer.Address)
+}
+
+// setPeerConn updates the connection towards the peer with the given index, nil once disconnected
+func (da *DiameterAgent) setPeerConn(idx int, c diam.Conn) {
+	da.peerConnsLck.Lock()
+	da.peerConns[idx] = c
+	da.peerConnsLck.Unlock()
+}
+
+// activePeerConns returns the connections towards the configured peers, in the order of preference
+func (da *DiameterAgent) activePeerConns() (conns []diam.Conn) {
+	da.peerConnsLck.RLock()
+	defer da.peerConnsLck.RUnlock()
+	for _, c := range da.peerConns {
+		if c != nil {
+			conns = append(conns, c)
+		}
+	}
+	return
+}
+
+// diamAnsKey identifies the answer awaited by SendRequest, the Hop-by-Hop-Ids being unique per connection
+type diamAnsKey struct {
+	c          diam.Conn
+	hopByHopID uint32
+}
+
+// SendRequest sends the request towards the configured peers in the order of preference and waits for the answer,
+// failing over to the next peer if writing fails or if the answer does not come within the timeout
+func (da *DiameterAgent) SendRequest(m *diam.Message, timeout time.Duration) (a *diam.Message, err error) {
+	err = utils.ErrDisconnected
+	for _, c := range da.activePeerConns() {
+		if a, err = da.sendOnConn(c, m, timeout); err == nil {
+			return
+		}
+		if err == utils.ErrTimedOut { // the previous peer could have received it (RFC 6733 section 5.5.4)
+			m.Header.CommandFlags |= diam.RetransmittedFlag
+		}
+	}
+	return
+}
+
+// sendOnConn sends the request on the connection and waits for the answer
+func (da *DiameterAgent) sendOnConn(c diam.Conn, m *diam.Message, timeout time.Duration) (a *diam.Message, err error) {
+	key := diamAnsKey{c: c, hopByHopID: da.nextHopByHopID(c)}
+	m.Header.HopByHopID = key.hopByHopID
+	ansCh := make(chan *diam.Message, 1)
+	da.ansLck.Lock()
+	da.ans[key] = ansCh
+	da.ansLck.Unlock()
+	defer func() {
+		da.ansLck.Lock()
+		delete(da.ans, key)
+		da.ansLck.Unlock()
+	}()
+	if err = writeOnConn(c, m); err != nil {
+		return
+	}
+	select {
+	case a = <-ansCh:
+	case <-time.After(timeout):
+		err = utils.ErrTimedOut
+	}
+	return
+}
+
+// nextHopByHopID returns the Hop-by-Hop-Id of the next request sent on the connection,
+// increasing from a random value for each connection (RFC 6733 section 3)
+func (da *DiameterAgent) nextHopByHopID(c diam.Conn) uint32 {
+	da.hopByHopLck.Lock()
+	defer da.hopByHopLck.Unlock()
+	hopByHopID, has := da.hopByHopIDs[c]
+	if !has {
+		hopByHopID = rand.Uint32()
+		if cn, canCast := c.(diam.CloseNotifier); canCast {
+			go func() {
+				<-cn.CloseNotify()
+				da.hopByHopLck.Lock()
+				delete(da.hopByHopIDs, c)
+				da.hopByHopLck.Unlock()
+			}()
+		}
+	}
+	hopByHopID++
+	da.hopByHopIDs[c] = hopByHopID
+	return hopByHopID
+}
+
+// handleAnswer passes the answer to the SendRequest waiting for it,
+// returning false if the answer was not requested by the agent
+func (da *DiameterAgent) handleAnswer(c diam.Conn, m *diam.Message) bool {
+	da.ansLck.Lock()
+	ch, has := da.ans[diamAnsKey{c: c, hopByHopID: m.Header.HopByHopID}]
+	da.ansLck.Unlock()
+	if !has {
+		return false
+	}
+	ch <- m
+	return true
+}
+
+// nextReconnectDelay doubles the delay between the reconnect attempts, up to the maximum one
+func nextReconnectDelay(delay, maxDelay time.Duration) time.Duration {
+	if delay *= 2; delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// diamAppIDAVPs builds the application id AVPs advertised within the CER
+func diamAppIDAVPs(code uint32, appIDs []int) (avps []*diam.AVP) {
+	for _, appID := range appIDs {
+		avps = append(avps, diam.NewAVP(code, avp.Mbit, 0, datatype.Unsigned32(appID)))
+	}
+	return
+}
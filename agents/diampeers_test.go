@@ -0,0 +1,335 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+)
+
+func TestNextReconnectDelay(t *testing.T) {
+	delay := 10 * time.Millisecond
+	for _, exp := range []time.Duration{
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+	} {
+		if delay = nextReconnectDelay(delay, 50*time.Millisecond); delay != exp {
+			t.Errorf("expected %s, received %s", exp, delay)
+		}
+	}
+}
+
+func TestDiamAppIDAVPs(t *testing.T) {
+	if rcv := diamAppIDAVPs(avp.AuthApplicationID, nil); rcv != nil {
+		t.Errorf("expected no AVPs, received %+v", rcv)
+	}
+	rcv := diamAppIDAVPs(avp.AcctApplicationID, []int{3, 4})
+	if len(rcv) != 2 {
+		t.Fatalf("expected 2 AVPs, received %+v", rcv)
+	}
+	for i, exp := range []datatype.Unsigned32{3, 4} {
+		if rcv[i].Code != avp.AcctApplicationID || rcv[i].Data != exp {
+			t.Errorf("expected Acct-Application-Id %d, received %+v", exp, rcv[i])
+		}
+	}
+}
+
+// newTestDiamOCS starts a diameter server answering the CCRs, used as peer for the DiameterAgent
+func newTestDiamOCS(t *testing.T, originHost, addr string) net.Listener {
+	return newTestDiamPeer(t, originHost, addr, func(c diam.Conn, m *diam.Message) {
+		a := m.Answer(diam.Success)
+		a.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(originHost))
+		a.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("cgrates.org"))
+		// echo the Route-Record so the relayed requests can be checked
+		if rrs, err := m.FindAVPsWithPath([]any{avp.RouteRecord}, 0); err == nil {
+			for _, rr := range rrs {
+				a.AddAVP(rr)
+			}
+		}
+		a.WriteTo(c)
+	})
+}
+
+// newTestDiamPeer starts a diameter server handling the CCRs with ccrHandler
+func newTestDiamPeer(t *testing.T, originHost, addr string, ccrHandler diam.HandlerFunc) net.Listener {
+	lsn, err := net.Listen(utils.TCP, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dSM := sm.New(&sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(originHost),
+		OriginRealm:      datatype.DiameterIdentity("cgrates.org"),
+		VendorID:         datatype.Unsigned32(0),
+		ProductName:      datatype.UTF8String("OCS"),
+		FirmwareRevision: datatype.Unsigned32(1),
+		HostIPAddresses:  []datatype.Address{datatype.Address(net.ParseIP("127.0.0.1"))},
+	})
+	dSM.HandleFunc("CCR", ccrHandler)
+	go (&diam.Server{Handler: dSM}).Serve(lsn)
+	return lsn
+}
+
+func newTestDiamCCR(sessionID, destRealm string) *diam.Message {
+	m := diam.NewRequest(diam.CreditControl, diam.CHARGING_CONTROL_APP_ID, dict.Default)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("CGR-DA"))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("cgrates.org"))
+	m.NewAVP(avp.DestinationRealm, avp.Mbit, 0, datatype.DiameterIdentity(destRealm))
+	m.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.CHARGING_CONTROL_APP_ID))
+	m.NewAVP(avp.CCRequestType, avp.Mbit, 0, datatype.Enumerated(1))
+	m.NewAVP(avp.CCRequestNumber, avp.Mbit, 0, datatype.Unsigned32(0))
+	return m
+}
+
+// waitDiamPeers waits for the DiameterAgent to have the number of connections towards the configured peers
+func waitDiamPeers(t *testing.T, da *DiameterAgent, nrConns int) []diam.Conn {
+	for i := 0; i < 200; i++ {
+		if conns := da.activePeerConns(); len(conns) == nrConns {
+			return conns
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d peer connections, received %d", nrConns, len(da.activePeerConns()))
+	return nil
+}
+
+func TestDiamAgentPeersFailover(t *testing.T) {
+	ocs1 := newTestDiamOCS(t, "ocs1", "127.0.0.1:0")
+	ocs1Addr := ocs1.Addr().String()
+	ocs2 := newTestDiamOCS(t, "ocs2", "127.0.0.1:0")
+	defer ocs2.Close()
+	ln, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().Listen = addr
+	cfg.DiameterAgentCfg().DictionariesPath = utils.EmptyString
+	cfg.DiameterAgentCfg().ReconnectInterval = 10 * time.Millisecond
+	cfg.DiameterAgentCfg().MaxReconnectInterval = 50 * time.Millisecond
+	cfg.DiameterAgentCfg().Peers = []*config.DiameterPeer{
+		{ID: "ocs1", Network: utils.TCP, Address: ocs1Addr},
+		{ID: "ocs2", Network: utils.TCP, Address: ocs2.Addr().String()},
+	}
+	da, err := NewDiameterAgent(cfg, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopChan := make(chan struct{})
+	go da.ListenAndServe(stopChan)
+	defer close(stopChan)
+
+	conns := waitDiamPeers(t, da, 2)
+	da.peersLck.Lock()
+	_, has := da.peers["ocs1:cgrates.org"]
+	da.peersLck.Unlock()
+	if !has {
+		t.Error("expected ocs1 to be registered within the peers")
+	}
+	checkAnswerFrom := func(originHost string) {
+		t.Helper()
+		a, err := da.SendRequest(newTestDiamCCR(utils.GenUUID(), "cgrates.org"), time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rcv, err := diamIdentityAVP(a, avp.OriginHost); err != nil {
+			t.Error(err)
+		} else if rcv != originHost {
+			t.Errorf("expected answer from %s, received from %s", originHost, rcv)
+		}
+	}
+	checkAnswerFrom("ocs1")
+
+	// ocs1 goes down, the requests fail over to ocs2
+	ocs1.Close()
+	conns[0].Close()
+	waitDiamPeers(t, da, 1)
+	checkAnswerFrom("ocs2")
+
+	// ocs1 comes back on the same address
+	ocs1 = newTestDiamOCS(t, "ocs1", ocs1Addr)
+	defer ocs1.Close()
+	waitDiamPeers(t, da, 2)
+	checkAnswerFrom("ocs1")
+}
+
+func TestDiamAgentPeersAnswerTimeout(t *testing.T) {
+	retransmitted := make(chan bool, 1)
+	ocs1 := newTestDiamPeer(t, "ocs1", "127.0.0.1:0", func(c diam.Conn, m *diam.Message) {}) // never answers
+	defer ocs1.Close()
+	ocs2 := newTestDiamPeer(t, "ocs2", "127.0.0.1:0", func(c diam.Conn, m *diam.Message) {
+		retransmitted <- m.Header.CommandFlags&diam.RetransmittedFlag != 0
+		a := m.Answer(diam.Success)
+		a.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("ocs2"))
+		a.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("cgrates.org"))
+		a.WriteTo(c)
+	})
+	defer ocs2.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().Listen = "127.0.0.1:0"
+	cfg.DiameterAgentCfg().DictionariesPath = utils.EmptyString
+	cfg.DiameterAgentCfg().Peers = []*config.DiameterPeer{
+		{ID: "ocs1", Network: utils.TCP, Address: ocs1.Addr().String()},
+		{ID: "ocs2", Network: utils.TCP, Address: ocs2.Addr().String()},
+	}
+	da, err := NewDiameterAgent(cfg, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopChan := make(chan struct{})
+	go da.ListenAndServe(stopChan)
+	defer close(stopChan)
+	conns := waitDiamPeers(t, da, 2)
+
+	a, err := da.SendRequest(newTestDiamCCR(utils.GenUUID(), "cgrates.org"), 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := diamIdentityAVP(a, avp.OriginHost); err != nil {
+		t.Error(err)
+	} else if rcv != "ocs2" {
+		t.Errorf("expected answer from ocs2, received from %s", rcv)
+	}
+	if !<-retransmitted {
+		t.Error("expected the request sent to ocs2 flagged as retransmitted")
+	}
+
+	// the Hop-by-Hop-Ids are increasing within each connection
+	hopByHopID := da.nextHopByHopID(conns[0])
+	if rcv := da.nextHopByHopID(conns[0]); rcv != hopByHopID+1 {
+		t.Errorf("expected Hop-by-Hop-Id %d, received %d", hopByHopID+1, rcv)
+	}
+	da.hopByHopLck.Lock()
+	nrConns := len(da.hopByHopIDs)
+	da.hopByHopLck.Unlock()
+	if nrConns != 2 {
+		t.Errorf("expected the Hop-by-Hop-Ids of 2 connections, received %d", nrConns)
+	}
+	// an answer with the same Hop-by-Hop-Id on a different connection is not matched
+	m := newTestDiamCCR(utils.GenUUID(), "cgrates.org")
+	m.Header.HopByHopID = hopByHopID + 2
+	da.ansLck.Lock()
+	da.ans[diamAnsKey{c: conns[0], hopByHopID: m.Header.HopByHopID}] = make(chan *diam.Message, 1)
+	da.ansLck.Unlock()
+	if da.handleAnswer(conns[1], m.Answer(diam.Success)) {
+		t.Error("expected the answer on a different connection not matched")
+	}
+	if !da.handleAnswer(conns[0], m.Answer(diam.Success)) {
+		t.Error("expected the answer matched")
+	}
+}
+
+func TestDiamAgentPeerRequest(t *testing.T) {
+	ocs1 := newTestDiamOCS(t, "ocs1", "127.0.0.1:0")
+	defer ocs1.Close()
+	ln, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().Listen = addr
+	cfg.DiameterAgentCfg().DictionariesPath = utils.EmptyString
+	cfg.DiameterAgentCfg().Peers = []*config.DiameterPeer{
+		{ID: "ocs1", Network: utils.TCP, Address: ocs1.Addr().String()},
+	}
+	peerTpl := []*config.FCTemplate{
+		{Tag: "SessionId", Path: "*diamreq.Session-Id", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Session-Id", utils.InfieldSep)},
+		{Tag: "OriginHost", Path: "*diamreq.Origin-Host", Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("CGR-DA", utils.InfieldSep)},
+		{Tag: "OriginRealm", Path: "*diamreq.Origin-Realm", Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep)},
+		{Tag: "DestinationRealm", Path: "*diamreq.Destination-Realm", Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("ocs.org", utils.InfieldSep)},
+		{Tag: "AuthApplicationId", Path: "*diamreq.Auth-Application-Id", Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("4", utils.InfieldSep)},
+		{Tag: "CCRequestType", Path: "*diamreq.CC-Request-Type", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.CC-Request-Type", utils.InfieldSep)},
+		{Tag: "CCRequestNumber", Path: "*diamreq.CC-Request-Number", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.CC-Request-Number", utils.InfieldSep)},
+	}
+	replyFlds := []*config.FCTemplate{
+		{Tag: "ResultCode", Path: "*rep.Result-Code", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*peer_answer.Result-Code", utils.InfieldSep)},
+		{Tag: "ProxyHost", Path: "*rep.Proxy-Info.Proxy-Host", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*peer_answer.Origin-Host", utils.InfieldSep)},
+	}
+	for _, fld := range append(peerTpl, replyFlds...) {
+		fld.ComputePath()
+	}
+	cfg.TemplatesCfg()["TPL_UPSTREAM_CCR"] = peerTpl
+	cfg.DiameterAgentCfg().RequestProcessors = []*config.RequestProcessor{
+		{
+			ID:    "upstream",
+			Flags: utils.FlagsWithParamsFromSlice([]string{utils.MetaNone, "*peer_request:TPL_UPSTREAM_CCR", utils.MetaContinue}),
+		},
+		{
+			ID:          "reply",
+			Flags:       utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+			ReplyFields: replyFlds,
+		},
+	}
+	da, err := NewDiameterAgent(cfg, engine.NewFilterS(cfg, nil, nil), nil, engine.NewCaps(0, utils.MetaBusy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopChan := make(chan struct{})
+	go da.ListenAndServe(stopChan)
+	defer close(stopChan)
+	waitDiamPeers(t, da, 1)
+
+	dc, err := NewDiameterClient(addr, "client1", "cgrates.org", 0, "CGRateS", 1, utils.EmptyString, utils.TCP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dc.SendMessage(newTestDiamCCR(utils.GenUUID(), "cgrates.org")); err != nil {
+		t.Fatal(err)
+	}
+	a := dc.ReceivedMessage(time.Second)
+	if a == nil {
+		t.Fatal("no answer received")
+	}
+	if avps, err := a.FindAVPsWithPath([]any{avp.ResultCode}, 0); err != nil || len(avps) != 1 ||
+		avps[0].Data != datatype.Unsigned32(diam.Success) {
+		t.Errorf("expected the Result-Code of the peer, received %s", a)
+	}
+	if avps, err := a.FindAVPsWithPath([]any{avp.ProxyInfo, avp.ProxyHost}, 0); err != nil || len(avps) != 1 ||
+		avps[0].Data != datatype.DiameterIdentity("ocs1") {
+		t.Errorf("expected the answer of ocs1, received %s", a)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smpeer"
+)
+
+// diamRelayedReq is a request relayed towards a peer, waiting for its answer
+type diamRelayedReq struct {
+	c       diam.Conn     // connection the request was received on
+	m       *diam.Message // request as received
+	reqVars *utils.DataNode
+}
+
+// matchRoute returns the first route matching the Destination-Realm and the Application-Id of the request
+func (da *DiameterAgent) matchRoute(m *diam.Message) *config.DiameterRoute {
+	if m.Header.CommandFlags&diam.ProxiableFlag == 0 {
+		return nil // only the proxiable requests are relayed
+	}
+	destRealm, err := diamIdentityAVP(m, avp.DestinationRealm)
+	if err != nil {
+		return nil
+	}
+	for _, route := range da.cgrCfg.DiameterAgentCfg().Routes {
+		if (route.Realm == utils.MetaAny || route.Realm == destRealm) &&
+			(len(route.ApplicationIDs) == 0 ||
+				slices.Contains(route.ApplicationIDs, int(m.Header.ApplicationID))) {
+			return route
+		}
+	}
+	return nil
+}
+
+// relayRequest forwards the request towards the peers of the route,
+// the answer is sent back on the connection the request was received on by relayAnswer
+func (da *DiameterAgent) relayRequest(c diam.Conn, m *diam.Message,
+	route *config.DiameterRoute, diamDP utils.DataProvider, reqVars *utils.DataNode) {
+	if diamRouteRecorded(m, da.cgrCfg.DiameterAgentCfg().OriginHost) {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> loop detected relaying message %s from %s on route <%s>",
+				utils.DiameterAgent, m, c.RemoteAddr(), route.ID))
+		diamErr(c, m, diam.LoopDetected, reqVars, da.cgrCfg, da.filterS)
+		return
+	}
+	if route.RequestProcessor != utils.EmptyString {
+		da.processRelayedRequest(route, diamDP, reqVars)
+	}
+	fwd := diam.NewMessage(m.Header.CommandCode, m.Header.CommandFlags,
+		m.Header.ApplicationID, 0, m.Header.EndToEndID, m.Dictionary())
+	for _, a := range m.AVP {
+		fwd.AddAVP(a)
+	}
+	fwd.NewAVP(avp.RouteRecord, avp.Mbit, 0, datatype.DiameterIdentity(diamPeerIdentity(c, m)))
+	hopByHopID := fwd.Header.HopByHopID
+	da.relayLck.Lock()
+	da.relayed[hopByHopID] = &diamRelayedReq{c: c, m: m, reqVars: reqVars}
+	da.relayLck.Unlock()
+	for _, pc := range da.routePeerConns(route) {
+		if err := writeOnConn(pc, fwd); err == nil {
+			time.AfterFunc(da.cgrCfg.GeneralCfg().ReplyTimeout, func() {
+				if req := da.popRelayedReq(hopByHopID); req != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> no answer from %s for the message relayed on route <%s>",
+							utils.DiameterAgent, pc.RemoteAddr(), route.ID))
+					diamErr(req.c, req.m, diam.UnableToDeliver, req.reqVars, da.cgrCfg, da.filterS)
+				}
+			})
+			return
+		}
+	}
+	da.popRelayedReq(hopByHopID)
+	utils.Logger.Warning(
+		fmt.Sprintf("<%s> no peer available on route <%s> for message %s",
+			utils.DiameterAgent, route.ID, m))
+	diamErr(c, m, diam.UnableToDeliver, reqVars, da.cgrCfg, da.filterS)
+}
+
+// processRelayedRequest runs the request processor of the route before relaying the request
+// the reply is ignored since the answer comes from the peer
+func (da *DiameterAgent) processRelayedRequest(route *config.DiameterRoute,
+	diamDP utils.DataProvider, reqVars *utils.DataNode) {
+	idx := slices.IndexFunc(da.cgrCfg.DiameterAgentCfg().RequestProcessors,
+		func(rp *config.RequestProcessor) bool { return rp.ID == route.RequestProcessor })
+	if idx == -1 {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> request processor <%s> of route <%s> not found",
+				utils.DiameterAgent, route.RequestProcessor, route.ID))
+		return
+	}
+	reqProcessor := da.cgrCfg.DiameterAgentCfg().RequestProcessors[idx]
+	if _, err := processRequest(
+		da.ctx,
+		reqProcessor,
+		NewAgentRequest(
+			diamDP, reqVars,
+			&utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{}},
+			utils.NewOrderedNavigableMap(),
+			utils.MapStorage{}, reqProcessor.Tenant,
+			da.cgrCfg.GeneralCfg().DefaultTenant,
+			utils.FirstNonEmpty(
+				reqProcessor.Timezone,
+				da.cgrCfg.GeneralCfg().DefaultTimezone,
+			),
+			da.filterS, nil),
+		utils.DiameterAgent, da.connMgr,
+		da.cgrCfg.DiameterAgentCfg().SessionSConns,
+		da.filterS); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s processing message relayed on route <%s>",
+				utils.DiameterAgent, err.Error(), route.ID))
+	}
+}
+
+// relayAnswer sends the answer back towards the peer the request was received from,
+// returning false if the answer is not for a relayed request
+func (da *DiameterAgent) relayAnswer(m *diam.Message) bool {
+	req := da.popRelayedReq(m.Header.HopByHopID)
+	if req == nil {
+		return false
+	}
+	m.Header.HopByHopID = req.m.Header.HopByHopID // restore the Hop-by-Hop-Id of the received request
+	writeOnConn(req.c, m)
+	return true
+}
+
+// popRelayedReq removes the relayed request out of the ones waiting for an answer
+func (da *DiameterAgent) popRelayedReq(hopByHopID uint32) (req *diamRelayedReq) {
+	da.relayLck.Lock()
+	defer da.relayLck.Unlock()
+	if req = da.relayed[hopByHopID]; req != nil {
+		delete(da.relayed, hopByHopID)
+	}
+	return
+}
+
+// routePeerConns returns the connections towards the peers of the route, ordered by weight
+// the peers with the same weight are shuffled so the requests are shared between them
+func (da *DiameterAgent) routePeerConns(route *config.DiameterRoute) (conns []diam.Conn) {
+	routePeers := make([]*config.DiameterRoutePeer, len(route.Peers))
+	copy(routePeers, route.Peers)
+	rand.Shuffle(len(routePeers), func(i, j int) {
+		routePeers[i], routePeers[j] = routePeers[j], routePeers[i]
+	})
+	sort.SliceStable(routePeers, func(i, j int) bool {
+		return routePeers[i].Weight > routePeers[j].Weight
+	})
+	peers := da.cgrCfg.DiameterAgentCfg().Peers
+	da.peerConnsLck.RLock()
+	defer da.peerConnsLck.RUnlock()
+	for _, routePeer := range routePeers {
+		idx := slices.IndexFunc(peers, func(peer *config.DiameterPeer) bool {
+			return peer.ID == routePeer.ID
+		})
+		if idx != -1 && da.peerConns[idx] != nil {
+			conns = append(conns, da.peerConns[idx])
+		}
+	}
+	return
+}
+
+// diamPeerIdentity returns the identity of the peer the message was received from
+func diamPeerIdentity(c diam.Conn, m *diam.Message) string {
+	if meta, has := smpeer.FromContext(c.Context()); has {
+		return string(meta.OriginHost)
+	}
+	originHost, _ := diamIdentityAVP(m, avp.OriginHost)
+	return originHost
+}
+
+// diamRouteRecorded checks if the identity is found within the Route-Record AVPs of the message
+func diamRouteRecorded(m *diam.Message, identity string) bool {
+	avps, err := m.FindAVPsWithPath([]any{avp.RouteRecord}, 0)
+	if err != nil {
+		return false
+	}
+	for _, a := range avps {
+		if rr, canCast := a.Data.(datatype.DiameterIdentity); canCast &&
+			string(rr) == identity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+func TestDiamRouteRecorded(t *testing.T) {
+	m := newTestDiamCCR("session1", "cgrates.org")
+	if diamRouteRecorded(m, "CGR-DA") {
+		t.Error("expected no Route-Record")
+	}
+	m.NewAVP(avp.RouteRecord, avp.Mbit, 0, datatype.DiameterIdentity("dra1"))
+	m.NewAVP(avp.RouteRecord, avp.Mbit, 0, datatype.DiameterIdentity("CGR-DA"))
+	if !diamRouteRecorded(m, "CGR-DA") {
+		t.Error("expected CGR-DA within the Route-Record")
+	}
+	if diamRouteRecorded(m, "dra2") {
+		t.Error("expected dra2 not within the Route-Record")
+	}
+}
+
+func TestDiamAgentMatchRoute(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().Routes = []*config.DiameterRoute{
+		{ID: "gy", Realm: "ocs.org", ApplicationIDs: []int{diam.CHARGING_CONTROL_APP_ID}},
+		{ID: "default", Realm: utils.MetaAny, ApplicationIDs: []int{diam.GX_CHARGING_CONTROL_APP_ID}},
+	}
+	da := &DiameterAgent{cgrCfg: cfg}
+	m := newTestDiamCCR("session1", "cgrates.org")
+	if route := da.matchRoute(m); route != nil {
+		t.Errorf("expected no route, received %+v", route)
+	}
+	m.Header.CommandFlags |= diam.ProxiableFlag
+	if route := da.matchRoute(m); route != nil {
+		t.Errorf("expected no route, received %+v", route)
+	}
+	m.Header.ApplicationID = diam.GX_CHARGING_CONTROL_APP_ID
+	if route := da.matchRoute(m); route == nil || route.ID != "default" {
+		t.Errorf("expected default route, received %+v", route)
+	}
+	m = newTestDiamCCR("session2", "ocs.org")
+	m.Header.CommandFlags |= diam.ProxiableFlag
+	if route := da.matchRoute(m); route == nil || route.ID != "gy" {
+		t.Errorf("expected gy route, received %+v", route)
+	}
+}
+
+func TestDiamAgentRelay(t *testing.T) {
+	ocs1 := newTestDiamOCS(t, "ocs1", "127.0.0.1:0")
+	defer ocs1.Close()
+	ln, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().Listen = addr
+	cfg.DiameterAgentCfg().DictionariesPath = utils.EmptyString
+	cfg.DiameterAgentCfg().Peers = []*config.DiameterPeer{
+		{ID: "ocs1", Network: utils.TCP, Address: ocs1.Addr().String()},
+	}
+	cfg.DiameterAgentCfg().Routes = []*config.DiameterRoute{
+		{ID: "gy", Realm: "ocs.org", Peers: []*config.DiameterRoutePeer{{ID: "ocs1"}}},
+	}
+	da, err := NewDiameterAgent(cfg, nil, nil, engine.NewCaps(0, utils.MetaBusy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopChan := make(chan struct{})
+	go da.ListenAndServe(stopChan)
+	defer close(stopChan)
+	waitDiamPeers(t, da, 1)
+
+	dc, err := NewDiameterClient(addr, "client1", "cgrates.org", 0, "CGRateS", 1, utils.EmptyString, utils.TCP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRelayedCCR := func() *diam.Message {
+		m := newTestDiamCCR(utils.GenUUID(), "ocs.org")
+		m.Header.CommandFlags |= diam.ProxiableFlag
+		return m
+	}
+
+	m := newRelayedCCR()
+	if err = dc.SendMessage(m); err != nil {
+		t.Fatal(err)
+	}
+	a := dc.ReceivedMessage(time.Second)
+	if a == nil {
+		t.Fatal("no answer received")
+	}
+	if a.Header.HopByHopID != m.Header.HopByHopID {
+		t.Errorf("expected Hop-by-Hop-Id %d, received %d", m.Header.HopByHopID, a.Header.HopByHopID)
+	}
+	if rcv, err := diamIdentityAVP(a, avp.OriginHost); err != nil {
+		t.Error(err)
+	} else if rcv != "ocs1" {
+		t.Errorf("expected answer from ocs1, received from %s", rcv)
+	}
+	if !diamRouteRecorded(a, "client1") {
+		t.Errorf("expected client1 within the Route-Record, received %s", a)
+	}
+
+	// loop back to the agent
+	m = newRelayedCCR()
+	m.NewAVP(avp.RouteRecord, avp.Mbit, 0, datatype.DiameterIdentity("CGR-DA"))
+	if err = dc.SendMessage(m); err != nil {
+		t.Fatal(err)
+	}
+	if a = dc.ReceivedMessage(time.Second); a == nil {
+		t.Fatal("no answer received")
+	}
+	if rsltCode, err := a.FindAVP(avp.ResultCode, 0); err != nil {
+		t.Error(err)
+	} else if rsltCode.Data != datatype.Unsigned32(diam.LoopDetected) {
+		t.Errorf("expected Result-Code %d, received %v", diam.LoopDetected, rsltCode.Data)
+	}
+}
@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -79,22 +80,47 @@ func NewRadiusAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 	radAgent.dacCfg = newRadiusDAClientCfg(dicts, secrets, radAgentCfg)
 	radAgent.rsAuth = make(map[string]*radigo.Server, len(radAgentCfg.Listeners))
 	radAgent.rsAcct = make(map[string]*radigo.Server, len(radAgentCfg.Listeners))
+	radAgent.rsTLS = make(map[string]*radsecServer)
 	for i := range radAgentCfg.Listeners {
 		net := radAgentCfg.Listeners[i].Network
 		authAddr := radAgentCfg.Listeners[i].AuthAddr
+		acctAddr := radAgentCfg.Listeners[i].AcctAddr
+		if net == utils.TLSNoCaps { // RadSec serves both auth and acct on the same address
+			radAgent.radsecServer(authAddr, dicts).handlers[radigo.AccessRequest] = radAgent.handleAuth
+			radAgent.radsecServer(acctAddr, dicts).handlers[radigo.AccountingRequest] = radAgent.handleAcct
+			continue
+		}
 		radAgent.rsAuth[net+"://"+authAddr] = radigo.NewServer(net, authAddr, secrets, dicts,
 			map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
-				radigo.AccessRequest: radAgent.handleAuth,
+				radigo.AccessRequest: withRemoteAddr(radAgent.handleAuth),
 			}, nil, utils.Logger)
-		acctAddr := radAgentCfg.Listeners[i].AcctAddr
 		radAgent.rsAcct[net+"://"+acctAddr] = radigo.NewServer(net, acctAddr, secrets, dicts,
 			map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
-				radigo.AccountingRequest: radAgent.handleAcct,
+				radigo.AccountingRequest: withRemoteAddr(radAgent.handleAcct),
 			}, nil, utils.Logger)
 	}
 	return radAgent, nil
 }
 
+// radsecServer returns the RadSec server listening on addr, creating it if not already there
+func (ra *RadiusAgent) radsecServer(addr string, dicts *radigo.Dictionaries) *radsecServer {
+	uri := utils.TLSNoCaps + "://" + addr
+	if rs, has := ra.rsTLS[uri]; has {
+		return rs
+	}
+	ra.rsTLS[uri] = newRadsecServer(addr, func() (*tls.Config, error) {
+		return ra.cgrCfg.TLSCfg().TLSConfig(true)
+	}, dicts)
+	return ra.rsTLS[uri]
+}
+
+// withRemoteAddr adapts the handlers to the radigo servers, which keep the remote address within the packet
+func withRemoteAddr(hndlr func(*radigo.Packet, net.Addr) (*radigo.Packet, error)) func(*radigo.Packet) (*radigo.Packet, error) {
+	return func(reqPacket *radigo.Packet) (*radigo.Packet, error) {
+		return hndlr(reqPacket, reqPacket.RemoteAddr())
+	}
+}
+
 type RadiusAgent struct {
 	sync.RWMutex
 	cgrCfg  *config.CGRConfig // reference for future config reloads
@@ -102,6 +128,7 @@ type RadiusAgent struct {
 	filterS *engine.FilterS
 	rsAuth  map[string]*radigo.Server
 	rsAcct  map[string]*radigo.Server
+	rsTLS   map[string]*radsecServer // RadSec servers
 	dacCfg  radiusDAClientCfg
 	ctx     *context.Context
 	sync.WaitGroup
@@ -136,7 +163,7 @@ func newRadiusDAClientCfg(dicts *radigo.Dictionaries, secrets *radigo.Secrets,
 }
 
 // handleAuth handles RADIUS Authorization request
-func (ra *RadiusAgent) handleAuth(reqPacket *radigo.Packet) (*radigo.Packet, error) {
+func (ra *RadiusAgent) handleAuth(reqPacket *radigo.Packet, remoteAddr net.Addr) (*radigo.Packet, error) {
 	reqPacket.SetAVPValues() // populate string values in AVPs
 	replyPacket := reqPacket.Reply()
 	replyPacket.Code = radigo.AccessAccept
@@ -147,7 +174,7 @@ func (ra *RadiusAgent) handleAuth(reqPacket *radigo.Packet) (*radigo.Packet, err
 	varsDataNode := &utils.DataNode{
 		Type: utils.NMMapType,
 		Map: map[string]*utils.DataNode{
-			utils.RemoteHost: utils.NewLeafNode(reqPacket.RemoteAddr().String()),
+			utils.RemoteHost: utils.NewLeafNode(remoteAddr.String()),
 		},
 	}
 	radDP := newRADataProvider(reqPacket)
@@ -187,7 +214,7 @@ func (ra *RadiusAgent) handleAuth(reqPacket *radigo.Packet) (*radigo.Packet, err
 
 // handleAcct processes RADIUS Accounting requests and generates a reply.
 // It supports Acct-Status-Type values: Start, Interim-Update, Stop.
-func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet) (*radigo.Packet, error) {
+func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet, remoteAddr net.Addr) (*radigo.Packet, error) {
 	reqPacket.SetAVPValues() // populate string values in AVPs
 	replyPacket := reqPacket.Reply()
 	replyPacket.Code = radigo.AccountingResponse
@@ -198,11 +225,10 @@ func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet) (*radigo.Packet, err
 	rplyNM := utils.NewOrderedNavigableMap()
 	opts := utils.MapStorage{}
 
-	remoteAddr := reqPacket.RemoteAddr().String()
 	varsDataNode := &utils.DataNode{
 		Type: utils.NMMapType,
 		Map: map[string]*utils.DataNode{
-			utils.RemoteHost: utils.NewLeafNode(remoteAddr),
+			utils.RemoteHost: utils.NewLeafNode(remoteAddr.String()),
 		},
 	}
 
@@ -243,7 +269,7 @@ func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet) (*radigo.Packet, err
 
 	// Cache the RADIUS Packet for future CoA/Disconnect Requests.
 	if cacheKeyTpl := radAgentCfg.RequestsCacheKey; cacheKeyTpl != nil {
-		err := cacheRadiusPacket(reqPacket, remoteAddr, radAgentCfg,
+		err := cacheRadiusPacket(reqPacket, remoteAddr.String(), radAgentCfg,
 			utils.MapStorage{
 				utils.MetaReq:  radDP,
 				utils.MetaVars: varsDataNode,
@@ -261,6 +287,12 @@ func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet) (*radigo.Packet, err
 	return replyPacket, nil
 }
 
+// radCachedPacket is the RADIUS packet cached for future CoA/Disconnect Requests together with its source address.
+type radCachedPacket struct {
+	*radigo.Packet
+	remoteAddr string
+}
+
 // cacheRadiusPacket caches a RADIUS packet if there are client options found for its source address.
 func cacheRadiusPacket(packet *radigo.Packet, address string, cfg *config.RadiusAgentCfg,
 	dp utils.DataProvider) error {
@@ -272,7 +304,8 @@ func cacheRadiusPacket(packet *radigo.Packet, address string, cfg *config.Radius
 	if err != nil {
 		return fmt.Errorf("failed to parse the RADIUS packet cache key: %w", err)
 	}
-	if err = engine.Cache.Set(utils.CacheRadiusPackets, cacheKey, packet,
+	if err = engine.Cache.Set(utils.CacheRadiusPackets, cacheKey,
+		&radCachedPacket{Packet: packet, remoteAddr: address},
 		nil, true, utils.NonTransactional); err != nil {
 		return fmt.Errorf("failed to cache RADIUS packet: %w", err)
 	}
@@ -493,6 +526,22 @@ func (ra *RadiusAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 		}(server, uri)
 	}
 
+	for uri, server := range ra.rsTLS {
+		ra.Add(1)
+		go func(srv *radsecServer, uri string) {
+			defer ra.Done()
+			utils.Logger.Info(fmt.Sprintf("<%s> Start listening for RadSec requests on <%s>", utils.RadiusAgent, uri))
+			if err := srv.ListenAndServe(stopChan); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error <%v>, on ListenAndServe <%s>",
+					utils.RadiusAgent, err, uri))
+				if strings.Contains(err.Error(), "address already in use") {
+					return
+				}
+				errListen <- err
+			}
+		}(server, uri)
+	}
+
 	err = <-errListen
 	return
 }
@@ -579,26 +628,30 @@ func (ra *RadiusAgent) sendRadDaReq(requestType radigo.PacketCode, requestTempla
 	if !has {
 		return 0, fmt.Errorf("failed to retrieve packet from cache: %w", utils.ErrNotFound)
 	}
-	packet := cachedPacket.(*radigo.Packet)
+	packet := cachedPacket.(*radCachedPacket)
 
 	agReq := NewAgentRequest(
 		requestEv, requestVars, nil, nil, nil, nil,
 		ra.cgrCfg.GeneralCfg().DefaultTenant,
 		ra.cgrCfg.GeneralCfg().DefaultTimezone,
 		ra.filterS, map[string]utils.DataProvider{
-			utils.MetaOReq: newRADataProvider(packet),
+			utils.MetaOReq: newRADataProvider(packet.Packet),
 		})
 	err := agReq.SetFields(ra.cgrCfg.TemplatesCfg()[requestTemplate])
 	if err != nil {
 		return 0, fmt.Errorf("could not set attributes: %w", err)
 	}
 
-	remoteAddr, remoteHost, err := daRequestAddress(packet.RemoteAddr().String(),
+	remoteAddr, remoteHost, err := daRequestAddress(packet.remoteAddr,
 		ra.cgrCfg.RadiusAgentCfg().ClientDaAddresses)
 	if err != nil {
 		return 0, fmt.Errorf("retrieving remote address failed: %w", err)
 	}
 	clientOpts := ra.cgrCfg.RadiusAgentCfg().ClientDaAddresses[remoteHost]
+	if clientOpts.Transport == utils.TLSNoCaps {
+		return ra.sendRadsecDaReq(requestType, sessionID, remoteAddr, remoteHost,
+			clientOpts, agReq)
+	}
 	dynAuthClient, err := radigo.NewClient(clientOpts.Transport, remoteAddr,
 		ra.dacCfg.secrets.GetSecret(remoteHost),
 		ra.dacCfg.dicts.GetInstance(remoteHost),
@@ -622,6 +675,31 @@ func (ra *RadiusAgent) sendRadDaReq(requestType radigo.PacketCode, requestTempla
 	return dynAuthReply.Code, nil
 }
 
+// sendRadsecDaReq sends the CoA/Disconnect Request over TLS to the RadSec capable clients.
+func (ra *RadiusAgent) sendRadsecDaReq(requestType radigo.PacketCode, sessionID, remoteAddr, remoteHost string,
+	clientOpts config.DAClientOpts, agReq *AgentRequest) (radigo.PacketCode, error) {
+	tlsCfg, err := ra.cgrCfg.TLSCfg().TLSConfig(false)
+	if err != nil {
+		return 0, fmt.Errorf("dynamic authorization client init failed: %w", err)
+	}
+	dict := ra.dacCfg.dicts.GetInstance(remoteHost)
+	dynAuthReq := radigo.NewPacket(requestType, 1, dict, radigo.NewCoder(), radsecSecret)
+	if err = radAppendAttributes(dynAuthReq, agReq.radDAReq); err != nil {
+		return 0, fmt.Errorf("could not append attributes to the request packet: %w", err)
+	}
+	if clientOpts.Flags.Has(utils.MetaLog) {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> LOG, sending %s for session with ID '%s' to '%s': %s",
+				utils.RadiusAgent, requestType, sessionID, remoteAddr, utils.ToJSON(dynAuthReq)))
+	}
+	dynAuthReply, err := sendRadsecRequest(dynAuthReq, remoteAddr, tlsCfg, dict,
+		ra.cgrCfg.GeneralCfg().ReplyTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	return dynAuthReply.Code, nil
+}
+
 // daRequestAddress ranges over the client_da_addresses map and returns the address configured for a
 // specific client alongside the host.
 func daRequestAddress(remoteAddr string, dynAuthAddresses map[string]config.DAClientOpts) (string, string, error) {
@@ -0,0 +1,234 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+)
+
+const (
+	// radsecSecret is the shared secret used over TLS connections (RFC 6614 section 2.3)
+	radsecSecret = "radsec"
+
+	radHeaderLen    = 20   // code, identifier, length and authenticator
+	radMaxPacketLen = 4096 // RFC 2865 section 3
+)
+
+// readRadPacket reads one RADIUS packet out of a stream connection
+func readRadPacket(r io.Reader) (raw []byte, err error) {
+	var hdr [radHeaderLen]byte
+	if _, err = io.ReadFull(r, hdr[:]); err != nil {
+		return
+	}
+	pktLen := int(binary.BigEndian.Uint16(hdr[2:4]))
+	if pktLen < radHeaderLen || pktLen > radMaxPacketLen {
+		return nil, fmt.Errorf("unexpected packet length: %d", pktLen)
+	}
+	raw = make([]byte, pktLen)
+	copy(raw, hdr[:])
+	_, err = io.ReadFull(r, raw[radHeaderLen:])
+	return
+}
+
+// encodeRadPacket returns the packet in wire format
+func encodeRadPacket(pkt *radigo.Packet) ([]byte, error) {
+	var buf [radMaxPacketLen]byte
+	n, err := pkt.Encode(buf[:])
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// isAuthenticRadsecReq checks the Request Authenticator of the packets which carry one (RFC 2866, RFC 5176)
+func isAuthenticRadsecReq(raw []byte) bool {
+	switch radigo.PacketCode(raw[0]) {
+	case radigo.AccountingRequest, radigo.DisconnectRequest, radigo.CoARequest:
+	default:
+		return true
+	}
+	hash := md5.New()
+	hash.Write(raw[:4])
+	hash.Write(make([]byte, 16))
+	hash.Write(raw[radHeaderLen:])
+	hash.Write([]byte(radsecSecret))
+	return bytes.Equal(hash.Sum(nil), raw[4:radHeaderLen])
+}
+
+// isAuthenticRadsecReply checks the Response Authenticator against the one of the request
+func isAuthenticRadsecReply(raw []byte, reqAuthenticator [16]byte) bool {
+	hash := md5.New()
+	hash.Write(raw[:4])
+	hash.Write(reqAuthenticator[:])
+	hash.Write(raw[radHeaderLen:])
+	hash.Write([]byte(radsecSecret))
+	return bytes.Equal(hash.Sum(nil), raw[4:radHeaderLen])
+}
+
+// newRadsecServer constructs a RADIUS over TLS server (RFC 6614)
+func newRadsecServer(addr string, tlsCfg func() (*tls.Config, error),
+	dicts *radigo.Dictionaries) *radsecServer {
+	return &radsecServer{
+		addr:     addr,
+		tlsCfg:   tlsCfg,
+		dicts:    dicts,
+		coder:    radigo.NewCoder(),
+		handlers: make(map[radigo.PacketCode]func(*radigo.Packet, net.Addr) (*radigo.Packet, error)),
+	}
+}
+
+// radsecServer serves RADIUS requests over TLS connections
+type radsecServer struct {
+	addr     string
+	tlsCfg   func() (*tls.Config, error) // called for each handshake
+	dicts    *radigo.Dictionaries
+	coder    radigo.Coder
+	handlers map[radigo.PacketCode]func(*radigo.Packet, net.Addr) (*radigo.Packet, error)
+}
+
+// ListenAndServe listens on the TCP address and serves the TLS connections until stopChan is closed
+func (rs *radsecServer) ListenAndServe(stopChan <-chan struct{}) (err error) {
+	tlsCfg := &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return rs.tlsCfg()
+		},
+	}
+	var ln net.Listener
+	if ln, err = tls.Listen(utils.TCP, rs.addr, tlsCfg); err != nil {
+		return
+	}
+	go func() {
+		<-stopChan
+		ln.Close()
+	}()
+	for {
+		var conn net.Conn
+		if conn, err = ln.Accept(); err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			utils.Logger.Warning(fmt.Sprintf("<%s> error <%v> when accepting RadSec connection",
+				utils.RadiusAgent, err))
+			continue
+		}
+		go rs.handleConn(conn)
+	}
+}
+
+// handleConn reads the packets out of one connection, replying to them asynchronously
+func (rs *radsecServer) handleConn(conn net.Conn) {
+	defer conn.Close()
+	remoteAddr := conn.RemoteAddr()
+	remoteHost, _, _ := net.SplitHostPort(remoteAddr.String())
+	var wrMux sync.Mutex // protects the writes on connection
+	for {
+		raw, err := readRadPacket(conn)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error <%v> when reading RadSec packets from <%s>, disconnecting",
+					utils.RadiusAgent, err, remoteAddr))
+			}
+			return
+		}
+		if !isAuthenticRadsecReq(raw) {
+			utils.Logger.Warning(fmt.Sprintf("<%s> ignoring unauthentic RadSec packet from <%s>",
+				utils.RadiusAgent, remoteAddr))
+			continue
+		}
+		pkt := radigo.NewPacket(0, 0, rs.dicts.GetInstance(remoteHost), rs.coder, radsecSecret)
+		if err = pkt.Decode(raw); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error <%v> when decoding RadSec packet from <%s>",
+				utils.RadiusAgent, err, remoteAddr))
+			continue
+		}
+		go func() { // execute the handler asynchronously
+			hndlr, has := rs.handlers[pkt.Code]
+			if !has {
+				hndlr = func(pkt *radigo.Packet, _ net.Addr) (*radigo.Packet, error) {
+					return nil, fmt.Errorf("no handler for packet with code: %d", pkt.Code)
+				}
+			}
+			rply, err := hndlr(pkt, remoteAddr)
+			if err != nil {
+				rply = pkt.NegativeReply(err.Error())
+			}
+			if rply == nil {
+				return
+			}
+			rplyRaw, err := encodeRadPacket(rply)
+			if err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error <%v> when encoding RadSec reply",
+					utils.RadiusAgent, err))
+				return
+			}
+			wrMux.Lock()
+			defer wrMux.Unlock()
+			if _, err = conn.Write(rplyRaw); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error <%v> when sending RadSec reply to <%s>",
+					utils.RadiusAgent, err, remoteAddr))
+			}
+		}()
+	}
+}
+
+// sendRadsecRequest sends the request over a new TLS connection and returns the reply
+func sendRadsecRequest(req *radigo.Packet, addr string, tlsCfg *tls.Config,
+	dict *radigo.Dictionary, timeout time.Duration) (rply *radigo.Packet, err error) {
+	var conn *tls.Conn
+	if conn, err = tls.DialWithDialer(&net.Dialer{Timeout: timeout}, utils.TCP, addr, tlsCfg); err != nil {
+		return
+	}
+	defer conn.Close()
+	var raw []byte
+	if raw, err = encodeRadPacket(req); err != nil {
+		return
+	}
+	if timeout != 0 {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+	if _, err = conn.Write(raw); err != nil {
+		return
+	}
+	for {
+		if raw, err = readRadPacket(conn); err != nil {
+			return
+		}
+		if raw[1] == req.Identifier {
+			break
+		}
+	}
+	if !isAuthenticRadsecReply(raw, req.Authenticator) {
+		return nil, errors.New("invalid packet")
+	}
+	rply = radigo.NewPacket(0, 0, dict, radigo.NewCoder(), radsecSecret)
+	err = rply.Decode(raw)
+	return
+}
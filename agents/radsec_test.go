@@ -0,0 +1,176 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/radigo"
+)
+
+// newTestTLSCfg generates a self signed certificate used by both server and client
+func newTestTLSCfg(t *testing.T) *config.TLSCfg {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	crtPath, keyPath := path.Join(dir, "agent.crt"), path.Join(dir, "agent.key")
+	if err = os.WriteFile(crtPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return &config.TLSCfg{
+		ServerCerificate: crtPath,
+		ServerKey:        keyPath,
+		ServerPolicy:     int(tls.RequireAndVerifyClientCert),
+		ClientCerificate: crtPath,
+		ClientKey:        keyPath,
+		CaCertificate:    crtPath,
+	}
+}
+
+// startRadsecTestServer starts a RadSec server replying to the CoA requests
+func startRadsecTestServer(t *testing.T, tlsCfg *config.TLSCfg) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	rs := newRadsecServer(addr, func() (*tls.Config, error) {
+		return tlsCfg.TLSConfig(true)
+	}, radigo.NewDictionaries(map[string]*radigo.Dictionary{radigo.MetaDefault: dictRad}))
+	rs.handlers[radigo.CoARequest] = func(req *radigo.Packet, remoteAddr net.Addr) (*radigo.Packet, error) {
+		if host, _, _ := net.SplitHostPort(remoteAddr.String()); host != "127.0.0.1" {
+			t.Errorf("unexpected remote address: %s", remoteAddr)
+		}
+		rply := req.Reply()
+		rply.Code = radigo.CoAACK
+		return rply, nil
+	}
+	stopChan := make(chan struct{})
+	go rs.ListenAndServe(stopChan)
+	t.Cleanup(func() { close(stopChan) })
+	for i := 0; i < 50; i++ { // wait for the listener
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return addr
+}
+
+func TestRadsecSendRequest(t *testing.T) {
+	tlsCfg := newTestTLSCfg(t)
+	addr := startRadsecTestServer(t, tlsCfg)
+	clntCfg, err := tlsCfg.TLSConfig(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := radigo.NewPacket(radigo.CoARequest, 1, dictRad, coder, radsecSecret)
+	if err = req.AddAVPWithName("User-Name", "1001", ""); err != nil {
+		t.Fatal(err)
+	}
+	rply, err := sendRadsecRequest(req, addr, clntCfg, dictRad, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rply.Code != radigo.CoAACK {
+		t.Errorf("expected reply code %s, received: %s", radigo.CoAACK, rply.Code)
+	}
+
+	req = radigo.NewPacket(radigo.DisconnectRequest, 2, dictRad, coder, radsecSecret)
+	if rply, err = sendRadsecRequest(req, addr, clntCfg, dictRad, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if rply.Code != radigo.DisconnectNAK {
+		t.Errorf("expected reply code %s for missing handler, received: %s", radigo.DisconnectNAK, rply.Code)
+	}
+
+	clntCfg.Certificates = nil // mutual TLS is required by the server
+	req = radigo.NewPacket(radigo.CoARequest, 3, dictRad, coder, radsecSecret)
+	if _, err = sendRadsecRequest(req, addr, clntCfg, dictRad, time.Second); err == nil {
+		t.Error("expected error for client without certificate")
+	}
+}
+
+func TestRadsecIsAuthenticReq(t *testing.T) {
+	req := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, radsecSecret)
+	if err := req.AddAVPWithName("User-Name", "1001", ""); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := encodeRadPacket(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isAuthenticRadsecReq(raw) {
+		t.Error("expected the request to be authentic")
+	}
+	raw[len(raw)-1] = '2'
+	if isAuthenticRadsecReq(raw) {
+		t.Error("expected the altered request to not be authentic")
+	}
+}
+
+func TestRadsecTLSConfigErr(t *testing.T) {
+	if _, err := (&config.TLSCfg{ServerCerificate: "/not/a/cert"}).TLSConfig(true); err == nil {
+		t.Error("expected error for invalid certificate path")
+	}
+	if _, err := (&config.TLSCfg{CaCertificate: "/not/a/ca"}).TLSConfig(false); err == nil {
+		t.Error("expected error for invalid CA path")
+	}
+}
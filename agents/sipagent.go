@@ -19,8 +19,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"crypto/rand"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"regexp"
 	"sync"
 	"time"
@@ -31,6 +34,7 @@ import (
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/sipingo"
+	"nhooyr.io/websocket"
 )
 
 const (
@@ -58,6 +62,10 @@ func NewSIPAgent(connMgr *engine.ConnManager, cfg *config.CGRConfig,
 		cfg:      cfg,
 		ackMap:   make(map[string]chan struct{}),
 		stopChan: make(chan struct{}),
+		nonceKey: make([]byte, 32),
+	}
+	if _, err = rand.Read(sa.nonceKey); err != nil {
+		return nil, err
 	}
 	msgTemplates := sa.cfg.TemplatesCfg()
 	// Inflate *template field types
@@ -84,6 +92,7 @@ type SIPAgent struct {
 	stopChan chan struct{}
 	ackMap   map[string]chan struct{}
 	ackLocks sync.RWMutex
+	nonceKey []byte // signs the nonces of the digest challenges sent by the registrar
 }
 
 // Shutdown will stop the SIPAgent server
@@ -102,9 +111,17 @@ func (sa *SIPAgent) ListenAndServe() (err error) {
 		utils.SIPAgent, sa.cfg.SIPAgentCfg().ListenNet, sa.cfg.SIPAgentCfg().Listen))
 	switch sa.cfg.SIPAgentCfg().ListenNet {
 	case utils.TCP:
-		return sa.serveTCP(sa.stopChan)
+		return sa.serveTCP(sa.stopChan, nil)
+	case utils.TLSNoCaps:
+		var tlsCfg *tls.Config
+		if tlsCfg, err = sa.cfg.TLSCfg().TLSConfig(true); err != nil {
+			return
+		}
+		return sa.serveTCP(sa.stopChan, tlsCfg)
 	case utils.UDP:
 		return sa.serveUDP(sa.stopChan)
+	case utils.WS, utils.WSS:
+		return sa.serveWS(sa.stopChan)
 	default:
 		return fmt.Errorf("Unecepected protocol %s", sa.cfg.SIPAgentCfg().ListenNet)
 	}
@@ -161,7 +178,8 @@ func (sa *SIPAgent) serveUDP(stop chan struct{}) (err error) {
 	}
 }
 
-func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
+// serveTCP listens for SIP over TCP, over TLS if tlsCfg is not nil
+func (sa *SIPAgent) serveTCP(stop chan struct{}, tlsCfg *tls.Config) (err error) {
 	var l *net.TCPListener
 	var addr *net.TCPAddr
 	if addr, err = net.ResolveTCPAddr("tcp", sa.cfg.SIPAgentCfg().Listen); err != nil {
@@ -200,6 +218,17 @@ func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
 		}
 		wg.Add(1)
 		go func(conn net.Conn) {
+			if tlsCfg != nil {
+				var err error
+				if conn, err = sa.tlsHandshake(conn, tlsCfg); err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> TLS handshake with %s failed because of error %s",
+							utils.SIPAgent, conn.RemoteAddr(), err.Error()))
+					conn.Close()
+					wg.Done()
+					return
+				}
+			}
 			buf := make([]byte, bufferSize)
 			for {
 				select {
@@ -229,6 +258,76 @@ func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
 	}
 }
 
+// tlsHandshake wraps the accepted connection into a TLS one, doing the handshake within the connect timeout
+func (sa *SIPAgent) tlsHandshake(conn net.Conn, tlsCfg *tls.Config) (net.Conn, error) {
+	tlsConn := tls.Server(conn, tlsCfg)
+	tlsConn.SetDeadline(time.Now().Add(sa.cfg.GeneralCfg().ConnectTimeout))
+	if err := tlsConn.Handshake(); err != nil {
+		return conn, err
+	}
+	tlsConn.SetDeadline(time.Time{})
+	return tlsConn, nil
+}
+
+// serveWS listens for SIP over WebSocket (RFC 7118), over TLS for wss
+func (sa *SIPAgent) serveWS(stop chan struct{}) (err error) {
+	srv := &http.Server{
+		Addr:    sa.cfg.SIPAgentCfg().Listen,
+		Handler: http.HandlerFunc(sa.handleWS),
+	}
+	if sa.cfg.SIPAgentCfg().ListenNet == utils.WSS {
+		if srv.TLSConfig, err = sa.cfg.TLSCfg().TLSConfig(true); err != nil {
+			return
+		}
+	}
+	var l net.Listener
+	if l, err = net.Listen(utils.TCP, sa.cfg.SIPAgentCfg().Listen); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: %s unable to listen to: %s",
+				utils.SIPAgent, err.Error(), sa.cfg.SIPAgentCfg().Listen))
+		return
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		if srv.TLSConfig != nil {
+			errChan <- srv.ServeTLS(l, utils.EmptyString, utils.EmptyString)
+			return
+		}
+		errChan <- srv.Serve(l)
+	}()
+	select {
+	case err = <-errChan:
+		return
+	case <-stop:
+		return srv.Close()
+	}
+}
+
+// handleWS reads the SIP messages out of the WebSocket connection, one message per frame
+func (sa *SIPAgent) handleWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		Subprotocols:   []string{utils.SIPWsSubProto},
+		OriginPatterns: []string{"*"}, // the WebRTC clients are served from other origins
+	})
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> unable to accept WebSocket connection from %s because of error %s",
+				utils.SIPAgent, r.RemoteAddr, err.Error()))
+		return
+	}
+	defer conn.CloseNow()
+	ctx := r.Context()
+	for {
+		_, msg, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+		sa.answerMessage(string(msg), r.RemoteAddr, func(ans []byte) error {
+			return conn.Write(ctx, websocket.MessageText, ans)
+		}) // do not log the received error because is already logged in function so for now just ignore it
+	}
+}
+
 func (sa *SIPAgent) answerMessage(messageStr, addr string, write func(ans []byte) error) (err error) {
 	var sipMessage sipingo.Message // recreate map SIP
 	if sipMessage, err = sipingo.NewMessage(messageStr); err != nil {
@@ -298,6 +397,11 @@ func (sa *SIPAgent) handleMessage(sipMessage sipingo.Message, remoteHost string)
 	if sipMessage[userAgentHeader] != "" {
 		sipMessage[userAgentHeader] = fmt.Sprintf("%s@%s", utils.CGRateS, utils.Version)
 	}
+	if sa.cfg.SIPAgentCfg().Registrar != nil && sa.cfg.SIPAgentCfg().Registrar.Enabled {
+		if sipAnswer, handled := sa.handleRegistrar(sipMessage); handled {
+			return sipAnswer
+		}
+	}
 	sipMessageIface := make(map[string]any)
 	for k, v := range sipMessage {
 		sipMessageIface[k] = v
@@ -333,7 +437,7 @@ func (sa *SIPAgent) handleMessage(sipMessage sipingo.Message, remoteHost string)
 			opts, reqProcessor.Tenant, sa.cfg.GeneralCfg().DefaultTenant,
 			utils.FirstNonEmpty(reqProcessor.Timezone,
 				config.CgrConfig().GeneralCfg().DefaultTimezone),
-			sa.filterS, nil)
+			sa.filterS, map[string]utils.DataProvider{utils.MetaSIPReg: sipRegistrationsDP{}})
 		var lclProcessed bool
 		if lclProcessed, err = sa.processRequest(reqProcessor, agReq); err != nil {
 			utils.Logger.Warning(
@@ -0,0 +1,141 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/sipingo"
+	"nhooyr.io/websocket"
+)
+
+const testSIPRegister = "REGISTER sip:192.168.58.203 SIP/2.0\r\nCall-ID: 4d4d84b0cc83fc90aca41e295cd8ff43@0:0:0:0:0:0:0:0\r\nCSeq: 1 REGISTER\r\nFrom: \"1001\" <sip:1001@192.168.58.203>;tag=99f35805\r\nTo: <sip:1001@192.168.58.203>\r\nVia: SIP/2.0/TLS 192.168.58.201:5061;branch=z9hG4bK-393139\r\nContent-Length: 0\r\n\r\n"
+
+// startTestSIPAgent starts a SIPAgent answering the requests with 405 Method Not Allowed
+func startTestSIPAgent(t *testing.T, listenNet string) (addr string, tlsCfg *tls.Config) {
+	ln, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr = ln.Addr().String()
+	ln.Close()
+	cfg := config.NewDefaultCGRConfig()
+	*cfg.TLSCfg() = *newTestTLSCfg(t)
+	cfg.SIPAgentCfg().Listen = addr
+	cfg.SIPAgentCfg().ListenNet = listenNet
+	cfg.SIPAgentCfg().RetransmissionTimer = 0
+	cfg.TemplatesCfg()[utils.MetaErr] = []*config.FCTemplate{}
+	cfg.SIPAgentCfg().RequestProcessors = []*config.RequestProcessor{{
+		ID:    "Register",
+		Flags: utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+		ReplyFields: []*config.FCTemplate{{
+			Tag: "Request", Path: "*rep.Request", Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("SIP/2.0 405 Method Not Allowed", utils.InfieldSep),
+		}},
+	}}
+	for _, rp := range cfg.SIPAgentCfg().RequestProcessors {
+		for _, fld := range rp.ReplyFields {
+			fld.ComputePath()
+		}
+	}
+	sa, err := NewSIPAgent(nil, cfg, engine.NewFilterS(cfg, nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go sa.ListenAndServe()
+	t.Cleanup(sa.Shutdown)
+	for i := 0; i < 50; i++ { // wait for the listener
+		if conn, err := net.Dial(utils.TCP, addr); err == nil {
+			conn.Close()
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if tlsCfg, err = cfg.TLSCfg().TLSConfig(false); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func checkTestSIPAnswer(t *testing.T, ans []byte) {
+	t.Helper()
+	received, err := sipingo.NewMessage(string(ans))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "SIP/2.0 405 Method Not Allowed"; received["Request"] != exp {
+		t.Errorf("expected %q, received: %q", exp, received["Request"])
+	}
+}
+
+func TestSIPAgentTLS(t *testing.T) {
+	addr, tlsCfg := startTestSIPAgent(t, utils.TLSNoCaps)
+	conn, err := tls.Dial(utils.TCP, addr, tlsCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err = conn.Write([]byte(testSIPRegister)); err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, bufferSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestSIPAnswer(t, buf[:n])
+}
+
+func TestSIPAgentWebSocket(t *testing.T) {
+	for _, listenNet := range []string{utils.WS, utils.WSS} {
+		t.Run(listenNet, func(t *testing.T) {
+			addr, tlsCfg := startTestSIPAgent(t, listenNet)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			conn, _, err := websocket.Dial(ctx, listenNet+"://"+addr, &websocket.DialOptions{
+				HTTPClient:   &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}},
+				Subprotocols: []string{utils.SIPWsSubProto},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.CloseNow()
+			if conn.Subprotocol() != utils.SIPWsSubProto {
+				t.Errorf("expected subprotocol %q, received %q", utils.SIPWsSubProto, conn.Subprotocol())
+			}
+			if err = conn.Write(ctx, websocket.MessageText, []byte(testSIPRegister)); err != nil {
+				t.Fatal(err)
+			}
+			_, ans, err := conn.Read(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTestSIPAnswer(t, ans)
+		})
+	}
+}
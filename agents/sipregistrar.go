@@ -0,0 +1,316 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/sipingo"
+)
+
+const (
+	optionsMethod         = "OPTIONS"
+	registerMethod        = "REGISTER"
+	toHeader              = "To"
+	contactHeader         = "Contact"
+	expiresHeader         = "Expires"
+	allowHeader           = "Allow"
+	authorizationHeader   = "Authorization"
+	wwwAuthenticateHeader = "WWW-Authenticate"
+	sipOK                 = "SIP/2.0 200 OK"
+	sipUnauthorized       = "SIP/2.0 401 Unauthorized"
+	sipBadRequest         = "SIP/2.0 400 Bad Request"
+	sipForbidden          = "SIP/2.0 403 Forbidden"
+	sipAllowedMethods     = "INVITE, ACK, OPTIONS, REGISTER"
+	sipNonceTTL           = 5 * time.Minute // time the client has to answer the digest challenge
+)
+
+var (
+	sipExpiresRgx = regexp.MustCompile(`;\s*expires=(\d+)`)
+)
+
+// sipRegistration is a contact registered to the SIPAgent
+type sipRegistration struct {
+	Contact   string // URI where the user can be reached
+	ExpiresAt time.Time
+}
+
+// handleRegistrar answers the OPTIONS and the REGISTER requests within the agent,
+// returning false for the requests left to the request processors
+func (sa *SIPAgent) handleRegistrar(sipMessage sipingo.Message) (sipAnswer sipingo.Message, handled bool) {
+	switch sipMessage.MethodFrom(requestHeader) {
+	case optionsMethod:
+		sipAnswer = bareSipErr(sipMessage, sipOK)
+		sipAnswer[allowHeader] = sipAllowedMethods
+		return sipAnswer, true
+	case registerMethod:
+		return sa.register(sipMessage), true
+	}
+	return
+}
+
+// register authenticates the REGISTER request and stores the contact
+func (sa *SIPAgent) register(sipMessage sipingo.Message) sipingo.Message {
+	regCfg := sa.cfg.SIPAgentCfg().Registrar
+	realm := utils.FirstNonEmpty(regCfg.Realm, sipMessage.HostFrom(requestHeader))
+	auth, has := sipMessage[authorizationHeader]
+	if !has {
+		return sa.sipChallenge(sipMessage, realm, false)
+	}
+	digest := parseSIPDigest(auth)
+	if digest["realm"] != realm {
+		return sa.sipChallenge(sipMessage, realm, false)
+	}
+	if valid, stale := sa.checkSIPNonce(digest["nonce"]); !valid {
+		return sa.sipChallenge(sipMessage, realm, stale)
+	}
+	if digest["uri"] != sipRequestURI(sipMessage[requestHeader]) {
+		return bareSipErr(sipMessage, sipBadRequest)
+	}
+	user := sipMessage.UserFrom(toHeader)
+	if digest["username"] != user { // the credentials of one user cannot register another one
+		return bareSipErr(sipMessage, sipForbidden)
+	}
+	pass, err := sa.sipUserPassword(digest["username"])
+	if err != nil {
+		if err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s querying password of user <%s>",
+					utils.SIPAgent, err.Error(), digest["username"]))
+			return bareSipErr(sipMessage, sipServerErr)
+		}
+		return bareSipErr(sipMessage, sipForbidden)
+	}
+	if subtle.ConstantTimeCompare([]byte(sipDigestResponse(digest, registerMethod, pass)),
+		[]byte(digest["response"])) != 1 {
+		return bareSipErr(sipMessage, sipForbidden)
+	}
+	contact := sipMessage[contactHeader]
+	expires := sipExpires(contact, sipMessage[expiresHeader], regCfg.MaxExpires)
+	if contact == "*" || expires == 0 { // unregister all or the given contact
+		if err = engine.Cache.Remove(utils.CacheSIPRegistrations, user,
+			true, utils.NonTransactional); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s removing the registration of user <%s>",
+					utils.SIPAgent, err.Error(), user))
+			return bareSipErr(sipMessage, sipServerErr)
+		}
+		sipAnswer := bareSipErr(sipMessage, sipOK)
+		delete(sipAnswer, contactHeader)
+		return sipAnswer
+	}
+	reg := &sipRegistration{
+		Contact:   sipContactURI(contact),
+		ExpiresAt: time.Now().Add(expires),
+	}
+	if err = engine.Cache.Set(utils.CacheSIPRegistrations, user, reg,
+		nil, true, utils.NonTransactional); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s storing the registration of user <%s>",
+				utils.SIPAgent, err.Error(), user))
+		return bareSipErr(sipMessage, sipServerErr)
+	}
+	sipAnswer := bareSipErr(sipMessage, sipOK)
+	delete(sipAnswer, authorizationHeader)
+	expiresSecs := strconv.Itoa(int(expires.Seconds()))
+	sipAnswer[contactHeader] = fmt.Sprintf("<%s>;expires=%s", reg.Contact, expiresSecs)
+	sipAnswer[expiresHeader] = expiresSecs
+	return sipAnswer
+}
+
+// sipChallenge builds the 401 answer challenging the client to authenticate
+func (sa *SIPAgent) sipChallenge(sipMessage sipingo.Message, realm string, stale bool) sipingo.Message {
+	sipAnswer := bareSipErr(sipMessage, sipUnauthorized)
+	delete(sipAnswer, authorizationHeader)
+	sipAnswer[wwwAuthenticateHeader] = fmt.Sprintf(`Digest realm="%s", nonce="%s", algorithm=MD5, qop="auth"`,
+		realm, sa.newSIPNonce(time.Now()))
+	if stale {
+		sipAnswer[wwwAuthenticateHeader] += ", stale=true"
+	}
+	return sipAnswer
+}
+
+// newSIPNonce builds a nonce out of the time it was issued at, signed with the key of the agent
+// so it can be checked without keeping the issued nonces
+func (sa *SIPAgent) newSIPNonce(issuedAt time.Time) string {
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(issuedAt.UnixNano()))
+	mac := hmac.New(sha256.New, sa.nonceKey)
+	mac.Write(ts)
+	return hex.EncodeToString(append(ts, mac.Sum(nil)[:16]...))
+}
+
+// checkSIPNonce checks if the nonce was issued by the agent, stale being true for the expired ones
+func (sa *SIPAgent) checkSIPNonce(nonce string) (valid, stale bool) {
+	b, err := hex.DecodeString(nonce)
+	if err != nil || len(b) != 24 {
+		return
+	}
+	mac := hmac.New(sha256.New, sa.nonceKey)
+	mac.Write(b[:8])
+	if !hmac.Equal(b[8:], mac.Sum(nil)[:16]) {
+		return
+	}
+	if time.Since(time.Unix(0, int64(binary.BigEndian.Uint64(b[:8])))) > sipNonceTTL {
+		return false, true
+	}
+	return true, false
+}
+
+// sipUserPassword queries AttributeS for the UserPassword of the registering user
+func (sa *SIPAgent) sipUserPassword(user string) (pass string, err error) {
+	ev := &utils.CGREvent{
+		Tenant: sa.cfg.GeneralCfg().DefaultTenant,
+		ID:     utils.GenUUID(),
+		Event: map[string]any{
+			utils.AccountField: user,
+		},
+		APIOpts: map[string]any{
+			utils.OptsContext: utils.MetaSIPReg,
+		},
+	}
+	var rply engine.AttrSProcessEventReply
+	if err = sa.connMgr.Call(context.TODO(), sa.cfg.SIPAgentCfg().Registrar.AttributeSConns,
+		utils.AttributeSv1ProcessEvent, ev, &rply); err != nil {
+		if err.Error() == utils.ErrNotFound.Error() {
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	if rply.CGREvent == nil {
+		return utils.EmptyString, utils.ErrNotFound
+	}
+	passIface, has := rply.Event[utils.UserPassword]
+	if !has {
+		return utils.EmptyString, utils.ErrNotFound
+	}
+	return utils.IfaceAsString(passIface), nil
+}
+
+// parseSIPDigest returns the parameters of the digest credentials
+func parseSIPDigest(auth string) (params map[string]string) {
+	params = make(map[string]string)
+	auth = strings.TrimSpace(auth)
+	if len(auth) < 7 || !strings.EqualFold(auth[:7], "Digest ") {
+		return
+	}
+	var inQuotes bool
+	start := 7
+	for i := start; i <= len(auth); i++ {
+		if i < len(auth) && (auth[i] != ',' || inQuotes) {
+			if auth[i] == '"' {
+				inQuotes = !inQuotes
+			}
+			continue
+		}
+		if key, val, has := strings.Cut(auth[start:i], "="); has {
+			params[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(val), `"`)
+		}
+		start = i + 1
+	}
+	return
+}
+
+// sipDigestResponse computes the digest response expected from the client (RFC 2617)
+func sipDigestResponse(params map[string]string, method, pass string) string {
+	ha1 := md5Hex(params["username"] + ":" + params["realm"] + ":" + pass)
+	ha2 := md5Hex(method + ":" + params["uri"])
+	if params["qop"] == "auth" {
+		return md5Hex(ha1 + ":" + params["nonce"] + ":" + params["nc"] + ":" +
+			params["cnonce"] + ":" + params["qop"] + ":" + ha2)
+	}
+	return md5Hex(ha1 + ":" + params["nonce"] + ":" + ha2)
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// sipContactURI returns the URI out of the Contact header
+func sipContactURI(contact string) string {
+	if start := strings.IndexByte(contact, '<'); start != -1 {
+		if end := strings.IndexByte(contact[start:], '>'); end != -1 {
+			return contact[start+1 : start+end]
+		}
+	}
+	uri, _, _ := strings.Cut(contact, ";")
+	return strings.TrimSpace(uri)
+}
+
+// sipRequestURI returns the Request-URI out of the request line
+func sipRequestURI(requestLine string) string {
+	if flds := strings.Fields(requestLine); len(flds) == 3 {
+		return flds[1]
+	}
+	return utils.EmptyString
+}
+
+// sipExpires returns the registration time requested by the client, limited to maxExpires
+func sipExpires(contact, expiresHdr string, maxExpires time.Duration) time.Duration {
+	expires := maxExpires
+	if match := sipExpiresRgx.FindStringSubmatch(contact); len(match) == 2 {
+		expiresHdr = match[1]
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(expiresHdr)); err == nil && secs >= 0 {
+		expires = time.Duration(secs) * time.Second
+	}
+	return min(expires, maxExpires)
+}
+
+// sipRegistrationsDP exposes the registered contacts as *sipreg.<user> within the agent requests
+type sipRegistrationsDP struct{}
+
+// String implements utils.DataProvider
+func (sipRegistrationsDP) String() string { return utils.MetaSIPReg }
+
+// FieldAsInterface implements utils.DataProvider
+func (sipRegistrationsDP) FieldAsInterface(fldPath []string) (any, error) {
+	itm, has := engine.Cache.Get(utils.CacheSIPRegistrations, strings.Join(fldPath, utils.NestingSep))
+	if !has {
+		return nil, utils.ErrNotFound
+	}
+	reg, canCast := itm.(*sipRegistration)
+	if !canCast || time.Now().After(reg.ExpiresAt) {
+		return nil, utils.ErrNotFound
+	}
+	return reg.Contact, nil
+}
+
+// FieldAsString implements utils.DataProvider
+func (dp sipRegistrationsDP) FieldAsString(fldPath []string) (string, error) {
+	val, err := dp.FieldAsInterface(fldPath)
+	if err != nil {
+		return utils.EmptyString, err
+	}
+	return utils.IfaceAsString(val), nil
+}
@@ -0,0 +1,249 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/sipingo"
+)
+
+func TestParseSIPDigest(t *testing.T) {
+	auth := `Digest username="Mufasa", realm="testrealm@host.com", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093", uri="/dir/index.html", qop=auth, nc=00000001, cnonce="0a4f113b", response="6629fae49393a05397450978507c4ef1", opaque="5ccc069c403ebaf9f0171e9517f40e41"`
+	exp := map[string]string{
+		"username": "Mufasa",
+		"realm":    "testrealm@host.com",
+		"nonce":    "dcd98b7102dd2f0e8b11d0f600bfb0c093",
+		"uri":      "/dir/index.html",
+		"qop":      "auth",
+		"nc":       "00000001",
+		"cnonce":   "0a4f113b",
+		"response": "6629fae49393a05397450978507c4ef1",
+		"opaque":   "5ccc069c403ebaf9f0171e9517f40e41",
+	}
+	rcv := parseSIPDigest(auth)
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %+v, received %+v", exp, rcv)
+	}
+	// RFC 2617 example
+	if resp := sipDigestResponse(rcv, "GET", "Circle Of Life"); resp != rcv["response"] {
+		t.Errorf("expected response %q, received %q", rcv["response"], resp)
+	}
+	if rcv = parseSIPDigest(`Basic QWxhZGRpbjpvcGVuIHNlc2FtZQ==`); len(rcv) != 0 {
+		t.Errorf("expected no parameters, received %+v", rcv)
+	}
+}
+
+func TestSIPNonce(t *testing.T) {
+	sa := &SIPAgent{nonceKey: []byte("nonceKey")}
+	if valid, stale := sa.checkSIPNonce(sa.newSIPNonce(time.Now())); !valid || stale {
+		t.Errorf("expected valid nonce, received valid: %v, stale: %v", valid, stale)
+	}
+	if valid, stale := sa.checkSIPNonce(sa.newSIPNonce(time.Now().Add(-2 * sipNonceTTL))); valid || !stale {
+		t.Errorf("expected stale nonce, received valid: %v, stale: %v", valid, stale)
+	}
+	nonce := (&SIPAgent{nonceKey: []byte("otherKey")}).newSIPNonce(time.Now())
+	if valid, stale := sa.checkSIPNonce(nonce); valid || stale {
+		t.Errorf("expected invalid nonce, received valid: %v, stale: %v", valid, stale)
+	}
+	if valid, _ := sa.checkSIPNonce("dcd98b7102dd2f0e8b11d0f600bfb0c093"); valid {
+		t.Error("expected invalid nonce")
+	}
+}
+
+func TestSIPRequestURI(t *testing.T) {
+	if rcv := sipRequestURI("REGISTER sip:cgrates.org SIP/2.0"); rcv != "sip:cgrates.org" {
+		t.Errorf("expected %q, received %q", "sip:cgrates.org", rcv)
+	}
+	if rcv := sipRequestURI("SIP/2.0 200 OK"); rcv != "200" {
+		t.Errorf("expected %q, received %q", "200", rcv)
+	}
+	if rcv := sipRequestURI("REGISTER"); rcv != utils.EmptyString {
+		t.Errorf("expected empty Request-URI, received %q", rcv)
+	}
+}
+
+func TestSIPExpires(t *testing.T) {
+	for _, tc := range []struct {
+		contact, expiresHdr string
+		exp                 time.Duration
+	}{
+		{`<sip:1001@192.168.58.201:5060>;expires=600`, "3600", 10 * time.Minute},
+		{`<sip:1001@192.168.58.201:5060>`, "1800", 30 * time.Minute},
+		{`<sip:1001@192.168.58.201:5060>`, "", time.Hour},
+		{`<sip:1001@192.168.58.201:5060>`, "7200", time.Hour},
+		{`*`, "0", 0},
+	} {
+		if rcv := sipExpires(tc.contact, tc.expiresHdr, time.Hour); rcv != tc.exp {
+			t.Errorf("expected %s for %q/%q, received %s", tc.exp, tc.contact, tc.expiresHdr, rcv)
+		}
+	}
+}
+
+func TestSIPContactURI(t *testing.T) {
+	for contact, exp := range map[string]string{
+		`"1001" <sip:1001@192.168.58.201:5060;transport=udp>;expires=3600`: "sip:1001@192.168.58.201:5060;transport=udp",
+		`sip:1001@192.168.58.201:5060;expires=3600`:                        "sip:1001@192.168.58.201:5060",
+	} {
+		if rcv := sipContactURI(contact); rcv != exp {
+			t.Errorf("expected %q, received %q", exp, rcv)
+		}
+	}
+}
+
+func TestSIPAgentRegistrar(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SIPAgentCfg().Registrar.Enabled = true
+	cfg.SIPAgentCfg().Registrar.Realm = "cgrates.org"
+	cfg.TemplatesCfg()[utils.MetaErr] = []*config.FCTemplate{}
+	cfg.SIPAgentCfg().RequestProcessors = []*config.RequestProcessor{{
+		ID:    "Redirect",
+		Flags: utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+		ReplyFields: []*config.FCTemplate{
+			{Tag: "Request", Path: "*rep.Request", Type: utils.MetaConstant,
+				Value: config.NewRSRParsersMustCompile("SIP/2.0 302 Moved Temporarily", utils.InfieldSep)},
+			{Tag: "Contact", Path: "*rep.Contact", Type: utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile("~*sipreg.<~*req.To{*sipuri_user}>", utils.InfieldSep)},
+		},
+	}}
+	for _, fld := range cfg.SIPAgentCfg().RequestProcessors[0].ReplyFields {
+		fld.ComputePath()
+	}
+	attrS := &testMockSessionConn{calls: map[string]func(arg any, rply any) error{
+		utils.AttributeSv1ProcessEvent: func(arg any, rply any) error {
+			ev := arg.(*utils.CGREvent)
+			if ev.APIOpts[utils.OptsContext] != utils.MetaSIPReg {
+				t.Errorf("unexpected context: %v", ev.APIOpts[utils.OptsContext])
+			}
+			if ev.Event[utils.AccountField] != "1002" {
+				return utils.ErrNotFound
+			}
+			*rply.(*engine.AttrSProcessEventReply) = engine.AttrSProcessEventReply{
+				CGREvent: &utils.CGREvent{
+					Tenant: ev.Tenant,
+					ID:     ev.ID,
+					Event: map[string]any{
+						utils.AccountField: "1002",
+						utils.UserPassword: "CGRateS.org",
+					},
+				},
+			}
+			return nil
+		},
+	}}
+	attrSChan := make(chan birpc.ClientConnector, 1)
+	attrSChan <- attrS
+	connMgr := engine.NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes): attrSChan,
+	})
+	sa, err := NewSIPAgent(connMgr, cfg, engine.NewFilterS(cfg, nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRequest := func(method, user string) sipingo.Message {
+		return sipingo.Message{
+			requestHeader: method + " sip:cgrates.org SIP/2.0",
+			"Call-ID":     "4d4d84b0cc83fc90aca41e295cd8ff43@0:0:0:0:0:0:0:0",
+			"CSeq":        "1 " + method,
+			fromHeader:    fmt.Sprintf(`<sip:%s@cgrates.org>;tag=99f35805`, user),
+			toHeader:      fmt.Sprintf(`<sip:%s@cgrates.org>`, user),
+			"Via":         "SIP/2.0/UDP 192.168.58.201:5060;branch=z9hG4bK-393139",
+			contactHeader: fmt.Sprintf(`<sip:%s@192.168.58.201:5060;transport=udp>`, user),
+			expiresHeader: "7200",
+		}
+	}
+	// registers the user answering the challenge with the given credentials and digest uri
+	register := func(user, pass, uri string, msg sipingo.Message) sipingo.Message {
+		t.Helper()
+		ans := sa.handleMessage(msg.Clone(), "192.168.58.201:5060")
+		if ans[requestHeader] != sipUnauthorized {
+			t.Fatalf("expected %q, received %q", sipUnauthorized, ans[requestHeader])
+		}
+		challenge := parseSIPDigest(ans[wwwAuthenticateHeader])
+		if challenge["realm"] != "cgrates.org" || challenge["qop"] != "auth" {
+			t.Fatalf("unexpected challenge: %q", ans[wwwAuthenticateHeader])
+		}
+		digest := map[string]string{
+			"username": user,
+			"realm":    challenge["realm"],
+			"nonce":    challenge["nonce"],
+			"uri":      uri,
+			"qop":      "auth",
+			"nc":       "00000001",
+			"cnonce":   "0a4f113b",
+		}
+		msg[authorizationHeader] = fmt.Sprintf(
+			`Digest username="%s", realm="%s", nonce="%s", uri="%s", qop=auth, nc=00000001, cnonce="0a4f113b", response="%s", algorithm=MD5`,
+			user, digest["realm"], digest["nonce"], digest["uri"], sipDigestResponse(digest, registerMethod, pass))
+		return sa.handleMessage(msg, "192.168.58.201:5060")
+	}
+
+	if ans := sa.handleMessage(newRequest(optionsMethod, "1002"), "192.168.58.201:5060"); ans[requestHeader] != sipOK {
+		t.Errorf("expected %q, received %q", sipOK, ans[requestHeader])
+	} else if ans[allowHeader] != sipAllowedMethods {
+		t.Errorf("expected %q, received %q", sipAllowedMethods, ans[allowHeader])
+	}
+	if ans := register("1002", "wrongPass", "sip:cgrates.org", newRequest(registerMethod, "1002")); ans[requestHeader] != sipForbidden {
+		t.Errorf("expected %q, received %q", sipForbidden, ans[requestHeader])
+	}
+	if ans := register("1003", "CGRateS.org", "sip:cgrates.org", newRequest(registerMethod, "1003")); ans[requestHeader] != sipForbidden {
+		t.Errorf("expected %q, received %q", sipForbidden, ans[requestHeader])
+	}
+	// 1002 authenticates but registers the contact of 1003
+	if ans := register("1002", "CGRateS.org", "sip:cgrates.org", newRequest(registerMethod, "1003")); ans[requestHeader] != sipForbidden {
+		t.Errorf("expected %q, received %q", sipForbidden, ans[requestHeader])
+	}
+	if _, err := (sipRegistrationsDP{}).FieldAsString([]string{"1003"}); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if ans := register("1002", "CGRateS.org", "sip:example.org", newRequest(registerMethod, "1002")); ans[requestHeader] != sipBadRequest {
+		t.Errorf("expected %q, received %q", sipBadRequest, ans[requestHeader])
+	}
+	ans := register("1002", "CGRateS.org", "sip:cgrates.org", newRequest(registerMethod, "1002"))
+	if ans[requestHeader] != sipOK {
+		t.Fatalf("expected %q, received %q", sipOK, ans[requestHeader])
+	}
+	if exp := "<sip:1002@192.168.58.201:5060;transport=udp>;expires=3600"; ans[contactHeader] != exp {
+		t.Errorf("expected %q, received %q", exp, ans[contactHeader])
+	}
+
+	// the registered contact is used by the request processors
+	ans = sa.handleMessage(newRequest(inviteMethod, "1002"), "192.168.58.201:5060")
+	if exp := "SIP/2.0 302 Moved Temporarily"; ans[requestHeader] != exp {
+		t.Errorf("expected %q, received %q", exp, ans[requestHeader])
+	} else if exp := "sip:1002@192.168.58.201:5060;transport=udp"; ans[contactHeader] != exp {
+		t.Errorf("expected %q, received %q", exp, ans[contactHeader])
+	}
+
+	unregister := newRequest(registerMethod, "1002")
+	unregister[expiresHeader] = "0"
+	if ans = register("1002", "CGRateS.org", "sip:cgrates.org", unregister); ans[requestHeader] != sipOK {
+		t.Errorf("expected %q, received %q", sipOK, ans[requestHeader])
+	}
+	if _, err := (sipRegistrationsDP{}).FieldAsString([]string{"1002"}); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
@@ -386,6 +386,11 @@ func (dRoute *DispatcherRouteSv1) GetRoutesList(ctx *context.Context, args *util
 	return dRoute.dRoute.RouteSv1GetRoutesList(ctx, args, reply)
 }
 
+// SimulateRoutes replays the stored CDRs against a RouteProfile
+func (dRoute *DispatcherRouteSv1) SimulateRoutes(ctx *context.Context, args *engine.ArgsSimulateRoutes, reply *engine.RoutesSimulation) error {
+	return dRoute.dRoute.RouteSv1SimulateRoutes(ctx, args, reply)
+}
+
 func NewDispatcherAttributeSv1(dps *dispatchers.DispatcherService) *DispatcherAttributeSv1 {
 	return &DispatcherAttributeSv1{dA: dps}
 }
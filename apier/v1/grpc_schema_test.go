@@ -0,0 +1,59 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"flag"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+)
+
+var updateGRPCSchema = flag.Bool("update_grpc_schema", false, "regenerate data/grpc/cgrates.proto")
+
+// TestGRPCSchema checks data/grpc/cgrates.proto against the APIs reachable over gRPC
+func TestGRPCSchema(t *testing.T) {
+	var srvs []*birpc.Service
+	for _, rcvr := range []any{NewSessionSv1(nil), NewCDRsV1(nil),
+		NewAttributeSv1(nil), NewRouteSv1(nil), NewChargerSv1(nil)} {
+		srv, err := engine.NewService(rcvr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		srvs = append(srvs, srv)
+	}
+	schema := cores.GRPCSchema(srvs...)
+	fPath := path.Join("..", "..", "data", "grpc", "cgrates.proto")
+	if *updateGRPCSchema {
+		if err := os.WriteFile(fPath, []byte(schema), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exp, err := os.ReadFile(fPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(exp) != schema {
+		t.Errorf("%s is outdated, regenerate it with: go test ./apier/v1 -run TestGRPCSchema -update_grpc_schema", fPath)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SnapshotDataDB compacts the write-ahead log of the *internal DataDB into a new snapshot
+func (apierSv1 *APIerSv1) SnapshotDataDB(ctx *context.Context, _ *utils.TenantWithAPIOpts, reply *string) error {
+	if err := snapshotInternalDB(apierSv1.DataManager.DataDB(), utils.DataDB); err != nil {
+		return err
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// SnapshotStorDB compacts the write-ahead log of the *internal StorDB into a new snapshot
+func (apierSv1 *APIerSv1) SnapshotStorDB(ctx *context.Context, _ *utils.TenantWithAPIOpts, reply *string) error {
+	if err := snapshotInternalDB(apierSv1.StorDb, utils.StorDB); err != nil {
+		return err
+	}
+	*reply = utils.OK
+	return nil
+}
+
+func snapshotInternalDB(db any, dbName string) error {
+	iDB, canCast := db.(*engine.InternalDB)
+	if !canCast {
+		return utils.NewErrServerError(fmt.Errorf("%s is not of type %s", dbName, utils.MetaInternal))
+	}
+	if err := iDB.Snapshot(); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	return nil
+}
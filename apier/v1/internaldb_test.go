@@ -0,0 +1,78 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAPIerSv1SnapshotDataDB(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	db := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	apierSv1 := &APIerSv1{
+		DataManager: engine.NewDataManager(db, cfg.CacheCfg(), nil),
+		Config:      cfg,
+	}
+	var reply string
+	expErr := utils.NewErrServerError(utils.NewErrMandatoryIeMissing(utils.InternalDBDumpPathCfg)).Error()
+	if err := apierSv1.SnapshotDataDB(context.Background(), nil, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("expected %q, received %v", expErr, err)
+	}
+
+	dumpDB, err := engine.NewInternalDBWithDump(nil, nil, true, cfg.DataDbCfg().Items, t.TempDir(), -1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dumpDB.Close()
+	apierSv1.DataManager = engine.NewDataManager(dumpDB, cfg.CacheCfg(), nil)
+	if err := apierSv1.SnapshotDataDB(context.Background(), nil, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("expected %q, received %q", utils.OK, reply)
+	}
+}
+
+func TestAPIerSv1SnapshotStorDB(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	apierSv1 := &APIerSv1{
+		Config: cfg,
+	}
+	var reply string
+	expErr := "SERVER_ERROR: stor_db is not of type *internal"
+	if err := apierSv1.SnapshotStorDB(context.Background(), nil, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("expected %q, received %v", expErr, err)
+	}
+
+	storDB, err := engine.NewInternalDBWithDump(nil, nil, false, cfg.StorDbCfg().Items, t.TempDir(), 0, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storDB.Close()
+	apierSv1.StorDb = storDB
+	if err := apierSv1.SnapshotStorDB(context.Background(), nil, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("expected %q, received %q", utils.OK, reply)
+	}
+}
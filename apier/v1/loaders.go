@@ -20,6 +20,7 @@ package v1
 
 import (
 	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/loaders"
 )
 
@@ -41,3 +42,8 @@ func (ldrSv1 *LoaderSv1) Remove(ctx *context.Context, args *loaders.ArgsProcessF
 	rply *string) error {
 	return ldrSv1.ldrS.V1Remove(ctx, args, rply)
 }
+
+func (ldrSv1 *LoaderSv1) DryRun(ctx *context.Context, args *loaders.ArgsDryRun,
+	rply *engine.LoadReport) error {
+	return ldrSv1.ldrS.V1DryRun(ctx, args, rply)
+}
@@ -153,3 +153,8 @@ func (rS *RouteSv1) GetRouteProfilesForEvent(ctx *context.Context, args *utils.C
 func (rS *RouteSv1) GetRoutesList(ctx *context.Context, args *utils.CGREvent, reply *[]string) error {
 	return rS.rS.V1GetRoutesList(ctx, args, reply)
 }
+
+// SimulateRoutes replays the stored CDRs against a RouteProfile, comparing the cost of the chosen routes
+func (rS *RouteSv1) SimulateRoutes(ctx *context.Context, args *engine.ArgsSimulateRoutes, reply *engine.RoutesSimulation) error {
+	return rS.rS.V1SimulateRoutes(ctx, args, reply)
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/cgrates/cgrates/servmanager"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var (
@@ -77,6 +78,38 @@ func startFilterService(filterSChan chan *engine.FilterS, cacheS *engine.CacheS,
 	filterSChan <- engine.NewFilterS(cfg, connMgr, dm)
 }
 
+// registerPrometheusCollector registers the CGRateS collector once FilterS is available
+func registerPrometheusCollector(filterSChan chan *engine.FilterS, connMgr *engine.ConnManager,
+	cfg *config.CGRConfig, shdChan *utils.SyncedChan) {
+	var fltrS *engine.FilterS
+	select {
+	case fltrS = <-filterSChan:
+		filterSChan <- fltrS
+	case <-shdChan.Done():
+		return
+	}
+	if err := prometheus.Register(cores.NewPrometheusCollector(cfg, connMgr, fltrS)); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> could not register the prometheus collector: %v", utils.CoreS, err))
+	}
+}
+
+// startLiveFeed starts publishing the engine events on the HTTP server once FilterS is available
+func startLiveFeed(filterSChan chan *engine.FilterS, server *cores.Server,
+	cfg *config.CGRConfig, shdChan *utils.SyncedChan) {
+	var fltrS *engine.FilterS
+	select {
+	case fltrS = <-filterSChan:
+		filterSChan <- fltrS
+	case <-shdChan.Done():
+		return
+	}
+	lf := engine.NewLiveFeed(cfg, fltrS)
+	go lf.ListenAndServe(shdChan.Done())
+	engine.SetLiveFeed(lf)
+	server.ServeLiveFeed(lf, cfg.HTTPCfg().LiveFeedURL, cfg.HTTPCfg().WSLiveFeedURL,
+		cfg.HTTPCfg().HTTPUseBasicAuth, cfg.HTTPCfg().HTTPAuthUsers)
+}
+
 // initCacheS inits the CacheS and starts precaching as well as populating internal channel for RPC conns
 func initCacheS(internalCacheSChan chan birpc.ClientConnector,
 	server *cores.Server, dm *engine.DataManager, shdChan *utils.SyncedChan,
@@ -206,6 +239,9 @@ func startRPC(server *cores.Server, internalRaterChan,
 		cfg.HTTPCfg().HTTPAuthUsers,
 		shdChan,
 	)
+	if cfg.ListenCfg().GRPCListen != utils.EmptyString {
+		go server.ServeGRPC(cfg.ListenCfg().GRPCListen, shdChan)
+	}
 	if (len(cfg.ListenCfg().RPCGOBTLSListen) != 0 ||
 		len(cfg.ListenCfg().RPCJSONTLSListen) != 0 ||
 		len(cfg.ListenCfg().HTTPTLSListen) != 0) &&
@@ -416,9 +452,17 @@ func main() {
 	}
 
 	// init the concurrentRequests
-	caps := engine.NewCaps(cfg.CoreSCfg().Caps, cfg.CoreSCfg().CapsStrategy)
+	caps := engine.NewCapsWithRateLimits(cfg.CoreSCfg().Caps, cfg.CoreSCfg().CapsStrategy,
+		cfg.CoreSCfg().CapsRateLimits)
 	utils.Logger.Info(fmt.Sprintf("<CoreS> starting version <%s><%s>", vers, goVers))
 
+	// init the tracing of the API calls
+	stopTracing, err := engine.InitTracing(cfg)
+	if err != nil {
+		log.Fatalf("<%s> could not initialize tracing, err: <%s>", utils.CoreS, err.Error())
+	}
+	defer stopTracing()
+
 	// init the channel here because we need to pass them to connManager
 	internalServeManagerChan := make(chan birpc.ClientConnector, 1)
 	internalConfigChan := make(chan birpc.ClientConnector, 1)
@@ -612,7 +656,7 @@ func main() {
 
 	smg := services.NewSessionService(cfg, dmService, server, internalSessionSChan, shdChan, connManager, anz, srvDep)
 
-	ldrs := services.NewLoaderService(cfg, dmService, filterSChan, server,
+	ldrs := services.NewLoaderService(cfg, dmService, storDBService, filterSChan, server,
 		internalLoaderSChan, connManager, anz, srvDep)
 
 	srvManager.AddServices(gvService, attrS, chrS, tS, stS, trS, sgS, reS, routeS, schS, rals,
@@ -636,6 +680,15 @@ func main() {
 	// Start FilterS
 	go startFilterService(filterSChan, cacheS, connManager,
 		cfg, dmService.GetDM())
+	if pOpts := cfg.HTTPCfg().PrometheusOpts; cfg.HTTPCfg().PrometheusURL != utils.EmptyString &&
+		(len(pOpts.StatSConns) != 0 || len(pOpts.ThresholdSConns) != 0 ||
+			len(pOpts.TrendSConns) != 0 || len(pOpts.SessionSConns) != 0) {
+		go registerPrometheusCollector(filterSChan, connManager, cfg, shdChan)
+	}
+	if cfg.HTTPCfg().LiveFeedURL != utils.EmptyString ||
+		cfg.HTTPCfg().WSLiveFeedURL != utils.EmptyString {
+		go startLiveFeed(filterSChan, server, cfg, shdChan)
+	}
 
 	err = initServiceManagerV1(internalServeManagerChan, srvManager, server, anz)
 	if err != nil {
@@ -703,6 +756,7 @@ func main() {
 	<-shdChan.Done()
 	shtdDone := make(chan struct{})
 	go func() {
+		server.StopGRPC() // finish the gRPC requests in progress before the subsystems go down
 		shdWg.Wait()
 		close(shtdDone)
 	}()
@@ -43,7 +43,7 @@ var (
 		"Configuration directory path.")
 	printConfig = cgrLoaderFlags.Bool(utils.PrintCfgCgr, false, "Print the configuration object in JSON format")
 	dataDBType  = cgrLoaderFlags.String(utils.DataDBTypeCgr, dfltCfg.DataDbCfg().Type,
-		"The type of the DataDB database <*redis|*mongo>")
+		"The type of the DataDB database <*redis|*mongo|*sqlite>")
 	dataDBHost = cgrLoaderFlags.String(utils.DataDBHostCgr, dfltCfg.DataDbCfg().Host,
 		"The DataDb host to connect to.")
 	dataDBPort = cgrLoaderFlags.String(utils.DataDBPortCgr, dfltCfg.DataDbCfg().Port,
@@ -88,7 +88,7 @@ var (
 		"Scheme for MongoDB connection <mongodb|mongodb+srv>")
 
 	storDBType = cgrLoaderFlags.String(utils.StorDBTypeCgr, dfltCfg.StorDbCfg().Type,
-		"The type of the storDb database <*mysql|*postgres|*mongo>")
+		"The type of the storDb database <*mysql|*postgres|*mongo|*sqlite>")
 	storDBHost = cgrLoaderFlags.String(utils.StorDBHostCgr, dfltCfg.StorDbCfg().Host,
 		"The storDb host to connect to.")
 	storDBPort = cgrLoaderFlags.String(utils.StorDBPortCgr, dfltCfg.StorDbCfg().Port,
@@ -112,7 +112,7 @@ var (
 	verbose = cgrLoaderFlags.Bool(utils.VerboseCgr, false,
 		"Enable detailed verbose logging output")
 	dryRun = cgrLoaderFlags.Bool(utils.DryRunCfg, false,
-		"When true will not save loaded data to dataDb but just parse it for consistency and errors.")
+		"When true will not save loaded data to dataDb but just parse it for consistency and errors, printing the changes against the stored data.")
 	fieldSep = cgrLoaderFlags.String(utils.FieldSepCgr, ",",
 		`Separator for csv file (by default "," is used)`)
 
@@ -411,6 +411,12 @@ func main() {
 	}
 
 	if *dryRun { // We were just asked to parse the data, not saving it
+		if dataDB != nil { // report the changes against the data already stored
+			report := engine.NewLoadReport(engine.NewDataManager(dataDB, ldrCfg.CacheCfg(), nil))
+			tpReader.DryRun(report, *remove)
+			report.Complete()
+			fmt.Println(utils.ToIJSON(report))
+		}
 		return
 	}
 	if *printConfig {
@@ -50,7 +50,7 @@ var (
 	version = cgrMigratorFlags.Bool(utils.VersionCgr, false, "prints the application version")
 
 	inDataDBType = cgrMigratorFlags.String(utils.DataDBTypeCgr, dfltCfg.DataDbCfg().Type,
-		"the type of the DataDB Database <*redis|*mongo>")
+		"the type of the DataDB Database <*redis|*mongo|*sqlite>")
 	inDataDBHost = cgrMigratorFlags.String(utils.DataDBHostCgr, dfltCfg.DataDbCfg().Host,
 		"the DataDB host")
 	inDataDBPort = cgrMigratorFlags.String(utils.DataDBPortCgr, dfltCfg.DataDbCfg().Port,
@@ -95,7 +95,7 @@ var (
 		"Scheme for MongoDB connection <mongodb|mongodb+srv>")
 
 	outDataDBType = cgrMigratorFlags.String(utils.OutDataDBTypeCfg, utils.MetaDataDB,
-		"output DataDB type <*redis|*mongo>")
+		"output DataDB type <*redis|*mongo|*sqlite>")
 	outDataDBHost = cgrMigratorFlags.String(utils.OutDataDBHostCfg, utils.MetaDataDB,
 		"output DataDB host to connect to")
 	outDataDBPort = cgrMigratorFlags.String(utils.OutDataDBPortCfg, utils.MetaDataDB,
@@ -112,7 +112,7 @@ var (
 		"the name of redis sentinel")
 
 	inStorDBType = cgrMigratorFlags.String(utils.StorDBTypeCgr, dfltCfg.StorDbCfg().Type,
-		"the type of the StorDB Database <*mysql|*postgres|*mongo>")
+		"the type of the StorDB Database <*mysql|*postgres|*mongo|*sqlite>")
 	inStorDBHost = cgrMigratorFlags.String(utils.StorDBHostCgr, dfltCfg.StorDbCfg().Host,
 		"the StorDB host")
 	inStorDBPort = cgrMigratorFlags.String(utils.StorDBPortCgr, dfltCfg.StorDbCfg().Port,
@@ -125,7 +125,7 @@ var (
 		"the StorDB password")
 
 	outStorDBType = cgrMigratorFlags.String(utils.OutStorDBTypeCfg, utils.MetaStorDB,
-		"output StorDB type for move mode <*mysql|*postgres|*mongo>")
+		"output StorDB type for move mode <*mysql|*postgres|*mongo|*sqlite>")
 	outStorDBHost = cgrMigratorFlags.String(utils.OutStorDBHostCfg, utils.MetaStorDB,
 		"output StorDB host")
 	outStorDBPort = cgrMigratorFlags.String(utils.OutStorDBPortCfg, utils.MetaStorDB,
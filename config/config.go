@@ -71,6 +71,10 @@ func newDbDefaults() dbDefaults {
 			"DbName": "internal",
 			"DbPort": "internal",
 		},
+		utils.MetaSQLite: map[string]string{
+			"DbName": "/var/lib/cgrates/cgrates.db",
+			"DbPort": "",
+		},
 	}
 	return deflt
 }
@@ -134,6 +138,7 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.httpCfg = new(HTTPCfg)
 	cfg.httpCfg.dialer = &net.Dialer{}
 	cfg.httpCfg.ClientOpts = &http.Transport{}
+	cfg.httpCfg.PrometheusOpts = new(PrometheusOpts)
 	cfg.filterSCfg = new(FilterSCfg)
 	cfg.ralsCfg = new(RalsCfg)
 	cfg.ralsCfg.MaxComputedUsage = make(map[string]time.Duration)
@@ -180,7 +185,7 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.configSCfg = new(ConfigSCfg)
 	cfg.apiBanCfg = new(APIBanCfg)
 	cfg.sentryPeerCfg = new(SentryPeerCfg)
-	cfg.coreSCfg = new(CoreSCfg)
+	cfg.coreSCfg = &CoreSCfg{Tracing: new(TracingCfg)}
 	cfg.dfltEvExp = &EventExporterCfg{Opts: &EventExporterOpts{
 		Els:   new(ElsOpts),
 		SQL:   new(SQLOpts),
@@ -343,17 +348,25 @@ type CGRConfig struct {
 var posibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
 	utils.MetaResources, utils.MetaFilters, utils.MetaStats,
 	utils.MetaRoutes, utils.MetaThresholds, utils.MetaChargers,
-	utils.MetaDispatchers, utils.MetaDispatcherHosts})
+	utils.MetaDispatchers, utils.MetaDispatcherHosts, utils.MetaDestinations,
+	utils.MetaRates, utils.MetaDestinationRates, utils.MetaRatingPlans,
+	utils.MetaRatingProfiles, utils.MetaActions, utils.MetaActionTriggers,
+	utils.MetaActionPlans, utils.MetaAccountActions})
 
 var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 	utils.MetaKafkajsonMap, utils.MetaFileXML, utils.MetaSQL, utils.MetaFileFWV,
 	utils.MetaFileJSON, utils.MetaNone, utils.MetaAMQPjsonMap, utils.MetaS3jsonMap,
-	utils.MetaSQSjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaNatsjsonMap})
+	utils.MetaSQSjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaNatsjsonMap, utils.MetaFileParquet,
+	utils.MetaFileAvro})
 
 var possibleExporterTypes = utils.NewStringSet([]string{utils.MetaFileCSV, utils.MetaNone, utils.MetaFileFWV,
 	utils.MetaHTTPPost, utils.MetaHTTPjsonMap, utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap,
 	utils.MetaKafkajsonMap, utils.MetaS3jsonMap, utils.MetaElastic, utils.MetaVirt, utils.MetaSQL, utils.MetaNatsjsonMap,
-	utils.MetaLog, utils.MetaRPC})
+	utils.MetaLog, utils.MetaRPC, utils.MetaFileParquet, utils.MetaFileAvro})
+
+var parquetCompressions = utils.NewStringSet([]string{"uncompressed", "snappy", "gzip", "lz4", "zstd"})
+
+var avroCodecs = utils.NewStringSet([]string{"null", "deflate", "snappy"})
 
 // Loads from json configuration object, will be used for defaults, config from file and reload, might need lock
 func (cfg *CGRConfig) loadFromJSONCfg(jsnCfg *CgrJsonCfg) (err error) {
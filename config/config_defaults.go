@@ -62,6 +62,23 @@ const CGRATES_CFG_JSON = `
 	"caps": 0,			// maximum concurrent request allowed ( 0 to disabled )
 	"caps_strategy": "*busy",	// strategy in case of concurrent requests reached	
 	"caps_stats_interval": "0",	// the interval duration we sample for caps stats ( 0 to disabled )
+	"caps_rate_limits": [		// token-bucket limits applied per API method and tenant
+		// {
+		// 	"id": "",			// identifier of the limit
+		// 	"methods": ["*any"],		// API methods limited <*any|Service.*|Service.Method>
+		// 	"tenants": [],			// tenants limited, empty for all; each tenant gets its own bucket
+		// 	"rate": 100,			// requests allowed per second
+		// 	"burst": 100			// maximum requests allowed at once
+		// },
+	],
+	"tracing": {			// OpenTelemetry tracing of the API calls
+		"enabled": false,		// starts spans for the API calls and propagates the trace context in APIOpts
+		"exporter": "*otlp",		// where the spans are exported <*otlp|*stdout|*file>
+		"endpoint": "localhost:4317",	// address of the OTLP/gRPC collector
+		"insecure": true,		// connect to the collector without TLS
+		"file_path": "/var/log/cgrates/traces.json",	// the file where the *file exporter writes the spans
+		"sampler_ratio": 1		// ratio of the new traces sampled, the remote ones follow their parent
+	},
 	"shutdown_timeout": "1s"	// the duration to wait until all services are stopped
 },
 
@@ -88,7 +105,7 @@ const CGRATES_CFG_JSON = `
 
 
 "data_db": {					// database used to store runtime data (eg: accounts)
-	"db_type": "*redis",			// data_db type: <*redis|*mongo>
+	"db_type": "*redis",			// data_db type: <*redis|*mongo|*internal|*sqlite>
 	"db_host": "127.0.0.1",			// data_db host address
 	"db_port": 6379, 			// data_db port to reach the database
 	"db_name": "10", 			// data_db database name to connect to
@@ -155,13 +172,16 @@ const CGRATES_CFG_JSON = `
 		"redisClientKey":"",			// path to client key
 		"redisCACertificate":"",		// path to CA certificate (populate for self-signed certificate otherwise let it empty)
 		"mongoQueryTimeout":"10s",		// timeout for query when mongo is used
-		"mongoConnScheme": "mongodb"		// scheme for MongoDB connection <mongodb|mongodb+srv>
+		"mongoConnScheme": "mongodb",		// scheme for MongoDB connection <mongodb|mongodb+srv>
+		"internalDBDumpPath": "",		// directory where the *internal db keeps its snapshot and write-ahead log, empty to keep the data only in memory
+		"internalDBFsyncInterval": "1s",	// interval of syncing the write-ahead log to disk, 0 to sync on each write, -1 to leave it to the OS
+		"internalDBSnapshotInterval": "1h"	// interval of compacting the write-ahead log into a snapshot, 0 to compact only on shutdown or on demand
 	}
 },
 
 
 "stor_db": {					// database used to store offline tariff plans and CDRs
-	"db_type": "*mysql",			// stor database type to use: <*mongo|*mysql|*postgres|*internal>
+	"db_type": "*mysql",			// stor database type to use: <*mongo|*mysql|*postgres|*internal|*sqlite>
 	"db_host": "127.0.0.1",			// the host to connect to
 	"db_port": 3306,			// the port to reach the stor_db
 	"db_name": "cgrates",			// stor database name
@@ -183,7 +203,10 @@ const CGRATES_CFG_JSON = `
 		//"pgSSLPassword": "",		// specifies the password for the secret key specified in pgSSLKey
 		//"pgSSLCertMode": "allow",	// determines whether a client certificate may be sent to the server, and whether the server is required to request one
 		//"pgSSLRootCert": "",		// name of a file containing SSL certificate authority (CA) certificate(s)
-		"pgSchema": ""			// postgres schema to use
+		"pgSchema": "",			// postgres schema to use
+		"internalDBDumpPath": "",		// directory where the *internal db keeps its snapshot and write-ahead log, empty to keep the data only in memory
+		"internalDBFsyncInterval": "1s",	// interval of syncing the write-ahead log to disk, 0 to sync on each write, -1 to leave it to the OS
+		"internalDBSnapshotInterval": "1h"	// interval of compacting the write-ahead log into a snapshot, 0 to compact only on shutdown or on demand
 	},
 	"items":{
 		"*session_costs": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
@@ -221,7 +244,8 @@ const CGRATES_CFG_JSON = `
 	"http": "127.0.0.1:2080",		// HTTP listening address
 	"rpc_json_tls" : "127.0.0.1:2022",	// RPC JSON TLS listening address
 	"rpc_gob_tls": "127.0.0.1:2023",	// RPC GOB TLS listening address
-	"http_tls": "127.0.0.1:2280"		// HTTP TLS listening address
+	"http_tls": "127.0.0.1:2280",		// HTTP TLS listening address
+	"grpc": ""				// gRPC listening address, empty to disable
 },
 
 
@@ -241,11 +265,24 @@ const CGRATES_CFG_JSON = `
 	"registrars_url": "/registrar",			// registrar service relative URL
 	"prometheus_url": "/prometheus",		// endpoint for prometheus metrics
 	"ws_url": "/ws",				// WebSockets relative URL ("" to disable)
+	"live_feed_url": "/live_feed",			// Server-Sent Events live feed of the session, CDR, threshold and account events ("" to disable)
+	"ws_live_feed_url": "/ws_live_feed",		// WebSocket live feed of the session, CDR, threshold and account events ("" to disable)
 	"freeswitch_cdrs_url": "/freeswitch_json",	// Freeswitch CDRS relative URL ("" to disable)
 	"http_cdrs": "/cdr_http",			// CDRS relative URL ("" to disable)
 	"pprof_path": "/debug/pprof/",			// endpoint for serving runtime profiling data for pprof visualization
 	"use_basic_auth": false,			// use basic authentication
 	"auth_users": {},				// basic authentication usernames and base64-encoded passwords (eg: { "username1": "cGFzc3dvcmQ=", "username2": "cGFzc3dvcmQy "})
+	"prometheus_opts": {				// data published on prometheus_url next to the process metrics
+		"tenants": [],				// tenants queried for metrics, empty for the default tenant
+		"stats_conns": [],			// connections to StatS for StatQueue metrics, empty to disable <""|*internal|$rpc_conns_id>
+		"stat_filters": [],			// filters selecting the published StatQueues
+		"thresholds_conns": [],			// connections to ThresholdS for threshold hits, empty to disable <""|*internal|$rpc_conns_id>
+		"threshold_filters": [],		// filters selecting the published Thresholds
+		"trends_conns": [],			// connections to TrendS for trend growth, empty to disable <""|*internal|$rpc_conns_id>
+		"trend_filters": [],			// filters selecting the published Trends
+		"sessions_conns": [],			// connections to SessionS for active sessions, empty to disable <""|*internal|$rpc_conns_id>
+		"session_filters": []			// filters selecting the counted active sessions
+	},
 	"client_opts":{
 		"skipTlsVerify": false, 		// if enabled Http Client will accept any TLS certificate
 
@@ -332,6 +369,7 @@ const CGRATES_CFG_JSON = `
 		"*sentrypeer":{"limit": -1, "ttl": "86400s", "static_ttl": true, "remote":false, "replicate": false},
 		"*caps_events": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate": false},				// caps cached samples
 		"*replication_hosts": {"limit": 0, "ttl": "", "static_ttl": false, "remote":false, "replicate": false},				// the replication hosts cache(used when replication_filtered is enbled)
+		"*sip_registrations": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate": false},			// contacts registered to the SIPAgent
 	},
 	"replication_conns": [],
 	"remote_conns": []	// the conns that are queried when the items are not found in cache
@@ -497,6 +535,8 @@ const CGRATES_CFG_JSON = `
 	"attributes_conns":[],				// RPC Connections IDs
 	"cache": {
 		"*file_csv": {"limit": -1, "ttl": "5s", "static_ttl": false},
+		// "*file_parquet": {"limit": -1, "ttl": "5s", "static_ttl": false},
+		// "*file_avro": {"limit": -1, "ttl": "5s", "static_ttl": false},
 	},
 	"exporters": [
 		{
@@ -517,6 +557,10 @@ const CGRATES_CFG_JSON = `
 				// CSV
 				// "csvFieldSeparator": ",",			// separator used when reading the fields
 
+				// Parquet and Avro
+				// "parquetCompression": "snappy",		// compression codec of the parquet files <uncompressed|snappy|gzip|lz4|zstd>
+				// "avroCodec": "null",			// compression codec of the avro files <null|deflate|snappy>
+
 				
  				// Elasticsearch options
 				// "elsCloud":true,			// ExportPath will be  an CLoud ID deployment
@@ -566,6 +610,9 @@ const CGRATES_CFG_JSON = `
 				// "kafkaTLS": false,			// if true, it will try to authenticate the server
 				// "kafkaCAPath": "", 			// path to certificate authority pem
 				// "kafkaSkipTLSVerify: false, 		// if true it will skip certificate verification
+				// "kafkaEncoding": "*json",		// the encoding of the exported messages <*json|*avro>
+				// "kafkaSchemaRegistry": "",		// schema registry URL where the avro schema is registered, empty to send plain avro
+				// "kafkaSchemaSubject": "",		// schema registry subject, defaults to <kafkaTopic>-value
 
 
 				// AMQP
@@ -722,7 +769,7 @@ const CGRATES_CFG_JSON = `
 "diameter_agent": {
 	"enabled": false,						// enables the diameter agent: <true|false>
 	"listen": "127.0.0.1:3868",					// address where to listen for diameter requests <x.y.z.y/x1.y1.z1.y1:1234>
-	"listen_net": "tcp",						// transport type for diameter <tcp|sctp>
+	"listen_net": "tcp",						// transport type for diameter <tcp|sctp|tls>
 	"dictionaries_path": "/usr/share/cgrates/diameter/dict/",	// path towards directory holding additional dictionaries to load
 	"sessions_conns": ["*birpc_internal"],
 	"origin_host": "CGR-DA",					// diameter Origin-Host AVP used in replies
@@ -733,6 +780,30 @@ const CGRATES_CFG_JSON = `
 	"asr_template": "",						// enable AbortSession message being sent to client on DisconnectSession
 	"rar_template": "",						// template used to build the Re-Auth-Request
 	"forced_disconnect": "*none",					// the request to send to diameter on DisconnectSession <*none|*asr|*rar>
+	"allowed_peers": [],						// peers allowed to connect, empty for any <$origin_host[:$origin_realm]|*any[:$origin_realm]>
+	"peers": [							// peers the agent connects to, in the order of preference for failover
+		// {
+		//	"id": "ocs1",					// identifier of the peer, used in logs
+		//	"network": "tcp",				// transport type towards the peer <tcp|sctp|tls>
+		//	"address": "127.0.0.1:3868",			// address of the peer
+		//	"auth_application_ids": [4],			// Auth-Application-Id AVPs advertised within the CER
+		//	"acct_application_ids": []			// Acct-Application-Id AVPs advertised within the CER
+		// }
+	],
+	"watchdog_interval": "30s",					// interval between the DWRs sent towards the peers
+	"reconnect_interval": "5s",					// initial time to wait before reconnecting to a peer, doubled on each failed attempt
+	"max_reconnect_interval": "5m",					// max time to wait in between reconnect attempts
+	"routes": [							// relay the matching requests towards the peers instead of processing them, first matching route wins
+		// {
+		//	"id": "ocs",					// identifier of the route, used in logs
+		//	"realm": "*any",				// Destination-Realm of the relayed requests <*any|$realm>
+		//	"application_ids": [],				// Application-Ids of the relayed requests, empty for any
+		//	"peers": [					// peers (out of the peers list) receiving the requests, tried in the order of the weight
+		//		{"id": "ocs1", "weight": 20}
+		//	],
+		//	"request_processor": ""				// id of the request processor ran before relaying, empty for none
+		// }
+	],
 	"request_processors": []					// list of processors to be applied to diameter messages
 },
 
@@ -741,7 +812,7 @@ const CGRATES_CFG_JSON = `
 	"enabled": false,					// enables the radius agent: <true|false>
 	"listeners":[
 		{
-			"network": "udp",			// network to listen on <udp|tcp>
+			"network": "udp",			// network to listen on <udp|tcp|tls>
 			"auth_address": "127.0.0.1:1812",	// address where to listen for radius authentication requests <x.y.z.y:1234>
 			"acct_address": "127.0.0.1:1813"	// address where to listen for radius accounting requests <x.y.z.y:1234>
 		}
@@ -756,7 +827,7 @@ const CGRATES_CFG_JSON = `
 	},
 	"client_da_addresses": { 				// configuration for clients capable of handling Dynamic Authorization (CoA/DM) requests.
 		// "nasIdentifier": { 				// identifier for the NAS, typically the host from the initial RADIUS packet.
-		// 	"transport": "udp", 			// transport protocol for Dynamic Authorization requests, defaults to UDP.
+		// 	"transport": "udp", 			// transport protocol for Dynamic Authorization requests <udp|tcp|tls>, defaults to UDP.
 		// 	"host": "", 				// optionally specify an alternative host for DA requests. Defaults to the NAS identifier if empty.
 		// 	"port": 3799, 				// port for Dynamic Authorization requests, default is 3799.
 		// 	"flags": [] 				// additional options, currently supports *log for logging DA requests before sending.
@@ -904,8 +975,12 @@ const CGRATES_CFG_JSON = `
 	"attributes_conns": [],		// connections to AttributeS for altering events before route queries: <""|*internal|$rpc_conns_id>
 	"resources_conns": [],		// connections to ResourceS for *res sorting, empty to disable functionality: <""|*internal|$rpc_conns_id>
 	"stats_conns": [],		// connections to StatS for *stats sorting, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
+	"rankings_conns": [],		// connections to RankingS for *rank sorting, empty to disable functionality: <""|*internal|$rpc_conns_id>
+	"trends_conns": [],		// connections to TrendS for *trend sorting, empty to disable functionality: <""|*internal|$rpc_conns_id>
 	"rals_conns": [],		// connections to Rater for calculating cost, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
+	"cdrs_conns": [],		// connections to CDRs for querying the CDRs replayed by route simulations: <""|*internal|$rpc_conns_id>
 	"default_ratio":1,		// default ratio used in case of *load strategy
+	"ranking_stale_age": "0",	// rankings not updated within this interval are ignored by *rank sorting, falling back to weight, 0 to disable
 	"opts": {
 		"*context": "*routes",
 		// "*profileCount": 1,
@@ -929,6 +1004,8 @@ const CGRATES_CFG_JSON = `
 		"field_separator": ",",				// separator used in case of csv files
 		"tp_in_dir": "/var/spool/cgrates/loader/in",	// absolute path towards the directory where the TPs are stored
 		"tp_out_dir": "/var/spool/cgrates/loader/out",	// absolute path towards the directory where processed TPs will be moved
+		"pull_url": "",					// remote source pulled into tp_in_dir: <""|http(s)://host/path|s3://bucket/prefix>
+		"pull_interval": "0",				// interval between consecutive pulls out of pull_url, 0 to disable pulling
 		"data":[					// data profiles to load
 			{
 				"type": "*attributes",		// data source type
@@ -1032,6 +1109,8 @@ const CGRATES_CFG_JSON = `
 					{"tag": "RouteBlocker", "path": "RouteBlocker", "type": "*variable", "value": "~*req.13"},
 					{"tag": "RouteParameters", "path": "RouteParameters", "type": "*variable", "value": "~*req.14"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.15"},
+					{"tag": "RouteRankingIDs", "path": "RouteRankingIDs", "type": "*variable", "value": "~*req.16"},
+					{"tag": "RouteTrendIDs", "path": "RouteTrendIDs", "type": "*variable", "value": "~*req.17"},
 				],
 			},
 			{
@@ -1229,10 +1308,16 @@ const CGRATES_CFG_JSON = `
 "sip_agent": {					// SIP Agents, only used for redirections
 	"enabled": false,			// enables the SIP agent: <true|false>
 	"listen": "127.0.0.1:5060",		// address where to listen for SIP requests <x.y.z.y:1234>
-	"listen_net": "udp",			// network to listen on <udp|tcp|tcp-tls>
+	"listen_net": "udp",			// network to listen on <udp|tcp|tls|ws|wss>
 	"sessions_conns": ["*internal"],
 	"timezone": "",				// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
 	"retransmission_timer": "1s",		// the duration to wait to receive an ACK before resending the reply
+	"registrar": {				// answers OPTIONS and REGISTER within the agent, before the request processors
+		"enabled": false,			// enables the registrar: <true|false>
+		"realm": "",				// realm of the digest challenge, empty for the host of the request URI
+		"attributes_conns": ["*internal"],	// connections to AttributeS for the UserPassword of the registering users: <*internal|$rpc_conns_id>
+		"max_expires": "1h"			// registrations requesting longer expiry are shortened to it
+	},
 	"request_processors": []		// request processors to be applied to SIP messages
 },
 
@@ -71,7 +71,16 @@ func TestDfCoreSJsonCfg(t *testing.T) {
 		Caps:                utils.IntPointer(0),
 		Caps_strategy:       utils.StringPointer(utils.MetaBusy),
 		Caps_stats_interval: utils.StringPointer("0"),
-		Shutdown_timeout:    utils.StringPointer("1s"),
+		Caps_rate_limits:    &[]*CapsRateLimitJsonCfg{},
+		Tracing: &TracingJsonCfg{
+			Enabled:       utils.BoolPointer(false),
+			Exporter:      utils.StringPointer(utils.MetaOTLP),
+			Endpoint:      utils.StringPointer("localhost:4317"),
+			Insecure:      utils.BoolPointer(true),
+			File_path:     utils.StringPointer("/var/log/cgrates/traces.json"),
+			Sampler_ratio: utils.Float64Pointer(1),
+		},
+		Shutdown_timeout: utils.StringPointer("1s"),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -242,6 +251,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheReplicationHosts: {Limit: utils.IntPointer(0),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Remote: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheSIPRegistrations: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Remote: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
 		},
 		Replication_conns: &[]string{},
 		Remote_conns:      &[]string{},
@@ -266,6 +278,7 @@ func TestDfListenJsonCfg(t *testing.T) {
 		Rpc_json_tls: utils.StringPointer("127.0.0.1:2022"),
 		Rpc_gob_tls:  utils.StringPointer("127.0.0.1:2023"),
 		Http_tls:     utils.StringPointer("127.0.0.1:2280"),
+		Grpc:         utils.StringPointer(""),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -309,6 +322,10 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 			RedisClientCertificate:  utils.StringPointer(utils.EmptyString),
 			RedisClientKey:          utils.StringPointer(utils.EmptyString),
 			RedisCACertificate:      utils.StringPointer(utils.EmptyString),
+
+			InternalDBDumpPath:         utils.StringPointer(utils.EmptyString),
+			InternalDBFsyncInterval:    utils.StringPointer("1s"),
+			InternalDBSnapshotInterval: utils.StringPointer("1h"),
 		},
 		Items: &map[string]*ItemOptJson{
 			utils.MetaAccounts: {
@@ -604,6 +621,10 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 			PgSSLMode:          utils.StringPointer(utils.PgSSLModeDisable),
 			MySQLLocation:      utils.StringPointer("Local"),
 			PgSchema:           utils.StringPointer(""),
+
+			InternalDBDumpPath:         utils.StringPointer(utils.EmptyString),
+			InternalDBFsyncInterval:    utils.StringPointer("1s"),
+			InternalDBSnapshotInterval: utils.StringPointer("1h"),
 		},
 		Items: &map[string]*ItemOptJson{
 			utils.CacheTBLTPTimings: {
@@ -1011,20 +1032,26 @@ func TestAsteriskAgentJsonCfg(t *testing.T) {
 
 func TestDiameterAgentJsonCfg(t *testing.T) {
 	eCfg := &DiameterAgentJsonCfg{
-		Enabled:              utils.BoolPointer(false),
-		Listen:               utils.StringPointer("127.0.0.1:3868"),
-		Listen_net:           utils.StringPointer(utils.TCP),
-		Dictionaries_path:    utils.StringPointer("/usr/share/cgrates/diameter/dict/"),
-		Sessions_conns:       &[]string{rpcclient.BiRPCInternal},
-		Origin_host:          utils.StringPointer("CGR-DA"),
-		Origin_realm:         utils.StringPointer("cgrates.org"),
-		Vendor_id:            utils.IntPointer(0),
-		Product_name:         utils.StringPointer("CGRateS"),
-		Synced_conn_requests: utils.BoolPointer(false),
-		Asr_template:         utils.StringPointer(""),
-		Rar_template:         utils.StringPointer(""),
-		Forced_disconnect:    utils.StringPointer(utils.MetaNone),
-		Request_processors:   &[]*ReqProcessorJsnCfg{},
+		Enabled:                utils.BoolPointer(false),
+		Listen:                 utils.StringPointer("127.0.0.1:3868"),
+		Listen_net:             utils.StringPointer(utils.TCP),
+		Dictionaries_path:      utils.StringPointer("/usr/share/cgrates/diameter/dict/"),
+		Sessions_conns:         &[]string{rpcclient.BiRPCInternal},
+		Origin_host:            utils.StringPointer("CGR-DA"),
+		Origin_realm:           utils.StringPointer("cgrates.org"),
+		Vendor_id:              utils.IntPointer(0),
+		Product_name:           utils.StringPointer("CGRateS"),
+		Synced_conn_requests:   utils.BoolPointer(false),
+		Asr_template:           utils.StringPointer(""),
+		Rar_template:           utils.StringPointer(""),
+		Forced_disconnect:      utils.StringPointer(utils.MetaNone),
+		Allowed_peers:          &[]string{},
+		Peers:                  &[]*DiamPeerJsnCfg{},
+		Watchdog_interval:      utils.StringPointer("30s"),
+		Reconnect_interval:     utils.StringPointer("5s"),
+		Max_reconnect_interval: utils.StringPointer("5m"),
+		Routes:                 &[]*DiamRouteJsnCfg{},
+		Request_processors:     &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1268,8 +1295,12 @@ func TestDfRouteSJsonCfg(t *testing.T) {
 		Attributes_conns:      &[]string{},
 		Resources_conns:       &[]string{},
 		Stats_conns:           &[]string{},
+		Rankings_conns:        &[]string{},
+		Trends_conns:          &[]string{},
 		Rals_conns:            &[]string{},
+		Cdrs_conns:            &[]string{},
 		Default_ratio:         utils.IntPointer(1),
+		Ranking_stale_age:     utils.StringPointer("0"),
 		Nested_fields:         utils.BoolPointer(false),
 		Opts: &RoutesOptsJson{
 			Context:      utils.StringPointer(utils.MetaRoutes),
@@ -1301,6 +1332,8 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 			Field_separator: utils.StringPointer(","),
 			Tp_in_dir:       utils.StringPointer("/var/spool/cgrates/loader/in"),
 			Tp_out_dir:      utils.StringPointer("/var/spool/cgrates/loader/out"),
+			Pull_url:        utils.StringPointer(utils.EmptyString),
+			Pull_interval:   utils.StringPointer("0"),
 			Data: &[]*LoaderJsonDataType{
 				{
 					Type:      utils.StringPointer(utils.MetaAttributes),
@@ -1621,6 +1654,14 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Weight"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.15")},
+						{Tag: utils.StringPointer("RouteRankingIDs"),
+							Path:  utils.StringPointer("RouteRankingIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.16")},
+						{Tag: utils.StringPointer("RouteTrendIDs"),
+							Path:  utils.StringPointer("RouteTrendIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.17")},
 					},
 				},
 				{
@@ -1866,6 +1907,8 @@ func TestDfHttpJsonCfg(t *testing.T) {
 		Json_rpc_url:        utils.StringPointer("/jsonrpc"),
 		Registrars_url:      utils.StringPointer("/registrar"),
 		PrometheusURL:       utils.StringPointer("/prometheus"),
+		Live_feed_url:       utils.StringPointer("/live_feed"),
+		Ws_live_feed_url:    utils.StringPointer("/ws_live_feed"),
 		Ws_url:              utils.StringPointer("/ws"),
 		Freeswitch_cdrs_url: utils.StringPointer("/freeswitch_json"),
 		Http_Cdrs:           utils.StringPointer("/cdr_http"),
@@ -1888,6 +1931,17 @@ func TestDfHttpJsonCfg(t *testing.T) {
 			DialFallbackDelay:     utils.StringPointer("300ms"),
 			DialKeepAlive:         utils.StringPointer("30s"),
 		},
+		Prometheus_opts: &PrometheusOptsJson{
+			Tenants:           &[]string{},
+			Stats_conns:       &[]string{},
+			Stat_filters:      &[]string{},
+			Thresholds_conns:  &[]string{},
+			Threshold_filters: &[]string{},
+			Trends_conns:      &[]string{},
+			Trend_filters:     &[]string{},
+			Sessions_conns:    &[]string{},
+			Session_filters:   &[]string{},
+		},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -21,6 +21,7 @@ package config
 import (
 	"fmt"
 	"math"
+	"net/url"
 	"os"
 	"path"
 	"slices"
@@ -131,6 +132,16 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, ldrSCfg.TpOutDir)
 			}
 		}
+		if ldrSCfg.PullURL != utils.EmptyString {
+			if u, err := url.Parse(ldrSCfg.PullURL); err != nil {
+				return fmt.Errorf("<%s> invalid pull_url: %s", utils.LoaderS, ldrSCfg.PullURL)
+			} else if !slices.Contains([]string{utils.SchemeHTTP, utils.SchemeHTTPS, utils.SchemeS3}, u.Scheme) {
+				return fmt.Errorf("<%s> unsupported pull_url scheme: %s", utils.LoaderS, u.Scheme)
+			} else if qry := u.Query(); u.Scheme == utils.SchemeS3 &&
+				(qry.Has("key") || qry.Has("secret") || qry.Has("token")) { // credentials would be exposed in logs and config
+				return fmt.Errorf("<%s> credentials not supported within pull_url, use the default AWS credentials instead", utils.LoaderS)
+			}
+		}
 		if ldrSCfg.LockFilePath != utils.EmptyString { // tpOutDir support empty string for no moving files after process
 			pathL := ldrSCfg.GetLockFilePath()
 			if _, err := os.Stat(path.Dir(pathL)); err != nil && os.IsNotExist(err) {
@@ -311,6 +322,58 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.DiameterAgent, connID)
 			}
 		}
+		if !slices.Contains([]string{utils.TCP, utils.SCTP, utils.TLSNoCaps}, cfg.diameterAgentCfg.ListenNet) {
+			return fmt.Errorf("<%s> unsupported listen_net: <%s>", utils.DiameterAgent, cfg.diameterAgentCfg.ListenNet)
+		}
+		if cfg.diameterAgentCfg.ListenNet == utils.TLSNoCaps &&
+			(cfg.tlsCfg.ServerCerificate == utils.EmptyString || cfg.tlsCfg.ServerKey == utils.EmptyString) {
+			return fmt.Errorf("<%s> tls listener requires the server certificate and key to be defined in the %s section", utils.DiameterAgent, TlsCfgJson)
+		}
+		peerIDs := make(utils.StringSet)
+		for _, peer := range cfg.diameterAgentCfg.Peers {
+			if peer.ID == utils.EmptyString {
+				return fmt.Errorf("<%s> peer with address <%s> has no id", utils.DiameterAgent, peer.Address)
+			}
+			if peerIDs.Has(peer.ID) {
+				return fmt.Errorf("<%s> duplicated peer id: <%s>", utils.DiameterAgent, peer.ID)
+			}
+			peerIDs.Add(peer.ID)
+			if peer.Address == utils.EmptyString {
+				return fmt.Errorf("<%s> no address defined for peer <%s>", utils.DiameterAgent, peer.ID)
+			}
+			if !slices.Contains([]string{utils.TCP, utils.SCTP, utils.TLSNoCaps}, peer.Network) {
+				return fmt.Errorf("<%s> unsupported network: <%s> for peer <%s>", utils.DiameterAgent, peer.Network, peer.ID)
+			}
+		}
+		for _, route := range cfg.diameterAgentCfg.Routes {
+			if route.Realm == utils.EmptyString {
+				return fmt.Errorf("<%s> no realm defined for route <%s>", utils.DiameterAgent, route.ID)
+			}
+			if len(route.Peers) == 0 {
+				return fmt.Errorf("<%s> no peers defined for route <%s>", utils.DiameterAgent, route.ID)
+			}
+			for _, peer := range route.Peers {
+				if !peerIDs.Has(peer.ID) {
+					return fmt.Errorf("<%s> peer <%s> used by route <%s> not defined", utils.DiameterAgent, peer.ID, route.ID)
+				}
+			}
+			if route.RequestProcessor != utils.EmptyString &&
+				!slices.ContainsFunc(cfg.diameterAgentCfg.RequestProcessors, func(rp *RequestProcessor) bool {
+					return rp.ID == route.RequestProcessor
+				}) {
+				return fmt.Errorf("<%s> request processor <%s> used by route <%s> not defined",
+					utils.DiameterAgent, route.RequestProcessor, route.ID)
+			}
+		}
+		if len(cfg.diameterAgentCfg.Peers) != 0 {
+			if cfg.diameterAgentCfg.ReconnectInterval <= 0 {
+				return fmt.Errorf("<%s> %s needs to be positive", utils.DiameterAgent, utils.ReconnectIntervalCfg)
+			}
+			if cfg.diameterAgentCfg.MaxReconnectInterval < cfg.diameterAgentCfg.ReconnectInterval {
+				return fmt.Errorf("<%s> %s cannot be lower than %s", utils.DiameterAgent,
+					utils.MaxReconnectIntervalCfg, utils.ReconnectIntervalCfg)
+			}
+		}
 		for prf, tmp := range cfg.templates {
 			for _, field := range tmp {
 				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
@@ -369,6 +432,16 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			if err := utils.CheckInLineFilter(req.Filters); err != nil {
 				return fmt.Errorf("<%s> %s for %s at %s", utils.DiameterAgent, err, req.Filters, utils.RequestProcessorsCfg)
 			}
+			if req.Flags.Has(utils.MetaPeerRequest) {
+				if len(cfg.diameterAgentCfg.Peers) == 0 {
+					return fmt.Errorf("<%s> no peers defined for the %s of request processor <%s>",
+						utils.DiameterAgent, utils.MetaPeerRequest, req.ID)
+				}
+				if tplID := req.Flags.ParamValue(utils.MetaPeerRequest); cfg.templates[tplID] == nil {
+					return fmt.Errorf("<%s> template <%s> used by the %s of request processor <%s> not defined",
+						utils.DiameterAgent, tplID, utils.MetaPeerRequest, req.ID)
+				}
+			}
 		}
 	}
 	//Radius Agent
@@ -383,6 +456,20 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> DMR Template %s not defined", utils.RadiusAgent, cfg.radiusAgentCfg.DMRTemplate)
 			}
 		}
+		for _, lstn := range cfg.radiusAgentCfg.Listeners {
+			if !slices.Contains([]string{utils.UDP, utils.TCP, utils.TLSNoCaps}, lstn.Network) {
+				return fmt.Errorf("<%s> unsupported network: <%s>", utils.RadiusAgent, lstn.Network)
+			}
+			if lstn.Network == utils.TLSNoCaps &&
+				(cfg.tlsCfg.ServerCerificate == utils.EmptyString || cfg.tlsCfg.ServerKey == utils.EmptyString) {
+				return fmt.Errorf("<%s> tls listener requires the server certificate and key to be defined in the %s section", utils.RadiusAgent, TlsCfgJson)
+			}
+		}
+		for client, daOpts := range cfg.radiusAgentCfg.ClientDaAddresses {
+			if !slices.Contains([]string{utils.UDP, utils.TCP, utils.TLSNoCaps}, daOpts.Transport) {
+				return fmt.Errorf("<%s> unsupported transport: <%s> for client_da_addresses: <%s>", utils.RadiusAgent, daOpts.Transport, client)
+			}
+		}
 		for _, connID := range cfg.radiusAgentCfg.SessionSConns {
 			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.sessionSCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.RadiusAgent)
@@ -540,6 +627,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 
 	//SIP Agent
 	if cfg.sipAgentCfg.Enabled {
+		if !slices.Contains([]string{utils.UDP, utils.TCP, utils.TLSNoCaps, utils.WS, utils.WSS}, cfg.sipAgentCfg.ListenNet) {
+			return fmt.Errorf("<%s> unsupported listen_net: <%s>", utils.SIPAgent, cfg.sipAgentCfg.ListenNet)
+		}
+		if (cfg.sipAgentCfg.ListenNet == utils.TLSNoCaps || cfg.sipAgentCfg.ListenNet == utils.WSS) &&
+			(cfg.tlsCfg.ServerCerificate == utils.EmptyString || cfg.tlsCfg.ServerKey == utils.EmptyString) {
+			return fmt.Errorf("<%s> %s listener requires the server certificate and key to be defined in the %s section",
+				utils.SIPAgent, cfg.sipAgentCfg.ListenNet, TlsCfgJson)
+		}
 		if len(cfg.sipAgentCfg.SessionSConns) == 0 {
 			return fmt.Errorf("<%s> no %s connections defined",
 				utils.SIPAgent, utils.SessionS)
@@ -552,6 +647,23 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SIPAgent, connID)
 			}
 		}
+		if cfg.sipAgentCfg.Registrar != nil && cfg.sipAgentCfg.Registrar.Enabled {
+			if len(cfg.sipAgentCfg.Registrar.AttributeSConns) == 0 {
+				return fmt.Errorf("<%s> no %s connections defined for the registrar",
+					utils.SIPAgent, utils.AttributeS)
+			}
+			for _, connID := range cfg.sipAgentCfg.Registrar.AttributeSConns {
+				if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.attributeSCfg.Enabled {
+					return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.AttributeS, utils.SIPAgent)
+				}
+				if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+					return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SIPAgent, connID)
+				}
+			}
+			if cfg.sipAgentCfg.Registrar.MaxExpires <= 0 {
+				return fmt.Errorf("<%s> registrar max_expires needs to be positive", utils.SIPAgent)
+			}
+		}
 		for _, req := range cfg.sipAgentCfg.RequestProcessors {
 			for _, field := range req.RequestFields {
 				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
@@ -657,6 +769,33 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
 			}
 		}
+		for _, connID := range cfg.routeSCfg.RankingSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.rankingsCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.RankingS, utils.RouteS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
+			}
+		}
+		for _, connID := range cfg.routeSCfg.TrendSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.trendsCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.TrendS, utils.RouteS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
+			}
+		}
+		for _, connID := range cfg.routeSCfg.CDRsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.cdrsCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.CDRs, utils.RouteS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
+			}
+		}
+		if cfg.routeSCfg.RankingStaleAge < 0 {
+			return fmt.Errorf("<%s> ranking_stale_age needs to be positive", utils.RouteS)
+		}
 		for _, connID := range cfg.routeSCfg.ResourceSConns {
 			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.resourceSCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.ResourceS, utils.RouteS)
@@ -775,6 +914,16 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				}
 			}
 			switch rdr.Type {
+			case utils.MetaFileParquet, utils.MetaFileAvro:
+				paths := []string{rdr.ProcessedPath, rdr.SourcePath}
+				if rdr.ProcessedPath == utils.EmptyString {
+					paths = []string{rdr.SourcePath}
+				}
+				for _, dir := range paths {
+					if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
+						return fmt.Errorf("<%s> nonexistent folder: %s for reader with ID: %s", utils.ERs, dir, rdr.ID)
+					}
+				}
 			case utils.MetaFileCSV:
 				paths := []string{rdr.ProcessedPath, rdr.SourcePath}
 				if rdr.ProcessedPath == utils.EmptyString {
@@ -923,6 +1072,37 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 						return fmt.Errorf("<%s> nonexistent folder: %s for exporter with ID: %s", utils.EEs, dir, exp.ID)
 					}
 				}
+			case utils.MetaFileParquet, utils.MetaFileAvro:
+				if _, err := os.Stat(exp.ExportPath); err != nil && os.IsNotExist(err) {
+					return fmt.Errorf("<%s> nonexistent folder: %s for exporter with ID: %s", utils.EEs, exp.ExportPath, exp.ID)
+				}
+				if len(exp.ContentFields()) == 0 {
+					return fmt.Errorf("<%s> empty content fields for exporter with ID: %s", utils.EEs, exp.ID)
+				}
+				if exp.Opts.ParquetCompression != nil &&
+					!parquetCompressions.Has(*exp.Opts.ParquetCompression) {
+					return fmt.Errorf("<%s> unsupported %s: %s for exporter with ID: %s", utils.EEs,
+						utils.ParquetCompressionOpt, *exp.Opts.ParquetCompression, exp.ID)
+				}
+				if exp.Opts.AvroCodec != nil &&
+					!avroCodecs.Has(*exp.Opts.AvroCodec) {
+					return fmt.Errorf("<%s> unsupported %s: %s for exporter with ID: %s", utils.EEs,
+						utils.AvroCodecOpt, *exp.Opts.AvroCodec, exp.ID)
+				}
+			case utils.MetaKafkajsonMap:
+				if exp.Opts.Kafka == nil || exp.Opts.Kafka.Encoding == nil {
+					break
+				}
+				switch *exp.Opts.Kafka.Encoding {
+				case utils.MetaJSON:
+				case utils.MetaAvro:
+					if len(exp.ContentFields()) == 0 {
+						return fmt.Errorf("<%s> empty content fields for exporter with ID: %s", utils.EEs, exp.ID)
+					}
+				default:
+					return fmt.Errorf("<%s> unsupported %s: %s for exporter with ID: %s", utils.EEs,
+						utils.KafkaEncoding, *exp.Opts.Kafka.Encoding, exp.ID)
+				}
 			case utils.MetaSQL:
 				if len(exp.ContentFields()) == 0 {
 					return fmt.Errorf("<%s> empty content fields for exporter with ID: %s", utils.EEs, exp.ID)
@@ -974,8 +1154,18 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> unsupported pgSSLCertMode (sslcertmode) in storDB configuration", utils.StorDB)
 		}
 	}
+	if cfg.storDbCfg.Type == utils.MetaInternal && cfg.storDbCfg.Opts.InternalDBDumpPath != utils.EmptyString {
+		if _, err := os.Stat(cfg.storDbCfg.Opts.InternalDBDumpPath); err != nil && os.IsNotExist(err) {
+			return fmt.Errorf("<%s> nonexistent folder: %s", utils.StorDB, cfg.storDbCfg.Opts.InternalDBDumpPath)
+		}
+	}
 	// DataDB sanity checks
 	if cfg.dataDbCfg.Type == utils.MetaInternal {
+		if cfg.dataDbCfg.Opts.InternalDBDumpPath != utils.EmptyString {
+			if _, err := os.Stat(cfg.dataDbCfg.Opts.InternalDBDumpPath); err != nil && os.IsNotExist(err) {
+				return fmt.Errorf("<%s> nonexistent folder: %s", utils.DataDB, cfg.dataDbCfg.Opts.InternalDBDumpPath)
+			}
+		}
 		for key, config := range cfg.cacheCfg.Partitions {
 			if utils.DataDBPartitions.Has(key) && config.Limit != 0 {
 				return fmt.Errorf("<%s> %s needs to be 0 when DataBD is *internal, received : %d", utils.CacheS, key, config.Limit)
@@ -1178,6 +1368,37 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> the CleanupInterval needs to be bigger than 0", utils.AnalyzerS)
 		}
 	}
+	// CoreS rate limits check
+	for _, rl := range cfg.coreSCfg.CapsRateLimits {
+		if len(rl.Methods) == 0 {
+			return fmt.Errorf("<%s> no methods defined for rate limit with id: <%s>", utils.CoreS, rl.ID)
+		}
+		if rl.Rate <= 0 {
+			return fmt.Errorf("<%s> the rate for rate limit with id: <%s> needs to be bigger than 0", utils.CoreS, rl.ID)
+		}
+		if rl.Burst < 1 {
+			return fmt.Errorf("<%s> the burst for rate limit with id: <%s> needs to be at least 1", utils.CoreS, rl.ID)
+		}
+	}
+	// CoreS tracing check
+	if tr := cfg.coreSCfg.Tracing; tr.Enabled {
+		switch tr.Exporter {
+		case utils.MetaOTLP:
+			if tr.Endpoint == utils.EmptyString {
+				return fmt.Errorf("<%s> empty endpoint for tracing exporter: <%s>", utils.CoreS, tr.Exporter)
+			}
+		case utils.MetaFile:
+			if tr.FilePath == utils.EmptyString {
+				return fmt.Errorf("<%s> empty file_path for tracing exporter: <%s>", utils.CoreS, tr.Exporter)
+			}
+		case utils.MetaStdLog:
+		default:
+			return fmt.Errorf("<%s> unsupported tracing exporter: <%s>", utils.CoreS, tr.Exporter)
+		}
+		if tr.SamplerRatio < 0 || tr.SamplerRatio > 1 {
+			return fmt.Errorf("<%s> the tracing sampler_ratio needs to be between 0 and 1", utils.CoreS)
+		}
+	}
 
 	return nil
 }
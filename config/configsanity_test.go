@@ -19,6 +19,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -184,6 +185,17 @@ func TestConfigSanityLoaders(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
+	cfg.loaderCfg[0].PullURL = "s3://cgrates/tariffs?region=eu-west-1&key=AKID&secret=SECRET"
+	expected = "<LoaderS> credentials not supported within pull_url, use the default AWS credentials instead"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.loaderCfg[0].PullURL = "s3://cgrates/tariffs?region=eu-west-1"
+	expected = "<LoaderS> unsupported data type wrongtype"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
 	cfg.loaderCfg = LoaderSCfgs{
 		&LoaderSCfg{
 			Enabled:  true,
@@ -463,7 +475,8 @@ func TestConfigSanityDAgent(t *testing.T) {
 		},
 	}
 	cfg.diameterAgentCfg = &DiameterAgentCfg{
-		Enabled: true,
+		Enabled:   true,
+		ListenNet: utils.TCP,
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -497,6 +510,75 @@ func TestConfigSanityDAgent(t *testing.T) {
 	}
 
 	cfg.rpcConns["test"] = nil
+	cfg.diameterAgentCfg.ListenNet = utils.UDP
+	expected = "<DiameterAgent> unsupported listen_net: <udp>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.ListenNet = utils.TLSNoCaps
+	expected = "<DiameterAgent> tls listener requires the server certificate and key to be defined in the tls section"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.ListenNet = utils.TCP
+
+	cfg.diameterAgentCfg.Peers = []*DiameterPeer{{Address: "127.0.0.1:3868"}}
+	expected = "<DiameterAgent> peer with address <127.0.0.1:3868> has no id"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Peers = []*DiameterPeer{
+		{ID: "ocs1", Network: utils.TCP, Address: "127.0.0.1:3868"},
+		{ID: "ocs1", Network: utils.TCP, Address: "127.0.0.1:3869"},
+	}
+	expected = "<DiameterAgent> duplicated peer id: <ocs1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Peers = []*DiameterPeer{{ID: "ocs1", Network: utils.TCP}}
+	expected = "<DiameterAgent> no address defined for peer <ocs1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Peers = []*DiameterPeer{{ID: "ocs1", Network: utils.UDP, Address: "127.0.0.1:3868"}}
+	expected = "<DiameterAgent> unsupported network: <udp> for peer <ocs1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Peers[0].Network = utils.TCP
+	expected = "<DiameterAgent> reconnect_interval needs to be positive"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.ReconnectInterval = 5 * time.Second
+	expected = "<DiameterAgent> max_reconnect_interval cannot be lower than reconnect_interval"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.MaxReconnectInterval = 5 * time.Minute
+	cfg.diameterAgentCfg.Routes = []*DiameterRoute{{ID: "route1"}}
+	expected = "<DiameterAgent> no realm defined for route <route1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Routes[0].Realm = utils.MetaAny
+	expected = "<DiameterAgent> no peers defined for route <route1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Routes[0].Peers = []*DiameterRoutePeer{{ID: "ocs2"}}
+	expected = "<DiameterAgent> peer <ocs2> used by route <route1> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Routes[0].Peers[0].ID = "ocs1"
+	cfg.diameterAgentCfg.Routes[0].RequestProcessor = "acct"
+	expected = "<DiameterAgent> request processor <acct> used by route <route1> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Routes[0].RequestProcessor = "cgrates"
+
 	expected = "<DiameterAgent> MANDATORY_IE_MISSING: [Path] for template *ees at SessionId"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
@@ -586,6 +668,19 @@ func TestConfigSanityDAgent(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 	cfg.diameterAgentCfg.RequestProcessors[0].Filters = []string{"*string:~*req.Valid.Field"}
+
+	cfg.diameterAgentCfg.RequestProcessors[0].Filters = nil
+	cfg.diameterAgentCfg.RequestProcessors[0].Flags = utils.FlagsWithParamsFromSlice([]string{"*peer_request:TPL_CCR"})
+	expected = "<DiameterAgent> template <TPL_CCR> used by the *peer_request of request processor <cgrates> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Routes = nil
+	cfg.diameterAgentCfg.Peers = nil
+	expected = "<DiameterAgent> no peers defined for the *peer_request of request processor <cgrates>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityRadiusAgent(t *testing.T) {
@@ -622,6 +717,24 @@ func TestConfigSanityRadiusAgent(t *testing.T) {
 	}
 	cfg.radiusAgentCfg.DMRTemplate = "*dmr" // point to default DMR template
 
+	cfg.radiusAgentCfg.Listeners = []RadiusListener{{Network: "sctp"}}
+	expected = "<RadiusAgent> unsupported network: <sctp>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.radiusAgentCfg.Listeners = []RadiusListener{{Network: utils.TLSNoCaps}}
+	expected = "<RadiusAgent> tls listener requires the server certificate and key to be defined in the tls section"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.radiusAgentCfg.Listeners = nil
+	cfg.radiusAgentCfg.ClientDaAddresses = map[string]DAClientOpts{"127.0.0.1": {Transport: "sctp"}}
+	expected = "<RadiusAgent> unsupported transport: <sctp> for client_da_addresses: <127.0.0.1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.radiusAgentCfg.ClientDaAddresses = nil
+
 	cfg.radiusAgentCfg.SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
 	expected = "<SessionS> not enabled but requested by <RadiusAgent> component"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
@@ -940,7 +1053,8 @@ func TestConfigSanitySipAgent(t *testing.T) {
 	cfg := NewDefaultCGRConfig()
 
 	cfg.sipAgentCfg = &SIPAgentCfg{
-		Enabled: true,
+		Enabled:   true,
+		ListenNet: "sctp",
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -957,7 +1071,18 @@ func TestConfigSanitySipAgent(t *testing.T) {
 		},
 	}
 
-	expected := "<SIPAgent> no SessionS connections defined"
+	expected := "<SIPAgent> unsupported listen_net: <sctp>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.sipAgentCfg.ListenNet = utils.WSS
+	expected = "<SIPAgent> wss listener requires the server certificate and key to be defined in the tls section"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.sipAgentCfg.ListenNet = utils.UDP
+
+	expected = "<SIPAgent> no SessionS connections defined"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
@@ -973,8 +1098,30 @@ func TestConfigSanitySipAgent(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
-	//Request fields
 	cfg.rpcConns["test"] = nil
+	cfg.sipAgentCfg.Registrar = &SIPRegistrarCfg{Enabled: true}
+	expected = "<SIPAgent> no AttributeS connections defined for the registrar"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.sipAgentCfg.Registrar.AttributeSConns = []string{utils.MetaInternal}
+	expected = "<AttributeS> not enabled but requested by <SIPAgent> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.sipAgentCfg.Registrar.AttributeSConns = []string{"attrs"}
+	expected = "<SIPAgent> connection with id: <attrs> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.sipAgentCfg.Registrar.AttributeSConns = []string{"test"}
+	expected = "<SIPAgent> registrar max_expires needs to be positive"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.sipAgentCfg.Registrar = nil
+
+	//Request fields
 	expected = "<SIPAgent> MANDATORY_IE_MISSING: [Path] for cgrates at SessionId"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
@@ -1151,6 +1298,49 @@ func TestConfigSanityRouteS(t *testing.T) {
 	}
 	cfg.routeSCfg.StatSConns = []string{}
 
+	cfg.routeSCfg.RankingSConns = []string{utils.MetaInternal}
+	expected = "<RankingS> not enabled but requested by <RouteS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.RankingSConns = []string{"test"}
+	expected = "<RouteS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.RankingSConns = []string{}
+
+	cfg.routeSCfg.TrendSConns = []string{utils.MetaInternal}
+	expected = "<TrendS> not enabled but requested by <RouteS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.TrendSConns = []string{"test"}
+	expected = "<RouteS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.TrendSConns = []string{}
+
+	cfg.routeSCfg.CDRsConns = []string{utils.MetaInternal}
+	expected = "<CDRs> not enabled but requested by <RouteS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.CDRsConns = []string{"test"}
+	expected = "<RouteS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.CDRsConns = []string{}
+
+	cfg.routeSCfg.RankingStaleAge = -time.Second
+	expected = "<RouteS> ranking_stale_age needs to be positive"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.RankingStaleAge = 0
+
 	cfg.routeSCfg.AttributeSConns = []string{utils.MetaInternal}
 	expected = "<AttributeS> not enabled but requested by <RouteS> component"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
@@ -1262,6 +1452,20 @@ func TestConfigSanityEventReader(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
+	cfg.ersCfg.Readers = []*EventReaderCfg{{
+		ID:            "test_parquet",
+		Type:          utils.MetaFileParquet,
+		ProcessedPath: "not/a/path",
+		SourcePath:    "/",
+		Opts: &EventReaderOpts{
+			PartialCacheAction: utils.StringPointer(utils.MetaNone),
+		},
+	}}
+	expected = "<ERs> nonexistent folder: not/a/path for reader with ID: test_parquet"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
 	cfg.ersCfg.Readers = []*EventReaderCfg{{
 		ID:            "test3",
 		Type:          utils.MetaFileCSV,
@@ -1493,6 +1697,42 @@ func TestConfigSanityEventExporter(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
+	cfg.eesCfg.Exporters[0].Type = utils.MetaFileParquet
+	expected = "<EEs> nonexistent folder: randomPath for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].ExportPath = "/"
+	expected = "<EEs> empty content fields for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Fields[0].Path = "*exp.SessionId"
+	cfg.eesCfg.Exporters[0].Fields[0].ComputePath()
+	cfg.eesCfg.Exporters[0].ComputeFields()
+	cfg.eesCfg.Exporters[0].Opts.ParquetCompression = utils.StringPointer("brotli")
+	expected = "<EEs> unsupported parquetCompression: brotli for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Type = utils.MetaFileAvro
+	cfg.eesCfg.Exporters[0].Opts.ParquetCompression = nil
+	cfg.eesCfg.Exporters[0].Opts.AvroCodec = utils.StringPointer("gzip")
+	expected = "<EEs> unsupported avroCodec: gzip for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Type = utils.MetaKafkajsonMap
+	cfg.eesCfg.Exporters[0].Opts.Kafka = &KafkaOpts{Encoding: utils.StringPointer("*xml")}
+	expected = "<EEs> unsupported kafkaEncoding: *xml for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts = &EventExporterOpts{}
+	cfg.eesCfg.Exporters[0].Fields[0].Path = utils.EmptyString
+	cfg.eesCfg.Exporters[0].contentFields = nil
+	cfg.eesCfg.Exporters[0].ExportPath = "randomPath"
+
 	cfg.eesCfg.Exporters[0].Type = utils.MetaHTTPPost
 	cfg.eesCfg.Exporters[0].Fields[0].Path = "~Field1..Field2[0]"
 	expected = "<EEs> Empty field path  for ~Field1..Field2[0] at Path"
@@ -1704,6 +1944,16 @@ func TestConfigSanityStorDB(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.storDbCfg = &StorDbCfg{
+		Type: utils.MetaInternal,
+		Opts: &StorDBOpts{
+			InternalDBDumpPath: "/inexistent/Path",
+		},
+	}
+	expected = "<stor_db> nonexistent folder: /inexistent/Path"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityAnalyzer(t *testing.T) {
@@ -1753,6 +2003,12 @@ func TestConfigSanityDataDB(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err != nil {
 		t.Error(err)
 	}
+	cfg.dataDbCfg.Opts.InternalDBDumpPath = "/inexistent/Path"
+	expected := "<data_db> nonexistent folder: /inexistent/Path"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.dataDbCfg.Opts.InternalDBDumpPath = utils.EmptyString
 	cfg.cacheCfg = &CacheCfg{
 		Partitions: map[string]*CacheParamCfg{
 			utils.CacheAccounts: {
@@ -1760,7 +2016,7 @@ func TestConfigSanityDataDB(t *testing.T) {
 			},
 		},
 	}
-	expected := "<CacheS> *accounts needs to be 0 when DataBD is *internal, received : 1"
+	expected = "<CacheS> *accounts needs to be 0 when DataBD is *internal, received : 1"
 	cfg.cacheCfg.Partitions[utils.CacheAccounts].Limit = 0
 	cfg.resourceSCfg.Enabled = true
 	expected = "<ResourceS> the StoreInterval field needs to be -1 when DataBD is *internal, received : 0"
@@ -2116,3 +2372,54 @@ func TestConfigSanityERsXmlRootPath(t *testing.T) {
 		t.Errorf("expected: %s, received: %s", experr, err)
 	}
 }
+
+func TestConfigSanityCoreSRateLimits(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.coreSCfg.CapsRateLimits = []*CapsRateLimitCfg{{ID: "auth", Rate: 1, Burst: 1}}
+	expErr := "<CoreS> no methods defined for rate limit with id: <auth>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expErr {
+		t.Errorf("expected: %s, received: %v", expErr, err)
+	}
+	cfg.coreSCfg.CapsRateLimits[0].Methods = []string{utils.SessionSv1AuthorizeEvent}
+	cfg.coreSCfg.CapsRateLimits[0].Rate = 0
+	expErr = "<CoreS> the rate for rate limit with id: <auth> needs to be bigger than 0"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expErr {
+		t.Errorf("expected: %s, received: %v", expErr, err)
+	}
+	cfg.coreSCfg.CapsRateLimits[0].Rate = 10
+	cfg.coreSCfg.CapsRateLimits[0].Burst = 0
+	expErr = "<CoreS> the burst for rate limit with id: <auth> needs to be at least 1"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expErr {
+		t.Errorf("expected: %s, received: %v", expErr, err)
+	}
+	cfg.coreSCfg.CapsRateLimits[0].Burst = 10
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConfigSanityCoreSTracing(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.coreSCfg.Tracing.Enabled = true
+	cfg.coreSCfg.Tracing.Exporter = "*zipkin"
+	expErr := "<CoreS> unsupported tracing exporter: <*zipkin>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expErr {
+		t.Errorf("expected: %s, received: %v", expErr, err)
+	}
+	cfg.coreSCfg.Tracing.Exporter = utils.MetaFile
+	cfg.coreSCfg.Tracing.FilePath = utils.EmptyString
+	expErr = "<CoreS> empty file_path for tracing exporter: <*file>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expErr {
+		t.Errorf("expected: %s, received: %v", expErr, err)
+	}
+	cfg.coreSCfg.Tracing.Exporter = utils.MetaOTLP
+	cfg.coreSCfg.Tracing.SamplerRatio = 2
+	expErr = "<CoreS> the tracing sampler_ratio needs to be between 0 and 1"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expErr {
+		t.Errorf("expected: %s, received: %v", expErr, err)
+	}
+	cfg.coreSCfg.Tracing.SamplerRatio = 0.5
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"slices"
 	"time"
 
 	"github.com/cgrates/cgrates/utils"
@@ -29,9 +30,114 @@ type CoreSCfg struct {
 	Caps              int
 	CapsStrategy      string
 	CapsStatsInterval time.Duration
+	CapsRateLimits    []*CapsRateLimitCfg
+	Tracing           *TracingCfg
 	ShutdownTimeout   time.Duration
 }
 
+// TracingCfg the config for the OpenTelemetry tracing of the API calls
+type TracingCfg struct {
+	Enabled      bool
+	Exporter     string // <*otlp|*stdout|*file>
+	Endpoint     string // OTLP/gRPC collector address
+	Insecure     bool
+	FilePath     string // used by the *file exporter
+	SamplerRatio float64
+}
+
+func (tr *TracingCfg) loadFromJSONCfg(jsnCfg *TracingJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		tr.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Exporter != nil {
+		tr.Exporter = *jsnCfg.Exporter
+	}
+	if jsnCfg.Endpoint != nil {
+		tr.Endpoint = *jsnCfg.Endpoint
+	}
+	if jsnCfg.Insecure != nil {
+		tr.Insecure = *jsnCfg.Insecure
+	}
+	if jsnCfg.File_path != nil {
+		tr.FilePath = *jsnCfg.File_path
+	}
+	if jsnCfg.Sampler_ratio != nil {
+		tr.SamplerRatio = *jsnCfg.Sampler_ratio
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (tr *TracingCfg) AsMapInterface() map[string]any {
+	return map[string]any{
+		utils.EnabledCfg:      tr.Enabled,
+		utils.ExporterCfg:     tr.Exporter,
+		utils.EndpointCfg:     tr.Endpoint,
+		utils.InsecureCfg:     tr.Insecure,
+		utils.FilePathCfg:     tr.FilePath,
+		utils.SamplerRatioCfg: tr.SamplerRatio,
+	}
+}
+
+// Clone returns a deep copy of TracingCfg
+func (tr TracingCfg) Clone() *TracingCfg {
+	return &tr
+}
+
+// CapsRateLimitCfg the config for a token-bucket limit applied on API calls
+type CapsRateLimitCfg struct {
+	ID      string
+	Methods []string // <*any|Service.*|Service.Method>
+	Tenants []string // empty for all tenants, each tenant has its own bucket
+	Rate    float64  // tokens refilled per second
+	Burst   int      // maximum tokens in one bucket
+}
+
+func (rl *CapsRateLimitCfg) loadFromJSONCfg(jsnCfg *CapsRateLimitJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Id != nil {
+		rl.ID = *jsnCfg.Id
+	}
+	if jsnCfg.Methods != nil {
+		rl.Methods = slices.Clone(*jsnCfg.Methods)
+	}
+	if jsnCfg.Tenants != nil {
+		rl.Tenants = slices.Clone(*jsnCfg.Tenants)
+	}
+	if jsnCfg.Rate != nil {
+		rl.Rate = *jsnCfg.Rate
+	}
+	if jsnCfg.Burst != nil {
+		rl.Burst = *jsnCfg.Burst
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (rl *CapsRateLimitCfg) AsMapInterface() map[string]any {
+	return map[string]any{
+		utils.IDCfg:      rl.ID,
+		utils.MethodsCfg: slices.Clone(rl.Methods),
+		utils.Tenants:    slices.Clone(rl.Tenants),
+		utils.RateCfg:    rl.Rate,
+		utils.BurstCfg:   rl.Burst,
+	}
+}
+
+// Clone returns a deep copy of CapsRateLimitCfg
+func (rl CapsRateLimitCfg) Clone() *CapsRateLimitCfg {
+	return &CapsRateLimitCfg{
+		ID:      rl.ID,
+		Methods: slices.Clone(rl.Methods),
+		Tenants: slices.Clone(rl.Tenants),
+		Rate:    rl.Rate,
+		Burst:   rl.Burst,
+	}
+}
+
 func (cS *CoreSCfg) loadFromJSONCfg(jsnCfg *CoreSJsonCfg) (err error) {
 	if jsnCfg == nil {
 		return
@@ -47,6 +153,25 @@ func (cS *CoreSCfg) loadFromJSONCfg(jsnCfg *CoreSJsonCfg) (err error) {
 			return
 		}
 	}
+	if jsnCfg.Caps_rate_limits != nil {
+		for _, jsnLmt := range *jsnCfg.Caps_rate_limits {
+			var lmt *CapsRateLimitCfg
+			if jsnLmt.Id != nil {
+				for _, rl := range cS.CapsRateLimits {
+					if rl.ID == *jsnLmt.Id {
+						lmt = rl
+						break
+					}
+				}
+			}
+			if lmt == nil {
+				lmt = new(CapsRateLimitCfg)
+				cS.CapsRateLimits = append(cS.CapsRateLimits, lmt)
+			}
+			lmt.loadFromJSONCfg(jsnLmt)
+		}
+	}
+	cS.Tracing.loadFromJSONCfg(jsnCfg.Tracing)
 	if jsnCfg.Shutdown_timeout != nil {
 		if cS.ShutdownTimeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Shutdown_timeout); err != nil {
 			return
@@ -63,6 +188,14 @@ func (cS *CoreSCfg) AsMapInterface() map[string]any {
 		utils.CapsStatsIntervalCfg: cS.CapsStatsInterval.String(),
 		utils.ShutdownTimeoutCfg:   cS.ShutdownTimeout.String(),
 	}
+	rateLimits := make([]map[string]any, len(cS.CapsRateLimits))
+	for i, rl := range cS.CapsRateLimits {
+		rateLimits[i] = rl.AsMapInterface()
+	}
+	mp[utils.CapsRateLimitsCfg] = rateLimits
+	if cS.Tracing != nil {
+		mp[utils.TracingCfg] = cS.Tracing.AsMapInterface()
+	}
 	if cS.CapsStatsInterval == 0 {
 		mp[utils.CapsStatsIntervalCfg] = "0"
 	}
@@ -74,10 +207,20 @@ func (cS *CoreSCfg) AsMapInterface() map[string]any {
 
 // Clone returns a deep copy of CoreSCfg
 func (cS CoreSCfg) Clone() *CoreSCfg {
-	return &CoreSCfg{
+	cln := &CoreSCfg{
 		Caps:              cS.Caps,
 		CapsStrategy:      cS.CapsStrategy,
 		CapsStatsInterval: cS.CapsStatsInterval,
 		ShutdownTimeout:   cS.ShutdownTimeout,
 	}
+	if cS.Tracing != nil {
+		cln.Tracing = cS.Tracing.Clone()
+	}
+	if cS.CapsRateLimits != nil {
+		cln.CapsRateLimits = make([]*CapsRateLimitCfg, len(cS.CapsRateLimits))
+		for i, rl := range cS.CapsRateLimits {
+			cln.CapsRateLimits[i] = rl.Clone()
+		}
+	}
+	return cln
 }
@@ -89,6 +89,7 @@ func TestCoreSAsMapInterface(t *testing.T) {
 		utils.CapsCfg:              0,
 		utils.CapsStrategyCfg:      utils.MetaBusy,
 		utils.CapsStatsIntervalCfg: "0",
+		utils.CapsRateLimitsCfg:    []map[string]any{},
 		utils.ShutdownTimeoutCfg:   "0",
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
@@ -102,11 +103,29 @@ func TestCoreSAsMapInterface(t *testing.T) {
 	}
 	eMap[utils.CapsStatsIntervalCfg] = "1s"
 	eMap[utils.ShutdownTimeoutCfg] = "1s"
+	eMap[utils.CapsRateLimitsCfg] = []map[string]any{
+		{
+			utils.IDCfg:      "auth",
+			utils.MethodsCfg: []string{utils.SessionSv1AuthorizeEvent},
+			utils.Tenants:    []string{"cgrates.org"},
+			utils.RateCfg:    10.,
+			utils.BurstCfg:   20,
+		},
+	}
 	alS = CoreSCfg{
 		Caps:              0,
 		CapsStatsInterval: time.Second,
 		ShutdownTimeout:   time.Second,
 		CapsStrategy:      utils.MetaBusy,
+		CapsRateLimits: []*CapsRateLimitCfg{
+			{
+				ID:      "auth",
+				Methods: []string{utils.SessionSv1AuthorizeEvent},
+				Tenants: []string{"cgrates.org"},
+				Rate:    10,
+				Burst:   20,
+			},
+		},
 	}
 	if rcv := alS.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
 		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
@@ -128,3 +147,100 @@ func TestCoreSCfgClone(t *testing.T) {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
 }
+
+func TestCoreSCfgLoadRateLimits(t *testing.T) {
+	cS := &CoreSCfg{
+		CapsRateLimits: []*CapsRateLimitCfg{
+			{
+				ID:      "cdrs",
+				Methods: []string{utils.CDRsV1GetCDRs},
+				Rate:    1,
+				Burst:   1,
+			},
+		},
+	}
+	cfgJSONStr := `{
+		"cores": {
+			"caps_rate_limits": [
+				{"id": "cdrs", "burst": 5},
+				{"id": "apier", "methods": ["APIerSv1.*"], "tenants": ["cgrates.org"], "rate": 2.5, "burst": 3},
+			],
+		},
+}`
+	exp := &CoreSCfg{
+		CapsRateLimits: []*CapsRateLimitCfg{
+			{
+				ID:      "cdrs",
+				Methods: []string{utils.CDRsV1GetCDRs},
+				Rate:    1,
+				Burst:   5,
+			},
+			{
+				ID:      "apier",
+				Methods: []string{"APIerSv1.*"},
+				Tenants: []string{"cgrates.org"},
+				Rate:    2.5,
+				Burst:   3,
+			},
+		},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnCS, err := jsnCfg.CoreSCfgJson(); err != nil {
+		t.Error(err)
+	} else if err = cS.loadFromJSONCfg(jsnCS); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, cS) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(exp), utils.ToJSON(cS))
+	}
+	rcv := cS.Clone()
+	if !reflect.DeepEqual(cS, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(cS), utils.ToJSON(rcv))
+	}
+	if rcv.CapsRateLimits[1].Tenants[0] = "itsyscom.com"; cS.CapsRateLimits[1].Tenants[0] != "cgrates.org" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
+
+func TestCoreSCfgLoadTracing(t *testing.T) {
+	cS := &CoreSCfg{Tracing: &TracingCfg{
+		Exporter:     utils.MetaOTLP,
+		Endpoint:     "localhost:4317",
+		Insecure:     true,
+		SamplerRatio: 1,
+	}}
+	cfgJSONStr := `{
+		"cores": {
+			"tracing": {
+				"enabled": true,
+				"exporter": "*file",
+				"file_path": "/tmp/traces.json",
+				"sampler_ratio": 0.25,
+			},
+		},
+}`
+	exp := &CoreSCfg{Tracing: &TracingCfg{
+		Enabled:      true,
+		Exporter:     utils.MetaFile,
+		Endpoint:     "localhost:4317",
+		Insecure:     true,
+		FilePath:     "/tmp/traces.json",
+		SamplerRatio: 0.25,
+	}}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnCS, err := jsnCfg.CoreSCfgJson(); err != nil {
+		t.Error(err)
+	} else if err = cS.loadFromJSONCfg(jsnCS); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, cS) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(exp), utils.ToJSON(cS))
+	}
+	rcv := cS.Clone()
+	if !reflect.DeepEqual(cS, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(cS), utils.ToJSON(rcv))
+	}
+	if rcv.Tracing.SamplerRatio = 1; cS.Tracing.SamplerRatio != 0.25 {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
@@ -45,6 +45,10 @@ type DataDBOpts struct {
 	RedisCACertificate      string
 	MongoQueryTimeout       time.Duration
 	MongoConnScheme         string
+
+	InternalDBDumpPath         string        // directory where the *internal DataDB keeps its snapshot and write-ahead log, empty to keep it in memory only
+	InternalDBFsyncInterval    time.Duration // 0 to fsync the write-ahead log on each write, -1 to leave it to the OS
+	InternalDBSnapshotInterval time.Duration // interval of the compacting snapshots, 0 to take them only on shutdown or on demand
 }
 
 // DataDbCfg Database config
@@ -133,6 +137,19 @@ func (dbOpts *DataDBOpts) loadFromJSONCfg(jsnCfg *DBOptsJson) (err error) {
 	if jsnCfg.MongoConnScheme != nil {
 		dbOpts.MongoConnScheme = *jsnCfg.MongoConnScheme
 	}
+	if jsnCfg.InternalDBDumpPath != nil {
+		dbOpts.InternalDBDumpPath = *jsnCfg.InternalDBDumpPath
+	}
+	if jsnCfg.InternalDBFsyncInterval != nil {
+		if dbOpts.InternalDBFsyncInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.InternalDBFsyncInterval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.InternalDBSnapshotInterval != nil {
+		if dbOpts.InternalDBSnapshotInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.InternalDBSnapshotInterval); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -233,6 +250,10 @@ func (dbOpts *DataDBOpts) Clone() *DataDBOpts {
 		RedisCACertificate:      dbOpts.RedisCACertificate,
 		MongoQueryTimeout:       dbOpts.MongoQueryTimeout,
 		MongoConnScheme:         dbOpts.MongoConnScheme,
+
+		InternalDBDumpPath:         dbOpts.InternalDBDumpPath,
+		InternalDBFsyncInterval:    dbOpts.InternalDBFsyncInterval,
+		InternalDBSnapshotInterval: dbOpts.InternalDBSnapshotInterval,
 	}
 }
 
@@ -285,6 +306,10 @@ func (dbcfg *DataDbCfg) AsMapInterface() (mp map[string]any) {
 		utils.RedisCACertificate:         dbcfg.Opts.RedisCACertificate,
 		utils.MongoQueryTimeoutCfg:       dbcfg.Opts.MongoQueryTimeout.String(),
 		utils.MongoConnSchemeCfg:         dbcfg.Opts.MongoConnScheme,
+
+		utils.InternalDBDumpPathCfg:         dbcfg.Opts.InternalDBDumpPath,
+		utils.InternalDBFsyncIntervalCfg:    dbcfg.Opts.InternalDBFsyncInterval.String(),
+		utils.InternalDBSnapshotIntervalCfg: dbcfg.Opts.InternalDBSnapshotInterval.String(),
 	}
 	mp = map[string]any{
 		utils.DataDbTypeCfg:          dbcfg.Type,
@@ -132,6 +132,18 @@ func TestDataDbCfgloadFromJsonCfg(t *testing.T) {
 		},
 	}); err == nil {
 		t.Error(err)
+	} else if err := jsnCfg.dataDbCfg.loadFromJSONCfg(&DbJsonCfg{
+		Opts: &DBOptsJson{
+			InternalDBFsyncInterval: utils.StringPointer("test6"),
+		},
+	}); err == nil {
+		t.Error(err)
+	} else if err := jsnCfg.dataDbCfg.loadFromJSONCfg(&DbJsonCfg{
+		Opts: &DBOptsJson{
+			InternalDBSnapshotInterval: utils.StringPointer("test7"),
+		},
+	}); err == nil {
+		t.Error(err)
 	} else if err := jsnCfg.dataDbCfg.loadFromJSONCfg(&DbJsonCfg{
 		Items: &map[string]*ItemOptJson{
 			utils.MetaAccounts: {
@@ -19,26 +19,201 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
 )
 
+// DiameterPeer describes a peer the DiameterAgent connects to
+type DiameterPeer struct {
+	ID                 string
+	Network            string // sctp, tcp or tls
+	Address            string // address of the peer <x.y.z.y:1234>
+	AuthApplicationIDs []int  // advertised within the CER as Auth-Application-Id
+	AcctApplicationIDs []int  // advertised within the CER as Acct-Application-Id
+}
+
+func (dp *DiameterPeer) loadFromJSONCfg(jsnCfg *DiamPeerJsnCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Id != nil {
+		dp.ID = *jsnCfg.Id
+	}
+	if jsnCfg.Network != nil {
+		dp.Network = *jsnCfg.Network
+	}
+	if jsnCfg.Address != nil {
+		dp.Address = *jsnCfg.Address
+	}
+	if jsnCfg.Auth_application_ids != nil {
+		dp.AuthApplicationIDs = make([]int, len(*jsnCfg.Auth_application_ids))
+		copy(dp.AuthApplicationIDs, *jsnCfg.Auth_application_ids)
+	}
+	if jsnCfg.Acct_application_ids != nil {
+		dp.AcctApplicationIDs = make([]int, len(*jsnCfg.Acct_application_ids))
+		copy(dp.AcctApplicationIDs, *jsnCfg.Acct_application_ids)
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (dp *DiameterPeer) AsMapInterface() (mp map[string]any) {
+	mp = map[string]any{
+		utils.IDCfg:      dp.ID,
+		utils.NetworkCfg: dp.Network,
+		utils.AddressCfg: dp.Address,
+	}
+	if dp.AuthApplicationIDs != nil {
+		authAppIDs := make([]int, len(dp.AuthApplicationIDs))
+		copy(authAppIDs, dp.AuthApplicationIDs)
+		mp[utils.AuthAppIDsCfg] = authAppIDs
+	}
+	if dp.AcctApplicationIDs != nil {
+		acctAppIDs := make([]int, len(dp.AcctApplicationIDs))
+		copy(acctAppIDs, dp.AcctApplicationIDs)
+		mp[utils.AcctAppIDsCfg] = acctAppIDs
+	}
+	return
+}
+
+// Clone returns a deep copy of DiameterPeer
+func (dp *DiameterPeer) Clone() (cln *DiameterPeer) {
+	cln = &DiameterPeer{
+		ID:      dp.ID,
+		Network: dp.Network,
+		Address: dp.Address,
+	}
+	if dp.AuthApplicationIDs != nil {
+		cln.AuthApplicationIDs = make([]int, len(dp.AuthApplicationIDs))
+		copy(cln.AuthApplicationIDs, dp.AuthApplicationIDs)
+	}
+	if dp.AcctApplicationIDs != nil {
+		cln.AcctApplicationIDs = make([]int, len(dp.AcctApplicationIDs))
+		copy(cln.AcctApplicationIDs, dp.AcctApplicationIDs)
+	}
+	return
+}
+
+// DiameterRoutePeer is a peer receiving the requests relayed on a route
+type DiameterRoutePeer struct {
+	ID     string // id of the peer out of the peers list
+	Weight float64
+}
+
+// DiameterRoute describes where the requests are relayed based on their Destination-Realm and Application-Id
+type DiameterRoute struct {
+	ID               string
+	Realm            string // Destination-Realm matched, *any for all
+	ApplicationIDs   []int  // Application-Ids matched, empty for all
+	Peers            []*DiameterRoutePeer
+	RequestProcessor string // request processor to run before relaying the request, empty for none
+}
+
+func (dr *DiameterRoute) loadFromJSONCfg(jsnCfg *DiamRouteJsnCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Id != nil {
+		dr.ID = *jsnCfg.Id
+	}
+	if jsnCfg.Realm != nil {
+		dr.Realm = *jsnCfg.Realm
+	}
+	if jsnCfg.Application_ids != nil {
+		dr.ApplicationIDs = make([]int, len(*jsnCfg.Application_ids))
+		copy(dr.ApplicationIDs, *jsnCfg.Application_ids)
+	}
+	if jsnCfg.Peers != nil {
+		dr.Peers = make([]*DiameterRoutePeer, len(*jsnCfg.Peers))
+		for i, peerJsn := range *jsnCfg.Peers {
+			dr.Peers[i] = new(DiameterRoutePeer)
+			if peerJsn == nil {
+				continue
+			}
+			if peerJsn.Id != nil {
+				dr.Peers[i].ID = *peerJsn.Id
+			}
+			if peerJsn.Weight != nil {
+				dr.Peers[i].Weight = *peerJsn.Weight
+			}
+		}
+	}
+	if jsnCfg.Request_processor != nil {
+		dr.RequestProcessor = *jsnCfg.Request_processor
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (dr *DiameterRoute) AsMapInterface() (mp map[string]any) {
+	mp = map[string]any{
+		utils.IDCfg:               dr.ID,
+		utils.RealmCfg:            dr.Realm,
+		utils.RequestProcessorCfg: dr.RequestProcessor,
+	}
+	if dr.ApplicationIDs != nil {
+		appIDs := make([]int, len(dr.ApplicationIDs))
+		copy(appIDs, dr.ApplicationIDs)
+		mp[utils.AppIDsCfg] = appIDs
+	}
+	if dr.Peers != nil {
+		peers := make([]map[string]any, len(dr.Peers))
+		for i, peer := range dr.Peers {
+			peers[i] = map[string]any{
+				utils.IDCfg:     peer.ID,
+				utils.WeightCfg: peer.Weight,
+			}
+		}
+		mp[utils.PeersCfg] = peers
+	}
+	return
+}
+
+// Clone returns a deep copy of DiameterRoute
+func (dr *DiameterRoute) Clone() (cln *DiameterRoute) {
+	cln = &DiameterRoute{
+		ID:               dr.ID,
+		Realm:            dr.Realm,
+		RequestProcessor: dr.RequestProcessor,
+	}
+	if dr.ApplicationIDs != nil {
+		cln.ApplicationIDs = make([]int, len(dr.ApplicationIDs))
+		copy(cln.ApplicationIDs, dr.ApplicationIDs)
+	}
+	if dr.Peers != nil {
+		cln.Peers = make([]*DiameterRoutePeer, len(dr.Peers))
+		for i, peer := range dr.Peers {
+			cln.Peers[i] = &DiameterRoutePeer{
+				ID:     peer.ID,
+				Weight: peer.Weight,
+			}
+		}
+	}
+	return
+}
+
 // DiameterAgentCfg the config section that describes the Diameter Agent
 type DiameterAgentCfg struct {
-	Enabled           bool   // enables the diameter agent: <true|false>
-	ListenNet         string // sctp or tcp
-	Listen            string // address where to listen for diameter requests <x.y.z.y:1234>
-	DictionariesPath  string
-	SessionSConns     []string
-	OriginHost        string
-	OriginRealm       string
-	VendorID          int
-	ProductName       string
-	SyncedConnReqs    bool
-	ASRTemplate       string
-	RARTemplate       string
-	ForcedDisconnect  string
-	RequestProcessors []*RequestProcessor
+	Enabled              bool   // enables the diameter agent: <true|false>
+	ListenNet            string // sctp, tcp or tls
+	Listen               string // address where to listen for diameter requests <x.y.z.y:1234>
+	DictionariesPath     string
+	SessionSConns        []string
+	OriginHost           string
+	OriginRealm          string
+	VendorID             int
+	ProductName          string
+	SyncedConnReqs       bool
+	ASRTemplate          string
+	RARTemplate          string
+	ForcedDisconnect     string
+	AllowedPeers         []string         // peers allowed to connect in the form <origin_host[:origin_realm]>, empty for any
+	Peers                []*DiameterPeer  // peers the agent connects to, in the order of preference
+	WatchdogInterval     time.Duration    // interval between the DWRs sent on the connections towards peers
+	ReconnectInterval    time.Duration    // initial delay between reconnect attempts, doubled after each failure
+	MaxReconnectInterval time.Duration    // upper limit of the delay between reconnect attempts
+	Routes               []*DiameterRoute // routes of the requests relayed towards the peers
+	RequestProcessors    []*RequestProcessor
 }
 
 func (da *DiameterAgentCfg) loadFromJSONCfg(jsnCfg *DiameterAgentJsonCfg, separator string) (err error) {
@@ -92,6 +267,39 @@ func (da *DiameterAgentCfg) loadFromJSONCfg(jsnCfg *DiameterAgentJsonCfg, separa
 	if jsnCfg.Forced_disconnect != nil {
 		da.ForcedDisconnect = *jsnCfg.Forced_disconnect
 	}
+	if jsnCfg.Allowed_peers != nil {
+		da.AllowedPeers = make([]string, len(*jsnCfg.Allowed_peers))
+		copy(da.AllowedPeers, *jsnCfg.Allowed_peers)
+	}
+	if jsnCfg.Peers != nil {
+		da.Peers = make([]*DiameterPeer, len(*jsnCfg.Peers))
+		for i, peerJsn := range *jsnCfg.Peers {
+			da.Peers[i] = new(DiameterPeer)
+			da.Peers[i].loadFromJSONCfg(peerJsn)
+		}
+	}
+	if jsnCfg.Watchdog_interval != nil {
+		if da.WatchdogInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Watchdog_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Reconnect_interval != nil {
+		if da.ReconnectInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reconnect_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Max_reconnect_interval != nil {
+		if da.MaxReconnectInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Max_reconnect_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Routes != nil {
+		da.Routes = make([]*DiameterRoute, len(*jsnCfg.Routes))
+		for i, routeJsn := range *jsnCfg.Routes {
+			da.Routes[i] = new(DiameterRoute)
+			da.Routes[i].loadFromJSONCfg(routeJsn)
+		}
+	}
 	if jsnCfg.Request_processors != nil {
 		for _, reqProcJsn := range *jsnCfg.Request_processors {
 			rp := new(RequestProcessor)
@@ -117,18 +325,40 @@ func (da *DiameterAgentCfg) loadFromJSONCfg(jsnCfg *DiameterAgentJsonCfg, separa
 // AsMapInterface returns the config as a map[string]any
 func (da *DiameterAgentCfg) AsMapInterface(separator string) (initialMP map[string]any) {
 	initialMP = map[string]any{
-		utils.EnabledCfg:          da.Enabled,
-		utils.ListenNetCfg:        da.ListenNet,
-		utils.ListenCfg:           da.Listen,
-		utils.DictionariesPathCfg: da.DictionariesPath,
-		utils.OriginHostCfg:       da.OriginHost,
-		utils.OriginRealmCfg:      da.OriginRealm,
-		utils.VendorIDCfg:         da.VendorID,
-		utils.ProductNameCfg:      da.ProductName,
-		utils.SyncedConnReqsCfg:   da.SyncedConnReqs,
-		utils.ASRTemplateCfg:      da.ASRTemplate,
-		utils.RARTemplateCfg:      da.RARTemplate,
-		utils.ForcedDisconnectCfg: da.ForcedDisconnect,
+		utils.EnabledCfg:              da.Enabled,
+		utils.ListenNetCfg:            da.ListenNet,
+		utils.ListenCfg:               da.Listen,
+		utils.DictionariesPathCfg:     da.DictionariesPath,
+		utils.OriginHostCfg:           da.OriginHost,
+		utils.OriginRealmCfg:          da.OriginRealm,
+		utils.VendorIDCfg:             da.VendorID,
+		utils.ProductNameCfg:          da.ProductName,
+		utils.SyncedConnReqsCfg:       da.SyncedConnReqs,
+		utils.ASRTemplateCfg:          da.ASRTemplate,
+		utils.RARTemplateCfg:          da.RARTemplate,
+		utils.ForcedDisconnectCfg:     da.ForcedDisconnect,
+		utils.WatchdogIntervalCfg:     da.WatchdogInterval.String(),
+		utils.ReconnectIntervalCfg:    da.ReconnectInterval.String(),
+		utils.MaxReconnectIntervalCfg: da.MaxReconnectInterval.String(),
+	}
+	if da.AllowedPeers != nil {
+		allowedPeers := make([]string, len(da.AllowedPeers))
+		copy(allowedPeers, da.AllowedPeers)
+		initialMP[utils.AllowedPeersCfg] = allowedPeers
+	}
+	if da.Peers != nil {
+		peers := make([]map[string]any, len(da.Peers))
+		for i, peer := range da.Peers {
+			peers[i] = peer.AsMapInterface()
+		}
+		initialMP[utils.PeersCfg] = peers
+	}
+	if da.Routes != nil {
+		routes := make([]map[string]any, len(da.Routes))
+		for i, route := range da.Routes {
+			routes[i] = route.AsMapInterface()
+		}
+		initialMP[utils.RoutesCfg] = routes
 	}
 
 	requestProcessors := make([]map[string]any, len(da.RequestProcessors))
@@ -167,11 +397,31 @@ func (da DiameterAgentCfg) Clone() (cln *DiameterAgentCfg) {
 		ASRTemplate:      da.ASRTemplate,
 		RARTemplate:      da.RARTemplate,
 		ForcedDisconnect: da.ForcedDisconnect,
+
+		WatchdogInterval:     da.WatchdogInterval,
+		ReconnectInterval:    da.ReconnectInterval,
+		MaxReconnectInterval: da.MaxReconnectInterval,
 	}
 	if da.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(da.SessionSConns))
 		copy(cln.SessionSConns, da.SessionSConns)
 	}
+	if da.AllowedPeers != nil {
+		cln.AllowedPeers = make([]string, len(da.AllowedPeers))
+		copy(cln.AllowedPeers, da.AllowedPeers)
+	}
+	if da.Peers != nil {
+		cln.Peers = make([]*DiameterPeer, len(da.Peers))
+		for i, peer := range da.Peers {
+			cln.Peers[i] = peer.Clone()
+		}
+	}
+	if da.Routes != nil {
+		cln.Routes = make([]*DiameterRoute, len(da.Routes))
+		for i, route := range da.Routes {
+			cln.Routes[i] = route.Clone()
+		}
+	}
 	if da.RequestProcessors != nil {
 		cln.RequestProcessors = make([]*RequestProcessor, len(da.RequestProcessors))
 		for i, req := range da.RequestProcessors {
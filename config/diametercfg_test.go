@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
@@ -40,6 +41,29 @@ func TestDiameterAgentCfgloadFromJsonCfg(t *testing.T) {
 		Asr_template:         utils.StringPointer("randomTemplate"),
 		Rar_template:         utils.StringPointer("randomTemplate"),
 		Forced_disconnect:    utils.StringPointer("forced"),
+		Allowed_peers:        &[]string{"peer1.cgrates.org:cgrates.org", utils.MetaAny},
+		Peers: &[]*DiamPeerJsnCfg{
+			{
+				Id:                   utils.StringPointer("ocs1"),
+				Network:              utils.StringPointer(utils.TCP),
+				Address:              utils.StringPointer("127.0.0.1:3869"),
+				Auth_application_ids: &[]int{4},
+			},
+		},
+		Watchdog_interval:      utils.StringPointer("10s"),
+		Reconnect_interval:     utils.StringPointer("1s"),
+		Max_reconnect_interval: utils.StringPointer("1m"),
+		Routes: &[]*DiamRouteJsnCfg{
+			{
+				Id:              utils.StringPointer("route1"),
+				Realm:           utils.StringPointer("ocs.org"),
+				Application_ids: &[]int{4},
+				Peers: &[]*DiamRoutePeerJsnCfg{
+					{Id: utils.StringPointer("ocs1"), Weight: utils.Float64Pointer(10)},
+				},
+				Request_processor: utils.StringPointer(utils.CGRateSLwr),
+			},
+		},
 		Request_processors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:       utils.StringPointer(utils.CGRateSLwr),
@@ -61,6 +85,27 @@ func TestDiameterAgentCfgloadFromJsonCfg(t *testing.T) {
 		ASRTemplate:      "randomTemplate",
 		RARTemplate:      "randomTemplate",
 		ForcedDisconnect: "forced",
+		AllowedPeers:     []string{"peer1.cgrates.org:cgrates.org", utils.MetaAny},
+		Peers: []*DiameterPeer{
+			{
+				ID:                 "ocs1",
+				Network:            utils.TCP,
+				Address:            "127.0.0.1:3869",
+				AuthApplicationIDs: []int{4},
+			},
+		},
+		WatchdogInterval:     10 * time.Second,
+		ReconnectInterval:    time.Second,
+		MaxReconnectInterval: time.Minute,
+		Routes: []*DiameterRoute{
+			{
+				ID:               "route1",
+				Realm:            "ocs.org",
+				ApplicationIDs:   []int{4},
+				Peers:            []*DiameterRoutePeer{{ID: "ocs1", Weight: 10}},
+				RequestProcessor: "cgrates",
+			},
+		},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -144,19 +189,25 @@ func TestDiameterAgentCfgAsMapInterface(t *testing.T) {
 	},
 }`
 	eMap := map[string]any{
-		utils.ASRTemplateCfg:      "",
-		utils.DictionariesPathCfg: "/usr/share/cgrates/diameter/dict/",
-		utils.EnabledCfg:          false,
-		utils.ForcedDisconnectCfg: "*none",
-		utils.ListenCfg:           "127.0.0.1:3868",
-		utils.ListenNetCfg:        "tcp",
-		utils.OriginHostCfg:       "CGR-DA",
-		utils.OriginRealmCfg:      "cgrates.org",
-		utils.ProductNameCfg:      "CGRateS",
-		utils.RARTemplateCfg:      "",
-		utils.SessionSConnsCfg:    []string{rpcclient.BiRPCInternal, utils.MetaInternal, "*conn1"},
-		utils.SyncedConnReqsCfg:   true,
-		utils.VendorIDCfg:         0,
+		utils.AllowedPeersCfg:         []string{},
+		utils.ASRTemplateCfg:          "",
+		utils.DictionariesPathCfg:     "/usr/share/cgrates/diameter/dict/",
+		utils.EnabledCfg:              false,
+		utils.ForcedDisconnectCfg:     "*none",
+		utils.ListenCfg:               "127.0.0.1:3868",
+		utils.ListenNetCfg:            "tcp",
+		utils.OriginHostCfg:           "CGR-DA",
+		utils.OriginRealmCfg:          "cgrates.org",
+		utils.ProductNameCfg:          "CGRateS",
+		utils.RARTemplateCfg:          "",
+		utils.SessionSConnsCfg:        []string{rpcclient.BiRPCInternal, utils.MetaInternal, "*conn1"},
+		utils.SyncedConnReqsCfg:       true,
+		utils.VendorIDCfg:             0,
+		utils.PeersCfg:                []map[string]any{},
+		utils.WatchdogIntervalCfg:     "30s",
+		utils.ReconnectIntervalCfg:    "5s",
+		utils.MaxReconnectIntervalCfg: "5m0s",
+		utils.RoutesCfg:               []map[string]any{},
 		utils.RequestProcessorsCfg: []map[string]any{
 			{
 				utils.IDCfg:       utils.CGRateSLwr,
@@ -201,22 +252,51 @@ func TestDiameterAgentCfgAsMapInterface1(t *testing.T) {
 		"enabled": true,
 		"dictionaries_path": "/usr/share/cgrates/diameter",			
 		"synced_conn_requests": false,
+		"peers": [
+			{"id": "ocs1", "network": "sctp", "address": "127.0.0.1:3869", "acct_application_ids": [3]},
+		],
+		"watchdog_interval": "10s",
+		"routes": [
+			{"id": "route1", "realm": "*any", "peers": [{"id": "ocs1", "weight": 10}]},
+		],
 	},
 }`
 	eMap := map[string]any{
-		utils.ASRTemplateCfg:       "",
-		utils.DictionariesPathCfg:  "/usr/share/cgrates/diameter",
-		utils.EnabledCfg:           true,
-		utils.ForcedDisconnectCfg:  "*none",
-		utils.ListenCfg:            "127.0.0.1:3868",
-		utils.ListenNetCfg:         "tcp",
-		utils.OriginHostCfg:        "CGR-DA",
-		utils.OriginRealmCfg:       "cgrates.org",
-		utils.ProductNameCfg:       "CGRateS",
-		utils.RARTemplateCfg:       "",
-		utils.SessionSConnsCfg:     []string{rpcclient.BiRPCInternal},
-		utils.SyncedConnReqsCfg:    false,
-		utils.VendorIDCfg:          0,
+		utils.AllowedPeersCfg:     []string{},
+		utils.ASRTemplateCfg:      "",
+		utils.DictionariesPathCfg: "/usr/share/cgrates/diameter",
+		utils.EnabledCfg:          true,
+		utils.ForcedDisconnectCfg: "*none",
+		utils.ListenCfg:           "127.0.0.1:3868",
+		utils.ListenNetCfg:        "tcp",
+		utils.OriginHostCfg:       "CGR-DA",
+		utils.OriginRealmCfg:      "cgrates.org",
+		utils.ProductNameCfg:      "CGRateS",
+		utils.RARTemplateCfg:      "",
+		utils.SessionSConnsCfg:    []string{rpcclient.BiRPCInternal},
+		utils.SyncedConnReqsCfg:   false,
+		utils.VendorIDCfg:         0,
+		utils.PeersCfg: []map[string]any{
+			{
+				utils.IDCfg:         "ocs1",
+				utils.NetworkCfg:    utils.SCTP,
+				utils.AddressCfg:    "127.0.0.1:3869",
+				utils.AcctAppIDsCfg: []int{3},
+			},
+		},
+		utils.WatchdogIntervalCfg:     "10s",
+		utils.ReconnectIntervalCfg:    "5s",
+		utils.MaxReconnectIntervalCfg: "5m0s",
+		utils.RoutesCfg: []map[string]any{
+			{
+				utils.IDCfg:               "route1",
+				utils.RealmCfg:            utils.MetaAny,
+				utils.RequestProcessorCfg: "",
+				utils.PeersCfg: []map[string]any{
+					{utils.IDCfg: "ocs1", utils.WeightCfg: 10.},
+				},
+			},
+		},
 		utils.RequestProcessorsCfg: []map[string]any{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
@@ -241,6 +321,28 @@ func TestDiameterAgentCfgClone(t *testing.T) {
 		ASRTemplate:      "randomTemplate",
 		RARTemplate:      "randomTemplate",
 		ForcedDisconnect: "forced",
+		AllowedPeers:     []string{"peer1.cgrates.org:cgrates.org", utils.MetaAny},
+		Peers: []*DiameterPeer{
+			{
+				ID:                 "ocs1",
+				Network:            utils.TCP,
+				Address:            "127.0.0.1:3869",
+				AuthApplicationIDs: []int{4},
+				AcctApplicationIDs: []int{3},
+			},
+		},
+		WatchdogInterval:     10 * time.Second,
+		ReconnectInterval:    time.Second,
+		MaxReconnectInterval: time.Minute,
+		Routes: []*DiameterRoute{
+			{
+				ID:               "route1",
+				Realm:            "ocs.org",
+				ApplicationIDs:   []int{4},
+				Peers:            []*DiameterRoutePeer{{ID: "ocs1", Weight: 10}},
+				RequestProcessor: "cgrates",
+			},
+		},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -258,4 +360,10 @@ func TestDiameterAgentCfgClone(t *testing.T) {
 	if rcv.RequestProcessors[0].ID = ""; ban.RequestProcessors[0].ID != "cgrates" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.Peers[0].AuthApplicationIDs[0] = 0; ban.Peers[0].AuthApplicationIDs[0] != 4 {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.Routes[0].Peers[0].Weight = 0; ban.Routes[0].Peers[0].Weight != 10 {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
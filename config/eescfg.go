@@ -242,22 +242,27 @@ type RPCOpts struct {
 }
 
 type KafkaOpts struct {
-	Topic         *string
-	BatchSize     *int
-	TLS           *bool
-	CAPath        *string
-	SkipTLSVerify *bool
+	Topic          *string
+	BatchSize      *int
+	TLS            *bool
+	CAPath         *string
+	SkipTLSVerify  *bool
+	Encoding       *string // <*json|*avro>
+	SchemaRegistry *string // schema registry URL used to register the Avro schema
+	SchemaSubject  *string // schema registry subject, defaults to <topic>-value
 }
 
 type EventExporterOpts struct {
-	CSVFieldSeparator *string
-	Els               *ElsOpts
-	SQL               *SQLOpts
-	AMQP              *AMQPOpts
-	AWS               *AWSOpts
-	NATS              *NATSOpts
-	RPC               *RPCOpts
-	Kafka             *KafkaOpts
+	CSVFieldSeparator  *string
+	ParquetCompression *string
+	AvroCodec          *string
+	Els                *ElsOpts
+	SQL                *SQLOpts
+	AMQP               *AMQPOpts
+	AWS                *AWSOpts
+	NATS               *NATSOpts
+	RPC                *RPCOpts
+	Kafka              *KafkaOpts
 }
 
 // EventExporterCfg the config for a Event Exporter
@@ -399,6 +404,15 @@ func (kafkaOpts *KafkaOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err
 	if jsnCfg.KafkaSkipTLSVerify != nil {
 		kafkaOpts.SkipTLSVerify = jsnCfg.KafkaSkipTLSVerify
 	}
+	if jsnCfg.KafkaEncoding != nil {
+		kafkaOpts.Encoding = jsnCfg.KafkaEncoding
+	}
+	if jsnCfg.KafkaSchemaRegistry != nil {
+		kafkaOpts.SchemaRegistry = jsnCfg.KafkaSchemaRegistry
+	}
+	if jsnCfg.KafkaSchemaSubject != nil {
+		kafkaOpts.SchemaSubject = jsnCfg.KafkaSchemaSubject
+	}
 	return
 }
 
@@ -561,6 +575,12 @@ func (eeOpts *EventExporterOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson)
 	if jsnCfg.CSVFieldSeparator != nil {
 		eeOpts.CSVFieldSeparator = jsnCfg.CSVFieldSeparator
 	}
+	if jsnCfg.ParquetCompression != nil {
+		eeOpts.ParquetCompression = jsnCfg.ParquetCompression
+	}
+	if jsnCfg.AvroCodec != nil {
+		eeOpts.AvroCodec = jsnCfg.AvroCodec
+	}
 	if err = eeOpts.Els.loadFromJSONCfg(jsnCfg); err != nil {
 		return
 	}
@@ -747,6 +767,18 @@ func (kafkaOpts *KafkaOpts) Clone() *KafkaOpts {
 		cln.SkipTLSVerify = new(bool)
 		*cln.SkipTLSVerify = *kafkaOpts.SkipTLSVerify
 	}
+	if kafkaOpts.Encoding != nil {
+		cln.Encoding = new(string)
+		*cln.Encoding = *kafkaOpts.Encoding
+	}
+	if kafkaOpts.SchemaRegistry != nil {
+		cln.SchemaRegistry = new(string)
+		*cln.SchemaRegistry = *kafkaOpts.SchemaRegistry
+	}
+	if kafkaOpts.SchemaSubject != nil {
+		cln.SchemaSubject = new(string)
+		*cln.SchemaSubject = *kafkaOpts.SchemaSubject
+	}
 	return cln
 }
 
@@ -933,6 +965,14 @@ func (eeOpts *EventExporterOpts) Clone() *EventExporterOpts {
 		cln.CSVFieldSeparator = new(string)
 		*cln.CSVFieldSeparator = *eeOpts.CSVFieldSeparator
 	}
+	if eeOpts.ParquetCompression != nil {
+		cln.ParquetCompression = new(string)
+		*cln.ParquetCompression = *eeOpts.ParquetCompression
+	}
+	if eeOpts.AvroCodec != nil {
+		cln.AvroCodec = new(string)
+		*cln.AvroCodec = *eeOpts.AvroCodec
+	}
 	if eeOpts.Els != nil {
 		cln.Els = eeOpts.Els.Clone()
 	}
@@ -1007,6 +1047,12 @@ func (eeC *EventExporterCfg) AsMapInterface(separator string) (initialMP map[str
 	if eeC.Opts.CSVFieldSeparator != nil {
 		opts[utils.CSVFieldSepOpt] = *eeC.Opts.CSVFieldSeparator
 	}
+	if eeC.Opts.ParquetCompression != nil {
+		opts[utils.ParquetCompressionOpt] = *eeC.Opts.ParquetCompression
+	}
+	if eeC.Opts.AvroCodec != nil {
+		opts[utils.AvroCodecOpt] = *eeC.Opts.AvroCodec
+	}
 	if elsOpts := eeC.Opts.Els; elsOpts != nil {
 		if elsOpts.Index != nil {
 			opts[utils.ElsIndex] = *elsOpts.Index
@@ -1078,6 +1124,15 @@ func (eeC *EventExporterCfg) AsMapInterface(separator string) (initialMP map[str
 		if kafkaOpts.SkipTLSVerify != nil {
 			opts[utils.KafkaSkipTLSVerify] = *kafkaOpts.SkipTLSVerify
 		}
+		if kafkaOpts.Encoding != nil {
+			opts[utils.KafkaEncoding] = *kafkaOpts.Encoding
+		}
+		if kafkaOpts.SchemaRegistry != nil {
+			opts[utils.KafkaSchemaRegistry] = *kafkaOpts.SchemaRegistry
+		}
+		if kafkaOpts.SchemaSubject != nil {
+			opts[utils.KafkaSchemaSubject] = *kafkaOpts.SchemaSubject
+		}
 	}
 	if amOpts := eeC.Opts.AMQP; amOpts != nil {
 		if amOpts.QueueID != nil {
@@ -22,6 +22,7 @@ import (
 	"crypto/tls"
 	"net"
 	"net/http"
+	"slices"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -32,15 +33,118 @@ type HTTPCfg struct {
 	RegistrarSURL         string            // registrar service relative URL
 	PrometheusURL         string            // endpoint for prometheus metrics ("" to disable)
 	HTTPWSURL             string            // WebSocket relative URL ("" to disable)
+	LiveFeedURL           string            // Server-Sent Events live feed relative URL ("" to disable)
+	WSLiveFeedURL         string            // WebSocket live feed relative URL ("" to disable)
 	HTTPFreeswitchCDRsURL string            // Freeswitch CDRS relative URL ("" to disable)
 	HTTPCDRsURL           string            // CDRS relative URL ("" to disable)
 	PprofPath             string            // runtime profiling url path ("" to disable)
 	HTTPUseBasicAuth      bool              // Use basic auth for HTTP API
 	HTTPAuthUsers         map[string]string // Basic auth user:password map (base64 passwords)
 	ClientOpts            *http.Transport
+	PrometheusOpts        *PrometheusOpts // data published by the prometheus collectors
 	dialer                *net.Dialer
 }
 
+// PrometheusOpts selects the CGRateS data published on the prometheus endpoint
+type PrometheusOpts struct {
+	Tenants          []string
+	StatSConns       []string
+	StatFilters      []string
+	ThresholdSConns  []string
+	ThresholdFilters []string
+	TrendSConns      []string
+	TrendFilters     []string
+	SessionSConns    []string
+	SessionFilters   []string
+}
+
+// internalConns replaces the *internal connection with the subsystem specific one
+func internalConns(conns []string, subsystem string) (iConns []string) {
+	iConns = make([]string, len(conns))
+	for i, connID := range conns {
+		iConns[i] = connID
+		if connID == utils.MetaInternal {
+			iConns[i] = utils.ConcatenatedKey(utils.MetaInternal, subsystem)
+		}
+	}
+	return
+}
+
+// externalConns reverts the subsystem specific *internal connection
+func externalConns(conns []string, subsystem string) (eConns []string) {
+	eConns = make([]string, len(conns))
+	for i, connID := range conns {
+		eConns[i] = connID
+		if connID == utils.ConcatenatedKey(utils.MetaInternal, subsystem) {
+			eConns[i] = utils.MetaInternal
+		}
+	}
+	return
+}
+
+func (pOpts *PrometheusOpts) loadFromJSONCfg(jsnCfg *PrometheusOptsJson) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Tenants != nil {
+		pOpts.Tenants = slices.Clone(*jsnCfg.Tenants)
+	}
+	if jsnCfg.Stats_conns != nil {
+		pOpts.StatSConns = internalConns(*jsnCfg.Stats_conns, utils.MetaStats)
+	}
+	if jsnCfg.Stat_filters != nil {
+		pOpts.StatFilters = slices.Clone(*jsnCfg.Stat_filters)
+	}
+	if jsnCfg.Thresholds_conns != nil {
+		pOpts.ThresholdSConns = internalConns(*jsnCfg.Thresholds_conns, utils.MetaThresholds)
+	}
+	if jsnCfg.Threshold_filters != nil {
+		pOpts.ThresholdFilters = slices.Clone(*jsnCfg.Threshold_filters)
+	}
+	if jsnCfg.Trends_conns != nil {
+		pOpts.TrendSConns = internalConns(*jsnCfg.Trends_conns, utils.MetaTrends)
+	}
+	if jsnCfg.Trend_filters != nil {
+		pOpts.TrendFilters = slices.Clone(*jsnCfg.Trend_filters)
+	}
+	if jsnCfg.Sessions_conns != nil {
+		pOpts.SessionSConns = internalConns(*jsnCfg.Sessions_conns, utils.MetaSessionS)
+	}
+	if jsnCfg.Session_filters != nil {
+		pOpts.SessionFilters = slices.Clone(*jsnCfg.Session_filters)
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (pOpts *PrometheusOpts) AsMapInterface() map[string]any {
+	return map[string]any{
+		utils.Tenants:                       slices.Clone(pOpts.Tenants),
+		utils.StatSConnsCfg:                 externalConns(pOpts.StatSConns, utils.MetaStats),
+		utils.PrometheusStatFiltersCfg:      slices.Clone(pOpts.StatFilters),
+		utils.ThresholdSConnsCfg:            externalConns(pOpts.ThresholdSConns, utils.MetaThresholds),
+		utils.PrometheusThresholdFiltersCfg: slices.Clone(pOpts.ThresholdFilters),
+		utils.TrendSConnsCfg:                externalConns(pOpts.TrendSConns, utils.MetaTrends),
+		utils.PrometheusTrendFiltersCfg:     slices.Clone(pOpts.TrendFilters),
+		utils.SessionSConnsCfg:              externalConns(pOpts.SessionSConns, utils.MetaSessionS),
+		utils.PrometheusSessionFiltersCfg:   slices.Clone(pOpts.SessionFilters),
+	}
+}
+
+// Clone returns a deep copy of PrometheusOpts
+func (pOpts *PrometheusOpts) Clone() *PrometheusOpts {
+	return &PrometheusOpts{
+		Tenants:          slices.Clone(pOpts.Tenants),
+		StatSConns:       slices.Clone(pOpts.StatSConns),
+		StatFilters:      slices.Clone(pOpts.StatFilters),
+		ThresholdSConns:  slices.Clone(pOpts.ThresholdSConns),
+		ThresholdFilters: slices.Clone(pOpts.ThresholdFilters),
+		TrendSConns:      slices.Clone(pOpts.TrendSConns),
+		TrendFilters:     slices.Clone(pOpts.TrendFilters),
+		SessionSConns:    slices.Clone(pOpts.SessionSConns),
+		SessionFilters:   slices.Clone(pOpts.SessionFilters),
+	}
+}
+
 func newDialer(dialer *net.Dialer, jsnCfg *HTTPClientOptsJson) (err error) {
 	if jsnCfg == nil {
 		return
@@ -130,6 +234,12 @@ func (httpcfg *HTTPCfg) loadFromJSONCfg(jsnHTTPCfg *HTTPJsonCfg) (err error) {
 	if jsnHTTPCfg.Ws_url != nil {
 		httpcfg.HTTPWSURL = *jsnHTTPCfg.Ws_url
 	}
+	if jsnHTTPCfg.Live_feed_url != nil {
+		httpcfg.LiveFeedURL = *jsnHTTPCfg.Live_feed_url
+	}
+	if jsnHTTPCfg.Ws_live_feed_url != nil {
+		httpcfg.WSLiveFeedURL = *jsnHTTPCfg.Ws_live_feed_url
+	}
 	if jsnHTTPCfg.Freeswitch_cdrs_url != nil {
 		httpcfg.HTTPFreeswitchCDRsURL = *jsnHTTPCfg.Freeswitch_cdrs_url
 	}
@@ -151,6 +261,7 @@ func (httpcfg *HTTPCfg) loadFromJSONCfg(jsnHTTPCfg *HTTPJsonCfg) (err error) {
 		}
 		err = loadTransportFromJSONCfg(httpcfg.ClientOpts, httpcfg.dialer, jsnHTTPCfg.Client_opts)
 	}
+	httpcfg.PrometheusOpts.loadFromJSONCfg(jsnHTTPCfg.Prometheus_opts)
 	return nil
 }
 
@@ -180,12 +291,15 @@ func (httpcfg *HTTPCfg) AsMapInterface() map[string]any {
 		utils.RegistrarSURLCfg:         httpcfg.RegistrarSURL,
 		utils.PrometheusURLCfg:         httpcfg.PrometheusURL,
 		utils.HTTPWSURLCfg:             httpcfg.HTTPWSURL,
+		utils.LiveFeedURLCfg:           httpcfg.LiveFeedURL,
+		utils.WSLiveFeedURLCfg:         httpcfg.WSLiveFeedURL,
 		utils.HTTPFreeswitchCDRsURLCfg: httpcfg.HTTPFreeswitchCDRsURL,
 		utils.HTTPCDRsURLCfg:           httpcfg.HTTPCDRsURL,
 		utils.PprofPathCfg:             httpcfg.PprofPath,
 		utils.HTTPUseBasicAuthCfg:      httpcfg.HTTPUseBasicAuth,
 		utils.HTTPAuthUsersCfg:         httpcfg.HTTPAuthUsers,
 		utils.HTTPClientOptsCfg:        clientOpts,
+		utils.PrometheusOptsCfg:        httpcfg.PrometheusOpts.AsMapInterface(),
 	}
 }
 
@@ -201,12 +315,15 @@ func (httpcfg HTTPCfg) Clone() (cln *HTTPCfg) {
 		RegistrarSURL:         httpcfg.RegistrarSURL,
 		PrometheusURL:         httpcfg.PrometheusURL,
 		HTTPWSURL:             httpcfg.HTTPWSURL,
+		LiveFeedURL:           httpcfg.LiveFeedURL,
+		WSLiveFeedURL:         httpcfg.WSLiveFeedURL,
 		HTTPFreeswitchCDRsURL: httpcfg.HTTPFreeswitchCDRsURL,
 		HTTPCDRsURL:           httpcfg.HTTPCDRsURL,
 		PprofPath:             httpcfg.PprofPath,
 		HTTPUseBasicAuth:      httpcfg.HTTPUseBasicAuth,
 		HTTPAuthUsers:         make(map[string]string),
 		ClientOpts:            httpcfg.ClientOpts.Clone(),
+		PrometheusOpts:        httpcfg.PrometheusOpts.Clone(),
 		dialer:                dialer,
 	}
 	for u, a := range httpcfg.HTTPAuthUsers {
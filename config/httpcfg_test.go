@@ -33,6 +33,8 @@ func TestHTTPCfgloadFromJsonCfg(t *testing.T) {
 		Json_rpc_url:        utils.StringPointer("/jsonrpc"),
 		PrometheusURL:       utils.StringPointer("/prometheus"),
 		Ws_url:              utils.StringPointer("/ws"),
+		Live_feed_url:       utils.StringPointer("/live"),
+		Ws_live_feed_url:    utils.StringPointer(""),
 		Registrars_url:      utils.StringPointer("/randomUrl"),
 		PprofPath:           utils.StringPointer("/pprof/test"),
 		Freeswitch_cdrs_url: utils.StringPointer("/freeswitch_json"),
@@ -44,6 +46,7 @@ func TestHTTPCfgloadFromJsonCfg(t *testing.T) {
 		HTTPJsonRPCURL:        "/jsonrpc",
 		PrometheusURL:         "/prometheus",
 		HTTPWSURL:             "/ws",
+		LiveFeedURL:           "/live",
 		RegistrarSURL:         "/randomUrl",
 		HTTPFreeswitchCDRsURL: "/freeswitch_json",
 		HTTPCDRsURL:           "/cdr_http",
@@ -66,6 +69,13 @@ func TestHTTPCfgloadFromJsonCfg(t *testing.T) {
 			ExpectContinueTimeout: 0,
 			ForceAttemptHTTP2:     true,
 		},
+		PrometheusOpts: &PrometheusOpts{
+			Tenants:          []string{},
+			StatFilters:      []string{},
+			ThresholdFilters: []string{},
+			TrendFilters:     []string{},
+			SessionFilters:   []string{},
+		},
 		dialer: &net.Dialer{
 			Timeout:       30 * time.Second,
 			FallbackDelay: 300 * time.Millisecond,
@@ -91,6 +101,8 @@ func TestHTTPCfgAsMapInterface(t *testing.T) {
 		utils.RegistrarSURLCfg:         "/registrar",
 		utils.PrometheusURLCfg:         "/prometheus",
 		utils.HTTPWSURLCfg:             "/ws",
+		utils.LiveFeedURLCfg:           "/live_feed",
+		utils.WSLiveFeedURLCfg:         "/ws_live_feed",
 		utils.HTTPFreeswitchCDRsURLCfg: "/freeswitch_json",
 		utils.HTTPCDRsURLCfg:           "/cdr_http",
 		utils.PprofPathCfg:             "/debug/pprof/",
@@ -112,6 +124,17 @@ func TestHTTPCfgAsMapInterface(t *testing.T) {
 			utils.HTTPClientDialFallbackDelayCfg:     "300ms",
 			utils.HTTPClientDialKeepAliveCfg:         "30s",
 		},
+		utils.PrometheusOptsCfg: map[string]any{
+			utils.Tenants:                       []string{},
+			utils.StatSConnsCfg:                 []string{},
+			utils.PrometheusStatFiltersCfg:      []string{},
+			utils.ThresholdSConnsCfg:            []string{},
+			utils.PrometheusThresholdFiltersCfg: []string{},
+			utils.TrendSConnsCfg:                []string{},
+			utils.PrometheusTrendFiltersCfg:     []string{},
+			utils.SessionSConnsCfg:              []string{},
+			utils.PrometheusSessionFiltersCfg:   []string{},
+		},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -126,6 +149,7 @@ func TestHTTPCfgAsMapInterface1(t *testing.T) {
 		"json_rpc_url": "/rpc",					
 		"ws_url": "",	
 		"prometheus_url": "/metrics",
+		"ws_live_feed_url": "",
 		"pprof_path": "/pprof/test",
 		"use_basic_auth": true,					
 		"auth_users": {"user1": "authenticated", "user2": "authenticated"},
@@ -137,6 +161,8 @@ func TestHTTPCfgAsMapInterface1(t *testing.T) {
 		utils.PrometheusURLCfg:         "/metrics",
 		utils.PprofPathCfg:             "/pprof/test",
 		utils.HTTPWSURLCfg:             "",
+		utils.LiveFeedURLCfg:           "/live_feed",
+		utils.WSLiveFeedURLCfg:         "",
 		utils.HTTPFreeswitchCDRsURLCfg: "/freeswitch_json",
 		utils.HTTPCDRsURLCfg:           "/cdr_http",
 		utils.HTTPUseBasicAuthCfg:      true,
@@ -160,6 +186,17 @@ func TestHTTPCfgAsMapInterface1(t *testing.T) {
 			utils.HTTPClientDialFallbackDelayCfg:     "300ms",
 			utils.HTTPClientDialKeepAliveCfg:         "30s",
 		},
+		utils.PrometheusOptsCfg: map[string]any{
+			utils.Tenants:                       []string{},
+			utils.StatSConnsCfg:                 []string{},
+			utils.PrometheusStatFiltersCfg:      []string{},
+			utils.ThresholdSConnsCfg:            []string{},
+			utils.PrometheusThresholdFiltersCfg: []string{},
+			utils.TrendSConnsCfg:                []string{},
+			utils.PrometheusTrendFiltersCfg:     []string{},
+			utils.SessionSConnsCfg:              []string{},
+			utils.PrometheusSessionFiltersCfg:   []string{},
+		},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -173,6 +210,8 @@ func TestHTTPCfgClone(t *testing.T) {
 		HTTPJsonRPCURL:        "/jsonrpc",
 		PrometheusURL:         "/prometheus",
 		HTTPWSURL:             "/ws",
+		LiveFeedURL:           "/live_feed",
+		WSLiveFeedURL:         "/ws_live_feed",
 		RegistrarSURL:         "/randomUrl",
 		HTTPFreeswitchCDRsURL: "/freeswitch_json",
 		HTTPCDRsURL:           "/cdr_http",
@@ -197,6 +236,10 @@ func TestHTTPCfgClone(t *testing.T) {
 			ExpectContinueTimeout: 0,
 			ForceAttemptHTTP2:     true,
 		},
+		PrometheusOpts: &PrometheusOpts{
+			StatSConns:  []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)},
+			StatFilters: []string{"*string:~*req.ID:Stats1"},
+		},
 		dialer: &net.Dialer{
 			Timeout:       30 * time.Second,
 			FallbackDelay: 300 * time.Millisecond,
@@ -215,6 +258,54 @@ func TestHTTPCfgClone(t *testing.T) {
 	if rcv.HTTPAuthUsers["user"] = ""; ban.HTTPAuthUsers["user"] != "pass" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.PrometheusOpts.StatFilters[0] = ""; ban.PrometheusOpts.StatFilters[0] != "*string:~*req.ID:Stats1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
+
+func TestHTTPCfgLoadPrometheusOpts(t *testing.T) {
+	cfgJSONStr := `{
+	"http": {
+		"prometheus_opts": {
+			"tenants": ["cgrates.org"],
+			"stats_conns": ["*internal"],
+			"stat_filters": ["*string:~*req.ID:Stats1"],
+			"thresholds_conns": ["*localhost"],
+			"trends_conns": ["*internal"],
+			"sessions_conns": ["*internal"],
+			"session_filters": ["*string:~*req.RunID:*default"],
+		},
+	},
+}`
+	exp := &PrometheusOpts{
+		Tenants:          []string{"cgrates.org"},
+		StatSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)},
+		StatFilters:      []string{"*string:~*req.ID:Stats1"},
+		ThresholdSConns:  []string{utils.MetaLocalHost},
+		ThresholdFilters: []string{},
+		TrendSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends)},
+		TrendFilters:     []string{},
+		SessionSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		SessionFilters:   []string{"*string:~*req.RunID:*default"},
+	}
+	eMap := map[string]any{
+		utils.Tenants:                       []string{"cgrates.org"},
+		utils.StatSConnsCfg:                 []string{utils.MetaInternal},
+		utils.PrometheusStatFiltersCfg:      []string{"*string:~*req.ID:Stats1"},
+		utils.ThresholdSConnsCfg:            []string{utils.MetaLocalHost},
+		utils.PrometheusThresholdFiltersCfg: []string{},
+		utils.TrendSConnsCfg:                []string{utils.MetaInternal},
+		utils.PrometheusTrendFiltersCfg:     []string{},
+		utils.SessionSConnsCfg:              []string{utils.MetaInternal},
+		utils.PrometheusSessionFiltersCfg:   []string{"*string:~*req.RunID:*default"},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, cgrCfg.httpCfg.PrometheusOpts) {
+		t.Errorf("Expected %+v, received %+v", utils.ToJSON(exp), utils.ToJSON(cgrCfg.httpCfg.PrometheusOpts))
+	} else if rcv := cgrCfg.httpCfg.PrometheusOpts.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected %+v, received %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
 }
 
 func TestHTTPNewDialer(t *testing.T) {
@@ -293,8 +384,9 @@ func TestHTTPLoadFromJSONCfg(t *testing.T) {
 			DialTimeout: utils.StringPointer("test")},
 	}
 	httpcg := &HTTPCfg{
-		dialer:     &net.Dialer{},
-		ClientOpts: &http.Transport{},
+		dialer:         &net.Dialer{},
+		ClientOpts:     &http.Transport{},
+		PrometheusOpts: new(PrometheusOpts),
 	}
 	if err := httpcg.loadFromJSONCfg(jsnHTTPCfg); err == nil {
 		t.Error(err)
@@ -59,6 +59,7 @@ type ListenJsonCfg struct {
 	Rpc_json_tls *string
 	Rpc_gob_tls  *string
 	Http_tls     *string
+	Grpc         *string
 }
 
 type HTTPClientOptsJson struct {
@@ -84,12 +85,28 @@ type HTTPJsonCfg struct {
 	Registrars_url      *string
 	PrometheusURL       *string `json:"prometheus_url"`
 	Ws_url              *string
+	Live_feed_url       *string
+	Ws_live_feed_url    *string
 	Freeswitch_cdrs_url *string
 	Http_Cdrs           *string
 	PprofPath           *string `json:"pprof_path"`
 	Use_basic_auth      *bool
 	Auth_users          *map[string]string
 	Client_opts         *HTTPClientOptsJson
+	Prometheus_opts     *PrometheusOptsJson
+}
+
+// PrometheusOptsJson selects the data published by the prometheus collectors
+type PrometheusOptsJson struct {
+	Tenants           *[]string
+	Stats_conns       *[]string
+	Stat_filters      *[]string
+	Thresholds_conns  *[]string
+	Threshold_filters *[]string
+	Trends_conns      *[]string
+	Trend_filters     *[]string
+	Sessions_conns    *[]string
+	Session_filters   *[]string
 }
 
 type TlsJsonCfg struct {
@@ -132,6 +149,10 @@ type DBOptsJson struct {
 	PgSSLRootCert           *string           `json:"pgSSLRootCert"`
 	PgSchema                *string           `json:"pgSchema"`
 	MySQLLocation           *string           `json:"mysqlLocation"`
+
+	InternalDBDumpPath         *string `json:"internalDBDumpPath"`
+	InternalDBFsyncInterval    *string `json:"internalDBFsyncInterval"`
+	InternalDBSnapshotInterval *string `json:"internalDBSnapshotInterval"`
 }
 
 // Database config
@@ -299,6 +320,8 @@ type EEsJsonCfg struct {
 
 type EventExporterOptsJson struct {
 	CSVFieldSeparator           *string           `json:"csvFieldSeparator"`
+	ParquetCompression          *string           `json:"parquetCompression"`
+	AvroCodec                   *string           `json:"avroCodec"`
 	ElsCloud                    *bool             `json:"elsCloud"`
 	ElsAPIKey                   *string           `json:"elsApiKey"`
 	ElsServiceToken             *string           `json:"elsServiceToken"`
@@ -336,6 +359,9 @@ type EventExporterOptsJson struct {
 	KafkaTLS                    *bool             `json:"kafkaTLS"`
 	KafkaCAPath                 *string           `json:"kafkaCAPath"`
 	KafkaSkipTLSVerify          *bool             `json:"kafkaSkipTLSVerify"`
+	KafkaEncoding               *string           `json:"kafkaEncoding"`
+	KafkaSchemaRegistry         *string           `json:"kafkaSchemaRegistry"`
+	KafkaSchemaSubject          *string           `json:"kafkaSchemaSubject"`
 	AMQPQueueID                 *string           `json:"amqpQueueID"`
 	AMQPRoutingKey              *string           `json:"amqpRoutingKey"`
 	AMQPExchange                *string           `json:"amqpExchange"`
@@ -526,20 +552,47 @@ type OsipsConnJsonCfg struct {
 
 // DiameterAgent configuration
 type DiameterAgentJsonCfg struct {
-	Enabled              *bool
-	Listen               *string
-	Listen_net           *string
-	Dictionaries_path    *string
-	Sessions_conns       *[]string
-	Origin_host          *string
-	Origin_realm         *string
-	Vendor_id            *int
-	Product_name         *string
-	Synced_conn_requests *bool
-	Asr_template         *string
-	Rar_template         *string
-	Forced_disconnect    *string
-	Request_processors   *[]*ReqProcessorJsnCfg
+	Enabled                *bool
+	Listen                 *string
+	Listen_net             *string
+	Dictionaries_path      *string
+	Sessions_conns         *[]string
+	Origin_host            *string
+	Origin_realm           *string
+	Vendor_id              *int
+	Product_name           *string
+	Synced_conn_requests   *bool
+	Asr_template           *string
+	Rar_template           *string
+	Forced_disconnect      *string
+	Allowed_peers          *[]string
+	Peers                  *[]*DiamPeerJsnCfg
+	Watchdog_interval      *string
+	Reconnect_interval     *string
+	Max_reconnect_interval *string
+	Routes                 *[]*DiamRouteJsnCfg
+	Request_processors     *[]*ReqProcessorJsnCfg
+}
+
+type DiamPeerJsnCfg struct {
+	Id                   *string
+	Network              *string
+	Address              *string
+	Auth_application_ids *[]int
+	Acct_application_ids *[]int
+}
+
+type DiamRouteJsnCfg struct {
+	Id                *string
+	Realm             *string
+	Application_ids   *[]int
+	Peers             *[]*DiamRoutePeerJsnCfg
+	Request_processor *string
+}
+
+type DiamRoutePeerJsnCfg struct {
+	Id     *string
+	Weight *float64
 }
 
 type RadiListenerJsnCfg struct {
@@ -736,8 +789,12 @@ type RouteSJsonCfg struct {
 	Attributes_conns      *[]string
 	Resources_conns       *[]string
 	Stats_conns           *[]string
+	Rankings_conns        *[]string
+	Trends_conns          *[]string
 	Rals_conns            *[]string
+	Cdrs_conns            *[]string
 	Default_ratio         *int
+	Ranking_stale_age     *string
 	Opts                  *RoutesOptsJson
 }
 
@@ -759,6 +816,8 @@ type LoaderJsonCfg struct {
 	Field_separator *string
 	Tp_in_dir       *string
 	Tp_out_dir      *string
+	Pull_url        *string
+	Pull_interval   *string
 	Data            *[]*LoaderJsonDataType
 }
 
@@ -907,9 +966,17 @@ type SIPAgentJsonCfg struct {
 	Sessions_conns       *[]string
 	Timezone             *string
 	Retransmission_timer *string
+	Registrar            *SIPRegistrarJsonCfg
 	Request_processors   *[]*ReqProcessorJsnCfg
 }
 
+type SIPRegistrarJsonCfg struct {
+	Enabled          *bool
+	Realm            *string
+	Attributes_conns *[]string
+	Max_expires      *string
+}
+
 type JanusAgentJsonCfg struct {
 	Enabled           *bool                  `json:"enabled"`
 	Url               *string                `json:"url"`
@@ -950,5 +1017,26 @@ type CoreSJsonCfg struct {
 	Caps                *int
 	Caps_strategy       *string
 	Caps_stats_interval *string
+	Caps_rate_limits    *[]*CapsRateLimitJsonCfg
+	Tracing             *TracingJsonCfg
 	Shutdown_timeout    *string
 }
+
+// TracingJsonCfg is the json config for the OpenTelemetry tracing
+type TracingJsonCfg struct {
+	Enabled       *bool
+	Exporter      *string
+	Endpoint      *string
+	Insecure      *bool
+	File_path     *string
+	Sampler_ratio *float64
+}
+
+// CapsRateLimitJsonCfg is the json config for one token-bucket API rate limit
+type CapsRateLimitJsonCfg struct {
+	Id      *string
+	Methods *[]string
+	Tenants *[]string
+	Rate    *float64
+	Burst   *int
+}
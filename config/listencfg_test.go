@@ -32,6 +32,7 @@ func TestListenCfgloadFromJsonCfg(t *testing.T) {
 		Rpc_json_tls: utils.StringPointer("127.0.0.1:2022"),
 		Rpc_gob_tls:  utils.StringPointer("127.0.0.1:2023"),
 		Http_tls:     utils.StringPointer("127.0.0.1:2280"),
+		Grpc:         utils.StringPointer("127.0.0.1:2014"),
 	}
 	expected := &ListenCfg{
 		RPCJSONListen:    "127.0.0.1:2012",
@@ -40,6 +41,7 @@ func TestListenCfgloadFromJsonCfg(t *testing.T) {
 		RPCJSONTLSListen: "127.0.0.1:2022",
 		RPCGOBTLSListen:  "127.0.0.1:2023",
 		HTTPTLSListen:    "127.0.0.1:2280",
+		GRPCListen:       "127.0.0.1:2014",
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err := jsnCfg.listenCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -60,6 +62,7 @@ func TestListenCfgAsMapInterface(t *testing.T) {
 		utils.RPCJSONTLSListenCfg: "127.0.0.1:2022",
 		utils.RPCGOBTLSListenCfg:  "127.0.0.1:2023",
 		utils.HTTPTLSListenCfg:    "127.0.0.1:2280",
+		utils.GRPCListenCfg:       "",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -76,6 +79,7 @@ func TestListenCfgAsMapInterface1(t *testing.T) {
         "rpc_json_tls" : "127.0.0.1:2025",		
         "rpc_gob_tls": "127.0.0.1:2001",		
         "http_tls": "127.0.0.1:2288",			
+        "grpc": "127.0.0.1:2014",
 	}
 }`
 	eMap := map[string]any{
@@ -85,6 +89,7 @@ func TestListenCfgAsMapInterface1(t *testing.T) {
 		utils.RPCJSONTLSListenCfg: "127.0.0.1:2025",
 		utils.RPCGOBTLSListenCfg:  "127.0.0.1:2001",
 		utils.HTTPTLSListenCfg:    "127.0.0.1:2288",
+		utils.GRPCListenCfg:       "127.0.0.1:2014",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -101,6 +106,7 @@ func TestListenCfgClone(t *testing.T) {
 		RPCJSONTLSListen: "127.0.0.1:2022",
 		RPCGOBTLSListen:  "127.0.0.1:2023",
 		HTTPTLSListen:    "127.0.0.1:2280",
+		GRPCListen:       "127.0.0.1:2014",
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
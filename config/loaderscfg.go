@@ -58,6 +58,23 @@ func (ldrs LoaderSCfgs) Enabled() bool {
 	return false
 }
 
+// RatingTPsEnabled returns true if any enabled loader loads rates, destination
+// rates or rating plans, which are kept within StorDB
+func (ldrs LoaderSCfgs) RatingTPsEnabled() bool {
+	for _, ldr := range ldrs {
+		if !ldr.Enabled {
+			continue
+		}
+		for _, ldrData := range ldr.Data {
+			switch ldrData.Type {
+			case utils.MetaRates, utils.MetaDestinationRates, utils.MetaRatingPlans:
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Clone itself into a new LoaderSCfgs
 func (ldrs LoaderSCfgs) Clone() (cln LoaderSCfgs) {
 	cln = make(LoaderSCfgs, len(ldrs))
@@ -79,6 +96,8 @@ type LoaderSCfg struct {
 	FieldSeparator string
 	TpInDir        string
 	TpOutDir       string
+	PullURL        string
+	PullInterval   time.Duration
 	Data           []*LoaderDataType
 }
 
@@ -160,6 +179,14 @@ func (l *LoaderSCfg) loadFromJSONCfg(jsnCfg *LoaderJsonCfg, msgTemplates map[str
 	if jsnCfg.Lockfile_path != nil {
 		l.LockFilePath = *jsnCfg.Lockfile_path
 	}
+	if jsnCfg.Pull_url != nil {
+		l.PullURL = *jsnCfg.Pull_url
+	}
+	if jsnCfg.Pull_interval != nil {
+		if l.PullInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Pull_interval); err != nil {
+			return
+		}
+	}
 	if jsnCfg.Data != nil {
 		data := make([]*LoaderDataType, len(*jsnCfg.Data))
 		for idx, jsnLoCfg := range *jsnCfg.Data {
@@ -213,6 +240,8 @@ func (l LoaderSCfg) Clone() (cln *LoaderSCfg) {
 		FieldSeparator: l.FieldSeparator,
 		TpInDir:        l.TpInDir,
 		TpOutDir:       l.TpOutDir,
+		PullURL:        l.PullURL,
+		PullInterval:   l.PullInterval,
 		Data:           make([]*LoaderDataType, len(l.Data)),
 	}
 
@@ -252,6 +281,8 @@ func (l *LoaderSCfg) AsMapInterface(separator string) (initialMP map[string]any)
 		utils.TpInDirCfg:      l.TpInDir,
 		utils.TpOutDirCfg:     l.TpOutDir,
 		utils.RunDelayCfg:     "0",
+		utils.PullURLCfg:      l.PullURL,
+		utils.PullIntervalCfg: "0",
 	}
 	if l.Data != nil {
 		data := make([]map[string]any, len(l.Data))
@@ -263,6 +294,9 @@ func (l *LoaderSCfg) AsMapInterface(separator string) (initialMP map[string]any)
 	if l.RunDelay != 0 {
 		initialMP[utils.RunDelayCfg] = l.RunDelay.String()
 	}
+	if l.PullInterval != 0 {
+		initialMP[utils.PullIntervalCfg] = l.PullInterval.String()
+	}
 	if l.CacheSConns != nil {
 		cacheSConns := make([]string, len(l.CacheSConns))
 		for i, item := range l.CacheSConns {
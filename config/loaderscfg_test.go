@@ -238,6 +238,25 @@ func TestEnabledCase2(t *testing.T) {
 	}
 }
 
+func TestLoaderSCfgsRatingTPsEnabled(t *testing.T) {
+	ldrs := LoaderSCfgs{
+		{
+			Enabled: true,
+			Data:    []*LoaderDataType{{Type: utils.MetaAttributes}},
+		},
+		{
+			Data: []*LoaderDataType{{Type: utils.MetaRates}},
+		},
+	}
+	if ldrs.RatingTPsEnabled() {
+		t.Error("expected no rating TPs for the disabled loader")
+	}
+	ldrs[1].Enabled = true
+	if !ldrs.RatingTPsEnabled() {
+		t.Error("expected rating TPs for the enabled loader")
+	}
+}
+
 func TestLoaderCfgloadFromJsonCfg(t *testing.T) {
 	cfgJSONStr := `{
 			"loaders": [												
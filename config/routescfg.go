@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -41,8 +43,12 @@ type RouteSCfg struct {
 	AttributeSConns     []string
 	ResourceSConns      []string
 	StatSConns          []string
+	RankingSConns       []string
+	TrendSConns         []string
 	RALsConns           []string
+	CDRsConns           []string
 	DefaultRatio        int
+	RankingStaleAge     time.Duration // rankings not updated within this interval are ignored by *rank sorting
 	NestedFields        bool
 	Opts                *RoutesOpts
 }
@@ -126,6 +132,26 @@ func (rts *RouteSCfg) loadFromJSONCfg(jsnCfg *RouteSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Rankings_conns != nil {
+		rts.RankingSConns = make([]string, len(*jsnCfg.Rankings_conns))
+		for idx, conn := range *jsnCfg.Rankings_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			rts.RankingSConns[idx] = conn
+			if conn == utils.MetaInternal {
+				rts.RankingSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRankings)
+			}
+		}
+	}
+	if jsnCfg.Trends_conns != nil {
+		rts.TrendSConns = make([]string, len(*jsnCfg.Trends_conns))
+		for idx, conn := range *jsnCfg.Trends_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			rts.TrendSConns[idx] = conn
+			if conn == utils.MetaInternal {
+				rts.TrendSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends)
+			}
+		}
+	}
 	if jsnCfg.Rals_conns != nil {
 		rts.RALsConns = make([]string, len(*jsnCfg.Rals_conns))
 		for idx, conn := range *jsnCfg.Rals_conns {
@@ -136,9 +162,24 @@ func (rts *RouteSCfg) loadFromJSONCfg(jsnCfg *RouteSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Cdrs_conns != nil {
+		rts.CDRsConns = make([]string, len(*jsnCfg.Cdrs_conns))
+		for idx, conn := range *jsnCfg.Cdrs_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			rts.CDRsConns[idx] = conn
+			if conn == utils.MetaInternal {
+				rts.CDRsConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)
+			}
+		}
+	}
 	if jsnCfg.Default_ratio != nil {
 		rts.DefaultRatio = *jsnCfg.Default_ratio
 	}
+	if jsnCfg.Ranking_stale_age != nil {
+		if rts.RankingStaleAge, err = utils.ParseDurationWithNanosecs(*jsnCfg.Ranking_stale_age); err != nil {
+			return
+		}
+	}
 	if jsnCfg.Nested_fields != nil {
 		rts.NestedFields = *jsnCfg.Nested_fields
 	}
@@ -167,11 +208,15 @@ func (rts *RouteSCfg) AsMapInterface() (initialMP map[string]any) {
 		opts[utils.MetaProfileCountCfg] = rts.Opts.ProfileCount
 	}
 	initialMP = map[string]any{
-		utils.EnabledCfg:        rts.Enabled,
-		utils.IndexedSelectsCfg: rts.IndexedSelects,
-		utils.DefaultRatioCfg:   rts.DefaultRatio,
-		utils.NestedFieldsCfg:   rts.NestedFields,
-		utils.OptsCfg:           opts,
+		utils.EnabledCfg:         rts.Enabled,
+		utils.IndexedSelectsCfg:  rts.IndexedSelects,
+		utils.DefaultRatioCfg:    rts.DefaultRatio,
+		utils.RankingStaleAgeCfg: "0",
+		utils.NestedFieldsCfg:    rts.NestedFields,
+		utils.OptsCfg:            opts,
+	}
+	if rts.RankingStaleAge != 0 {
+		initialMP[utils.RankingStaleAgeCfg] = rts.RankingStaleAge.String()
 	}
 	if rts.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*rts.StringIndexedFields))
@@ -208,6 +253,16 @@ func (rts *RouteSCfg) AsMapInterface() (initialMP map[string]any) {
 		}
 		initialMP[utils.RALsConnsCfg] = ralSConns
 	}
+	if rts.CDRsConns != nil {
+		cdrsConns := make([]string, len(rts.CDRsConns))
+		for i, item := range rts.CDRsConns {
+			cdrsConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs) {
+				cdrsConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.CDRsConnsCfg] = cdrsConns
+	}
 	if rts.ResourceSConns != nil {
 		resourceSConns := make([]string, len(rts.ResourceSConns))
 		for i, item := range rts.ResourceSConns {
@@ -228,6 +283,26 @@ func (rts *RouteSCfg) AsMapInterface() (initialMP map[string]any) {
 		}
 		initialMP[utils.StatSConnsCfg] = statSConns
 	}
+	if rts.RankingSConns != nil {
+		rankingSConns := make([]string, len(rts.RankingSConns))
+		for i, item := range rts.RankingSConns {
+			rankingSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRankings) {
+				rankingSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.RankingSConnsCfg] = rankingSConns
+	}
+	if rts.TrendSConns != nil {
+		trendSConns := make([]string, len(rts.TrendSConns))
+		for i, item := range rts.TrendSConns {
+			trendSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends) {
+				trendSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.TrendSConnsCfg] = trendSConns
+	}
 	return
 }
 
@@ -255,11 +330,12 @@ func (rts *RoutesOpts) Clone() (cln *RoutesOpts) {
 // Clone returns a deep copy of RouteSCfg
 func (rts RouteSCfg) Clone() (cln *RouteSCfg) {
 	cln = &RouteSCfg{
-		Enabled:        rts.Enabled,
-		IndexedSelects: rts.IndexedSelects,
-		DefaultRatio:   rts.DefaultRatio,
-		NestedFields:   rts.NestedFields,
-		Opts:           rts.Opts.Clone(),
+		Enabled:         rts.Enabled,
+		IndexedSelects:  rts.IndexedSelects,
+		DefaultRatio:    rts.DefaultRatio,
+		RankingStaleAge: rts.RankingStaleAge,
+		NestedFields:    rts.NestedFields,
+		Opts:            rts.Opts.Clone(),
 	}
 	if rts.AttributeSConns != nil {
 		cln.AttributeSConns = make([]string, len(rts.AttributeSConns))
@@ -273,10 +349,22 @@ func (rts RouteSCfg) Clone() (cln *RouteSCfg) {
 		cln.StatSConns = make([]string, len(rts.StatSConns))
 		copy(cln.StatSConns, rts.StatSConns)
 	}
+	if rts.RankingSConns != nil {
+		cln.RankingSConns = make([]string, len(rts.RankingSConns))
+		copy(cln.RankingSConns, rts.RankingSConns)
+	}
+	if rts.TrendSConns != nil {
+		cln.TrendSConns = make([]string, len(rts.TrendSConns))
+		copy(cln.TrendSConns, rts.TrendSConns)
+	}
 	if rts.RALsConns != nil {
 		cln.RALsConns = make([]string, len(rts.RALsConns))
 		copy(cln.RALsConns, rts.RALsConns)
 	}
+	if rts.CDRsConns != nil {
+		cln.CDRsConns = make([]string, len(rts.CDRsConns))
+		copy(cln.CDRsConns, rts.CDRsConns)
+	}
 	if rts.StringIndexedFields != nil {
 		idx := make([]string, len(*rts.StringIndexedFields))
 		copy(idx, *rts.StringIndexedFields)
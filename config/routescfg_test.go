@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -34,8 +35,12 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 		Attributes_conns:      &[]string{utils.MetaInternal, "conn1"},
 		Resources_conns:       &[]string{utils.MetaInternal, "conn1"},
 		Stats_conns:           &[]string{utils.MetaInternal, "conn1"},
+		Rankings_conns:        &[]string{utils.MetaInternal, "conn1"},
+		Trends_conns:          &[]string{utils.MetaInternal, "conn1"},
 		Rals_conns:            &[]string{utils.MetaInternal, "conn1"},
+		Cdrs_conns:            &[]string{utils.MetaInternal, "conn1"},
 		Default_ratio:         utils.IntPointer(10),
+		Ranking_stale_age:     utils.StringPointer("5m"),
 		Nested_fields:         utils.BoolPointer(true),
 		Opts: &RoutesOptsJson{
 			MaxCost:      utils.IntPointer(3),
@@ -53,8 +58,12 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "conn1"},
 		ResourceSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources), "conn1"},
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "conn1"},
+		RankingSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRankings), "conn1"},
+		TrendSConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends), "conn1"},
 		RALsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder), "conn1"},
+		CDRsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "conn1"},
 		DefaultRatio:        10,
+		RankingStaleAge:     5 * time.Minute,
 		NestedFields:        true,
 		Opts: &RoutesOpts{
 			Context:      utils.MetaRoutes,
@@ -128,8 +137,12 @@ func TestRouteSCfgAsMapInterface1(t *testing.T) {
 			"attributes_conns": ["*internal:*attributes", "conn1"],
 			"resources_conns": ["*internal:*resources", "conn1"],
 			"stats_conns": ["*internal:*stats", "conn1"],
+			"rankings_conns": ["*internal:*rankings", "conn1"],
+			"trends_conns": ["*internal:*trends", "conn1"],
 			"rals_conns": ["*internal:*responder", "conn1"],
+			"cdrs_conns": ["*internal:*cdrs", "conn1"],
 			"default_ratio":2,
+			"ranking_stale_age": "1m",
 		},
 	}`
 	eMap := map[string]any{
@@ -142,8 +155,12 @@ func TestRouteSCfgAsMapInterface1(t *testing.T) {
 		utils.AttributeSConnsCfg:     []string{utils.MetaInternal, "conn1"},
 		utils.ResourceSConnsCfg:      []string{utils.MetaInternal, "conn1"},
 		utils.StatSConnsCfg:          []string{utils.MetaInternal, "conn1"},
+		utils.RankingSConnsCfg:       []string{utils.MetaInternal, "conn1"},
+		utils.TrendSConnsCfg:         []string{utils.MetaInternal, "conn1"},
 		utils.RALsConnsCfg:           []string{utils.MetaInternal, "conn1"},
+		utils.CDRsConnsCfg:           []string{utils.MetaInternal, "conn1"},
 		utils.DefaultRatioCfg:        2,
+		utils.RankingStaleAgeCfg:     "1m0s",
 		utils.OptsCfg: map[string]any{
 			utils.OptsContext:         utils.MetaRoutes,
 			utils.MetaIgnoreErrorsCfg: false,
@@ -167,8 +184,12 @@ func TestRouteSCfgClone(t *testing.T) {
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "conn1"},
 		ResourceSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources), "conn1"},
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "conn1"},
+		RankingSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRankings), "conn1"},
+		TrendSConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends), "conn1"},
 		RALsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder), "conn1"},
+		CDRsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "conn1"},
 		DefaultRatio:        10,
+		RankingStaleAge:     time.Minute,
 		NestedFields:        true,
 		Opts: &RoutesOpts{
 			ProfileCount: utils.IntPointer(0),
@@ -189,9 +210,18 @@ func TestRouteSCfgClone(t *testing.T) {
 	if rcv.StatSConns[1] = ""; ban.StatSConns[1] != "conn1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.RankingSConns[1] = ""; ban.RankingSConns[1] != "conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.TrendSConns[1] = ""; ban.TrendSConns[1] != "conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 	if rcv.RALsConns[1] = ""; ban.RALsConns[1] != "conn1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.CDRsConns[1] = ""; ban.CDRsConns[1] != "conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 	if (*rcv.StringIndexedFields)[0] = ""; (*ban.StringIndexedFields)[0] != "*req.index1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
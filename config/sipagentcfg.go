@@ -32,9 +32,80 @@ type SIPAgentCfg struct {
 	SessionSConns       []string
 	Timezone            string
 	RetransmissionTimer time.Duration // timeout replies if not reaching back
+	Registrar           *SIPRegistrarCfg
 	RequestProcessors   []*RequestProcessor
 }
 
+// SIPRegistrarCfg the config for the registrar within the SIPAgent
+type SIPRegistrarCfg struct {
+	Enabled         bool
+	Realm           string // realm of the digest challenge, the host of the request URI if empty
+	AttributeSConns []string
+	MaxExpires      time.Duration // longer registrations are shortened to it
+}
+
+func (reg *SIPRegistrarCfg) loadFromJSONCfg(jsnCfg *SIPRegistrarJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		reg.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Realm != nil {
+		reg.Realm = *jsnCfg.Realm
+	}
+	if jsnCfg.Attributes_conns != nil {
+		reg.AttributeSConns = make([]string, len(*jsnCfg.Attributes_conns))
+		for idx, connID := range *jsnCfg.Attributes_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			reg.AttributeSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				reg.AttributeSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)
+			}
+		}
+	}
+	if jsnCfg.Max_expires != nil {
+		if reg.MaxExpires, err = utils.ParseDurationWithNanosecs(*jsnCfg.Max_expires); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (reg *SIPRegistrarCfg) AsMapInterface() (initialMP map[string]any) {
+	initialMP = map[string]any{
+		utils.EnabledCfg:    reg.Enabled,
+		utils.RealmCfg:      reg.Realm,
+		utils.MaxExpiresCfg: reg.MaxExpires.String(),
+	}
+	if reg.AttributeSConns != nil {
+		attributeSConns := make([]string, len(reg.AttributeSConns))
+		for i, item := range reg.AttributeSConns {
+			attributeSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes) {
+				attributeSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.AttributeSConnsCfg] = attributeSConns
+	}
+	return
+}
+
+// Clone returns a deep copy of SIPRegistrarCfg
+func (reg SIPRegistrarCfg) Clone() (cln *SIPRegistrarCfg) {
+	cln = &SIPRegistrarCfg{
+		Enabled:    reg.Enabled,
+		Realm:      reg.Realm,
+		MaxExpires: reg.MaxExpires,
+	}
+	if reg.AttributeSConns != nil {
+		cln.AttributeSConns = make([]string, len(reg.AttributeSConns))
+		copy(cln.AttributeSConns, reg.AttributeSConns)
+	}
+	return
+}
+
 func (sa *SIPAgentCfg) loadFromJSONCfg(jsnCfg *SIPAgentJsonCfg, sep string) (err error) {
 	if jsnCfg == nil {
 		return nil
@@ -66,6 +137,14 @@ func (sa *SIPAgentCfg) loadFromJSONCfg(jsnCfg *SIPAgentJsonCfg, sep string) (err
 			return err
 		}
 	}
+	if jsnCfg.Registrar != nil {
+		if sa.Registrar == nil {
+			sa.Registrar = new(SIPRegistrarCfg)
+		}
+		if err = sa.Registrar.loadFromJSONCfg(jsnCfg.Registrar); err != nil {
+			return
+		}
+	}
 	if jsnCfg.Request_processors != nil {
 		for _, reqProcJsn := range *jsnCfg.Request_processors {
 			rp := new(RequestProcessor)
@@ -97,6 +176,9 @@ func (sa *SIPAgentCfg) AsMapInterface(separator string) (initialMP map[string]an
 		utils.TimezoneCfg:            sa.Timezone,
 		utils.RetransmissionTimerCfg: sa.RetransmissionTimer,
 	}
+	if sa.Registrar != nil {
+		initialMP[utils.RegistrarCfg] = sa.Registrar.AsMapInterface()
+	}
 
 	requestProcessors := make([]map[string]any, len(sa.RequestProcessors))
 	for i, item := range sa.RequestProcessors {
@@ -130,6 +212,9 @@ func (sa SIPAgentCfg) Clone() (cln *SIPAgentCfg) {
 		cln.SessionSConns = make([]string, len(sa.SessionSConns))
 		copy(cln.SessionSConns, sa.SessionSConns)
 	}
+	if sa.Registrar != nil {
+		cln.Registrar = sa.Registrar.Clone()
+	}
 	if sa.RequestProcessors != nil {
 		cln.RequestProcessors = make([]*RequestProcessor, len(sa.RequestProcessors))
 		for i, rp := range sa.RequestProcessors {
@@ -33,6 +33,12 @@ func TestSIPAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		Sessions_conns:       &[]string{utils.MetaInternal},
 		Timezone:             utils.StringPointer("local"),
 		Retransmission_timer: utils.StringPointer("1"),
+		Registrar: &SIPRegistrarJsonCfg{
+			Enabled:          utils.BoolPointer(true),
+			Realm:            utils.StringPointer("cgrates.org"),
+			Attributes_conns: &[]string{utils.MetaInternal},
+			Max_expires:      utils.StringPointer("30m"),
+		},
 		Request_processors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:             utils.StringPointer("OutboundAUTHDryRun"),
@@ -58,6 +64,12 @@ func TestSIPAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		SessionSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
 		Timezone:            "local",
 		RetransmissionTimer: 1,
+		Registrar: &SIPRegistrarCfg{
+			Enabled:         true,
+			Realm:           "cgrates.org",
+			AttributeSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)},
+			MaxExpires:      30 * time.Minute,
+		},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -97,6 +109,12 @@ func TestSIPAgentCfgloadFromJsonCfgCase2(t *testing.T) {
 	if err := jsonCfg.sipAgentCfg.loadFromJSONCfg(cfgJSON, jsonCfg.generalCfg.RSRSep); err == nil || err.Error() != expected {
 		t.Errorf("Expected %+v, received %+v", expected, err)
 	}
+	cfgJSON = &SIPAgentJsonCfg{
+		Registrar: &SIPRegistrarJsonCfg{Max_expires: utils.StringPointer("1ss")},
+	}
+	if err := jsonCfg.sipAgentCfg.loadFromJSONCfg(cfgJSON, jsonCfg.generalCfg.RSRSep); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
 }
 
 func TestSIPAgentCfgloadFromJsonCfgCase4(t *testing.T) {
@@ -164,6 +182,12 @@ func TestSIPAgentCfgAsMapInterface(t *testing.T) {
 		utils.TimezoneCfg:            "",
 		utils.RetransmissionTimerCfg: 2 * time.Second,
 		utils.RequestProcessorsCfg:   []map[string]any{},
+		utils.RegistrarCfg: map[string]any{
+			utils.EnabledCfg:         false,
+			utils.RealmCfg:           "",
+			utils.AttributeSConnsCfg: []string{utils.MetaInternal},
+			utils.MaxExpiresCfg:      "1h0m0s",
+		},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -213,6 +237,12 @@ func TestSIPAgentCfgAsMapInterface1(t *testing.T) {
 		utils.SessionSConnsCfg:       []string{"*internal"},
 		utils.TimezoneCfg:            "UTC",
 		utils.RetransmissionTimerCfg: 5 * time.Second,
+		utils.RegistrarCfg: map[string]any{
+			utils.EnabledCfg:         false,
+			utils.RealmCfg:           "",
+			utils.AttributeSConnsCfg: []string{utils.MetaInternal},
+			utils.MaxExpiresCfg:      "1h0m0s",
+		},
 		utils.RequestProcessorsCfg: []map[string]any{
 			{
 				utils.IDCfg:            "OutboundAUTHDryRun",
@@ -244,6 +274,12 @@ func TestSIPAgentCfgAsMapInterface2(t *testing.T) {
 		"enabled": true,
 		"listen": "",
 		"sessions_conns": ["*conn1", "*conn2"],
+		"registrar": {
+			"enabled": true,
+			"realm": "cgrates.org",
+			"attributes_conns": ["*conn1"],
+			"max_expires": "10m",
+		},
 		"request_processors": [
          {
 			"id": "Register",
@@ -267,6 +303,12 @@ func TestSIPAgentCfgAsMapInterface2(t *testing.T) {
 		utils.SessionSConnsCfg:       []string{"*conn1", "*conn2"},
 		utils.TimezoneCfg:            "",
 		utils.RetransmissionTimerCfg: time.Second,
+		utils.RegistrarCfg: map[string]any{
+			utils.EnabledCfg:         true,
+			utils.RealmCfg:           "cgrates.org",
+			utils.AttributeSConnsCfg: []string{"*conn1"},
+			utils.MaxExpiresCfg:      "10m0s",
+		},
 		utils.RequestProcessorsCfg: []map[string]any{
 			{
 				utils.IDCfg:            "Register",
@@ -296,6 +338,11 @@ func TestSIPAgentCfgClone(t *testing.T) {
 		SessionSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
 		Timezone:            "UTC",
 		RetransmissionTimer: 1,
+		Registrar: &SIPRegistrarCfg{
+			Enabled:         true,
+			AttributeSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)},
+			MaxExpires:      time.Hour,
+		},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
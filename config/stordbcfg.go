@@ -42,6 +42,10 @@ type StorDBOpts struct {
 	PgSchema           string
 	MySQLLocation      string
 	MySQLDSNParams     map[string]string
+
+	InternalDBDumpPath         string        // directory where the *internal StorDB keeps its snapshot and write-ahead log, empty to keep it in memory only
+	InternalDBFsyncInterval    time.Duration // 0 to fsync the write-ahead log on each write, -1 to leave it to the OS
+	InternalDBSnapshotInterval time.Duration // interval of the compacting snapshots, 0 to take them only on shutdown or on demand
 }
 
 // StorDbCfg StroreDb config
@@ -111,6 +115,19 @@ func (dbOpts *StorDBOpts) loadFromJSONCfg(jsnCfg *DBOptsJson) (err error) {
 	if jsnCfg.MySQLLocation != nil {
 		dbOpts.MySQLLocation = *jsnCfg.MySQLLocation
 	}
+	if jsnCfg.InternalDBDumpPath != nil {
+		dbOpts.InternalDBDumpPath = *jsnCfg.InternalDBDumpPath
+	}
+	if jsnCfg.InternalDBFsyncInterval != nil {
+		if dbOpts.InternalDBFsyncInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.InternalDBFsyncInterval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.InternalDBSnapshotInterval != nil {
+		if dbOpts.InternalDBSnapshotInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.InternalDBSnapshotInterval); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -200,6 +217,10 @@ func (dbOpts *StorDBOpts) Clone() *StorDBOpts {
 		PgSSLRootCert:      dbOpts.PgSSLRootCert,
 		PgSchema:           dbOpts.PgSchema,
 		MySQLLocation:      dbOpts.MySQLLocation,
+
+		InternalDBDumpPath:         dbOpts.InternalDBDumpPath,
+		InternalDBFsyncInterval:    dbOpts.InternalDBFsyncInterval,
+		InternalDBSnapshotInterval: dbOpts.InternalDBSnapshotInterval,
 	}
 }
 
@@ -250,6 +271,10 @@ func (dbcfg *StorDbCfg) AsMapInterface() (mp map[string]any) {
 		utils.PgSSLModeCfg:         dbcfg.Opts.PgSSLMode,
 		utils.PgSchema:             dbcfg.Opts.PgSchema,
 		utils.MysqlLocation:        dbcfg.Opts.MySQLLocation,
+
+		utils.InternalDBDumpPathCfg:         dbcfg.Opts.InternalDBDumpPath,
+		utils.InternalDBFsyncIntervalCfg:    dbcfg.Opts.InternalDBFsyncInterval.String(),
+		utils.InternalDBSnapshotIntervalCfg: dbcfg.Opts.InternalDBSnapshotInterval.String(),
 	}
 	if dbcfg.Opts.PgSSLCert != "" {
 		opts[utils.PgSSLCertCfg] = dbcfg.Opts.PgSSLCert
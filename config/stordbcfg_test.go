@@ -55,6 +55,9 @@ func TestStoreDbCfgloadFromJsonCfgCase1(t *testing.T) {
 			MySQLLocation:      utils.StringPointer("UTC"),
 			MongoConnScheme:    utils.StringPointer("mongodb"),
 			PgSSLMode:          utils.StringPointer(utils.PgSSLModeDisable),
+
+			InternalDBDumpPath:      utils.StringPointer("/var/lib/cgrates/stor_db"),
+			InternalDBFsyncInterval: utils.StringPointer("0"),
 		},
 	}
 	expected := &StorDbCfg{
@@ -87,6 +90,10 @@ func TestStoreDbCfgloadFromJsonCfgCase1(t *testing.T) {
 			PgSSLMode:          "disable",
 			MySQLLocation:      "UTC",
 			MySQLDSNParams:     make(map[string]string),
+
+			InternalDBDumpPath:         "/var/lib/cgrates/stor_db",
+			InternalDBFsyncInterval:    0,
+			InternalDBSnapshotInterval: time.Hour,
 		},
 	}
 	jsonCfg := NewDefaultCGRConfig()
@@ -220,6 +227,8 @@ func TestStorDbCfgAsMapInterface(t *testing.T) {
 				"mongoConnScheme": "mongodb+srv",
 				"pgSSLMode":"disable",		
 				"mysqlLocation": "UTC",			
+				"internalDBDumpPath": "/var/lib/cgrates/stor_db",
+				"internalDBSnapshotInterval": "30m",
 			},
 			"items":{
 				"session_costs": {}, 
@@ -249,6 +258,10 @@ func TestStorDbCfgAsMapInterface(t *testing.T) {
 			utils.PgSSLModeCfg:          "disable",
 			utils.MysqlLocation:         "UTC",
 			utils.PgSchema:              "",
+
+			utils.InternalDBDumpPathCfg:         "/var/lib/cgrates/stor_db",
+			utils.InternalDBFsyncIntervalCfg:    "1s",
+			utils.InternalDBSnapshotIntervalCfg: "30m0s",
 		},
 		utils.ItemsCfg: map[string]any{
 			utils.SessionCostsTBL: map[string]any{utils.RemoteCfg: false, utils.ReplicateCfg: false},
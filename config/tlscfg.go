@@ -18,7 +18,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"crypto/tls"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 // TLSCfg is the configuration for tls
 type TLSCfg struct {
@@ -85,3 +89,13 @@ func (tls TLSCfg) Clone() *TLSCfg {
 		CaCertificate:    tls.CaCertificate,
 	}
 }
+
+// TLSConfig returns the TLS configuration of the listeners if isServer, otherwise of the clients
+func (tls *TLSCfg) TLSConfig(isServer bool) (*tls.Config, error) {
+	if isServer {
+		return utils.NewTLSConfig(tls.ServerCerificate, tls.ServerKey, tls.CaCertificate,
+			tls.ServerPolicy, tls.ServerName, true)
+	}
+	return utils.NewTLSConfig(tls.ClientCerificate, tls.ClientKey, tls.CaCertificate,
+		0, utils.EmptyString, false)
+}
@@ -0,0 +1,68 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdRoutesSimulate{
+		name:      "routes_simulate",
+		rpcMethod: utils.RouteSv1SimulateRoutes,
+		rpcParams: &engine.ArgsSimulateRoutes{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdRoutesSimulate struct {
+	name      string
+	rpcMethod string
+	rpcParams *engine.ArgsSimulateRoutes
+	*CommandExecuter
+}
+
+func (self *CmdRoutesSimulate) Name() string {
+	return self.name
+}
+
+func (self *CmdRoutesSimulate) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdRoutesSimulate) RpcParams(reset bool) any {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &engine.ArgsSimulateRoutes{
+			RPCCDRsFilter: new(utils.RPCCDRsFilter),
+			APIOpts:       make(map[string]any),
+		}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdRoutesSimulate) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdRoutesSimulate) RpcResult() any {
+	var sim engine.RoutesSimulation
+	return &sim
+}
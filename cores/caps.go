@@ -20,10 +20,12 @@ package cores
 
 import (
 	"net"
+	"reflect"
 
 	"github.com/cgrates/birpc"
 	"github.com/cgrates/birpc/jsonrpc"
 	"github.com/cgrates/cgrates/analyzers"
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
@@ -38,7 +40,7 @@ type conn interface {
 }
 
 func newCapsGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r birpc.ServerCodec) {
-	r = newCapsServerCodec(birpc.NewServerCodec(conn), caps)
+	r = newCapsServerCodec(newTracingServerCodec(birpc.NewServerCodec(conn)), caps)
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
@@ -56,7 +58,7 @@ func newCapsGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerServic
 }
 
 func newCapsJSONCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r birpc.ServerCodec) {
-	r = newCapsServerCodec(jsonrpc.NewServerCodec(conn), caps)
+	r = newCapsServerCodec(newTracingServerCodec(jsonrpc.NewServerCodec(conn)), caps)
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
@@ -74,7 +76,7 @@ func newCapsJSONCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerServi
 }
 
 func newCapsServerCodec(sc birpc.ServerCodec, caps *engine.Caps) birpc.ServerCodec {
-	if !caps.IsLimited() {
+	if !caps.IsLimited() && !caps.HasRateLimits() {
 		return sc
 	}
 	return &capsServerCodec{
@@ -86,30 +88,71 @@ func newCapsServerCodec(sc birpc.ServerCodec, caps *engine.Caps) birpc.ServerCod
 type capsServerCodec struct {
 	sc   birpc.ServerCodec
 	caps *engine.Caps
+
+	method string // method of the request being read
 }
 
-func (c *capsServerCodec) ReadRequestHeader(r *birpc.Request) error {
-	return c.sc.ReadRequestHeader(r)
+func (c *capsServerCodec) ReadRequestHeader(r *birpc.Request) (err error) {
+	if err = c.sc.ReadRequestHeader(r); err == nil {
+		c.method = r.ServiceMethod
+	}
+	return
 }
 
-func (c *capsServerCodec) ReadRequestBody(x any) error {
-	if err := c.caps.Allocate(); err != nil {
-		return err
+func (c *capsServerCodec) ReadRequestBody(x any) (err error) {
+	if c.caps.IsLimited() {
+		if err = c.caps.Allocate(); err != nil {
+			return
+		}
+	}
+	if err = c.sc.ReadRequestBody(x); err != nil ||
+		!c.caps.HasRateLimits() {
+		return
 	}
-	return c.sc.ReadRequestBody(x)
+	if err = c.caps.AllowRate(c.method, tenantFromArgs(x)); err != nil &&
+		c.caps.IsLimited() {
+		c.caps.Deallocate() // WriteResponse will not deallocate on rate errors
+	}
+	return
 }
+
 func (c *capsServerCodec) WriteResponse(r *birpc.Response, x any) error {
-	if r.Error == utils.ErrMaxConcurrentRPCExceededNoCaps.Error() {
+	switch {
+	case r.Error == utils.ErrMaxConcurrentRPCExceededNoCaps.Error():
 		r.Error = utils.ErrMaxConcurrentRPCExceeded.Error()
-	} else {
+	case r.Error == utils.ErrRateLimitExceededNoCaps.Error():
+		r.Error = utils.ErrRateLimitExceeded.Error()
+	case !c.caps.IsLimited():
+	default:
 		defer c.caps.Deallocate()
 	}
 	return c.sc.WriteResponse(r, x)
 }
 func (c *capsServerCodec) Close() error { return c.sc.Close() }
 
+// tenantFromArgs returns the Tenant field of the API arguments or the default tenant if missing
+func tenantFromArgs(args any) string {
+	v := reflect.ValueOf(args)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return config.CgrConfig().GeneralCfg().DefaultTenant
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		if sf, has := v.Type().FieldByName(utils.Tenant); has &&
+			sf.Type.Kind() == reflect.String {
+			if fld, err := v.FieldByIndexErr(sf.Index); err == nil &&
+				fld.String() != utils.EmptyString {
+				return fld.String()
+			}
+		}
+	}
+	return config.CgrConfig().GeneralCfg().DefaultTenant
+}
+
 func newCapsBiRPCGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r birpc.BirpcCodec) {
-	r = newCapsBiRPCCodec(birpc.NewGobBirpcCodec(conn), caps)
+	r = newCapsBiRPCCodec(newTracingBiRPCCodec(birpc.NewGobBirpcCodec(conn)), caps)
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
@@ -127,7 +170,7 @@ func newCapsBiRPCGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerS
 }
 
 func newCapsBiRPCJSONCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r birpc.BirpcCodec) {
-	r = newCapsBiRPCCodec(jsonrpc.NewJSONBirpcCodec(conn), caps)
+	r = newCapsBiRPCCodec(newTracingBiRPCCodec(jsonrpc.NewJSONBirpcCodec(conn)), caps)
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
@@ -145,7 +188,7 @@ func newCapsBiRPCJSONCodec(conn conn, caps *engine.Caps, anz *analyzers.Analyzer
 }
 
 func newCapsBiRPCCodec(sc birpc.BirpcCodec, caps *engine.Caps) birpc.BirpcCodec {
-	if !caps.IsLimited() {
+	if !caps.IsLimited() && !caps.HasRateLimits() {
 		return sc
 	}
 	return &capsBiRPCCodec{
@@ -157,25 +200,41 @@ func newCapsBiRPCCodec(sc birpc.BirpcCodec, caps *engine.Caps) birpc.BirpcCodec
 type capsBiRPCCodec struct {
 	sc   birpc.BirpcCodec
 	caps *engine.Caps
+
+	method string // method of the request being read, empty if not rate limited
 }
 
 // ReadHeader must read a message and populate either the request
 // or the response by inspecting the incoming message.
 func (c *capsBiRPCCodec) ReadHeader(req *birpc.Request, resp *birpc.Response) (err error) {
+	c.method = utils.EmptyString
 	if err = c.sc.ReadHeader(req, resp); err != nil ||
 		req.ServiceMethod == utils.EmptyString { // caps will not process replies
 		return
 	}
-	if err = c.caps.Allocate(); err != nil {
-		req.ServiceMethod = utils.SessionSv1CapsError
-		err = nil
+	if c.caps.IsLimited() {
+		if err = c.caps.Allocate(); err != nil {
+			req.ServiceMethod = utils.SessionSv1CapsError
+			return nil
+		}
 	}
+	c.method = req.ServiceMethod
 	return
 }
 
 // ReadRequestBody into args argument of handler function.
+// The rate limits are checked once the tenant is known out of the body.
 func (c *capsBiRPCCodec) ReadRequestBody(x any) (err error) {
-	return c.sc.ReadRequestBody(x)
+	if err = c.sc.ReadRequestBody(x); err != nil ||
+		c.method == utils.EmptyString ||
+		!c.caps.HasRateLimits() {
+		return
+	}
+	if err = c.caps.AllowRate(c.method, tenantFromArgs(x)); err != nil &&
+		c.caps.IsLimited() {
+		c.caps.Deallocate() // WriteResponse will not deallocate on rate errors
+	}
+	return
 }
 
 // ReadResponseBody into reply argument of handler function.
@@ -190,9 +249,12 @@ func (c *capsBiRPCCodec) WriteRequest(req *birpc.Request, x any) error {
 
 // WriteResponse must be safe for concurrent use by multiple goroutines.
 func (c *capsBiRPCCodec) WriteResponse(r *birpc.Response, x any) error {
-	if r.Error == utils.ErrMaxConcurrentRPCExceededNoCaps.Error() {
+	switch {
+	case r.Error == utils.ErrMaxConcurrentRPCExceededNoCaps.Error():
 		r.Error = utils.ErrMaxConcurrentRPCExceeded.Error()
-	} else {
+	case r.Error == utils.ErrRateLimitExceededNoCaps.Error():
+		r.Error = utils.ErrRateLimitExceeded.Error()
+	case c.caps.IsLimited():
 		defer c.caps.Deallocate()
 	}
 	return c.sc.WriteResponse(r, x)
@@ -26,6 +26,7 @@ import (
 	"github.com/cgrates/birpc"
 	"github.com/cgrates/birpc/jsonrpc"
 	"github.com/cgrates/cgrates/analyzers"
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
@@ -100,6 +101,161 @@ func TestNewCapsServerCodec(t *testing.T) {
 	}
 }
 
+type mockTenantServerCodec struct{}
+
+func (c *mockTenantServerCodec) ReadRequestHeader(r *birpc.Request) (err error) {
+	r.ServiceMethod = utils.CDRsV1GetCDRs
+	return
+}
+
+func (c *mockTenantServerCodec) ReadRequestBody(x any) (err error) {
+	if ev, canCast := x.(*utils.CGREvent); canCast {
+		ev.Tenant = "itsyscom.com"
+	}
+	return
+}
+func (c *mockTenantServerCodec) WriteResponse(r *birpc.Response, x any) error { return nil }
+func (c *mockTenantServerCodec) Close() error                                 { return nil }
+
+func TestCapsServerCodecRateLimits(t *testing.T) {
+	cr := engine.NewCapsWithRateLimits(1, utils.MetaBusy, []*config.CapsRateLimitCfg{{
+		ID:      "cdrs",
+		Methods: []string{utils.CDRsV1GetCDRs},
+		Tenants: []string{"itsyscom.com"},
+		Rate:    0.001,
+		Burst:   1,
+	}})
+	codec := newCapsServerCodec(new(mockTenantServerCodec), cr)
+	if err := codec.ReadRequestHeader(new(birpc.Request)); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.ReadRequestBody(new(utils.CGREvent)); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.WriteResponse(new(birpc.Response), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.ReadRequestHeader(new(birpc.Request)); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.ReadRequestBody(new(utils.CGREvent)); err != utils.ErrRateLimitExceededNoCaps {
+		t.Errorf("Expected error: %v ,received: %v ", utils.ErrRateLimitExceededNoCaps, err)
+	}
+	rsp := &birpc.Response{Error: utils.ErrRateLimitExceededNoCaps.Error()}
+	if err := codec.WriteResponse(rsp, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Error != utils.ErrRateLimitExceeded.Error() {
+		t.Errorf("Expected error: %v ,received: %v ", utils.ErrRateLimitExceeded, rsp.Error)
+	}
+	// the caps slot is freed on rate limit errors
+	if al := cr.Allocated(); al != 0 {
+		t.Errorf("Expected: %v ,received: %v", 0, al)
+	}
+	// other tenants are not limited
+	if err := codec.ReadRequestBody(&utils.TenantID{Tenant: "cgrates.org"}); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]uint64{"cdrs": 1}
+	if rcv := cr.RateLimited(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %v ,received: %v", exp, rcv)
+	}
+}
+
+func TestCapsServerCodecRateLimitReplies(t *testing.T) {
+	cr := engine.NewCaps(1, utils.MetaBusy)
+	codec := newCapsServerCodec(new(mockTenantServerCodec), cr)
+	// the rate limit errors passed from other connections free the caps slot
+	for i := 0; i < 2; i++ {
+		if err := codec.ReadRequestHeader(new(birpc.Request)); err != nil {
+			t.Fatal(err)
+		}
+		if err := codec.ReadRequestBody(new(utils.CGREvent)); err != nil {
+			t.Fatal(err)
+		}
+		if err := codec.WriteResponse(&birpc.Response{Error: utils.ErrRateLimitExceeded.Error()}, nil); err != nil {
+			t.Fatal(err)
+		}
+		if al := cr.Allocated(); al != 0 {
+			t.Errorf("Expected: %v ,received: %v", 0, al)
+		}
+	}
+}
+
+type mockTenantBiRPCCodec struct{ mockBiRPCCodec }
+
+func (mockTenantBiRPCCodec) ReadHeader(r *birpc.Request, _ *birpc.Response) error {
+	r.ServiceMethod = utils.SessionSv1AuthorizeEvent
+	return nil
+}
+
+func (mockTenantBiRPCCodec) ReadRequestBody(x any) error {
+	if ev, canCast := x.(*utils.CGREvent); canCast {
+		ev.Tenant = "itsyscom.com"
+	}
+	return nil
+}
+
+func TestCapsBiRPCCodecRateLimits(t *testing.T) {
+	cr := engine.NewCapsWithRateLimits(1, utils.MetaBusy, []*config.CapsRateLimitCfg{{
+		ID:      "sessions",
+		Methods: []string{utils.SessionSv1AuthorizeEvent},
+		Tenants: []string{"itsyscom.com"},
+		Rate:    0.001,
+		Burst:   1,
+	}})
+	codec := newCapsBiRPCCodec(new(mockTenantBiRPCCodec), cr)
+	for i := 0; i < 2; i++ {
+		if err := codec.ReadHeader(new(birpc.Request), nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := codec.ReadRequestBody(new(utils.CGREvent)); i == 0 && err != nil {
+			t.Fatal(err)
+		} else if i == 1 && err != utils.ErrRateLimitExceededNoCaps {
+			t.Errorf("Expected error: %v ,received: %v ", utils.ErrRateLimitExceededNoCaps, err)
+		}
+		rsp := new(birpc.Response)
+		if i == 1 {
+			rsp.Error = utils.ErrRateLimitExceededNoCaps.Error()
+		}
+		if err := codec.WriteResponse(rsp, nil); err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 && rsp.Error != utils.ErrRateLimitExceeded.Error() {
+			t.Errorf("Expected error: %v ,received: %v ", utils.ErrRateLimitExceeded, rsp.Error)
+		}
+		if al := cr.Allocated(); al != 0 {
+			t.Errorf("Expected: %v ,received: %v", 0, al)
+		}
+	}
+	// other tenants are not limited
+	if err := codec.ReadHeader(new(birpc.Request), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.ReadRequestBody(&utils.TenantID{Tenant: "cgrates.org"}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTenantFromArgs(t *testing.T) {
+	dfltTnt := config.CgrConfig().GeneralCfg().DefaultTenant
+	for _, tc := range []struct {
+		args any
+		exp  string
+	}{
+		{args: nil, exp: dfltTnt},
+		{args: "args", exp: dfltTnt},
+		{args: new(utils.CGREvent), exp: dfltTnt},
+		{args: &utils.CGREvent{Tenant: "itsyscom.com"}, exp: "itsyscom.com"},
+		{args: &utils.TenantIDWithAPIOpts{}, exp: dfltTnt},
+		{args: &utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: "itsyscom.com"}}, exp: "itsyscom.com"},
+	} {
+		if rcv := tenantFromArgs(tc.args); rcv != tc.exp {
+			t.Errorf("<%+v> expected: %q, received: %q", tc.args, tc.exp, rcv)
+		}
+	}
+}
+
 type mockConn struct{}
 
 func (*mockConn) Read(b []byte) (n int, err error)  { return 0, syscall.EINVAL }
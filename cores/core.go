@@ -292,14 +292,17 @@ func (cS *CoreService) V1Status(_ *context.Context, params *V1StatusParams, repl
 		return err
 	}
 	metrics.NodeID = cS.cfg.GeneralCfg().NodeID
-	if cS.cfg.CoreSCfg().Caps != 0 {
+	if cS.cfg.CoreSCfg().Caps != 0 || cS.caps.HasRateLimits() {
 		metrics.CapsStats = &CapsStats{
 			Allocated: cS.caps.Allocated(),
 		}
-		if cS.cfg.CoreSCfg().CapsStatsInterval != 0 {
+		if cS.cfg.CoreSCfg().Caps != 0 && cS.cfg.CoreSCfg().CapsStatsInterval != 0 {
 			peak := cS.CapsStats.GetPeak()
 			metrics.CapsStats.Peak = &peak
 		}
+		if cS.caps.HasRateLimits() {
+			metrics.CapsStats.RateLimited = cS.caps.RateLimited()
+		}
 	}
 	debug := false
 	timezone := cS.cfg.GeneralCfg().DefaultTimezone
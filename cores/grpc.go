@@ -0,0 +1,473 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/analyzers"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// grpcServices are the RPC services reachable over gRPC, their schema is in data/grpc/cgrates.proto
+var grpcServices = utils.NewStringSet([]string{utils.SessionSv1, utils.CDRsV1,
+	utils.AttributeSv1, utils.RouteSv1, utils.ChargerSv1})
+
+// registerGRPC builds the gRPC methods of the service, if reachable over gRPC
+// the caller needs to hold the lock
+func (s *Server) registerGRPC(name string, rcvr any) {
+	srv, isService := rcvr.(*birpc.Service)
+	if !isService {
+		var err error
+		if srv, err = birpc.NewService(rcvr, name, name != utils.EmptyString); err != nil {
+			return // logged by utils.RegisterRpcParams
+		}
+	}
+	if name == utils.EmptyString {
+		name = srv.Name
+	}
+	if !grpcServices.Has(name) {
+		return
+	}
+	if name != srv.Name { // registered under a different name, i.e. by DispatcherS
+		srv = &birpc.Service{Name: name, Methods: srv.Methods}
+	}
+	gs := newGRPCSchema(name + ".proto")
+	gs.addService(srv)
+	mthds, err := gs.methods()
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> cannot build the gRPC schema of <%s>: %s",
+			utils.CoreS, name, err.Error()))
+		return
+	}
+	s.unregisterGRPC(name)
+	if s.grpcMethods == nil {
+		s.grpcMethods = make(map[string]*grpcMethod)
+	}
+	for path, mthd := range mthds {
+		s.grpcMethods[path] = mthd
+	}
+}
+
+// unregisterGRPC removes the gRPC methods of the service
+// the caller needs to hold the lock
+func (s *Server) unregisterGRPC(name string) {
+	prfx := utils.Slash + grpcPackage + utils.NestingSep + name + utils.Slash
+	for path := range s.grpcMethods {
+		if strings.HasPrefix(path, prfx) {
+			delete(s.grpcMethods, path)
+		}
+	}
+}
+
+// ServeGRPC exposes the grpcServices over gRPC with the messages described in data/grpc/cgrates.proto
+func (s *Server) ServeGRPC(addr string, shdChan *utils.SyncedChan) {
+	s.RLock()
+	enabled := s.rpcEnabled
+	s.RUnlock()
+	if !enabled || addr == utils.EmptyString {
+		return
+	}
+	l, err := net.Listen(utils.TCP, addr)
+	if err != nil {
+		log.Printf("ServeGRPC listen error: %s", err)
+		shdChan.CloseOnce()
+		return
+	}
+	utils.Logger.Info(fmt.Sprintf("Starting CGRateS gRPC server at <%s>.", addr))
+	gSrv := grpc.NewServer(grpc.UnknownServiceHandler(s.handleGRPC))
+	s.Lock()
+	s.grpcSrv = gSrv
+	s.Unlock()
+	if err = gSrv.Serve(l); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<CGRServer> gRPC serve error: <%s>", err.Error()))
+		shdChan.CloseOnce()
+	}
+}
+
+// StopGRPC stops the gRPC server, waiting for the requests in progress
+func (s *Server) StopGRPC() {
+	s.Lock()
+	gSrv := s.grpcSrv
+	s.grpcSrv = nil
+	s.Unlock()
+	if gSrv != nil {
+		gSrv.GracefulStop()
+	}
+}
+
+// handleGRPC dispatches the gRPC request to the RPC server
+// e.g. /cgrates.SessionSv1/AuthorizeEvent is served by SessionSv1.AuthorizeEvent
+func (s *Server) handleGRPC(_ any, stream grpc.ServerStream) (err error) {
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+	s.RLock()
+	mthd, has := s.grpcMethods[fullMethod]
+	s.RUnlock()
+	if !has {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", fullMethod)
+	}
+	args := dynamicpb.NewMessage(mthd.args)
+	if err = stream.RecvMsg(args); err != nil {
+		return
+	}
+	var params []byte
+	if params, err = grpcMessageToJSON(args, mthd.wrapArgs); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	var from string
+	if p, has := peer.FromContext(stream.Context()); has && p.Addr != nil {
+		from = p.Addr.String()
+	}
+	c := &grpcServerCodec{method: mthd.method, params: params}
+	err = s.rpcSrv.ServeRequestContext(&context.Context{Context: stream.Context()},
+		newCapsGRPCCodec(c, s.caps, s.anz, from))
+	if c.err != utils.EmptyString { // the errors reading the request are also sent as response
+		return status.Error(grpcErrorCode(c.err), c.err)
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	rply := dynamicpb.NewMessage(mthd.reply)
+	if err = grpcJSONToMessage(c.reply, rply, mthd.wrapReply); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.SendMsg(rply)
+}
+
+// grpcErrorCode maps the RPC errors to gRPC status codes
+func grpcErrorCode(errStr string) codes.Code {
+	switch {
+	case errStr == utils.ErrMaxConcurrentRPCExceeded.Error(),
+		errStr == utils.ErrRateLimitExceeded.Error():
+		return codes.ResourceExhausted
+	case errStr == utils.ErrNotFound.Error():
+		return codes.NotFound
+	case strings.HasPrefix(errStr, utils.MandatoryIEMissingCaps):
+		return codes.InvalidArgument
+	case strings.HasPrefix(errStr, "rpc: can't find"):
+		return codes.Unimplemented
+	default:
+		return codes.Unknown
+	}
+}
+
+func newCapsGRPCCodec(c *grpcServerCodec, caps *engine.Caps, anz *analyzers.AnalyzerService, from string) (r birpc.ServerCodec) {
+	r = newCapsServerCodec(newTracingServerCodec(c), caps)
+	if anz != nil {
+		return analyzers.NewAnalyzerServerCodec(r, anz, utils.MetaGRPC, from, utils.LocalAddr().String())
+	}
+	return
+}
+
+// grpcServerCodec is a birpc.ServerCodec serving one JSON encoded request
+type grpcServerCodec struct {
+	method string
+	params []byte
+	read   bool
+
+	reply []byte // JSON encoded reply
+	err   string
+}
+
+func (c *grpcServerCodec) ReadRequestHeader(r *birpc.Request) error {
+	if c.read {
+		return io.EOF
+	}
+	c.read = true
+	r.ServiceMethod = c.method
+	return nil
+}
+
+func (c *grpcServerCodec) ReadRequestBody(x any) error {
+	if x == nil {
+		return nil
+	}
+	return json.Unmarshal(c.params, x)
+}
+
+func (c *grpcServerCodec) WriteResponse(r *birpc.Response, x any) (err error) {
+	if r.Error != utils.EmptyString {
+		c.err = r.Error
+		return
+	}
+	c.reply, err = json.Marshal(x)
+	return
+}
+
+func (c *grpcServerCodec) Close() error { return nil }
+
+// grpcJSONName returns the JSON key of the field
+func grpcJSONName(fd protoreflect.FieldDescriptor) string {
+	if fd.HasJSONName() {
+		return fd.JSONName()
+	}
+	return string(fd.Name())
+}
+
+// isGRPCWrapper checks if the message is one of the wrappers of the optional scalars
+func isGRPCWrapper(fullName string) bool {
+	for _, wrapper := range grpcWrappers {
+		if wrapper == fullName {
+			return true
+		}
+	}
+	return false
+}
+
+// grpcMessageToJSON encodes the message as the JSON arguments of the API
+func grpcMessageToJSON(m protoreflect.Message, wrapped bool) (b []byte, err error) {
+	var v any
+	if v, err = grpcMessageValue(m); err != nil {
+		return
+	}
+	if wrapped {
+		v = v.(map[string]any)[grpcItemsFld]
+	}
+	return json.Marshal(v)
+}
+
+// grpcMessageValue returns the value of the message as it would be decoded out of JSON
+func grpcMessageValue(m protoreflect.Message) (v any, err error) {
+	md := m.Descriptor()
+	switch fullName := string(md.FullName()); {
+	case fullName == grpcTimestamp:
+		return time.Unix(m.Get(md.Fields().ByNumber(1)).Int(),
+			m.Get(md.Fields().ByNumber(2)).Int()).UTC(), nil
+	case fullName == grpcStruct, fullName == grpcValue, fullName == grpcListValue:
+		var b []byte
+		if b, err = protojson.Marshal(m.Interface()); err != nil {
+			return
+		}
+		return json.RawMessage(b), nil
+	case isGRPCWrapper(fullName):
+		fd := md.Fields().ByNumber(1)
+		return grpcSingularValue(fd, m.Get(fd))
+	}
+	fields := make(map[string]any)
+	m.Range(func(fd protoreflect.FieldDescriptor, fv protoreflect.Value) bool {
+		fields[grpcJSONName(fd)], err = grpcFieldValue(fd, fv)
+		return err == nil
+	})
+	return fields, err
+}
+
+// grpcFieldValue returns the value of the field as it would be decoded out of JSON
+func grpcFieldValue(fd protoreflect.FieldDescriptor, fv protoreflect.Value) (v any, err error) {
+	switch {
+	case fd.IsList():
+		lst := fv.List()
+		vals := make([]any, lst.Len())
+		for i := range vals {
+			if vals[i], err = grpcSingularValue(fd, lst.Get(i)); err != nil {
+				return
+			}
+		}
+		return vals, nil
+	case fd.IsMap():
+		vals := make(map[string]any)
+		fv.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			vals[k.String()], err = grpcSingularValue(fd.MapValue(), mv)
+			return err == nil
+		})
+		return vals, err
+	}
+	return grpcSingularValue(fd, fv)
+}
+
+func grpcSingularValue(fd protoreflect.FieldDescriptor, fv protoreflect.Value) (any, error) {
+	if fd.Kind() == protoreflect.MessageKind {
+		return grpcMessageValue(fv.Message())
+	}
+	return fv.Interface(), nil // the bytes are encoded as base64, same as in JSON
+}
+
+// grpcJSONToMessage populates the message out of the JSON reply of the API
+func grpcJSONToMessage(b []byte, m protoreflect.Message, wrapped bool) (err error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err = dec.Decode(&v); err != nil {
+		return
+	}
+	if wrapped {
+		v = map[string]any{grpcItemsFld: v}
+	}
+	return grpcSetMessage(m, v)
+}
+
+// grpcSetMessage populates the message out of the value decoded from JSON
+func grpcSetMessage(m protoreflect.Message, v any) (err error) {
+	if v == nil {
+		return
+	}
+	md := m.Descriptor()
+	switch fullName := string(md.FullName()); {
+	case fullName == grpcTimestamp:
+		str, canCast := v.(string)
+		if !canCast {
+			return fmt.Errorf("cannot convert %T to %s", v, fullName)
+		}
+		var t time.Time
+		if t, err = time.Parse(time.RFC3339Nano, str); err != nil {
+			return
+		}
+		m.Set(md.Fields().ByNumber(1), protoreflect.ValueOfInt64(t.Unix()))
+		m.Set(md.Fields().ByNumber(2), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+		return
+	case fullName == grpcStruct, fullName == grpcValue, fullName == grpcListValue:
+		var b []byte
+		if b, err = json.Marshal(v); err != nil {
+			return
+		}
+		return protojson.Unmarshal(b, m.Interface())
+	case isGRPCWrapper(fullName):
+		fd := md.Fields().ByNumber(1)
+		var pv protoreflect.Value
+		if pv, err = grpcScalar(fd, v); err != nil {
+			return
+		}
+		m.Set(fd, pv)
+		return
+	}
+	fields, canCast := v.(map[string]any)
+	if !canCast {
+		return fmt.Errorf("cannot convert %T to %s", v, md.FullName())
+	}
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if fv, has := fields[grpcJSONName(fd)]; has && fv != nil {
+			if err = grpcSetField(m, fd, fv); err != nil {
+				return fmt.Errorf("%s: %w", fd.Name(), err)
+			}
+		}
+	}
+	return
+}
+
+// grpcSetField populates the field out of the value decoded from JSON
+func grpcSetField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v any) (err error) {
+	var pv protoreflect.Value
+	switch {
+	case fd.IsList():
+		vals, canCast := v.([]any)
+		if !canCast {
+			return fmt.Errorf("cannot convert %T to list", v)
+		}
+		lst := m.Mutable(fd).List()
+		for _, val := range vals {
+			if pv, err = grpcNewValue(fd, lst.NewElement, val); err != nil {
+				return
+			}
+			lst.Append(pv)
+		}
+	case fd.IsMap():
+		vals, canCast := v.(map[string]any)
+		if !canCast {
+			return fmt.Errorf("cannot convert %T to map", v)
+		}
+		mp := m.Mutable(fd).Map()
+		for k, val := range vals {
+			if pv, err = grpcNewValue(fd.MapValue(), mp.NewValue, val); err != nil {
+				return
+			}
+			mp.Set(protoreflect.ValueOfString(k).MapKey(), pv)
+		}
+	default:
+		if pv, err = grpcNewValue(fd, func() protoreflect.Value { return m.NewField(fd) }, v); err != nil {
+			return
+		}
+		m.Set(fd, pv)
+	}
+	return
+}
+
+// grpcNewValue converts the value decoded from JSON to the field kind, newMsg creating the messages
+func grpcNewValue(fd protoreflect.FieldDescriptor, newMsg func() protoreflect.Value, v any) (pv protoreflect.Value, err error) {
+	if fd.Kind() == protoreflect.MessageKind {
+		pv = newMsg()
+		err = grpcSetMessage(pv.Message(), v)
+		return
+	}
+	return grpcScalar(fd, v)
+}
+
+// grpcScalar converts the value decoded from JSON to the scalar kind of the field
+func grpcScalar(fd protoreflect.FieldDescriptor, v any) (pv protoreflect.Value, err error) {
+	if v == nil {
+		return fd.Default(), nil
+	}
+	switch val := v.(type) {
+	case string:
+		switch fd.Kind() {
+		case protoreflect.StringKind:
+			return protoreflect.ValueOfString(val), nil
+		case protoreflect.BytesKind:
+			var b []byte
+			if b, err = base64.StdEncoding.DecodeString(val); err != nil {
+				return
+			}
+			return protoreflect.ValueOfBytes(b), nil
+		}
+	case bool:
+		if fd.Kind() == protoreflect.BoolKind {
+			return protoreflect.ValueOfBool(val), nil
+		}
+	case json.Number:
+		switch fd.Kind() {
+		case protoreflect.Int64Kind:
+			var i int64
+			if i, err = val.Int64(); err != nil {
+				return
+			}
+			return protoreflect.ValueOfInt64(i), nil
+		case protoreflect.Uint64Kind:
+			var u uint64
+			if u, err = strconv.ParseUint(val.String(), 10, 64); err != nil {
+				return
+			}
+			return protoreflect.ValueOfUint64(u), nil
+		case protoreflect.DoubleKind:
+			var f float64
+			if f, err = val.Float64(); err != nil {
+				return
+			}
+			return protoreflect.ValueOfFloat64(f), nil
+		}
+	}
+	return pv, fmt.Errorf("cannot convert %T to %s", v, fd.Kind())
+}
@@ -0,0 +1,228 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type mockGRPCSv1 struct{}
+
+func (mockGRPCSv1) ProcessEvent(_ *context.Context, args *utils.CGREvent, reply *map[string]any) error {
+	if args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	*reply = map[string]any{utils.Tenant: args.Tenant, utils.ID: args.ID, utils.Usage: args.Event[utils.Usage]}
+	return nil
+}
+
+func TestServerHandleGRPC(t *testing.T) {
+	caps := engine.NewCapsWithRateLimits(0, utils.MetaBusy, []*config.CapsRateLimitCfg{{
+		ID:      "RL1",
+		Methods: []string{utils.AttributeSv1ProcessEvent},
+		Rate:    0.001,
+		Burst:   2,
+	}})
+	srv := NewServer(caps)
+	srv.RpcRegisterName(utils.AttributeSv1, new(mockGRPCSv1))
+	srv.RpcRegisterName(utils.CoreSv1, new(mockGRPCSv1))
+
+	l := bufconn.Listen(1024 * 1024)
+	gSrv := grpc.NewServer(grpc.UnknownServiceHandler(srv.handleGRPC))
+	go gSrv.Serve(l)
+	defer gSrv.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx gocontext.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	mthd := srv.grpcMethods["/cgrates.AttributeSv1/ProcessEvent"]
+	if mthd == nil {
+		t.Fatal("expected the method reachable over gRPC")
+	}
+	args := dynamicpb.NewMessage(mthd.args)
+	if err = protojson.Unmarshal([]byte(`{"Tenant":"cgrates.org","ID":"EV1","Event":{"Usage":"10s"}}`), args); err != nil {
+		t.Fatal(err)
+	}
+	rply := dynamicpb.NewMessage(mthd.reply)
+	if err = conn.Invoke(context.Background(), "/cgrates.AttributeSv1/ProcessEvent", args, rply); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]any{utils.Tenant: "cgrates.org", utils.ID: "EV1", utils.Usage: "10s"}
+	rcv := new(structpb.Struct)
+	if b, err := protojson.Marshal(rply); err != nil {
+		t.Fatal(err)
+	} else if err = protojson.Unmarshal(b, rcv); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exp, rcv.AsMap()) {
+		t.Errorf("Expected %v, received %v", exp, rcv.AsMap())
+	}
+
+	args = dynamicpb.NewMessage(mthd.args)
+	if err = protojson.Unmarshal([]byte(`{"Tenant":"cgrates.org"}`), args); err != nil {
+		t.Fatal(err)
+	}
+	if err = conn.Invoke(context.Background(), "/cgrates.AttributeSv1/ProcessEvent", args, rply); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, received %v", err)
+	}
+	if err = conn.Invoke(context.Background(), "/cgrates.AttributeSv1/ProcessEvent", args, rply); status.Code(err) != codes.ResourceExhausted ||
+		status.Convert(err).Message() != utils.ErrRateLimitExceeded.Error() {
+		t.Errorf("Expected ResourceExhausted, received %v", err)
+	}
+	for _, path := range []string{
+		"/AttributeSv1/ProcessEvent",                     // outside the cgrates package
+		"/cgrates.AttributeSv1/GetAttributeForEvent",     // not registered
+		"/cgrates.CoreSv1/ProcessEvent",                  // not reachable over gRPC
+		"/cgrates.SessionSv1/Sleep",                      // used internally
+		"/cgrates.SessionSv1/RegisterInternalBiJSONConn", // used internally
+	} {
+		if err = conn.Invoke(context.Background(), path, args, rply); status.Code(err) != codes.Unimplemented {
+			t.Errorf("Expected Unimplemented for %s, received %v", path, err)
+		}
+	}
+}
+
+type mockGRPCTypedSv1 struct{}
+
+type MockGRPCArgs struct {
+	Tenant  string
+	Usage   time.Duration
+	Time    *time.Time
+	Weight  *float64
+	Tags    []string
+	Weights map[string]float64
+	Opts    map[string]any
+	Data    []byte
+}
+
+func (mockGRPCTypedSv1) ProcessEvent(_ *context.Context, args *MockGRPCArgs, reply *MockGRPCArgs) error {
+	*reply = *args
+	return nil
+}
+
+func (mockGRPCTypedSv1) GetTags(_ *context.Context, args *[]string, reply *map[string]int) error {
+	*reply = make(map[string]int)
+	for i, tag := range *args {
+		(*reply)[tag] = i
+	}
+	return nil
+}
+
+func (mockGRPCTypedSv1) Sleep(_ *context.Context, _ *string, _ *string) error { return nil }
+
+func TestGRPCMessageConversions(t *testing.T) {
+	srv := NewServer(nil)
+	srv.RpcRegisterName(utils.SessionSv1, new(mockGRPCTypedSv1))
+	if _, has := srv.grpcMethods["/cgrates.SessionSv1/Sleep"]; has {
+		t.Error("expected the internal method excluded")
+	}
+
+	mthd := srv.grpcMethods["/cgrates.SessionSv1/ProcessEvent"]
+	if mthd == nil || mthd.wrapArgs || mthd.wrapReply {
+		t.Fatalf("unexpected method %+v", mthd)
+	}
+	tm := time.Date(2024, 3, 1, 10, 0, 0, 5, time.UTC)
+	weight := 10.5
+	argsIn := &MockGRPCArgs{
+		Tenant:  "cgrates.org",
+		Usage:   time.Minute,
+		Time:    &tm,
+		Weight:  &weight,
+		Tags:    []string{"t1", "t2"},
+		Weights: map[string]float64{"w1": 1.5},
+		Opts:    map[string]any{"*key": "val", "*nr": 2.},
+		Data:    []byte("data"),
+	}
+	b, err := json.Marshal(argsIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := dynamicpb.NewMessage(mthd.args)
+	if err = grpcJSONToMessage(b, msg, mthd.wrapArgs); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = grpcMessageToJSON(msg, mthd.wrapArgs); err != nil {
+		t.Fatal(err)
+	}
+	rcv := new(MockGRPCArgs)
+	if err = json.Unmarshal(b, rcv); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(argsIn, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(argsIn), utils.ToJSON(rcv))
+	}
+
+	mthd = srv.grpcMethods["/cgrates.SessionSv1/GetTags"]
+	if mthd == nil || !mthd.wrapArgs || !mthd.wrapReply {
+		t.Fatalf("unexpected method %+v", mthd)
+	}
+	if msg, err = grpcTestMessage(mthd.args, []string{"t1", "t2"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = grpcMessageToJSON(msg, mthd.wrapArgs); err != nil {
+		t.Fatal(err)
+	} else if string(b) != `["t1","t2"]` {
+		t.Errorf("Expected the list of tags, received %s", b)
+	}
+	if msg, err = grpcTestMessage(mthd.reply, map[string]int{"t1": 0, "t2": 1}, true); err != nil {
+		t.Fatal(err)
+	}
+	items := msg.Get(mthd.reply.Fields().ByName(grpcItemsFld)).Map()
+	if items.Len() != 2 || items.Get(protoreflect.ValueOfString("t2").MapKey()).Int() != 1 {
+		t.Errorf("unexpected items %v", items)
+	}
+
+	msg = dynamicpb.NewMessage(mthd.reply)
+	if err = grpcJSONToMessage([]byte(`{"t1":"first"}`), msg, true); err == nil {
+		t.Error("expected error converting a string to an integer")
+	}
+}
+
+func grpcTestMessage(md protoreflect.MessageDescriptor, v any, wrapped bool) (msg *dynamicpb.Message, err error) {
+	var b []byte
+	if b, err = json.Marshal(v); err != nil {
+		return
+	}
+	msg = dynamicpb.NewMessage(md)
+	err = grpcJSONToMessage(b, msg, wrapped)
+	return
+}
@@ -0,0 +1,533 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/cgrates/utils"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	grpcPackage   = "cgrates"
+	grpcItemsFld  = "Items" // field of the messages wrapping the lists and maps
+	grpcSchemaHdr = `// Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+// Copyright (C) ITsysCOM GmbH
+//
+// Schema of the RPC services exposed by the gRPC listener ("listen": {"grpc": "..."}).
+// Generated out of the arguments and replies of the APIs, do not edit. The field names
+// are the JSON keys of the matching JSON-RPC API, i.e. /cgrates.SessionSv1/AuthorizeEvent
+// is served by SessionSv1.AuthorizeEvent. The values without a fixed structure (i.e. the
+// event fields) are passed as google.protobuf.Struct or google.protobuf.Value.
+`
+)
+
+var (
+	// grpcExcludedMethods are the methods of the grpcServices used internally, not reachable over gRPC
+	grpcExcludedMethods = utils.NewStringSet([]string{utils.SessionSv1Sleep,
+		utils.SessionSv1CapsError, utils.SessionSv1RegisterInternalBiJSONConn})
+
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	grpcTimestamp = string((&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName())
+	grpcStruct    = string((&structpb.Struct{}).ProtoReflect().Descriptor().FullName())
+	grpcValue     = string((&structpb.Value{}).ProtoReflect().Descriptor().FullName())
+	grpcListValue = string((&structpb.ListValue{}).ProtoReflect().Descriptor().FullName())
+	grpcWrappers  = map[reflect.Kind]string{
+		reflect.String:  string((&wrapperspb.StringValue{}).ProtoReflect().Descriptor().FullName()),
+		reflect.Bool:    string((&wrapperspb.BoolValue{}).ProtoReflect().Descriptor().FullName()),
+		reflect.Int64:   string((&wrapperspb.Int64Value{}).ProtoReflect().Descriptor().FullName()),
+		reflect.Uint64:  string((&wrapperspb.UInt64Value{}).ProtoReflect().Descriptor().FullName()),
+		reflect.Float64: string((&wrapperspb.DoubleValue{}).ProtoReflect().Descriptor().FullName()),
+	}
+	grpcImports = map[string]string{
+		grpcTimestamp: timestamppb.File_google_protobuf_timestamp_proto.Path(),
+		grpcStruct:    structpb.File_google_protobuf_struct_proto.Path(),
+		grpcValue:     structpb.File_google_protobuf_struct_proto.Path(),
+		grpcListValue: structpb.File_google_protobuf_struct_proto.Path(),
+	}
+)
+
+func init() {
+	for _, wrapper := range grpcWrappers {
+		grpcImports[wrapper] = wrapperspb.File_google_protobuf_wrappers_proto.Path()
+	}
+}
+
+// grpcMethod is one API reachable over gRPC
+type grpcMethod struct {
+	method    string // the API name, i.e. SessionSv1.AuthorizeEvent
+	args      protoreflect.MessageDescriptor
+	reply     protoreflect.MessageDescriptor
+	wrapArgs  bool // the JSON arguments are in the Items field
+	wrapReply bool // the JSON reply is in the Items field
+}
+
+// grpcFieldType is the protobuf type of a Go type
+type grpcFieldType struct {
+	typ      descriptorpb.FieldDescriptorProto_Type
+	typeName string         // full name of the message types
+	list     bool           // repeated
+	mapVal   *grpcFieldType // map<string, mapVal>
+}
+
+// newGRPCSchema creates the schema builder for the given file
+func newGRPCSchema(fileName string) *grpcSchema {
+	return &grpcSchema{
+		fd: &descriptorpb.FileDescriptorProto{
+			Name:    proto.String(fileName),
+			Package: proto.String(grpcPackage),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptorpb.FileOptions{GoPackage: proto.String("github.com/cgrates/cgrates/cores")},
+		},
+		msgs:    make(map[reflect.Type]string),
+		names:   make(utils.StringSet),
+		imports: make(utils.StringSet),
+		wrapped: make(utils.StringSet),
+	}
+}
+
+// grpcSchema builds the protobuf messages out of the Go types of the API arguments and replies
+type grpcSchema struct {
+	fd      *descriptorpb.FileDescriptorProto
+	msgs    map[reflect.Type]string // full message name of the Go types
+	names   utils.StringSet         // message names in use
+	imports utils.StringSet
+	wrapped utils.StringSet // messages wrapping a list or a map
+}
+
+// addService adds the methods of the service reachable over gRPC, sorted by name
+func (gs *grpcSchema) addService(srv *birpc.Service) {
+	sd := &descriptorpb.ServiceDescriptorProto{Name: proto.String(srv.Name)}
+	mthdNames := make([]string, 0, len(srv.Methods))
+	for mthdName := range srv.Methods {
+		mthdNames = append(mthdNames, mthdName)
+	}
+	sort.Strings(mthdNames)
+	for _, mthdName := range mthdNames {
+		if grpcExcludedMethods.Has(srv.Name + utils.NestingSep + mthdName) {
+			continue
+		}
+		mt := srv.Methods[mthdName]
+		sd.Method = append(sd.Method, &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(mthdName),
+			InputType:  proto.String(gs.topMessage(mt.ArgType)),
+			OutputType: proto.String(gs.topMessage(mt.ReplyType)),
+		})
+	}
+	gs.fd.Service = append(gs.fd.Service, sd)
+}
+
+// file returns the descriptor with the dependencies sorted
+func (gs *grpcSchema) file() *descriptorpb.FileDescriptorProto {
+	gs.fd.Dependency = gs.imports.AsOrderedSlice()
+	return gs.fd
+}
+
+// methods returns the methods indexed on their gRPC path, i.e. /cgrates.SessionSv1/AuthorizeEvent
+func (gs *grpcSchema) methods() (mthds map[string]*grpcMethod, err error) {
+	var fd protoreflect.FileDescriptor
+	if fd, err = protodesc.NewFile(gs.file(), protoregistry.GlobalFiles); err != nil {
+		return
+	}
+	mthds = make(map[string]*grpcMethod)
+	for i := 0; i < fd.Services().Len(); i++ {
+		sd := fd.Services().Get(i)
+		for j := 0; j < sd.Methods().Len(); j++ {
+			md := sd.Methods().Get(j)
+			mthds[utils.Slash+string(sd.FullName())+utils.Slash+string(md.Name())] = &grpcMethod{
+				method:    string(sd.Name()) + utils.NestingSep + string(md.Name()),
+				args:      md.Input(),
+				reply:     md.Output(),
+				wrapArgs:  gs.wrapped.Has("." + string(md.Input().FullName())),
+				wrapReply: gs.wrapped.Has("." + string(md.Output().FullName())),
+			}
+		}
+	}
+	return
+}
+
+// topMessage returns the message of an API argument or reply
+func (gs *grpcSchema) topMessage(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	ft, ok := gs.fieldType(t)
+	switch {
+	case !ok:
+		return gs.wkt(grpcValue)
+	case ft.list || ft.mapVal != nil:
+		return gs.wrapperMessage(t, ft)
+	case ft.typ == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		return ft.typeName
+	case scalarKind(t) == reflect.Invalid:
+		return gs.wkt(grpcValue)
+	}
+	return gs.wkt(grpcWrappers[scalarKind(t)])
+}
+
+// wrapperMessage defines the message holding the list or map in its Items field
+func (gs *grpcSchema) wrapperMessage(t reflect.Type, ft grpcFieldType) string {
+	if name, has := gs.msgs[t]; has {
+		return name
+	}
+	elem := ft
+	if ft.mapVal != nil {
+		elem = *ft.mapVal
+	}
+	suffix := "List"
+	if ft.mapVal != nil {
+		suffix = "Map"
+	}
+	name := gs.newMsgName(grpcTypeTitle(elem) + suffix)
+	gs.msgs[t] = name
+	gs.wrapped.Add(name)
+	msg := &descriptorpb.DescriptorProto{Name: proto.String(shortName(name))}
+	gs.fd.MessageType = append(gs.fd.MessageType, msg)
+	gs.addField(msg, grpcItemsFld, grpcItemsFld, ft)
+	return name
+}
+
+// fieldType returns the protobuf type of the Go type, false if it cannot be encoded
+func (gs *grpcSchema) fieldType(t reflect.Type) (ft grpcFieldType, ok bool) {
+	switch {
+	case t == timeType, t == reflect.PointerTo(timeType):
+		return gs.msgType(gs.wkt(grpcTimestamp)), true
+	case t == durationType:
+		return grpcFieldType{typ: descriptorpb.FieldDescriptorProto_TYPE_INT64}, true
+	case customJSON(t): // no fixed structure
+		return gs.msgType(gs.wkt(grpcValue)), true
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		switch {
+		case t.Elem() == durationType:
+			return gs.msgType(gs.wkt(grpcWrappers[reflect.Int64])), true
+		case scalarKind(t.Elem()) != reflect.Invalid && !customJSON(t.Elem()):
+			return gs.msgType(gs.wkt(grpcWrappers[scalarKind(t.Elem())])), true
+		}
+		return gs.fieldType(t.Elem())
+	case reflect.String:
+		return grpcFieldType{typ: descriptorpb.FieldDescriptorProto_TYPE_STRING}, true
+	case reflect.Bool:
+		return grpcFieldType{typ: descriptorpb.FieldDescriptorProto_TYPE_BOOL}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return grpcFieldType{typ: descriptorpb.FieldDescriptorProto_TYPE_INT64}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return grpcFieldType{typ: descriptorpb.FieldDescriptorProto_TYPE_UINT64}, true
+	case reflect.Float32, reflect.Float64:
+		return grpcFieldType{typ: descriptorpb.FieldDescriptorProto_TYPE_DOUBLE}, true
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 { // base64 in JSON
+			return grpcFieldType{typ: descriptorpb.FieldDescriptorProto_TYPE_BYTES}, true
+		}
+		if ft, ok = gs.fieldType(t.Elem()); !ok || ft.list || ft.mapVal != nil ||
+			ft.typeName == "."+grpcValue {
+			return gs.msgType(gs.wkt(grpcListValue)), true
+		}
+		ft.list = true
+		return ft, true
+	case reflect.Map:
+		var val grpcFieldType
+		if t.Key().Kind() != reflect.String {
+			return gs.msgType(gs.wkt(grpcStruct)), true
+		}
+		if val, ok = gs.fieldType(t.Elem()); !ok || val.list || val.mapVal != nil ||
+			val.typeName == "."+grpcValue {
+			return gs.msgType(gs.wkt(grpcStruct)), true
+		}
+		return grpcFieldType{mapVal: &val}, true
+	case reflect.Struct:
+		return gs.msgType(gs.message(t, utils.EmptyString)), true
+	case reflect.Interface:
+		return gs.msgType(gs.wkt(grpcValue)), true
+	}
+	return // channels, functions
+}
+
+// message defines the message out of the Go struct, once per type
+func (gs *grpcSchema) message(t reflect.Type, nameHint string) string {
+	if name, has := gs.msgs[t]; has {
+		return name
+	}
+	name := t.Name()
+	if name == utils.EmptyString { // anonymous struct
+		name = nameHint
+	}
+	if gs.names.Has(name) {
+		name = grpcTitle(path.Base(t.PkgPath())) + name
+	}
+	name = gs.newMsgName(name)
+	gs.msgs[t] = name // before the fields for the recursive types
+	msg := &descriptorpb.DescriptorProto{Name: proto.String(shortName(name))}
+	gs.fd.MessageType = append(gs.fd.MessageType, msg)
+	gs.addStructFields(msg, t, make(utils.StringSet))
+	return name
+}
+
+// addStructFields adds the exported fields, flattening the embedded structs as encoding/json does
+func (gs *grpcSchema) addStructFields(msg *descriptorpb.DescriptorProto, t reflect.Type, seen utils.StringSet) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		jsonName, _, _ := strings.Cut(sf.Tag.Get("json"), utils.FieldsSep)
+		if jsonName == "-" {
+			continue
+		}
+		if sf.Anonymous && jsonName == utils.EmptyString {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !customJSON(ft) {
+				gs.addStructFields(msg, ft, seen)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if jsonName == utils.EmptyString {
+			jsonName = sf.Name
+		}
+		if seen.Has(jsonName) {
+			continue
+		}
+		ft, ok := gs.fieldType(sf.Type)
+		if !ok {
+			continue
+		}
+		seen.Add(jsonName)
+		if sf.Type.Kind() == reflect.Struct && sf.Type.Name() == utils.EmptyString {
+			ft = gs.msgType(gs.message(sf.Type, shortName(msg.GetName())+sf.Name))
+		}
+		protoName := jsonName
+		if !validProtoName(protoName) {
+			protoName = sf.Name
+		}
+		gs.addField(msg, protoName, jsonName, ft)
+	}
+}
+
+// addField adds the field to the message, numbered in the order of the Go fields
+func (gs *grpcSchema) addField(msg *descriptorpb.DescriptorProto, protoName, jsonName string, ft grpcFieldType) {
+	fld := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(protoName),
+		Number: proto.Int32(int32(len(msg.Field) + 1)),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if protoName != jsonName {
+		fld.JsonName = proto.String(jsonName)
+	}
+	if ft.mapVal != nil {
+		entry := &descriptorpb.DescriptorProto{
+			Name:    proto.String(mapEntryName(protoName)),
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+		gs.addField(entry, "key", "key", grpcFieldType{typ: descriptorpb.FieldDescriptorProto_TYPE_STRING})
+		gs.addField(entry, "value", "value", *ft.mapVal)
+		msg.NestedType = append(msg.NestedType, entry)
+		ft = gs.msgType("." + grpcPackage + "." + msg.GetName() + "." + entry.GetName())
+		ft.list = true
+	}
+	fld.Type = ft.typ.Enum()
+	if ft.typeName != utils.EmptyString {
+		fld.TypeName = proto.String(ft.typeName)
+	}
+	if ft.list {
+		fld.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	}
+	msg.Field = append(msg.Field, fld)
+}
+
+// wkt returns the full name of the well known type, importing its file
+func (gs *grpcSchema) wkt(name string) string {
+	gs.imports.Add(grpcImports[name])
+	return "." + name
+}
+
+func (gs *grpcSchema) msgType(fullName string) grpcFieldType {
+	return grpcFieldType{typ: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, typeName: fullName}
+}
+
+// newMsgName reserves an unique message name
+func (gs *grpcSchema) newMsgName(name string) string {
+	for i := 2; gs.names.Has(name); i++ {
+		name = strings.TrimRightFunc(name, unicode.IsDigit) + strconv.Itoa(i)
+	}
+	gs.names.Add(name)
+	return "." + grpcPackage + "." + name
+}
+
+// customJSON checks if the type has its own JSON encoding
+func customJSON(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// scalarKind returns the kind of the wrapper for the scalar types, reflect.Invalid otherwise
+func scalarKind(t reflect.Type) reflect.Kind {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool:
+		return t.Kind()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.Uint64
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
+
+// grpcTypeTitle names the type within the wrapper messages, i.e. StringList
+func grpcTypeTitle(ft grpcFieldType) string {
+	if ft.typeName != utils.EmptyString {
+		return shortName(ft.typeName)
+	}
+	return grpcTitle(strings.ToLower(strings.TrimPrefix(ft.typ.String(), "TYPE_")))
+}
+
+func grpcTitle(s string) string {
+	if s == utils.EmptyString {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// shortName returns the message name without its package
+func shortName(fullName string) string {
+	return fullName[strings.LastIndexByte(fullName, '.')+1:]
+}
+
+// mapEntryName returns the name protoc gives to the map entries of the field
+func mapEntryName(fldName string) string {
+	var sb strings.Builder
+	upNext := true
+	for _, r := range fldName {
+		if r == '_' {
+			upNext = true
+			continue
+		}
+		if upNext {
+			r = unicode.ToUpper(r)
+			upNext = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String() + "Entry"
+}
+
+// validProtoName checks if the name can be used as protobuf field name
+func validProtoName(name string) bool {
+	for i, r := range name {
+		if r != '_' && (r > unicode.MaxASCII || !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return name != utils.EmptyString
+}
+
+// GRPCSchema returns the .proto schema of the services reachable over gRPC
+func GRPCSchema(srvs ...*birpc.Service) string {
+	gs := newGRPCSchema("cgrates.proto")
+	for _, srv := range srvs {
+		if grpcServices.Has(srv.Name) {
+			gs.addService(srv)
+		}
+	}
+	return printProto(gs.file())
+}
+
+// printProto renders the file descriptor in the .proto format
+func printProto(fd *descriptorpb.FileDescriptorProto) string {
+	var sb strings.Builder
+	sb.WriteString(grpcSchemaHdr)
+	fmt.Fprintf(&sb, "\nsyntax = %q;\n\npackage %s;\n\n", fd.GetSyntax(), fd.GetPackage())
+	for _, dep := range fd.GetDependency() {
+		fmt.Fprintf(&sb, "import %q;\n", dep)
+	}
+	fmt.Fprintf(&sb, "\noption go_package = %q;\n", fd.GetOptions().GetGoPackage())
+	for _, sd := range fd.GetService() {
+		fmt.Fprintf(&sb, "\nservice %s {\n", sd.GetName())
+		for _, md := range sd.GetMethod() {
+			fmt.Fprintf(&sb, "  rpc %s(%s) returns (%s);\n", md.GetName(),
+				protoTypeName(md.GetInputType()), protoTypeName(md.GetOutputType()))
+		}
+		sb.WriteString("}\n")
+	}
+	for _, msg := range fd.GetMessageType() {
+		fmt.Fprintf(&sb, "\nmessage %s {\n", msg.GetName())
+		entries := make(map[string]*descriptorpb.DescriptorProto)
+		for _, entry := range msg.GetNestedType() {
+			entries["."+grpcPackage+"."+msg.GetName()+"."+entry.GetName()] = entry
+		}
+		for _, fld := range msg.GetField() {
+			var typ string
+			if entry, isMap := entries[fld.GetTypeName()]; isMap {
+				typ = "map<string, " + protoFieldType(entry.GetField()[1]) + ">"
+			} else {
+				typ = protoFieldType(fld)
+				if fld.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+					typ = "repeated " + typ
+				}
+			}
+			var opts string
+			if fld.JsonName != nil {
+				opts = fmt.Sprintf(" [json_name = %q]", fld.GetJsonName())
+			}
+			fmt.Fprintf(&sb, "  %s %s = %d%s;\n", typ, fld.GetName(), fld.GetNumber(), opts)
+		}
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+// protoFieldType returns the type of the field as written in the .proto files
+func protoFieldType(fld *descriptorpb.FieldDescriptorProto) string {
+	if fld.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		return protoTypeName(fld.GetTypeName())
+	}
+	return strings.ToLower(strings.TrimPrefix(fld.GetType().String(), "TYPE_"))
+}
+
+// protoTypeName returns the message name relative to the package
+func protoTypeName(fullName string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(fullName, "."), grpcPackage+".")
+}
@@ -0,0 +1,130 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"golang.org/x/net/websocket"
+)
+
+// liveFeedKeepAlive is the interval of the comments sent to idle Server-Sent Events clients
+const liveFeedKeepAlive = 15 * time.Second
+
+// ServeLiveFeed registers the live feed handlers on the HTTP servers
+// sseURL serves Server-Sent Events, subscribing with the query parameters:
+// tenant, filter (repeated for multiple filters) and type (repeated for multiple event types)
+// wsURL serves WebSocket connections, the first message sent by the client is the JSON encoded subscription
+func (s *Server) ServeLiveFeed(lf *engine.LiveFeed, sseURL, wsURL string, useBasicAuth bool,
+	userList map[string]string) {
+	if sseURL != utils.EmptyString {
+		utils.Logger.Info(fmt.Sprintf("<HTTP> live feed endpoint registered at %q", sseURL))
+		var h http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) { handleLiveFeedSSE(lf, w, r) }
+		if useBasicAuth {
+			h = use(h, basicAuth(userList))
+		}
+		s.RegisterHttpFunc(sseURL, h)
+	}
+	if wsURL != utils.EmptyString {
+		utils.Logger.Info(fmt.Sprintf("<HTTP> WebSocket live feed endpoint registered at %q", wsURL))
+		var h http.HandlerFunc = websocket.Handler(func(ws *websocket.Conn) { handleLiveFeedWS(lf, ws) }).ServeHTTP
+		if useBasicAuth {
+			h = use(h, basicAuth(userList))
+		}
+		s.RegisterHttpFunc(wsURL, h)
+	}
+}
+
+// handleLiveFeedSSE streams the events as Server-Sent Events until the client disconnects
+func handleLiveFeedSSE(lf *engine.LiveFeed, w http.ResponseWriter, r *http.Request) {
+	flusher, canFlush := w.(http.Flusher)
+	if !canFlush {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	qry := r.URL.Query()
+	subID, evChan, err := lf.Subscribe(&engine.LiveFeedSubscription{
+		Tenant:     qry.Get("tenant"),
+		FilterIDs:  qry["filter"],
+		EventTypes: qry["type"],
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer lf.Unsubscribe(subID)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	keepAlive := time.NewTicker(liveFeedKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err = fmt.Fprint(w, ":\n\n"); err != nil {
+				return
+			}
+		case ev := <-evChan:
+			if _, err = fmt.Fprintf(w, "data: %s\n\n", ev); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// handleLiveFeedWS reads the subscription and pushes the events as JSON text messages
+func handleLiveFeedWS(lf *engine.LiveFeed, ws *websocket.Conn) {
+	defer ws.Close()
+	var sub engine.LiveFeedSubscription
+	if err := websocket.JSON.Receive(ws, &sub); err != nil {
+		return
+	}
+	subID, evChan, err := lf.Subscribe(&sub)
+	if err != nil {
+		websocket.JSON.Send(ws, map[string]string{utils.Error: err.Error()})
+		return
+	}
+	defer lf.Unsubscribe(subID)
+	closed := make(chan struct{})
+	go func() { // nothing is expected after the subscription, a read error means the client left
+		var msg string
+		for websocket.Message.Receive(ws, &msg) == nil {
+		}
+		close(closed)
+	}()
+	for {
+		select {
+		case <-closed:
+			return
+		case ev := <-evChan:
+			if err = websocket.Message.Send(ws, string(ev)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestHandleLiveFeedSSE(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	lf := engine.NewLiveFeed(cfg, engine.NewFilterS(cfg, nil, dm))
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	go lf.ListenAndServe(stopChan)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handleLiveFeedSSE(lf, w, r)
+	}))
+	defer srv.Close()
+
+	rply, err := http.Get(srv.URL + "?type=*resource_alloc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rply.Body.Close()
+	if rply.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status: %d, received: %d", http.StatusBadRequest, rply.StatusCode)
+	}
+
+	if rply, err = http.Get(srv.URL + "?type=*cdr&filter=*string:~*req.Account:1001"); err != nil {
+		t.Fatal(err)
+	}
+	defer rply.Body.Close()
+	if ct := rply.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("unexpected content type: %q", ct)
+	}
+	// the subscription is registered before the headers are sent
+	lf.Publish(utils.MetaCDR, &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "CDR1",
+		Event:  map[string]any{utils.AccountField: "1002"},
+	})
+	lf.Publish(utils.MetaCDR, &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "CDR2",
+		Event:  map[string]any{utils.AccountField: "1001"},
+	})
+	line, err := bufio.NewReader(rply.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ev engine.LiveFeedEvent
+	if err = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.ID != "CDR2" || ev.Type != utils.MetaCDR {
+		t.Errorf("unexpected event: %s", utils.ToJSON(ev))
+	}
+}
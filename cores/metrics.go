@@ -94,6 +94,9 @@ func (sm StatusMetrics) ToMapCondensed(timezone string) (map[string]any, error)
 		if sm.CapsStats.Peak != nil {
 			m[utils.CAPSPeak] = *sm.CapsStats.Peak
 		}
+		if sm.CapsStats.RateLimited != nil {
+			m[utils.CAPSRateLimited] = sm.CapsStats.RateLimited
+		}
 	}
 	return m, nil
 }
@@ -198,14 +201,18 @@ func (ps ProcStats) ToMap() map[string]any {
 }
 
 type CapsStats struct {
-	Allocated int  `json:"allocated"`
-	Peak      *int `json:"peak"`
+	Allocated   int               `json:"allocated"`
+	Peak        *int              `json:"peak"`
+	RateLimited map[string]uint64 `json:"rate_limited"`
 }
 
 func (cs *CapsStats) ToMap() map[string]any {
-	m := make(map[string]any, 2)
+	m := make(map[string]any, 3)
 	m["allocated"] = cs.Allocated
 	m["peak"] = cs.Peak
+	if cs.RateLimited != nil {
+		m["rate_limited"] = cs.RateLimited
+	}
 	return m
 }
 
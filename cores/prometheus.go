@@ -0,0 +1,223 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"fmt"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const promNamespace = "cgrates"
+
+// NewPrometheusCollector returns the collector publishing the StatS, ThresholdS,
+// TrendS and SessionS data selected by the http prometheus_opts
+func NewPrometheusCollector(cfg *config.CGRConfig, connMgr *engine.ConnManager,
+	fltrS *engine.FilterS) *PrometheusCollector {
+	return &PrometheusCollector{
+		cfg:     cfg,
+		connMgr: connMgr,
+		fltrS:   fltrS,
+		statMetric: prometheus.NewDesc(
+			prometheus.BuildFQName(promNamespace, "stats", "metric_value"),
+			"Value of the StatQueue metric.",
+			[]string{"tenant", "queue", "metric"}, nil),
+		thresholdHits: prometheus.NewDesc(
+			prometheus.BuildFQName(promNamespace, "thresholds", "hits_total"),
+			"Number of times the Threshold was hit.",
+			[]string{"tenant", "threshold"}, nil),
+		trendValue: prometheus.NewDesc(
+			prometheus.BuildFQName(promNamespace, "trends", "metric_value"),
+			"Value of the metric at the last Trend run.",
+			[]string{"tenant", "trend", "metric"}, nil),
+		trendGrowth: prometheus.NewDesc(
+			prometheus.BuildFQName(promNamespace, "trends", "metric_growth"),
+			"Growth of the metric computed at the last Trend run, labeled with its direction.",
+			[]string{"tenant", "trend", "metric", "label"}, nil),
+		activeSessions: prometheus.NewDesc(
+			prometheus.BuildFQName(promNamespace, "sessions", "active"),
+			"Number of active sessions.",
+			[]string{"tenant", "charger"}, nil),
+	}
+}
+
+// PrometheusCollector implements prometheus.Collector querying the subsystems on each scrape
+type PrometheusCollector struct {
+	cfg     *config.CGRConfig
+	connMgr *engine.ConnManager
+	fltrS   *engine.FilterS
+
+	statMetric     *prometheus.Desc
+	thresholdHits  *prometheus.Desc
+	trendValue     *prometheus.Desc
+	trendGrowth    *prometheus.Desc
+	activeSessions *prometheus.Desc
+}
+
+// Describe implements prometheus.Collector
+func (pc *PrometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pc.statMetric
+	ch <- pc.thresholdHits
+	ch <- pc.trendValue
+	ch <- pc.trendGrowth
+	ch <- pc.activeSessions
+}
+
+// Collect implements prometheus.Collector
+func (pc *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	pOpts := pc.cfg.HTTPCfg().PrometheusOpts
+	tnts := pOpts.Tenants
+	if len(tnts) == 0 {
+		tnts = []string{pc.cfg.GeneralCfg().DefaultTenant}
+	}
+	for _, tnt := range tnts {
+		if len(pOpts.StatSConns) != 0 {
+			pc.collectStats(ch, tnt, pOpts)
+		}
+		if len(pOpts.ThresholdSConns) != 0 {
+			pc.collectThresholds(ch, tnt, pOpts)
+		}
+		if len(pOpts.TrendSConns) != 0 {
+			pc.collectTrends(ch, tnt, pOpts)
+		}
+		if len(pOpts.SessionSConns) != 0 {
+			pc.collectSessions(ch, tnt, pOpts)
+		}
+	}
+}
+
+// passes checks the object against the configured filters
+func (pc *PrometheusCollector) passes(tnt string, fltrIDs []string, obj map[string]any) bool {
+	if len(fltrIDs) == 0 {
+		return true
+	}
+	pass, err := pc.fltrS.Pass(tnt, fltrIDs, utils.MapStorage{utils.MetaReq: obj})
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed filtering for prometheus: %v", utils.CoreS, err))
+	}
+	return pass
+}
+
+func (pc *PrometheusCollector) collectStats(ch chan<- prometheus.Metric, tnt string, pOpts *config.PrometheusOpts) {
+	var qIDs []string
+	if err := pc.connMgr.Call(context.TODO(), pOpts.StatSConns, utils.StatSv1GetQueueIDs,
+		&utils.TenantWithAPIOpts{Tenant: tnt}, &qIDs); err != nil {
+		pc.logCallErr(utils.StatSv1GetQueueIDs, err)
+		return
+	}
+	for _, qID := range qIDs {
+		var metrics map[string]float64
+		if err := pc.connMgr.Call(context.TODO(), pOpts.StatSConns, utils.StatSv1GetQueueFloatMetrics,
+			&utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: tnt, ID: qID}}, &metrics); err != nil {
+			pc.logCallErr(utils.StatSv1GetQueueFloatMetrics, err)
+			continue
+		}
+		obj := map[string]any{utils.Tenant: tnt, utils.ID: qID}
+		for mID, val := range metrics {
+			obj[mID] = val
+		}
+		if !pc.passes(tnt, pOpts.StatFilters, obj) {
+			continue
+		}
+		for mID, val := range metrics {
+			ch <- prometheus.MustNewConstMetric(pc.statMetric, prometheus.GaugeValue, val, tnt, qID, mID)
+		}
+	}
+}
+
+func (pc *PrometheusCollector) collectThresholds(ch chan<- prometheus.Metric, tnt string, pOpts *config.PrometheusOpts) {
+	var tIDs []string
+	if err := pc.connMgr.Call(context.TODO(), pOpts.ThresholdSConns, utils.ThresholdSv1GetThresholdIDs,
+		&utils.TenantWithAPIOpts{Tenant: tnt}, &tIDs); err != nil {
+		pc.logCallErr(utils.ThresholdSv1GetThresholdIDs, err)
+		return
+	}
+	for _, tID := range tIDs {
+		var th engine.Threshold
+		if err := pc.connMgr.Call(context.TODO(), pOpts.ThresholdSConns, utils.ThresholdSv1GetThreshold,
+			&utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: tnt, ID: tID}}, &th); err != nil {
+			pc.logCallErr(utils.ThresholdSv1GetThreshold, err)
+			continue
+		}
+		if !pc.passes(tnt, pOpts.ThresholdFilters, map[string]any{
+			utils.Tenant: th.Tenant, utils.ID: th.ID, utils.Hits: th.Hits}) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(pc.thresholdHits, prometheus.CounterValue, float64(th.Hits), tnt, tID)
+	}
+}
+
+func (pc *PrometheusCollector) collectTrends(ch chan<- prometheus.Metric, tnt string, pOpts *config.PrometheusOpts) {
+	var schedTrends []utils.ScheduledTrend
+	if err := pc.connMgr.Call(context.TODO(), pOpts.TrendSConns, utils.TrendSv1GetScheduledTrends,
+		&utils.ArgScheduledTrends{TenantIDWithAPIOpts: utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: tnt}}},
+		&schedTrends); err != nil {
+		pc.logCallErr(utils.TrendSv1GetScheduledTrends, err)
+		return
+	}
+	for _, schedTrend := range schedTrends {
+		var ts engine.TrendSummary
+		if err := pc.connMgr.Call(context.TODO(), pOpts.TrendSConns, utils.TrendSv1GetTrendSummary,
+			utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: tnt, ID: schedTrend.TrendID}}, &ts); err != nil {
+			pc.logCallErr(utils.TrendSv1GetTrendSummary, err)
+			continue
+		}
+		obj := map[string]any{utils.Tenant: tnt, utils.ID: schedTrend.TrendID}
+		for mID, mWt := range ts.Metrics {
+			obj[mID] = mWt.Value
+		}
+		if !pc.passes(tnt, pOpts.TrendFilters, obj) {
+			continue
+		}
+		for mID, mWt := range ts.Metrics {
+			ch <- prometheus.MustNewConstMetric(pc.trendValue, prometheus.GaugeValue,
+				mWt.Value, tnt, schedTrend.TrendID, mID)
+			ch <- prometheus.MustNewConstMetric(pc.trendGrowth, prometheus.GaugeValue,
+				mWt.TrendGrowth, tnt, schedTrend.TrendID, mID, mWt.TrendLabel)
+		}
+	}
+}
+
+func (pc *PrometheusCollector) collectSessions(ch chan<- prometheus.Metric, tnt string, pOpts *config.PrometheusOpts) {
+	var aSs []*sessions.ExternalSession
+	if err := pc.connMgr.Call(context.TODO(), pOpts.SessionSConns, utils.SessionSv1GetActiveSessions,
+		&utils.SessionFilter{Tenant: tnt, Filters: pOpts.SessionFilters}, &aSs); err != nil {
+		if err.Error() != utils.ErrNotFound.Error() {
+			pc.logCallErr(utils.SessionSv1GetActiveSessions, err)
+		}
+		return
+	}
+	perCharger := make(map[string]int)
+	for _, aS := range aSs {
+		perCharger[aS.RunID]++
+	}
+	for chrgr, cnt := range perCharger {
+		ch <- prometheus.MustNewConstMetric(pc.activeSessions, prometheus.GaugeValue, float64(cnt), tnt, chrgr)
+	}
+}
+
+func (pc *PrometheusCollector) logCallErr(method string, err error) {
+	utils.Logger.Warning(fmt.Sprintf("<%s> failed calling %s for prometheus metrics: %v",
+		utils.CoreS, method, err))
+}
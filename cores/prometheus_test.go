@@ -0,0 +1,150 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ccMock struct {
+	calls map[string]func(args any, reply any) error
+}
+
+func (ccM *ccMock) Call(_ *context.Context, serviceMethod string, args any, reply any) (err error) {
+	if call, has := ccM.calls[serviceMethod]; !has {
+		return rpcclient.ErrUnsupporteServiceMethod
+	} else {
+		return call(args, reply)
+	}
+}
+
+func TestPrometheusCollector(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.HTTPCfg().PrometheusOpts = &config.PrometheusOpts{
+		StatSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)},
+		StatFilters:     []string{"*gte:~*req.*acd:10"},
+		ThresholdSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
+		TrendSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends)},
+		SessionSConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+	}
+	cc := &ccMock{
+		calls: map[string]func(args any, reply any) error{
+			utils.StatSv1GetQueueIDs: func(args, reply any) error {
+				*reply.(*[]string) = []string{"SQ_1", "SQ_2"}
+				return nil
+			},
+			utils.StatSv1GetQueueFloatMetrics: func(args, reply any) error {
+				switch args.(*utils.TenantIDWithAPIOpts).ID {
+				case "SQ_1":
+					*reply.(*map[string]float64) = map[string]float64{utils.MetaACD: 20, utils.MetaASR: 50}
+				default:
+					*reply.(*map[string]float64) = map[string]float64{utils.MetaACD: 5}
+				}
+				return nil
+			},
+			utils.ThresholdSv1GetThresholdIDs: func(args, reply any) error {
+				*reply.(*[]string) = []string{"TH_1"}
+				return nil
+			},
+			utils.ThresholdSv1GetThreshold: func(args, reply any) error {
+				*reply.(*engine.Threshold) = engine.Threshold{Tenant: "cgrates.org", ID: "TH_1", Hits: 3}
+				return nil
+			},
+			utils.TrendSv1GetScheduledTrends: func(args, reply any) error {
+				*reply.(*[]utils.ScheduledTrend) = []utils.ScheduledTrend{{TrendID: "TR_1"}}
+				return nil
+			},
+			utils.TrendSv1GetTrendSummary: func(args, reply any) error {
+				*reply.(*engine.TrendSummary) = engine.TrendSummary{
+					Tenant: "cgrates.org",
+					ID:     "TR_1",
+					Metrics: map[string]*engine.MetricWithTrend{
+						utils.MetaASR: {ID: utils.MetaASR, Value: 40, TrendGrowth: -10, TrendLabel: utils.MetaNegative},
+					},
+				}
+				return nil
+			},
+			utils.SessionSv1GetActiveSessions: func(args, reply any) error {
+				*reply.(*[]*sessions.ExternalSession) = []*sessions.ExternalSession{
+					{Tenant: "cgrates.org", RunID: utils.MetaDefault},
+					{Tenant: "cgrates.org", RunID: utils.MetaDefault},
+					{Tenant: "cgrates.org", RunID: "reseller"},
+				}
+				return nil
+			},
+		},
+	}
+	rpcInternal := make(chan birpc.ClientConnector, 1)
+	rpcInternal <- cc
+	connMgr := engine.NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats):      rpcInternal,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds): rpcInternal,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends):     rpcInternal,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS):   rpcInternal,
+	})
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(NewPrometheusCollector(cfg, connMgr, engine.NewFilterS(cfg, nil, dm))); err != nil {
+		t.Fatal(err)
+	}
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rcv []string
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			lbls := make([]string, len(m.GetLabel()))
+			for i, lbl := range m.GetLabel() {
+				lbls[i] = lbl.GetName() + "=" + lbl.GetValue()
+			}
+			val := m.GetGauge().GetValue()
+			if m.GetCounter() != nil {
+				val = m.GetCounter().GetValue()
+			}
+			rcv = append(rcv, mf.GetName()+"{"+strings.Join(lbls, ",")+"} "+strconv.FormatFloat(val, 'f', -1, 64))
+		}
+	}
+	sort.Strings(rcv)
+	exp := []string{
+		"cgrates_sessions_active{charger=*default,tenant=cgrates.org} 2",
+		"cgrates_sessions_active{charger=reseller,tenant=cgrates.org} 1",
+		"cgrates_stats_metric_value{metric=*acd,queue=SQ_1,tenant=cgrates.org} 20",
+		"cgrates_stats_metric_value{metric=*asr,queue=SQ_1,tenant=cgrates.org} 50",
+		"cgrates_thresholds_hits_total{tenant=cgrates.org,threshold=TH_1} 3",
+		"cgrates_trends_metric_growth{label=*negative,metric=*asr,tenant=cgrates.org,trend=TR_1} -10",
+		"cgrates_trends_metric_value{metric=*asr,tenant=cgrates.org,trend=TR_1} 40",
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s\nReceived: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
@@ -21,15 +21,12 @@ package cores
 import (
 	"bytes"
 	"crypto/tls"
-	"crypto/x509"
-	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"net/http"
 	"net/http/pprof"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -40,6 +37,7 @@ import (
 	"github.com/cgrates/cgrates/utils"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
 )
 
 func NewServer(caps *engine.Caps) (s *Server) {
@@ -64,6 +62,8 @@ type Server struct {
 	httpMux         *http.ServeMux
 	caps            *engine.Caps
 	anz             *analyzers.AnalyzerService
+	grpcSrv         *grpc.Server
+	grpcMethods     map[string]*grpcMethod // indexed on the gRPC path
 }
 
 func (s *Server) SetAnalyzer(anz *analyzers.AnalyzerService) {
@@ -75,6 +75,7 @@ func (s *Server) RpcRegister(rcvr any) {
 	s.rpcSrv.Register(rcvr)
 	s.Lock()
 	s.rpcEnabled = true
+	s.registerGRPC(utils.EmptyString, rcvr)
 	s.Unlock()
 }
 
@@ -83,11 +84,15 @@ func (s *Server) RpcRegisterName(name string, rcvr any) {
 	s.rpcSrv.RegisterName(name, rcvr)
 	s.Lock()
 	s.rpcEnabled = true
+	s.registerGRPC(name, rcvr)
 	s.Unlock()
 }
 
 func (s *Server) RpcUnregisterName(name string) {
 	s.rpcSrv.UnregisterName(name)
+	s.Lock()
+	s.unregisterGRPC(name)
+	s.Unlock()
 }
 
 func (s *Server) RegisterHttpFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
@@ -374,45 +379,6 @@ func (r *rpcRequest) Call() io.Reader {
 	return r.rw
 }
 
-func loadTLSConfig(serverCrt, serverKey, caCert string, serverPolicy int,
-	serverName string) (config *tls.Config, err error) {
-	cert, err := tls.LoadX509KeyPair(serverCrt, serverKey)
-	if err != nil {
-		utils.Logger.Crit(fmt.Sprintf("Error: %s when load server keys", err))
-		return nil, err
-	}
-
-	rootCAs, err := x509.SystemCertPool()
-	//This will only happen on windows
-	if err != nil {
-		utils.Logger.Crit(fmt.Sprintf("Error: %s when load SystemCertPool", err))
-		return nil, err
-	}
-
-	if caCert != "" {
-		ca, err := os.ReadFile(caCert)
-		if err != nil {
-			utils.Logger.Crit(fmt.Sprintf("Error: %s when read CA", err))
-			return config, err
-		}
-
-		if ok := rootCAs.AppendCertsFromPEM(ca); !ok {
-			utils.Logger.Crit("Cannot append certificate authority")
-			return config, errors.New("Cannot append certificate authority")
-		}
-	}
-
-	config = &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		ClientAuth:   tls.ClientAuthType(serverPolicy),
-		ClientCAs:    rootCAs,
-	}
-	if serverName != "" {
-		config.ServerName = serverName
-	}
-	return
-}
-
 func (s *Server) serveCodecTLS(addr, codecName, serverCrt, serverKey, caCert string,
 	serverPolicy int, serverName string, newCodec func(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) birpc.ServerCodec,
 	shdChan *utils.SyncedChan) {
@@ -422,8 +388,9 @@ func (s *Server) serveCodecTLS(addr, codecName, serverCrt, serverKey, caCert str
 	if !enabled {
 		return
 	}
-	config, err := loadTLSConfig(serverCrt, serverKey, caCert, serverPolicy, serverName)
+	config, err := utils.NewTLSConfig(serverCrt, serverKey, caCert, serverPolicy, serverName, true)
 	if err != nil {
+		utils.Logger.Crit(fmt.Sprintf("Error: %s when loading the TLS configuration", err))
 		shdChan.CloseOnce()
 		return
 	}
@@ -523,8 +490,9 @@ func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverP
 	if useBasicAuth {
 		utils.Logger.Info("<HTTPS> enabling basic auth")
 	}
-	config, err := loadTLSConfig(serverCrt, serverKey, caCert, serverPolicy, serverName)
+	config, err := utils.NewTLSConfig(serverCrt, serverKey, caCert, serverPolicy, serverName, true)
 	if err != nil {
+		utils.Logger.Crit(fmt.Sprintf("Error: %s when loading the TLS configuration", err))
 		shdChan.CloseOnce()
 		return
 	}
@@ -530,23 +530,23 @@ TEST
 	server = NewServer(caps)
 	server.RpcRegister(new(mockRegister))
 
-	expectedErr := "Cannot append certificate authority"
-	if _, err := loadTLSConfig(
+	expectedErr := "cannot append certificate authority"
+	if _, err := utils.NewTLSConfig(
 		"/usr/share/cgrates/tls/server.crt",
 		"/usr/share/cgrates/tls/server.key",
 		path.Join(flPath, "file.txt"),
 		0,
-		utils.EmptyString); err == nil || err.Error() != expectedErr {
+		utils.EmptyString, true); err == nil || err.Error() != expectedErr {
 		t.Errorf("Expected %+v, received %+v", expectedErr, err)
 	}
 
-	expectedErr = "open /tmp/testLoadTLSConfigErr1/file1.txt: no such file or directory"
-	if _, err := loadTLSConfig(
+	expectedErr = "read CA error <open /tmp/testLoadTLSConfigErr1/file1.txt: no such file or directory>"
+	if _, err := utils.NewTLSConfig(
 		"/usr/share/cgrates/tls/server.crt",
 		"/usr/share/cgrates/tls/server.key",
 		path.Join(flPath, "file1.txt"),
 		0,
-		utils.EmptyString); err == nil || err.Error() != expectedErr {
+		utils.EmptyString, true); err == nil || err.Error() != expectedErr {
 		t.Errorf("Expected %+v, received %+v", expectedErr, err)
 	}
 	if err := os.Remove(path.Join(flPath, "file.txt")); err != nil {
@@ -0,0 +1,157 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// rpcSpans keeps the server spans of the requests being served
+type rpcSpans struct {
+	spans map[uint64]trace.Span
+	sync.Mutex
+
+	method string // method of the request being read
+	seq    uint64
+}
+
+// start is called after the request body is read
+// the span context is written in the APIOpts of the arguments for the following hops
+func (s *rpcSpans) start(x any) {
+	if x == nil {
+		return
+	}
+	ctx, span := engine.StartRPCSpan(nil, s.method, x, trace.SpanKindServer)
+	engine.InjectRPCSpan(ctx, x) // x is decoded for this request only
+	s.Lock()
+	s.spans[s.seq] = span
+	s.Unlock()
+}
+
+func (s *rpcSpans) end(r *birpc.Response) {
+	s.Lock()
+	span, has := s.spans[r.Seq]
+	delete(s.spans, r.Seq)
+	s.Unlock()
+	if !has {
+		return
+	}
+	var err error
+	if r.Error != utils.EmptyString {
+		err = errors.New(r.Error)
+	}
+	engine.EndRPCSpan(span, err)
+}
+
+func newTracingServerCodec(sc birpc.ServerCodec) birpc.ServerCodec {
+	if !engine.TracingEnabled() {
+		return sc
+	}
+	return &tracingServerCodec{
+		sc:    sc,
+		spans: &rpcSpans{spans: make(map[uint64]trace.Span)},
+	}
+}
+
+// tracingServerCodec starts a server span for each request served
+type tracingServerCodec struct {
+	sc    birpc.ServerCodec
+	spans *rpcSpans
+}
+
+func (c *tracingServerCodec) ReadRequestHeader(r *birpc.Request) (err error) {
+	if err = c.sc.ReadRequestHeader(r); err == nil {
+		c.spans.method, c.spans.seq = r.ServiceMethod, r.Seq
+	}
+	return
+}
+
+func (c *tracingServerCodec) ReadRequestBody(x any) (err error) {
+	if err = c.sc.ReadRequestBody(x); err == nil {
+		c.spans.start(x)
+	}
+	return
+}
+
+func (c *tracingServerCodec) WriteResponse(r *birpc.Response, x any) error {
+	c.spans.end(r)
+	return c.sc.WriteResponse(r, x)
+}
+
+func (c *tracingServerCodec) Close() error { return c.sc.Close() }
+
+func newTracingBiRPCCodec(sc birpc.BirpcCodec) birpc.BirpcCodec {
+	if !engine.TracingEnabled() {
+		return sc
+	}
+	return &tracingBiRPCCodec{
+		sc:    sc,
+		spans: &rpcSpans{spans: make(map[uint64]trace.Span)},
+	}
+}
+
+// tracingBiRPCCodec starts a server span for each request received
+// the requests sent to the client are traced by the ConnManager
+type tracingBiRPCCodec struct {
+	sc    birpc.BirpcCodec
+	spans *rpcSpans
+}
+
+// ReadHeader must read a message and populate either the request
+// or the response by inspecting the incoming message.
+func (c *tracingBiRPCCodec) ReadHeader(req *birpc.Request, resp *birpc.Response) (err error) {
+	if err = c.sc.ReadHeader(req, resp); err == nil &&
+		req.ServiceMethod != utils.EmptyString {
+		c.spans.method, c.spans.seq = req.ServiceMethod, req.Seq
+	}
+	return
+}
+
+// ReadRequestBody into args argument of handler function.
+func (c *tracingBiRPCCodec) ReadRequestBody(x any) (err error) {
+	if err = c.sc.ReadRequestBody(x); err == nil {
+		c.spans.start(x)
+	}
+	return
+}
+
+// ReadResponseBody into reply argument of handler function.
+func (c *tracingBiRPCCodec) ReadResponseBody(x any) error {
+	return c.sc.ReadResponseBody(x)
+}
+
+// WriteRequest must be safe for concurrent use by multiple goroutines.
+func (c *tracingBiRPCCodec) WriteRequest(req *birpc.Request, x any) error {
+	return c.sc.WriteRequest(req, x)
+}
+
+// WriteResponse must be safe for concurrent use by multiple goroutines.
+func (c *tracingBiRPCCodec) WriteResponse(r *birpc.Response, x any) error {
+	c.spans.end(r)
+	return c.sc.WriteResponse(r, x)
+}
+
+// Close is called when client/server finished with the connection.
+func (c *tracingBiRPCCodec) Close() error { return c.sc.Close() }
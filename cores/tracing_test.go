@@ -0,0 +1,90 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"testing"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type mockTracedSv1 struct{}
+
+// ProcessEvent replies with the trace context received in APIOpts
+func (mockTracedSv1) ProcessEvent(_ *context.Context, args *utils.CGREvent, reply *string) error {
+	if args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	*reply = utils.IfaceAsString(args.APIOpts[utils.OptsTraceParent])
+	return nil
+}
+
+func TestTracingServerCodec(t *testing.T) {
+	if sc := newTracingServerCodec(new(grpcServerCodec)); sc == nil {
+		t.Fatal("expected a codec")
+	} else if _, traced := sc.(*tracingServerCodec); traced {
+		t.Error("expected no tracing codec while tracing is disabled")
+	}
+	exp := tracetest.NewInMemoryExporter()
+	engine.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+	defer engine.SetTracerProvider(nil)
+
+	srv := birpc.NewServer()
+	srv.RegisterName(utils.AttributeSv1, new(mockTracedSv1))
+
+	c := &grpcServerCodec{
+		method: utils.AttributeSv1ProcessEvent,
+		params: []byte(`{"ID":"EV1","APIOpts":{"*traceparent":"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}`),
+	}
+	if err := srv.ServeRequest(newTracingServerCodec(c)); err != nil {
+		t.Fatal(err)
+	}
+	spans := exp.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, received: %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != utils.AttributeSv1ProcessEvent ||
+		span.SpanKind != trace.SpanKindServer {
+		t.Errorf("unexpected span: <%s> <%s>", span.Name, span.SpanKind)
+	}
+	if span.Parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("expected the remote parent, received: %+v", span.Parent)
+	}
+	if expTP := `"00-4bf92f3577b34da6a3ce929d0e0e4736-` + span.SpanContext.SpanID().String() + `-01"`; string(c.reply) != expTP {
+		t.Errorf("expected the server span in APIOpts: %s, received: %s", expTP, c.reply)
+	}
+
+	exp.Reset()
+	c = &grpcServerCodec{method: utils.AttributeSv1ProcessEvent, params: []byte(`{}`)}
+	if err := srv.ServeRequest(newTracingServerCodec(c)); err != nil {
+		t.Fatal(err)
+	}
+	if spans = exp.GetSpans(); len(spans) != 1 {
+		t.Fatalf("expected 1 span, received: %d", len(spans))
+	} else if expErr := utils.NewErrMandatoryIeMissing(utils.ID).Error(); spans[0].Status.Description != expErr {
+		t.Errorf("expected the error in the span status, received: %+v", spans[0].Status)
+	}
+}
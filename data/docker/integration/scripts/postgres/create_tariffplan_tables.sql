@@ -356,6 +356,8 @@ CREATE TABLE tp_routes (
   "route_blocker" BOOLEAN NOT NULL,
   "route_parameters" varchar(64) NOT NULL,
   "weight" decimal(8,2) NOT NULL,
+  "route_ranking_ids" varchar(64) NOT NULL,
+  "route_trend_ids" varchar(64) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_routes_idx ON tp_routes (tpid);
@@ -416,6 +416,8 @@ CREATE TABLE tp_routes (
   `route_blocker` BOOLEAN NOT NULL,
   `route_parameters` varchar(64) NOT NULL,
   `weight` decimal(8,2) NOT NULL,
+  `route_ranking_ids` varchar(64) NOT NULL,
+  `route_trend_ids` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -154,3 +154,32 @@ func TestDspRouteSv1GetRouteProfilesForEventErrorNil(t *testing.T) {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, result)
 	}
 }
+
+func TestDspRouteSv1SimulateRoutesNil(t *testing.T) {
+	cgrCfg := config.NewDefaultCGRConfig()
+	dspSrv := NewDispatcherService(nil, cgrCfg, nil, nil)
+	cgrCfg.DispatcherSCfg().AttributeSConns = []string{"test"}
+	args := &engine.ArgsSimulateRoutes{
+		Tenant: "tenant",
+	}
+	var reply *engine.RoutesSimulation
+	result := dspSrv.RouteSv1SimulateRoutes(context.Background(), args, reply)
+	expected := "MANDATORY_IE_MISSING: [ApiKey]"
+	if result == nil || result.Error() != expected {
+		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, result)
+	}
+}
+
+func TestDspRouteSv1SimulateRoutesErrorNil(t *testing.T) {
+	cgrCfg := config.NewDefaultCGRConfig()
+	dspSrv := NewDispatcherService(nil, cgrCfg, nil, nil)
+	args := &engine.ArgsSimulateRoutes{
+		Tenant: "tenant",
+	}
+	var reply *engine.RoutesSimulation
+	result := dspSrv.RouteSv1SimulateRoutes(context.Background(), args, reply)
+	expected := "DISPATCHER_ERROR:NO_DATABASE_CONNECTION"
+	if result == nil || result.Error() != expected {
+		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, result)
+	}
+}
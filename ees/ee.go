@@ -53,6 +53,10 @@ func NewEventExporter(cfg *config.EventExporterCfg, cgrCfg *config.CGRConfig, fi
 		return NewFileCSVee(cfg, cgrCfg, filterS, dc)
 	case utils.MetaFileFWV:
 		return NewFileFWVee(cfg, cgrCfg, filterS, dc)
+	case utils.MetaFileParquet:
+		return NewFileParquetEE(cfg, dc)
+	case utils.MetaFileAvro:
+		return NewFileAvroEE(cfg, dc)
 	case utils.MetaHTTPPost:
 		return NewHTTPPostEE(cfg, cgrCfg, filterS, dc)
 	case utils.MetaHTTPjsonMap:
@@ -0,0 +1,107 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/linkedin/goavro/v2"
+)
+
+func NewFileAvroEE(cfg *config.EventExporterCfg, dc *utils.SafeMapStorage) (fAvro *FileAvroEE, err error) {
+	fAvro = &FileAvroEE{
+		cfg: cfg,
+		dc:  dc,
+	}
+	err = fAvro.init()
+	return
+}
+
+// FileAvroEE implements EventExporter interface for .avro object container files
+type FileAvroEE struct {
+	cfg    *config.EventExporterCfg
+	dc     *utils.SafeMapStorage
+	file   *os.File
+	writer *goavro.OCFWriter
+	schema []*schemaField
+	sync.Mutex
+	mapPreparing
+}
+
+// init will build the schema out of the content fields and create the file
+func (fAvro *FileAvroEE) init() (err error) {
+	if fAvro.schema, err = newEventSchema(fAvro.Cfg().ContentFields()); err != nil {
+		return
+	}
+	var avroSch string
+	if avroSch, err = avroSchema(fAvro.Cfg().ID, fAvro.schema); err != nil {
+		return
+	}
+	filePath := path.Join(fAvro.Cfg().ExportPath,
+		fAvro.Cfg().ID+utils.Underline+utils.UUIDSha1Prefix()+utils.AvroSuffix)
+	fAvro.dc.Lock()
+	fAvro.dc.MapStorage[utils.ExportPath] = filePath
+	fAvro.dc.Unlock()
+	// create the file
+	if fAvro.file, err = os.Create(filePath); err != nil {
+		return
+	}
+	ocfCfg := goavro.OCFConfig{
+		W:      fAvro.file,
+		Schema: avroSch,
+	}
+	if fAvro.Cfg().Opts.AvroCodec != nil {
+		ocfCfg.CompressionName = *fAvro.Cfg().Opts.AvroCodec
+	}
+	if fAvro.writer, err = goavro.NewOCFWriter(ocfCfg); err != nil {
+		fAvro.file.Close()
+	}
+	return
+}
+
+func (fAvro *FileAvroEE) Cfg() *config.EventExporterCfg { return fAvro.cfg }
+
+func (fAvro *FileAvroEE) Connect() (_ error) { return }
+
+func (fAvro *FileAvroEE) ExportEvent(ev any, _ string) (err error) {
+	var rec map[string]any
+	if rec, err = avroRecord(fAvro.schema, ev.(map[string]any)); err != nil {
+		return
+	}
+	fAvro.Lock() // make sure that only one event is writen in file at once
+	defer fAvro.Unlock()
+	return fAvro.writer.Append([]any{rec})
+}
+
+func (fAvro *FileAvroEE) Close() (err error) {
+	fAvro.Lock()
+	defer fAvro.Unlock()
+	if err = fAvro.file.Close(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when closing the file",
+			utils.EEs, fAvro.Cfg().ID, err.Error()))
+	}
+	return
+}
+
+func (fAvro *FileAvroEE) GetMetrics() *utils.SafeMapStorage { return fAvro.dc }
@@ -0,0 +1,202 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/linkedin/goavro/v2"
+)
+
+func TestFileAvroExport(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	eeCfg := newTypedEECfg(t, cfg, utils.MetaFileAvro)
+	eeCfg.Opts.AvroCodec = utils.StringPointer("deflate")
+	dc, err := newEEMetrics(utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fAvro, err := NewFileAvroEE(eeCfg, dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filterS := engine.NewFilterS(cfg, nil, nil)
+	for _, ev := range typedTestEvents() {
+		if err = exportEventWithExporter(fAvro, ev, false, cfg, filterS); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = fAvro.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if nrEvs := dc.MapStorage[utils.NumberOfEvents]; nrEvs != int64(2) {
+		t.Errorf("expected 2 events, received: %v", nrEvs)
+	}
+
+	f, err := os.Open(utils.IfaceAsString(dc.MapStorage[utils.ExportPath]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	ocfR, err := goavro.NewOCFReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cdc := ocfR.CompressionName(); cdc != "deflate" {
+		t.Errorf("expected deflate compression, received: %s", cdc)
+	}
+	var recs []any
+	for ocfR.Scan() {
+		rec, err := ocfR.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, rec)
+	}
+	exp := `[{"Account":{"string":"1001"},"AnswerTime":{"long":1704067200},"CGRID":{"string":"cgrid1"},"Cost":{"double":1.2345},"Usage":{"long":25000000000}},` +
+		`{"Account":null,"AnswerTime":{"long":1704067260},"CGRID":{"string":"cgrid2"},"Cost":null,"Usage":{"long":1000000000}}]`
+	if rcv := utils.ToJSON(recs); rcv != exp {
+		t.Errorf("expected: %s, received: %s", exp, rcv)
+	}
+}
+
+func TestNewEventSchema(t *testing.T) {
+	flds := []*config.FCTemplate{
+		{Path: "*exp.Cost", Type: utils.MetaSum},
+		{Path: "*exp.Cost", Type: utils.MetaVariable},
+		{Path: "*exp.Sub.2nd-Field", Type: utils.MetaComposed},
+		{Path: "*uch.Cost", Type: utils.MetaVariable},
+		{Path: "*exp.Usage", Type: utils.MetaUsageDifference},
+	}
+	exp := []*schemaField{
+		{Name: "Cost", Path: "Cost", Type: schemaDouble},
+		{Name: "Sub_2nd_Field", Path: "Sub.2nd-Field", Type: schemaString},
+		{Name: "Usage", Path: "Usage", Type: schemaLong, Duration: true},
+	}
+	if sch, err := newEventSchema(flds); err != nil {
+		t.Fatal(err)
+	} else if utils.ToJSON(sch) != utils.ToJSON(exp) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(sch))
+	}
+
+	expErr := "cannot convert field: <Cost> to double: strconv.ParseFloat: parsing \"abc\": invalid syntax"
+	if _, err := avroRecord(exp, map[string]any{"Cost": "abc"}); err == nil || err.Error() != expErr {
+		t.Errorf("expected error: %s, received: %v", expErr, err)
+	}
+}
+
+func TestKafkaAvroEncoding(t *testing.T) {
+	var rcvSchema string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subjects/cdrs-value/versions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]string
+		json.Unmarshal(body, &req)
+		rcvSchema = req["schema"]
+		w.Write([]byte(`{"id":7}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	eeCfg := newTypedEECfg(t, cfg, utils.MetaKafkajsonMap)
+	eeCfg.Opts.Kafka.Topic = utils.StringPointer("cdrs")
+	eeCfg.Opts.Kafka.Encoding = utils.StringPointer(utils.MetaAvro)
+	eeCfg.Opts.Kafka.SchemaRegistry = utils.StringPointer(srv.URL + "/")
+	dc, err := newEEMetrics(utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kfk, err := NewKafkaEE(eeCfg, dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kfk.Close()
+	expNM := utils.NewOrderedNavigableMap()
+	expNM.Append(&utils.FullPath{PathSlice: []string{"CGRID"}, Path: "CGRID"}, &utils.DataLeaf{Data: "cgrid1"})
+	expNM.Append(&utils.FullPath{PathSlice: []string{"Usage"}, Path: "Usage"}, &utils.DataLeaf{Data: "1m"})
+	ev, err := kfk.PrepareOrderMap(expNM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcvSchema != kfk.avro.codec.CanonicalSchema() {
+		t.Errorf("expected the schema to be registered, received: %q", rcvSchema)
+	}
+	msg := ev.([]byte)
+	if msg[0] != 0 || binary.BigEndian.Uint32(msg[1:5]) != 7 {
+		t.Fatalf("unexpected wire format header: %v", msg[:5])
+	}
+	rec, _, err := kfk.avro.codec.NativeFromBinary(msg[5:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"Account":null,"AnswerTime":null,"CGRID":{"string":"cgrid1"},"Cost":null,"Usage":{"long":60000000000}}`
+	if rcv := utils.ToJSON(rec); rcv != exp {
+		t.Errorf("expected: %s, received: %s", exp, rcv)
+	}
+}
+
+func TestAvroEncoderRegistryTimeout(t *testing.T) {
+	reached, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached <- struct{}{}
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	enc, err := newAvroEncoder("cdrs", []*config.FCTemplate{{Path: "*exp.CGRID", Type: utils.MetaVariable}},
+		srv.URL, "cdrs-value", 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := enc.header()
+		done <- err
+	}()
+	<-reached
+	if !enc.mu.TryLock() { // the registry is queried outside the lock
+		t.Fatal("expected the lock released while waiting for the registry")
+	}
+	enc.mu.Unlock()
+	select {
+	case err = <-done:
+		if err == nil {
+			t.Error("expected the registry request to time out")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the registry request did not time out")
+	}
+	if enc.schemaID != 0 {
+		t.Errorf("expected the schema not registered, received ID: %d", enc.schemaID)
+	}
+}
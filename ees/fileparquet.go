@@ -0,0 +1,125 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// parquetTypes maps the schema types to the parquet metadata
+var parquetTypes = map[string]string{
+	schemaString: "type=BYTE_ARRAY, convertedtype=UTF8",
+	schemaLong:   "type=INT64",
+	schemaDouble: "type=DOUBLE",
+}
+
+func NewFileParquetEE(cfg *config.EventExporterCfg, dc *utils.SafeMapStorage) (fPqt *FileParquetEE, err error) {
+	fPqt = &FileParquetEE{
+		cfg: cfg,
+		dc:  dc,
+	}
+	err = fPqt.init()
+	return
+}
+
+// FileParquetEE implements EventExporter interface for .parquet files
+type FileParquetEE struct {
+	cfg    *config.EventExporterCfg
+	dc     *utils.SafeMapStorage
+	file   *os.File
+	writer *writer.CSVWriter
+	schema []*schemaField
+	sync.Mutex
+	mapPreparing
+}
+
+// init will build the schema out of the content fields and create the file
+func (fPqt *FileParquetEE) init() (err error) {
+	if fPqt.schema, err = newEventSchema(fPqt.Cfg().ContentFields()); err != nil {
+		return
+	}
+	md := make([]string, len(fPqt.schema))
+	for i, sf := range fPqt.schema {
+		md[i] = fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", sf.Name, parquetTypes[sf.Type])
+	}
+	compression := parquet.CompressionCodec_SNAPPY
+	if fPqt.Cfg().Opts.ParquetCompression != nil {
+		if compression, err = parquet.CompressionCodecFromString(
+			strings.ToUpper(*fPqt.Cfg().Opts.ParquetCompression)); err != nil {
+			return
+		}
+	}
+	filePath := path.Join(fPqt.Cfg().ExportPath,
+		fPqt.Cfg().ID+utils.Underline+utils.UUIDSha1Prefix()+utils.ParquetSuffix)
+	fPqt.dc.Lock()
+	fPqt.dc.MapStorage[utils.ExportPath] = filePath
+	fPqt.dc.Unlock()
+	// create the file
+	if fPqt.file, err = os.Create(filePath); err != nil {
+		return
+	}
+	if fPqt.writer, err = writer.NewCSVWriterFromWriter(md, fPqt.file, 1); err != nil {
+		fPqt.file.Close()
+		return
+	}
+	fPqt.writer.CompressionType = compression
+	return
+}
+
+func (fPqt *FileParquetEE) Cfg() *config.EventExporterCfg { return fPqt.cfg }
+
+func (fPqt *FileParquetEE) Connect() (_ error) { return }
+
+func (fPqt *FileParquetEE) ExportEvent(ev any, _ string) (err error) {
+	rec := make([]any, len(fPqt.schema))
+	for i, sf := range fPqt.schema {
+		if rec[i], err = sf.value(ev.(map[string]any)); err != nil {
+			return
+		}
+	}
+	fPqt.Lock() // make sure that only one event is writen in file at once
+	defer fPqt.Unlock()
+	return fPqt.writer.Write(rec)
+}
+
+func (fPqt *FileParquetEE) Close() (err error) {
+	fPqt.Lock()
+	defer fPqt.Unlock()
+	// write the buffered row groups and the footer
+	if err = fPqt.writer.WriteStop(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when writing the footer",
+			utils.EEs, fPqt.Cfg().ID, err.Error()))
+	}
+	if err = fPqt.file.Close(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when closing the file",
+			utils.EEs, fPqt.Cfg().ID, err.Error()))
+	}
+	return
+}
+
+func (fPqt *FileParquetEE) GetMetrics() *utils.SafeMapStorage { return fPqt.dc }
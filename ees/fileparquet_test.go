@@ -0,0 +1,142 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func newTypedEECfg(t *testing.T, cfg *config.CGRConfig, eeType string) *config.EventExporterCfg {
+	eeCfg := cfg.EEsCfg().Exporters[0].Clone()
+	eeCfg.ID = "TypedExporter"
+	eeCfg.Type = eeType
+	eeCfg.ExportPath = t.TempDir()
+	eeCfg.Fields = []*config.FCTemplate{
+		{Tag: "CGRID", Path: "*exp.CGRID", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.CGRID", utils.InfieldSep)},
+		{Tag: "Account", Path: "*exp.Account", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Account", utils.InfieldSep)},
+		{Tag: "AnswerTime", Path: "*exp.AnswerTime", Type: utils.MetaUnixTimestamp,
+			Value: config.NewRSRParsersMustCompile("~*req.AnswerTime", utils.InfieldSep)},
+		{Tag: "Usage", Path: "*exp.Usage", Type: utils.MetaCCUsage,
+			Value: config.NewRSRParsersMustCompile("~*req.RequestNumber;~*req.LastUsed;10s", utils.InfieldSep)},
+		{Tag: "Cost", Path: "*exp.Cost", Type: utils.MetaVariable, RoundingDecimals: utils.IntPointer(2),
+			Value: config.NewRSRParsersMustCompile("~*req.Cost", utils.InfieldSep)},
+	}
+	for _, fld := range eeCfg.Fields {
+		fld.ComputePath()
+	}
+	eeCfg.ComputeFields()
+	return eeCfg
+}
+
+func typedTestEvents() []*utils.CGREvent {
+	return []*utils.CGREvent{
+		{
+			Tenant: "cgrates.org",
+			ID:     "EV1",
+			Event: map[string]any{
+				utils.CGRID:        "cgrid1",
+				utils.AccountField: "1001",
+				utils.AnswerTime:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				utils.LastUsed:     "5s",
+				"RequestNumber":    3,
+				utils.Cost:         1.2345,
+			},
+			APIOpts: map[string]any{},
+		},
+		{
+			Tenant: "cgrates.org",
+			ID:     "EV2",
+			Event: map[string]any{
+				utils.CGRID:      "cgrid2",
+				utils.AnswerTime: time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC),
+				utils.LastUsed:   "1s",
+				"RequestNumber":  0,
+			},
+			APIOpts: map[string]any{},
+		},
+	}
+}
+
+func TestFileParquetExport(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	eeCfg := newTypedEECfg(t, cfg, utils.MetaFileParquet)
+	eeCfg.Opts.ParquetCompression = utils.StringPointer("gzip")
+	dc, err := newEEMetrics(utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fPqt, err := NewFileParquetEE(eeCfg, dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filterS := engine.NewFilterS(cfg, nil, nil)
+	for _, ev := range typedTestEvents() {
+		if err = exportEventWithExporter(fPqt, ev, false, cfg, filterS); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = fPqt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if nrEvs := dc.MapStorage[utils.NumberOfEvents]; nrEvs != int64(2) {
+		t.Errorf("expected 2 events, received: %v", nrEvs)
+	}
+
+	fr, err := local.NewLocalFileReader(utils.IfaceAsString(dc.MapStorage[utils.ExportPath]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fr.Close()
+	pr, err := reader.NewParquetReader(fr, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+	if nrRows := pr.GetNumRows(); nrRows != 2 {
+		t.Fatalf("expected 2 rows, received: %d", nrRows)
+	}
+	rows, err := pr.ReadByNumber(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `[{"CGRID":"cgrid1","Account":"1001","AnswerTime":1704067200,"Usage":25000000000,"Cost":1.2345},` +
+		`{"CGRID":"cgrid2","Account":null,"AnswerTime":1704067260,"Usage":1000000000,"Cost":null}]`
+	if rcv := utils.ToJSON(rows); rcv != exp {
+		t.Errorf("expected: %s, received: %s", exp, rcv)
+	}
+}
+
+func TestFileParquetNoFields(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	eeCfg := cfg.EEsCfg().Exporters[0].Clone()
+	eeCfg.ExportPath = t.TempDir()
+	expErr := "no *exp fields to build the schema"
+	if _, err := NewFileParquetEE(eeCfg, &utils.SafeMapStorage{}); err == nil || err.Error() != expErr {
+		t.Errorf("expected error: %s, received: %v", expErr, err)
+	}
+}
@@ -92,6 +92,24 @@ func NewKafkaEE(cfg *config.EventExporterCfg, dc *utils.SafeMapStorage) (*KafkaE
 		pstr.writer.BatchSize = *opts.BatchSize
 	}
 
+	// the failed posts are replayed without fields, already encoded
+	if opts.Encoding != nil && *opts.Encoding == utils.MetaAvro &&
+		len(cfg.ContentFields()) != 0 {
+		var registry string
+		if opts.SchemaRegistry != nil {
+			registry = *opts.SchemaRegistry
+		}
+		subject := topic + "-value"
+		if opts.SchemaSubject != nil {
+			subject = *opts.SchemaSubject
+		}
+		var err error
+		if pstr.avro, err = newAvroEncoder(cfg.ID, cfg.ContentFields(), registry, subject,
+			config.CgrConfig().GeneralCfg().ReplyTimeout); err != nil {
+			return nil, err
+		}
+	}
+
 	return pstr, nil
 }
 
@@ -101,9 +119,18 @@ type KafkaEE struct {
 	cfg    *config.EventExporterCfg
 	dc     *utils.SafeMapStorage
 	reqs   *concReq
+	avro   *avroEncoder // nil for *json encoding
 	bytePreparing
 }
 
+func (k *KafkaEE) PrepareOrderMap(mp *utils.OrderedNavigableMap) (any, error) {
+	if k.avro == nil {
+		return k.bytePreparing.PrepareOrderMap(mp)
+	}
+	ev, _ := mapPreparing{}.PrepareOrderMap(mp)
+	return k.avro.encode(ev.(map[string]any))
+}
+
 func (k *KafkaEE) Cfg() *config.EventExporterCfg { return k.cfg }
 
 func (k *KafkaEE) Connect() error { return nil }
@@ -19,9 +19,15 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package ees
 
 import (
+	"bytes"
 	"encoding/gob"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
@@ -47,8 +53,11 @@ func getOneData(ub *engine.Account, extraData any) ([]byte, error) {
 	return nil, nil
 }
 
-func callURL(ub *engine.Account, a *engine.Action, _ engine.Actions, _ *engine.FilterS, extraData any,
+func callURL(ub *engine.Account, a *engine.Action, acts engine.Actions, _ *engine.FilterS, extraData any,
 	_ engine.SharedActionsData, _ engine.ActionConnCfg) error {
+	if strings.HasPrefix(strings.TrimSpace(a.ExtraParameters), "{") {
+		return callURLWithTemplate(ub, a, acts, extraData)
+	}
 	body, err := getOneData(ub, extraData)
 	if err != nil {
 		return err
@@ -101,3 +110,147 @@ func postEvent(_ *engine.Account, a *engine.Action, _ engine.Actions, _ *engine.
 	}
 	return err
 }
+
+// HTTPActionRequest is the *http_post request defined in the ExtraParameters of the action
+type HTTPActionRequest struct {
+	Url              string
+	Method           string            // defaults to POST
+	Headers          map[string]string // Content-Type defaults to application/json
+	Body             json.RawMessage   // a JSON string is sent unquoted, missing for the account or extra data
+	Attempts         int               // defaults to the general poster_attempts, 4xx replies are not retried
+	ContinueOnError  bool              // run the rest of the actions even if the request failed
+	ResponseBalances map[string]string // map[BalanceID]path in the JSON reply holding the new balance value
+}
+
+/*
+callURLWithTemplate sends the request built out of the ExtraParameters template
+using the same << .Object.Property >> syntax as *cgr_rpc with the objects:
+
+Account - the account that this action is called on
+Action - the action with all it's attributs
+Actions - the list of actions in the current action set
+ExtraData - the event that triggered the actions
+*/
+func callURLWithTemplate(ub *engine.Account, a *engine.Action, acts engine.Actions, extraData any) (err error) {
+	tmpl := template.New("extra_params")
+	tmpl.Delims("<<", ">>")
+	if tmpl, err = tmpl.Parse(a.ExtraParameters); err != nil {
+		return fmt.Errorf("error parsing %s template: %v", utils.MetaHTTPPost, err)
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, struct {
+		Account   *engine.Account
+		Action    *engine.Action
+		Actions   engine.Actions
+		ExtraData any
+	}{ub, a, acts, extraData}); err != nil {
+		return fmt.Errorf("error executing %s template: %v", utils.MetaHTTPPost, err)
+	}
+	var req HTTPActionRequest
+	if err = json.Unmarshal(buf.Bytes(), &req); err != nil {
+		return
+	}
+	var rply []byte
+	if rply, err = req.send(ub, extraData); err == nil {
+		err = req.updateBalances(ub, rply)
+	}
+	if err != nil && req.ContinueOnError {
+		utils.Logger.Warning(fmt.Sprintf("<%s> action %s failed: %v", utils.MetaHTTPPost, a.Id, err))
+		return nil
+	}
+	return
+}
+
+// send posts the request with attempts returning the reply body
+func (req *HTTPActionRequest) send(ub *engine.Account, extraData any) (rply []byte, err error) {
+	if req.Url == utils.EmptyString {
+		return nil, utils.NewErrMandatoryIeMissing("Url")
+	}
+	body := []byte(req.Body)
+	var str string
+	switch {
+	case len(body) == 0:
+		if body, err = getOneData(ub, extraData); err != nil {
+			return
+		}
+	case json.Unmarshal(body, &str) == nil:
+		body = []byte(str)
+	}
+	method := req.Method
+	if method == utils.EmptyString {
+		method = http.MethodPost
+	}
+	attempts := req.Attempts
+	if attempts <= 0 {
+		attempts = config.CgrConfig().GeneralCfg().PosterAttempts
+	}
+	client := &http.Client{Transport: engine.GetHTTPPstrTransport(),
+		Timeout: config.CgrConfig().GeneralCfg().ReplyTimeout}
+	fib := utils.FibDuration(time.Second, 0)
+	for i := 0; i < attempts; i++ {
+		var retry bool
+		if rply, retry, err = req.do(client, method, body); err == nil || !retry {
+			return
+		}
+		if i+1 < attempts {
+			time.Sleep(fib())
+		}
+	}
+	return
+}
+
+// do sends the request once, retry is false for the 4xx replies
+func (req *HTTPActionRequest) do(client *http.Client, method string, body []byte) (rply []byte, retry bool, err error) {
+	var hReq *http.Request
+	if hReq, err = http.NewRequest(method, req.Url, bytes.NewReader(body)); err != nil {
+		return
+	}
+	hReq.Header.Set("Content-Type", "application/json")
+	for k, v := range req.Headers {
+		hReq.Header.Set(k, v)
+	}
+	var resp *http.Response
+	if resp, err = client.Do(hReq); err != nil {
+		return nil, true, err
+	}
+	rply, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, true, err
+	}
+	if resp.StatusCode > 299 {
+		return rply, resp.StatusCode > 499,
+			fmt.Errorf("unexpected status code received: <%d>", resp.StatusCode)
+	}
+	return
+}
+
+// updateBalances sets the value of the ResponseBalances out of the JSON reply
+func (req *HTTPActionRequest) updateBalances(ub *engine.Account, rply []byte) (err error) {
+	if len(req.ResponseBalances) == 0 {
+		return
+	}
+	if ub == nil {
+		return fmt.Errorf("no account to update the balances for")
+	}
+	var rplyMp map[string]any
+	if err = json.Unmarshal(rply, &rplyMp); err != nil {
+		return
+	}
+	for balID, path := range req.ResponseBalances {
+		var val any
+		if val, err = utils.MapStorage(rplyMp).FieldAsInterface(strings.Split(path, utils.NestingSep)); err != nil {
+			return utils.ErrPrefix(err, path)
+		}
+		var fltVal float64
+		if fltVal, err = utils.IfaceAsFloat64(val); err != nil {
+			return
+		}
+		b, _ := ub.FindBalanceByID(balID)
+		if b == nil {
+			return utils.ErrPrefix(utils.ErrNotFound, balID)
+		}
+		b.SetValue(fltVal)
+	}
+	return
+}
@@ -0,0 +1,111 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestCallURLWithTemplate(t *testing.T) {
+	var rcvBody, rcvAuth, rcvCType string
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		b, _ := io.ReadAll(r.Body)
+		rcvBody = string(b)
+		rcvAuth = r.Header.Get("Authorization")
+		rcvCType = r.Header.Get("Content-Type")
+		switch r.URL.Path {
+		case "/bonus":
+			w.Write([]byte(`{"bonus":{"value":15}}`))
+		case "/denied":
+			w.WriteHeader(http.StatusForbidden)
+		case "/failing":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+	acc := &engine.Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]engine.Balances{
+			utils.MetaMonetary: {{ID: utils.MetaDefault, Value: 10}, {ID: "BONUS", Value: 0}},
+		},
+	}
+	act := &engine.Action{
+		Id:         "ACT_HTTP",
+		ActionType: utils.MetaHTTPPost,
+		ExtraParameters: `{"Url":"` + ts.URL + `/bonus","Headers":{"Authorization":"Bearer 123"},
+			"Body":{"Account":"<<.Account.ID>>","Value":<<.Account.GetDefaultMoneyBalance.Value>>},
+			"ResponseBalances":{"BONUS":"bonus.value"}}`,
+	}
+	if err := callURL(acc, act, engine.Actions{act}, nil, nil, engine.SharedActionsData{}, engine.ActionConnCfg{}); err != nil {
+		t.Fatal(err)
+	}
+	if exp := `{"Account":"cgrates.org:1001","Value":10}`; rcvBody != exp {
+		t.Errorf("Expected %s, received %s", exp, rcvBody)
+	}
+	if rcvAuth != "Bearer 123" || rcvCType != "application/json" {
+		t.Errorf("Unexpected headers: %q %q", rcvAuth, rcvCType)
+	}
+	if b, _ := acc.FindBalanceByID("BONUS"); b.GetValue() != 15 {
+		t.Errorf("Expected balance 15, received %v", b.GetValue())
+	}
+
+	hits = 0
+	act.ExtraParameters = `{"Url":"` + ts.URL + `/denied","Body":"Account <<.Account.ID>>","Attempts":3}`
+	if err := callURL(acc, act, engine.Actions{act}, nil, nil, engine.SharedActionsData{}, engine.ActionConnCfg{}); err == nil ||
+		err.Error() != "unexpected status code received: <403>" {
+		t.Errorf("Expected error, received %v", err)
+	}
+	if hits != 1 {
+		t.Errorf("Expected no retry for 4xx, received %d requests", hits)
+	}
+	if exp := "Account cgrates.org:1001"; rcvBody != exp {
+		t.Errorf("Expected %s, received %s", exp, rcvBody)
+	}
+
+	hits = 0
+	act.ExtraParameters = `{"Url":"` + ts.URL + `/failing","Attempts":2,"ContinueOnError":true}`
+	if err := callURL(acc, act, engine.Actions{act}, nil, nil, engine.SharedActionsData{}, engine.ActionConnCfg{}); err != nil {
+		t.Error(err)
+	}
+	if hits != 2 {
+		t.Errorf("Expected 2 attempts for 5xx, received %d requests", hits)
+	}
+	if !strings.Contains(rcvBody, `"ID":"cgrates.org:1001"`) {
+		t.Errorf("Expected the account as body, received %s", rcvBody)
+	}
+
+	act.ExtraParameters = `{"Url":"` + ts.URL + `/bonus","ResponseBalances":{"NOT_FOUND":"bonus.value"}}`
+	if err := callURL(acc, act, engine.Actions{act}, nil, nil, engine.SharedActionsData{}, engine.ActionConnCfg{}); err == nil ||
+		err.Error() != "NOT_FOUND:NOT_FOUND" {
+		t.Errorf("Expected error, received %v", err)
+	}
+	act.ExtraParameters = `{"Url":"<<.Account.Missing>>"}`
+	if err := callURL(acc, act, engine.Actions{act}, nil, nil, engine.SharedActionsData{}, engine.ActionConnCfg{}); err == nil {
+		t.Error("Expected template error")
+	}
+}
@@ -0,0 +1,282 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/linkedin/goavro/v2"
+)
+
+// the value types of the schema columns, named after the Avro primitive types
+const (
+	schemaString = "string"
+	schemaLong   = "long"
+	schemaDouble = "double"
+)
+
+// schemaField is a typed column built out of an exporter content field
+type schemaField struct {
+	Name     string // column name, valid for both Avro and Parquet
+	Path     string // path of the value inside the prepared event
+	Type     string // <string|long|double>
+	Duration bool   // the value is a duration, exported as nanoseconds
+}
+
+// newEventSchema builds the typed schema out of the *exp content fields
+// the type of the column is deduced out of the field type:
+// *unix_timestamp, *usage_difference and *cc_usage are exported as long,
+// *sum, *difference, *multiply, *divide, *value_exponent or fields with
+// rounding_decimals as double and everything else as string
+func newEventSchema(flds []*config.FCTemplate) (sch []*schemaField, err error) {
+	names := make(utils.StringSet)
+	pathPrfx := utils.MetaExp + utils.NestingSep
+	for _, fld := range flds {
+		if !strings.HasPrefix(fld.Path, pathPrfx) {
+			continue // not part of the exported event
+		}
+		sf := &schemaField{
+			Path: strings.TrimPrefix(fld.Path, pathPrfx),
+			Type: schemaString,
+		}
+		if sf.Name = schemaFieldName(sf.Path); names.Has(sf.Name) {
+			continue // already populated by a previous field(e.g. *group)
+		}
+		names.Add(sf.Name)
+		switch fld.Type {
+		case utils.MetaUnixTimestamp:
+			sf.Type = schemaLong
+		case utils.MetaUsageDifference, utils.MetaCCUsage:
+			sf.Type = schemaLong
+			sf.Duration = true
+		case utils.MetaSum, utils.MetaDifference, utils.MetaMultiply,
+			utils.MetaDivide, utils.MetaValueExponent:
+			sf.Type = schemaDouble
+		default:
+			if fld.RoundingDecimals != nil {
+				sf.Type = schemaDouble
+			}
+		}
+		sch = append(sch, sf)
+	}
+	if len(sch) == 0 {
+		return nil, errors.New("no *exp fields to build the schema")
+	}
+	return
+}
+
+// schemaFieldName replaces the characters not allowed by Avro in the column names
+func schemaFieldName(path string) string {
+	name := []byte(path)
+	for i, c := range name {
+		if !(c == '_' ||
+			(c >= 'a' && c <= 'z') ||
+			(c >= 'A' && c <= 'Z') ||
+			(i != 0 && c >= '0' && c <= '9')) {
+			name[i] = '_'
+		}
+	}
+	return string(name)
+}
+
+// value converts the exported value to the column type
+// missing or empty values are returned as nil
+func (sf *schemaField) value(ev map[string]any) (v any, err error) {
+	if v = ev[sf.Path]; v == nil {
+		return
+	}
+	if sf.Type == schemaString {
+		return utils.IfaceAsString(v), nil
+	}
+	if str, isStr := v.(string); isStr && str == utils.EmptyString {
+		return nil, nil
+	}
+	switch {
+	case sf.Duration:
+		var d time.Duration
+		d, err = utils.IfaceAsDuration(v)
+		v = d.Nanoseconds()
+	case sf.Type == schemaLong:
+		v, err = utils.IfaceAsTInt64(v)
+	default:
+		v, err = utils.IfaceAsFloat64(v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert field: <%s> to %s: %w", sf.Name, sf.Type, err)
+	}
+	return
+}
+
+// avroSchema returns the Avro record schema with all the fields nullable
+func avroSchema(name string, sch []*schemaField) (string, error) {
+	type avroField struct {
+		Name    string   `json:"name"`
+		Type    []string `json:"type"`
+		Default any      `json:"default"`
+	}
+	flds := make([]avroField, len(sch))
+	for i, sf := range sch {
+		flds[i] = avroField{
+			Name: sf.Name,
+			Type: []string{"null", sf.Type},
+		}
+	}
+	b, err := json.Marshal(map[string]any{
+		"type":      "record",
+		"name":      schemaFieldName(name),
+		"namespace": "org.cgrates",
+		"fields":    flds,
+	})
+	return string(b), err
+}
+
+// avroRecord converts the exported event to the Avro native record
+func avroRecord(sch []*schemaField, ev map[string]any) (map[string]any, error) {
+	rec := make(map[string]any, len(sch))
+	for _, sf := range sch {
+		v, err := sf.value(ev)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			rec[sf.Name] = nil
+			continue
+		}
+		rec[sf.Name] = goavro.Union(sf.Type, v)
+	}
+	return rec, nil
+}
+
+// mapPreparing prepares the events as map[string]any for the typed exporters
+type mapPreparing struct{}
+
+func (mapPreparing) PrepareMap(mp *utils.CGREvent) (any, error) {
+	return mp.Event, nil
+}
+
+func (mapPreparing) PrepareOrderMap(mp *utils.OrderedNavigableMap) (any, error) {
+	valMp := make(map[string]any)
+	for el := mp.GetFirstElement(); el != nil; el = el.Next() {
+		path := el.Value
+		nmIt, _ := mp.Field(path)
+		path = path[:len(path)-1] // remove the last index
+		valMp[strings.Join(path, utils.NestingSep)] = nmIt.Data
+	}
+	return valMp, nil
+}
+
+// schemaRegistryID registers the Avro schema under the subject and returns its ID
+func schemaRegistryID(client *http.Client, registryURL, subject, avroSch string) (id int, err error) {
+	body, err := json.Marshal(map[string]string{"schema": avroSch})
+	if err != nil {
+		return
+	}
+	resp, err := client.Post(strings.TrimSuffix(registryURL, utils.Slash)+"/subjects/"+
+		url.PathEscape(subject)+"/versions", "application/vnd.schemaregistry.v1+json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("schema registry replied with status: %d, body: %s", resp.StatusCode, respBody)
+	}
+	var rply struct{ ID int }
+	err = json.NewDecoder(resp.Body).Decode(&rply)
+	return rply.ID, err
+}
+
+// avroEncoder encodes the events as Avro binary
+// prefixed with the schema registry wire format header when a registry is used
+type avroEncoder struct {
+	schema   []*schemaField
+	codec    *goavro.Codec
+	registry string
+	subject  string
+	client   *http.Client
+
+	mu       sync.RWMutex
+	schemaID int // 0 until the schema is registered
+}
+
+func newAvroEncoder(name string, flds []*config.FCTemplate, registry, subject string,
+	timeout time.Duration) (enc *avroEncoder, err error) {
+	enc = &avroEncoder{
+		registry: registry,
+		subject:  subject,
+		client:   &http.Client{Transport: engine.GetHTTPPstrTransport(), Timeout: timeout},
+	}
+	if enc.schema, err = newEventSchema(flds); err != nil {
+		return
+	}
+	var avroSch string
+	if avroSch, err = avroSchema(name, enc.schema); err != nil {
+		return
+	}
+	enc.codec, err = goavro.NewCodec(avroSch)
+	return
+}
+
+// header returns the wire format header, registering the schema on first use
+// the registry is queried outside the lock so a slow registry does not block
+// the other exports, registering the same schema again returns the same ID
+func (enc *avroEncoder) header() (hdr []byte, err error) {
+	if enc.registry == utils.EmptyString {
+		return
+	}
+	enc.mu.RLock()
+	schemaID := enc.schemaID
+	enc.mu.RUnlock()
+	if schemaID == 0 {
+		if schemaID, err = schemaRegistryID(enc.client, enc.registry, enc.subject,
+			enc.codec.CanonicalSchema()); err != nil {
+			return
+		}
+		enc.mu.Lock()
+		enc.schemaID = schemaID
+		enc.mu.Unlock()
+	}
+	hdr = make([]byte, 5) // magic byte followed by the schema ID
+	binary.BigEndian.PutUint32(hdr[1:], uint32(schemaID))
+	return
+}
+
+func (enc *avroEncoder) encode(ev map[string]any) (b []byte, err error) {
+	var rec map[string]any
+	if rec, err = avroRecord(enc.schema, ev); err != nil {
+		return
+	}
+	if b, err = enc.header(); err != nil {
+		return
+	}
+	return enc.codec.BinaryFromNative(b, rec)
+}
@@ -1069,6 +1069,7 @@ func (acc *Account) Publish(initBal map[string]float64) {
 			utils.MetaEventType: utils.AccountUpdate,
 		},
 	}
+	PublishLiveEvent(utils.MetaAccountUpdate, cgrEv)
 	if len(config.CgrConfig().RalsCfg().ThresholdSConns) != 0 {
 		var tIDs []string
 		if err := connMgr.Call(context.TODO(), config.CgrConfig().RalsCfg().ThresholdSConns,
@@ -84,6 +84,10 @@ func init() {
 	gob.Register(new(StatSum))
 	gob.Register(new(StatAverage))
 	gob.Register(new(StatDistinct))
+	gob.Register(new(StatPercentile))
+	gob.Register(new(StatHistogram))
+	gob.Register(new(StatStdDev))
+	gob.Register(new(StatRate))
 
 	// others
 	gob.Register([]any{})
@@ -20,16 +20,20 @@ package engine
 
 import (
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
 )
 
 // Caps the structure that allocs requests for API
 type Caps struct {
-	strategy string
-	aReqs    chan struct{}
+	strategy   string
+	aReqs      chan struct{}
+	rateLimits []*capsRateLimit
 }
 
 // NewCaps creates a new caps
@@ -40,6 +44,45 @@ func NewCaps(reqs int, strategy string) *Caps {
 	}
 }
 
+// NewCapsWithRateLimits creates a new caps that also enforces the token-bucket limits
+func NewCapsWithRateLimits(reqs int, strategy string, rateLimits []*config.CapsRateLimitCfg) (cR *Caps) {
+	cR = NewCaps(reqs, strategy)
+	cR.rateLimits = make([]*capsRateLimit, len(rateLimits))
+	for i, rlCfg := range rateLimits {
+		cR.rateLimits[i] = newCapsRateLimit(rlCfg)
+	}
+	return
+}
+
+// HasRateLimits returns true if there are rate limits configured
+func (cR *Caps) HasRateLimits() bool {
+	return len(cR.rateLimits) != 0
+}
+
+// AllowRate consumes one token from each rate limit matching the method and tenant.
+// An empty tenant will only be checked against the limits not restricted to tenants.
+func (cR *Caps) AllowRate(method, tenant string) (err error) {
+	now := time.Now()
+	for _, rl := range cR.rateLimits {
+		if !rl.matches(method, tenant) {
+			continue
+		}
+		if !rl.allow(tenant, now) {
+			return utils.ErrRateLimitExceededNoCaps
+		}
+	}
+	return
+}
+
+// RateLimited returns the number of requests rejected by each rate limit
+func (cR *Caps) RateLimited() (rl map[string]uint64) {
+	rl = make(map[string]uint64, len(cR.rateLimits))
+	for _, lmt := range cR.rateLimits {
+		rl[lmt.id] = lmt.rejected.Load()
+	}
+	return
+}
+
 // IsLimited returns true if the limit is not 0
 func (cR *Caps) IsLimited() bool {
 	return cap(cR.aReqs) != 0
@@ -134,3 +177,107 @@ type floatDP float64
 func (f floatDP) String() string                                 { return strconv.FormatFloat(float64(f), 'f', -1, 64) }
 func (f floatDP) FieldAsInterface(fldPath []string) (any, error) { return float64(f), nil }
 func (f floatDP) FieldAsString(fldPath []string) (string, error) { return f.String(), nil }
+
+// newCapsRateLimit creates the limit out of its config
+func newCapsRateLimit(rlCfg *config.CapsRateLimitCfg) (rl *capsRateLimit) {
+	rl = &capsRateLimit{
+		id:       rlCfg.ID,
+		methods:  make(utils.StringSet),
+		services: make(utils.StringSet),
+		tenants:  utils.NewStringSet(rlCfg.Tenants),
+		rate:     rlCfg.Rate,
+		burst:    float64(rlCfg.Burst),
+		buckets:  make(map[string]*tokenBucket),
+	}
+	for _, method := range rlCfg.Methods {
+		switch {
+		case method == utils.MetaAny:
+			rl.anyMethod = true
+		case strings.HasSuffix(method, utils.NestingSep+utils.Meta):
+			rl.services.Add(strings.TrimSuffix(method, utils.NestingSep+utils.Meta))
+		default:
+			rl.methods.Add(method)
+		}
+	}
+	return
+}
+
+// capsRateLimit is a token-bucket limit with one bucket per tenant
+type capsRateLimit struct {
+	id        string
+	anyMethod bool
+	methods   utils.StringSet // full method names
+	services  utils.StringSet // services with all their methods limited
+	tenants   utils.StringSet
+	rate      float64
+	burst     float64
+
+	mux       sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time // last removal of the refilled buckets
+	rejected  atomic.Uint64
+}
+
+// matches checks if the limit applies to the method and tenant
+func (rl *capsRateLimit) matches(method, tenant string) bool {
+	if rl.tenants.Size() != 0 && !rl.tenants.Has(tenant) {
+		return false
+	}
+	if rl.anyMethod || rl.methods.Has(method) {
+		return true
+	}
+	srv, _, _ := strings.Cut(method, utils.NestingSep)
+	return rl.services.Has(srv)
+}
+
+// allow takes one token from the tenant bucket, counting the rejections
+func (rl *capsRateLimit) allow(tenant string, now time.Time) bool {
+	rl.mux.Lock()
+	rl.sweep(now)
+	tb, has := rl.buckets[tenant]
+	if !has {
+		tb = &tokenBucket{tokens: rl.burst, last: now}
+		rl.buckets[tenant] = tb
+	}
+	allowed := tb.take(rl.rate, rl.burst, now)
+	rl.mux.Unlock()
+	if !allowed {
+		rl.rejected.Add(1)
+	}
+	return allowed
+}
+
+// sweep removes the buckets refilled up to burst since they would be recreated the same,
+// keeping the buckets of the tenants not in use from piling up.
+// Runs at most once per the time needed to refill an empty bucket.
+func (rl *capsRateLimit) sweep(now time.Time) {
+	refill := rl.burst / rl.rate // seconds
+	if now.Sub(rl.lastSweep).Seconds() < refill {
+		return
+	}
+	rl.lastSweep = now
+	for tnt, tb := range rl.buckets {
+		if now.Sub(tb.last).Seconds() >= refill {
+			delete(rl.buckets, tnt)
+		}
+	}
+}
+
+// tokenBucket holds the tokens available at the last refill
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket based on the elapsed time and consumes one token if available
+func (tb *tokenBucket) take(rate, burst float64, now time.Time) bool {
+	if elapsed := now.Sub(tb.last); elapsed > 0 {
+		tb.tokens = min(burst, tb.tokens+elapsed.Seconds()*rate)
+		tb.last = now
+	}
+	if tb.tokens < 1 {
+		return false
+	}
+	tb.tokens--
+	return true
+}
@@ -193,3 +193,107 @@ func BenchmarkCaps(b *testing.B) {
 		}
 	}
 }
+
+func TestCapsRateLimits(t *testing.T) {
+	cs := NewCapsWithRateLimits(0, utils.MetaBusy, []*config.CapsRateLimitCfg{
+		{
+			ID:      "cdrs",
+			Methods: []string{utils.CDRsV1GetCDRs},
+			Rate:    0.001,
+			Burst:   2,
+		},
+		{
+			ID:      "apier",
+			Methods: []string{"APIerSv1.*"},
+			Tenants: []string{"cgrates.org"},
+			Rate:    0.001,
+			Burst:   1,
+		},
+	})
+	if cs.IsLimited() {
+		t.Errorf("Expected to not be limited")
+	}
+	if !cs.HasRateLimits() {
+		t.Errorf("Expected to have rate limits")
+	}
+	// each tenant has its own bucket
+	for _, tnt := range []string{"cgrates.org", "itsyscom.com"} {
+		for i := 0; i < 2; i++ {
+			if err := cs.AllowRate(utils.CDRsV1GetCDRs, tnt); err != nil {
+				t.Errorf("<%s> request %d: %v", tnt, i, err)
+			}
+		}
+		if err := cs.AllowRate(utils.CDRsV1GetCDRs, tnt); err != utils.ErrRateLimitExceededNoCaps {
+			t.Errorf("Expected: %v ,received: %v", utils.ErrRateLimitExceededNoCaps, err)
+		}
+	}
+	// other methods are not affected
+	if err := cs.AllowRate(utils.SessionSv1AuthorizeEvent, "cgrates.org"); err != nil {
+		t.Error(err)
+	}
+	// service wildcard only for the configured tenant
+	if err := cs.AllowRate(utils.APIerSv1GetAccount, "cgrates.org"); err != nil {
+		t.Error(err)
+	}
+	if err := cs.AllowRate(utils.APIerSv1SetAccount, "cgrates.org"); err != utils.ErrRateLimitExceededNoCaps {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrRateLimitExceededNoCaps, err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := cs.AllowRate(utils.APIerSv1SetAccount, "itsyscom.com"); err != nil {
+			t.Error(err)
+		}
+	}
+	exp := map[string]uint64{"cdrs": 2, "apier": 1}
+	if rcv := cs.RateLimited(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %v ,received: %v", exp, rcv)
+	}
+}
+
+func TestTokenBucketTake(t *testing.T) {
+	now := time.Now()
+	tb := &tokenBucket{tokens: 1, last: now}
+	if !tb.take(2, 2, now) {
+		t.Error("Expected the token to be taken")
+	}
+	if tb.take(2, 2, now) {
+		t.Error("Expected empty bucket")
+	}
+	// refill is capped at burst
+	if !tb.take(2, 2, now.Add(time.Hour)) {
+		t.Error("Expected the token to be taken")
+	}
+	if tb.tokens != 1 {
+		t.Errorf("Expected: %v ,received: %v", 1, tb.tokens)
+	}
+	if !tb.take(2, 2, now.Add(time.Hour)) {
+		t.Error("Expected the token to be taken")
+	}
+	if tb.take(2, 2, now.Add(time.Hour+time.Millisecond)) {
+		t.Error("Expected empty bucket")
+	}
+}
+
+func TestCapsRateLimitSweep(t *testing.T) {
+	rl := newCapsRateLimit(&config.CapsRateLimitCfg{
+		ID:      "cdrs",
+		Methods: []string{utils.MetaAny},
+		Rate:    1,
+		Burst:   2,
+	})
+	now := time.Now()
+	for _, tnt := range []string{"cgrates.org", "itsyscom.com"} {
+		if !rl.allow(tnt, now) {
+			t.Errorf("<%s> expected the token to be taken", tnt)
+		}
+	}
+	if len(rl.buckets) != 2 {
+		t.Errorf("Expected: %v ,received: %v", 2, len(rl.buckets))
+	}
+	// the idle buckets are removed once refilled
+	if !rl.allow("cgrates.org", now.Add(3*time.Second)) {
+		t.Error("Expected the token to be taken")
+	}
+	if _, has := rl.buckets["itsyscom.com"]; has || len(rl.buckets) != 1 {
+		t.Errorf("Expected only the active bucket, received: %v", utils.ToJSON(rl.buckets))
+	}
+}
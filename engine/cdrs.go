@@ -782,6 +782,9 @@ func (cdrS *CDRServer) processEvents(evs []*utils.CGREvent, args *cdrProcessingA
 			}
 		}
 	}
+	for _, cgrEv := range cgrEvs {
+		PublishLiveEvent(utils.MetaCDR, cgrEv)
+	}
 	var partiallyExecuted bool // from here actions are optional and a general error is returned
 	if args.export {
 		if len(cdrS.cgrCfg.CdrsCfg().EEsConns) != 0 {
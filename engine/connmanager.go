@@ -175,6 +175,11 @@ func (cM *ConnManager) Call(ctx *context.Context, connIDs []string, method strin
 	if len(connIDs) == 0 {
 		return utils.NewErrMandatoryIeMissing("connIDs")
 	}
+	if TracingEnabled() {
+		var endSpan func(error)
+		ctx, arg, endSpan = startConnSpan(ctx, connIDs, method, arg)
+		defer func() { endSpan(err) }()
+	}
 	var conn birpc.ClientConnector
 	for _, connID := range connIDs {
 		cM.lkConn(connID)
@@ -199,6 +204,12 @@ func (cM *ConnManager) CallWithConnIDs(connIDs []string, subsHostIDs utils.Strin
 	if subsHostIDs.Size() == 0 {
 		return
 	}
+	ctx := context.TODO()
+	if TracingEnabled() {
+		var endSpan func(error)
+		ctx, arg, endSpan = startConnSpan(ctx, connIDs, method, arg)
+		defer func() { endSpan(err) }()
+	}
 	var conn birpc.ClientConnector
 	for _, connID := range connIDs {
 		// recreate the config with only conns that are needed
@@ -219,10 +230,10 @@ func (cM *ConnManager) CallWithConnIDs(connIDs []string, subsHostIDs utils.Strin
 			// skip this pool if no connection matches
 			continue
 		}
-		if conn, err = cM.getConnWithConfig(context.TODO(), connID, newCfg, nil); err != nil {
+		if conn, err = cM.getConnWithConfig(ctx, connID, newCfg, nil); err != nil {
 			continue
 		}
-		if err = conn.Call(context.TODO(), method, arg, reply); !rpcclient.ShouldFailover(err) {
+		if err = conn.Call(ctx, method, arg, reply); !rpcclient.ShouldFailover(err) {
 			return
 		}
 	}
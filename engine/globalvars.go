@@ -20,6 +20,7 @@ package engine
 
 import (
 	"net/http"
+	"sync/atomic"
 
 	"github.com/cgrates/cgrates/config"
 )
@@ -31,6 +32,7 @@ var (
 	dm                *DataManager
 	cdrStorage        CdrStorage
 	connMgr           *ConnManager
+	liveFeed          atomic.Pointer[LiveFeed] // set at runtime, once FilterS is available
 )
 
 func init() {
@@ -48,6 +50,11 @@ func SetConnManager(conMgr *ConnManager) {
 	connMgr = conMgr
 }
 
+// SetLiveFeed sets the LiveFeed receiving the session, CDR, threshold and account events
+func SetLiveFeed(lf *LiveFeed) {
+	liveFeed.Store(lf)
+}
+
 // SetCdrStorage sets the database for CDR storing, used by *cdrlog in first place
 func SetCdrStorage(cStorage CdrStorage) {
 	cdrStorage = cStorage
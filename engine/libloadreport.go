@@ -0,0 +1,326 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// LoadDiff is the change loading one item would bring to DataDB
+type LoadDiff struct {
+	Type   string                // loader type of the item, ie: *attributes
+	ID     string                // tenant:ID for profiles
+	Status string                // <*created|*updated|*unchanged|*removed>
+	Fields map[string]*FieldDiff `json:",omitempty"` // the fields changed by an update
+}
+
+// FieldDiff holds the stored and the loaded value of one field
+type FieldDiff struct {
+	Old any
+	New any
+}
+
+// LoadError is an item which would fail loading
+type LoadError struct {
+	Type  string
+	ID    string
+	Error string
+}
+
+// LoadReport gathers the changes of a load without writing them to DataDB
+type LoadReport struct {
+	Diffs  []*LoadDiff
+	Errors []*LoadError
+
+	dm        *DataManager
+	filterIDs utils.StringSet   // filters loaded together with the profiles
+	fltrRefs  []*loadFilterRefs // checked once all the filters are known
+}
+
+type loadFilterRefs struct {
+	ldrType string
+	id      string
+	tenant  string
+	fltrIDs []string
+}
+
+// NewLoadReport returns a LoadReport comparing the items with the ones stored in dm
+func NewLoadReport(dm *DataManager) *LoadReport {
+	return &LoadReport{
+		dm:        dm,
+		filterIDs: make(utils.StringSet),
+	}
+}
+
+// volatile fields, changing on each load
+var loadDiffVolatileFields = utils.NewStringSet([]string{"Uuid", "UpdateTime"})
+
+// fields not overwritten when loading the item
+var loadDiffIgnoredFields = map[string][]string{
+	utils.MetaAccountActions: {"BalanceMap", "UnitCounters"},
+	utils.MetaActionPlans:    {"AccountIDs"},
+}
+
+// StoreItem validates the item and reports it against the stored one
+func (lr *LoadReport) StoreItem(ldrType, id string, item any) {
+	lr.validate(ldrType, id, item)
+	oldItem, err := lr.StoredItem(ldrType, id)
+	if err == utils.ErrNotFound {
+		lr.AddDiff(ldrType, id, nil, item)
+		return
+	}
+	if err != nil {
+		lr.AddError(ldrType, id, err)
+		return
+	}
+	lr.AddDiff(ldrType, id, oldItem, item)
+}
+
+// RemoveItem reports the removal of the stored item
+func (lr *LoadReport) RemoveItem(ldrType, id string) {
+	oldItem, err := lr.StoredItem(ldrType, id)
+	if err != nil {
+		lr.AddError(ldrType, id, err)
+		return
+	}
+	lr.AddDiff(ldrType, id, oldItem, nil)
+}
+
+// AddDiff compares the items field by field, a nil oldItem meaning created and a nil newItem removed
+func (lr *LoadReport) AddDiff(ldrType, id string, oldItem, newItem any) {
+	diff := &LoadDiff{Type: ldrType, ID: id}
+	switch {
+	case oldItem == nil:
+		diff.Status = utils.MetaCreated
+	case newItem == nil:
+		diff.Status = utils.MetaRemoved
+	default:
+		diff.Status = utils.MetaUnchanged
+		if flds := diffLoadFields(ldrType, oldItem, newItem); len(flds) != 0 {
+			diff.Status, diff.Fields = utils.MetaUpdated, flds
+		}
+	}
+	lr.Diffs = append(lr.Diffs, diff)
+}
+
+// AddError reports the item as failing to load
+func (lr *LoadReport) AddError(ldrType, id string, err error) {
+	lr.Errors = append(lr.Errors, &LoadError{Type: ldrType, ID: id, Error: err.Error()})
+}
+
+// Complete checks the filter references, once all the items are reported, and sorts the report
+func (lr *LoadReport) Complete() {
+	for _, ref := range lr.fltrRefs {
+		for _, fltrID := range ref.fltrIDs {
+			if lr.filterIDs.Has(utils.ConcatenatedKey(ref.tenant, fltrID)) {
+				continue
+			}
+			if err := lr.dm.checkFilters(ref.tenant, []string{fltrID}); err != nil {
+				lr.AddError(ref.ldrType, ref.id, err)
+			}
+		}
+	}
+	lr.fltrRefs = nil
+	sort.SliceStable(lr.Diffs, func(i, j int) bool {
+		if lr.Diffs[i].Type != lr.Diffs[j].Type {
+			return lr.Diffs[i].Type < lr.Diffs[j].Type
+		}
+		return lr.Diffs[i].ID < lr.Diffs[j].ID
+	})
+	sort.SliceStable(lr.Errors, func(i, j int) bool {
+		if lr.Errors[i].Type != lr.Errors[j].Type {
+			return lr.Errors[i].Type < lr.Errors[j].Type
+		}
+		return lr.Errors[i].ID < lr.Errors[j].ID
+	})
+}
+
+// validate checks the activation interval of the item and queues its filter references
+func (lr *LoadReport) validate(ldrType, id string, item any) {
+	var tnt string
+	var fltrIDs []string
+	var aI *utils.ActivationInterval
+	switch prf := item.(type) {
+	case *Filter:
+		lr.filterIDs.Add(prf.TenantID())
+		if err := CheckFilter(prf); err != nil {
+			lr.AddError(ldrType, id, err)
+		}
+		aI = prf.ActivationInterval
+	case *AttributeProfile:
+		tnt, aI = prf.Tenant, prf.ActivationInterval
+		fltrIDs = append(fltrIDs, prf.FilterIDs...)
+		for _, attr := range prf.Attributes {
+			fltrIDs = append(fltrIDs, attr.FilterIDs...)
+		}
+	case *ResourceProfile:
+		tnt, aI = prf.Tenant, prf.ActivationInterval
+		fltrIDs = prf.FilterIDs
+	case *StatQueueProfile:
+		tnt, aI = prf.Tenant, prf.ActivationInterval
+		fltrIDs = prf.FilterIDs
+	case *ThresholdProfile:
+		tnt, aI = prf.Tenant, prf.ActivationInterval
+		fltrIDs = prf.FilterIDs
+	case *RouteProfile:
+		tnt, aI = prf.Tenant, prf.ActivationInterval
+		fltrIDs = append(fltrIDs, prf.FilterIDs...)
+		for _, route := range prf.Routes {
+			fltrIDs = append(fltrIDs, route.FilterIDs...)
+		}
+	case *ChargerProfile:
+		tnt, aI = prf.Tenant, prf.ActivationInterval
+		fltrIDs = prf.FilterIDs
+	case *DispatcherProfile:
+		tnt, aI = prf.Tenant, prf.ActivationInterval
+		fltrIDs = append(fltrIDs, prf.FilterIDs...)
+		for _, host := range prf.Hosts {
+			fltrIDs = append(fltrIDs, host.FilterIDs...)
+		}
+	}
+	if aI != nil && !aI.ExpiryTime.IsZero() &&
+		!aI.ExpiryTime.After(aI.ActivationTime) {
+		lr.AddError(ldrType, id, fmt.Errorf("invalid ActivationInterval: ExpiryTime <%s> not after ActivationTime <%s>",
+			aI.ExpiryTime, aI.ActivationTime))
+	}
+	if len(fltrIDs) != 0 {
+		lr.fltrRefs = append(lr.fltrRefs, &loadFilterRefs{ldrType: ldrType, id: id,
+			tenant: tnt, fltrIDs: fltrIDs})
+	}
+}
+
+// StoredItem returns the item stored in DataDB for the loader type
+func (lr *LoadReport) StoredItem(ldrType, id string) (item any, err error) {
+	tntID := utils.NewTenantID(id)
+	switch ldrType {
+	case utils.MetaAttributes:
+		return lr.dm.GetAttributeProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaResources:
+		return lr.dm.GetResourceProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaFilters:
+		return lr.dm.GetFilter(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaStats:
+		return lr.dm.GetStatQueueProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaTrends:
+		return lr.dm.GetTrendProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaRankings:
+		return lr.dm.GetRankingProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaThresholds:
+		return lr.dm.GetThresholdProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaRoutes:
+		return lr.dm.GetRouteProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaChargers:
+		return lr.dm.GetChargerProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaDispatchers:
+		return lr.dm.GetDispatcherProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaDispatcherHosts:
+		return lr.dm.GetDispatcherHost(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaDestinations:
+		return lr.dm.GetDestination(id, false, false, utils.NonTransactional)
+	case utils.MetaTimings:
+		return lr.dm.GetTiming(id, true, utils.NonTransactional)
+	case utils.MetaRatingPlans:
+		return lr.dm.GetRatingPlan(id, true, utils.NonTransactional)
+	case utils.MetaRatingProfiles:
+		return lr.dm.GetRatingProfile(id, true, utils.NonTransactional)
+	case utils.MetaSharedGroups:
+		return lr.dm.GetSharedGroup(id, true, utils.NonTransactional)
+	case utils.MetaActions:
+		return lr.dm.GetActions(id, true, utils.NonTransactional)
+	case utils.MetaActionTriggers:
+		return lr.dm.GetActionTriggers(id, true, utils.NonTransactional)
+	case utils.MetaActionPlans:
+		return lr.dm.GetActionPlan(id, false, false, utils.NonTransactional)
+	case utils.MetaAccountActions:
+		return lr.dm.GetAccount(id)
+	}
+	return nil, fmt.Errorf("unsupported loader type <%s>", ldrType)
+}
+
+// diffLoadFields returns the fields differing between the two items, compared on their JSON representation
+func diffLoadFields(ldrType string, oldItem, newItem any) (flds map[string]*FieldDiff) {
+	oldFlds := loadDiffFields(ldrType, oldItem)
+	newFlds := loadDiffFields(ldrType, newItem)
+	flds = make(map[string]*FieldDiff)
+	for fld, newVal := range newFlds {
+		if oldVal := oldFlds[fld]; !reflect.DeepEqual(oldVal, newVal) {
+			flds[fld] = &FieldDiff{Old: oldVal, New: newVal}
+		}
+	}
+	for fld, oldVal := range oldFlds {
+		if _, has := newFlds[fld]; !has && oldVal != nil {
+			flds[fld] = &FieldDiff{Old: oldVal}
+		}
+	}
+	return
+}
+
+// loadDiffFields returns the fields of the item, indexed on name or on position for lists
+func loadDiffFields(ldrType string, item any) (flds map[string]any) {
+	flds = make(map[string]any)
+	var val any
+	if b, err := json.Marshal(item); err != nil ||
+		json.Unmarshal(b, &val) != nil {
+		return
+	}
+	switch v := removeVolatileFields(val).(type) {
+	case map[string]any:
+		flds = v
+	case []any:
+		for i, fldVal := range v {
+			flds[strconv.Itoa(i)] = fldVal
+		}
+	}
+	for _, fld := range loadDiffIgnoredFields[ldrType] {
+		delete(flds, fld)
+	}
+	return
+}
+
+// removeVolatileFields strips the volatile fields out of the decoded JSON value,
+// considering the empty lists and maps the same as the missing ones
+func removeVolatileFields(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			return nil
+		}
+		for fld, fldVal := range v {
+			if loadDiffVolatileFields.Has(fld) {
+				delete(v, fld)
+				continue
+			}
+			v[fld] = removeVolatileFields(fldVal)
+		}
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		for i, fldVal := range v {
+			v[i] = removeVolatileFields(fldVal)
+		}
+	}
+	return val
+}
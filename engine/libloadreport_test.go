@@ -0,0 +1,125 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestLoadReport(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	if err := dm.SetAttributeProfile(&AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_1",
+		Contexts:  []string{utils.MetaAny},
+		FilterIDs: []string{"*string:~*req.Account:1001"},
+		Weight:    10,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetDestination(&Destination{Id: "DST_1002", Prefixes: []string{"1002"}},
+		utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+
+	lr := NewLoadReport(dm)
+	lr.StoreItem(utils.MetaAttributes, "cgrates.org:ATTR_1", &AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_1",
+		Contexts:  []string{utils.MetaAny},
+		FilterIDs: []string{"*string:~*req.Account:1001", "FLTR_NEW"},
+		Weight:    20,
+	})
+	lr.StoreItem(utils.MetaAttributes, "cgrates.org:ATTR_2", &AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_2",
+		FilterIDs: []string{"FLTR_MISSING"},
+		ActivationInterval: &utils.ActivationInterval{
+			ActivationTime: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			ExpiryTime:     time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	// the filters loaded together with the profiles are known
+	lr.StoreItem(utils.MetaFilters, "cgrates.org:FLTR_NEW", &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_NEW",
+		Rules: []*FilterRule{{Type: utils.MetaString,
+			Element: "~*req.Account", Values: []string{"1002"}}},
+	})
+	lr.StoreItem(utils.MetaDestinations, "DST_1002",
+		&Destination{Id: "DST_1002", Prefixes: []string{"1002"}})
+	lr.RemoveItem(utils.MetaDestinations, "DST_1002")
+	lr.RemoveItem(utils.MetaDestinations, "DST_1003")
+	lr.Complete()
+
+	expDiffs := []*LoadDiff{
+		{Type: utils.MetaAttributes, ID: "cgrates.org:ATTR_1", Status: utils.MetaUpdated,
+			Fields: map[string]*FieldDiff{
+				"FilterIDs": {
+					Old: []any{"*string:~*req.Account:1001"},
+					New: []any{"*string:~*req.Account:1001", "FLTR_NEW"},
+				},
+				"Weight": {Old: float64(10), New: float64(20)},
+			}},
+		{Type: utils.MetaAttributes, ID: "cgrates.org:ATTR_2", Status: utils.MetaCreated},
+		{Type: utils.MetaDestinations, ID: "DST_1002", Status: utils.MetaUnchanged},
+		{Type: utils.MetaDestinations, ID: "DST_1002", Status: utils.MetaRemoved},
+		{Type: utils.MetaFilters, ID: "cgrates.org:FLTR_NEW", Status: utils.MetaCreated},
+	}
+	if !reflect.DeepEqual(expDiffs, lr.Diffs) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expDiffs), utils.ToJSON(lr.Diffs))
+	}
+	expErrs := []*LoadError{
+		{Type: utils.MetaAttributes, ID: "cgrates.org:ATTR_2",
+			Error: "invalid ActivationInterval: ExpiryTime <2026-10-17 00:00:00 +0000 UTC> not after ActivationTime <2026-10-18 00:00:00 +0000 UTC>"},
+		{Type: utils.MetaAttributes, ID: "cgrates.org:ATTR_2",
+			Error: "broken reference to filter: <FLTR_MISSING>"},
+		{Type: utils.MetaDestinations, ID: "DST_1003", Error: utils.ErrNotFound.Error()},
+	}
+	if !reflect.DeepEqual(expErrs, lr.Errors) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expErrs), utils.ToJSON(lr.Errors))
+	}
+}
+
+func TestLoadReportVolatileFields(t *testing.T) {
+	oldAP := &ActionPlan{Id: "AP_1", AccountIDs: utils.StringMap{"cgrates.org:1001": true},
+		ActionTimings: []*ActionTiming{{Uuid: "uuid1", ActionsID: "ACT_1", Weight: 10}}}
+	newAP := &ActionPlan{Id: "AP_1",
+		ActionTimings: []*ActionTiming{{Uuid: "uuid2", ActionsID: "ACT_1", Weight: 10}}}
+	if flds := diffLoadFields(utils.MetaActionPlans, oldAP, newAP); len(flds) != 0 {
+		t.Errorf("expected no changes, received %s", utils.ToJSON(flds))
+	}
+	newAP.ActionTimings[0].Weight = 20
+	if flds := diffLoadFields(utils.MetaActionPlans, oldAP, newAP); len(flds) != 1 ||
+		flds["ActionTimings"] == nil {
+		t.Errorf("expected ActionTimings changed, received %s", utils.ToJSON(flds))
+	}
+	// lists are compared on position
+	if flds := diffLoadFields(utils.MetaActions, Actions{{Id: "ACT_1", ActionType: utils.MetaTopUp}},
+		Actions{{Id: "ACT_1", ActionType: utils.MetaTopUp}, {Id: "ACT_1", ActionType: utils.MetaLog}}); len(flds) != 1 ||
+		flds["1"] == nil || flds["1"].Old != nil {
+		t.Errorf("expected the second action added, received %s", utils.ToJSON(flds))
+	}
+}
@@ -119,9 +119,10 @@ func (r *Ranking) TenantID() string {
 // asRankingSummary converts the Ranking instance into a RankingSummary one
 func (rk *Ranking) asRankingSummary() (rkSm *RankingSummary) {
 	rkSm = &RankingSummary{
-		Tenant:     rk.Tenant,
-		ID:         rk.ID,
-		LastUpdate: rk.LastUpdate,
+		Tenant:        rk.Tenant,
+		ID:            rk.ID,
+		LastUpdate:    rk.LastUpdate,
+		SortedStatIDs: make([]string, len(rk.SortedStatIDs)),
 	}
 	copy(rkSm.SortedStatIDs, rk.SortedStatIDs)
 	return
@@ -162,6 +162,60 @@ func (sRoutes *SortedRoutes) SortResourceDescendent() {
 	})
 }
 
+// SortRank is part of sort interface,
+// sort ascendent based on the position within the rankings with fallback on Weight
+// routes without a position are sorted after the ranked ones
+func (sRoutes *SortedRoutes) SortRank() {
+	sort.Slice(sRoutes.Routes, func(i, j int) bool {
+		rankI, hasRankI := sRoutes.Routes[i].sortingDataF64[utils.Rank]
+		rankJ, hasRankJ := sRoutes.Routes[j].sortingDataF64[utils.Rank]
+		if hasRankI != hasRankJ {
+			return hasRankI
+		}
+		if rankI == rankJ {
+			if sRoutes.Routes[i].sortingDataF64[utils.Weight] == sRoutes.Routes[j].sortingDataF64[utils.Weight] {
+				return utils.BoolGenerator().RandomBool()
+			}
+			return sRoutes.Routes[i].sortingDataF64[utils.Weight] > sRoutes.Routes[j].sortingDataF64[utils.Weight]
+		}
+		return rankI < rankJ
+	})
+}
+
+// SortTrend is part of sort interface,
+// sort based on the degrading trends of the metrics in params with fallback on Weight
+func (sRoutes *SortedRoutes) SortTrend(params []string) {
+	sort.Slice(sRoutes.Routes, func(i, j int) bool {
+		for _, param := range params {
+			// 1 for the routes with the metric degrading, 0 otherwise
+			degrI := sRoutes.Routes[i].sortingDataF64[param]
+			degrJ := sRoutes.Routes[j].sortingDataF64[param]
+			if degrI != degrJ {
+				return degrI < degrJ
+			}
+		}
+		//in case that the trends are the same for all params we sort base on weight
+		if sRoutes.Routes[i].sortingDataF64[utils.Weight] == sRoutes.Routes[j].sortingDataF64[utils.Weight] {
+			return utils.BoolGenerator().RandomBool()
+		}
+		return sRoutes.Routes[i].sortingDataF64[utils.Weight] > sRoutes.Routes[j].sortingDataF64[utils.Weight]
+	})
+}
+
+// SortScore is part of sort interface,
+// sort descendent based on Score with fallback on Weight
+func (sRoutes *SortedRoutes) SortScore() {
+	sort.Slice(sRoutes.Routes, func(i, j int) bool {
+		if sRoutes.Routes[i].sortingDataF64[utils.Score] == sRoutes.Routes[j].sortingDataF64[utils.Score] {
+			if sRoutes.Routes[i].sortingDataF64[utils.Weight] == sRoutes.Routes[j].sortingDataF64[utils.Weight] {
+				return utils.BoolGenerator().RandomBool()
+			}
+			return sRoutes.Routes[i].sortingDataF64[utils.Weight] > sRoutes.Routes[j].sortingDataF64[utils.Weight]
+		}
+		return sRoutes.Routes[i].sortingDataF64[utils.Score] > sRoutes.Routes[j].sortingDataF64[utils.Score]
+	})
+}
+
 // SortLoadDistribution is part of sort interface,
 // sort based on the following formula float64(metricVal/ratio) with fallback on Weight
 func (sRoutes *SortedRoutes) SortLoadDistribution() {
@@ -232,6 +286,9 @@ func NewRouteSortDispatcher(lcrS *RouteService) (rsd RouteSortDispatcher) {
 	rsd[utils.MetaReas] = NewResourceAscendetSorter(lcrS)
 	rsd[utils.MetaReds] = NewResourceDescendentSorter(lcrS)
 	rsd[utils.MetaLoad] = NewLoadDistributionSorter(lcrS)
+	rsd[utils.MetaRank] = NewRankRouteSorter(lcrS)
+	rsd[utils.MetaTrend] = NewTrendRouteSorter(lcrS)
+	rsd[utils.MetaScore] = NewScoreRouteSorter(lcrS)
 	return
 }
 
@@ -21,6 +21,7 @@ package engine
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"strconv"
@@ -1746,3 +1747,161 @@ func TestRouteIDs(t *testing.T) {
 
 	}
 }
+
+func TestLibRoutesSortRank(t *testing.T) {
+	sSpls := &SortedRoutes{
+		Routes: []*SortedRoute{
+			{
+				RouteID: "UNRANKED_LOW",
+				sortingDataF64: map[string]float64{
+					utils.Weight: 10.0,
+				},
+			},
+			{
+				RouteID: "RANK2",
+				sortingDataF64: map[string]float64{
+					utils.Rank:   2.0,
+					utils.Weight: 50.0,
+				},
+			},
+			{
+				RouteID: "UNRANKED_HIGH",
+				sortingDataF64: map[string]float64{
+					utils.Weight: 100.0,
+				},
+			},
+			{
+				RouteID: "RANK1",
+				sortingDataF64: map[string]float64{
+					utils.Rank:   1.0,
+					utils.Weight: 5.0,
+				},
+			},
+			{
+				RouteID: "RANK2_HIGH",
+				sortingDataF64: map[string]float64{
+					utils.Rank:   2.0,
+					utils.Weight: 60.0,
+				},
+			},
+		},
+	}
+	sSpls.SortRank()
+	exp := []string{"RANK1", "RANK2_HIGH", "RANK2", "UNRANKED_HIGH", "UNRANKED_LOW"}
+	if rcv := sSpls.RouteIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+}
+
+func TestLibRoutesSortTrend(t *testing.T) {
+	sSpls := &SortedRoutes{
+		Routes: []*SortedRoute{
+			{
+				RouteID: "ASR_DOWN",
+				sortingDataF64: map[string]float64{
+					utils.MetaASR: 1,
+					utils.MetaACD: 0,
+					utils.Weight:  50.0,
+				},
+			},
+			{
+				RouteID: "ACD_DOWN",
+				sortingDataF64: map[string]float64{
+					utils.MetaASR: 0,
+					utils.MetaACD: 1,
+					utils.Weight:  40.0,
+				},
+			},
+			{
+				RouteID: "STABLE_LOW",
+				sortingDataF64: map[string]float64{
+					utils.MetaASR: 0,
+					utils.MetaACD: 0,
+					utils.Weight:  10.0,
+				},
+			},
+			{
+				RouteID: "STABLE_HIGH",
+				sortingDataF64: map[string]float64{
+					utils.MetaASR: 0,
+					utils.MetaACD: 0,
+					utils.Weight:  20.0,
+				},
+			},
+		},
+	}
+	sSpls.SortTrend([]string{utils.MetaASR, utils.MetaACD})
+	exp := []string{"STABLE_HIGH", "STABLE_LOW", "ACD_DOWN", "ASR_DOWN"}
+	if rcv := sSpls.RouteIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+}
+
+func TestLibRoutesSortScore(t *testing.T) {
+	sSpls := &SortedRoutes{
+		Routes: []*SortedRoute{
+			{
+				RouteID:     "CHEAP_BAD",
+				SortingData: map[string]any{},
+				sortingDataF64: map[string]float64{
+					utils.Cost:    0.1,
+					utils.MetaASR: 20,
+					utils.Weight:  10,
+				},
+			},
+			{
+				RouteID:     "EXPENSIVE_GOOD",
+				SortingData: map[string]any{},
+				sortingDataF64: map[string]float64{
+					utils.Cost:    0.3,
+					utils.MetaASR: 80,
+					utils.Weight:  10,
+				},
+			},
+			{
+				RouteID:     "BALANCED",
+				SortingData: map[string]any{},
+				sortingDataF64: map[string]float64{
+					utils.Cost:    0.15,
+					utils.MetaASR: 70,
+					utils.Weight:  10,
+				},
+			},
+			{
+				RouteID:     "NO_STATS",
+				SortingData: map[string]any{},
+				sortingDataF64: map[string]float64{
+					utils.Cost:   0.1,
+					utils.Weight: 20,
+				},
+			},
+			{
+				RouteID:     "NA_STATS",
+				SortingData: map[string]any{},
+				sortingDataF64: map[string]float64{
+					utils.Cost:    0.1,
+					utils.MetaASR: utils.StatsNA,
+					utils.Weight:  15,
+				},
+			},
+		},
+	}
+	scoreRoutes(sSpls.Routes, []string{utils.Cost, utils.MetaASR}, []float64{1, 2})
+	expScores := map[string]float64{
+		"CHEAP_BAD":      1,
+		"EXPENSIVE_GOOD": 2,
+		"BALANCED":       0.75 + 2*50.0/60,
+		"NO_STATS":       1,
+		"NA_STATS":       1,
+	}
+	for _, route := range sSpls.Routes {
+		if score := route.SortingData[utils.Score].(float64); math.Abs(score-expScores[route.RouteID]) > 1e-9 {
+			t.Errorf("Expected score %v for %s, received %v", expScores[route.RouteID], route.RouteID, score)
+		}
+	}
+	sSpls.SortScore()
+	exp := []string{"BALANCED", "EXPENSIVE_GOOD", "NO_STATS", "NA_STATS", "CHEAP_BAD"}
+	if rcv := sSpls.RouteIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+}
@@ -460,6 +460,14 @@ func (sq *StatQueue) UnmarshalJSON(data []byte) (err error) {
 			metric = new(StatAverage)
 		case utils.MetaDistinct:
 			metric = new(StatDistinct)
+		case utils.MetaP50, utils.MetaP90, utils.MetaP95, utils.MetaP99:
+			metric = new(StatPercentile)
+		case utils.MetaHistogram:
+			metric = new(StatHistogram)
+		case utils.MetaStdDev:
+			metric = new(StatStdDev)
+		case utils.MetaRate:
+			metric = new(StatRate)
 		default:
 			return fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
 		}
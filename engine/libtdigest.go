@@ -0,0 +1,141 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"math"
+	"sort"
+)
+
+// tDigestCompression is the default compression used by the percentile metrics
+const tDigestCompression = 100
+
+// NewTDigest returns an empty TDigest with the given compression
+func NewTDigest(compression float64) *TDigest {
+	return &TDigest{Compression: compression}
+}
+
+// TDigestCentroid is a cluster of values inside the TDigest
+type TDigestCentroid struct {
+	Mean  float64
+	Count float64
+}
+
+// TDigest is a mergeable sketch used to estimate quantiles over a stream of values
+type TDigest struct {
+	Compression float64
+	Count       float64
+	Centroids   []*TDigestCentroid // sorted by Mean after compress
+}
+
+// Add inserts the value with the given weight
+func (td *TDigest) Add(val, weight float64) {
+	if weight <= 0 {
+		return
+	}
+	td.Centroids = append(td.Centroids, &TDigestCentroid{Mean: val, Count: weight})
+	td.Count += weight
+	if float64(len(td.Centroids)) > 10*td.Compression {
+		td.compress()
+	}
+}
+
+// Remove takes the weight out of the centroids closest to the value
+// the result is an approximation once the value was merged into a larger centroid
+func (td *TDigest) Remove(val, weight float64) {
+	for weight > 0 && len(td.Centroids) != 0 {
+		idx := 0
+		for i, c := range td.Centroids {
+			if math.Abs(c.Mean-val) < math.Abs(td.Centroids[idx].Mean-val) {
+				idx = i
+			}
+		}
+		c := td.Centroids[idx]
+		rem := math.Min(weight, c.Count)
+		c.Count -= rem
+		td.Count -= rem
+		weight -= rem
+		if c.Count <= 0 {
+			td.Centroids = append(td.Centroids[:idx], td.Centroids[idx+1:]...)
+		}
+	}
+	if len(td.Centroids) == 0 {
+		td.Count = 0
+	}
+}
+
+// Merge adds the centroids of the other TDigest to this one
+func (td *TDigest) Merge(other *TDigest) {
+	for _, c := range other.Centroids {
+		td.Centroids = append(td.Centroids, &TDigestCentroid{Mean: c.Mean, Count: c.Count})
+		td.Count += c.Count
+	}
+	td.compress()
+}
+
+// Quantile returns the estimated value at quantile q (0 <= q <= 1)
+func (td *TDigest) Quantile(q float64) float64 {
+	if len(td.Centroids) == 0 {
+		return math.NaN()
+	}
+	td.compress()
+	if len(td.Centroids) == 1 {
+		return td.Centroids[0].Mean
+	}
+	idx := q * td.Count
+	var cum float64 // weight before the current centroid
+	for i, c := range td.Centroids {
+		mid := cum + c.Count/2
+		if idx < mid {
+			if i == 0 {
+				return c.Mean
+			}
+			prev := td.Centroids[i-1]
+			prevMid := cum - prev.Count/2
+			return prev.Mean + (idx-prevMid)/(mid-prevMid)*(c.Mean-prev.Mean)
+		}
+		cum += c.Count
+	}
+	return td.Centroids[len(td.Centroids)-1].Mean
+}
+
+// compress sorts the centroids and merges the neighbours while they
+// stay under the size limit given by their position in the distribution
+func (td *TDigest) compress() {
+	if len(td.Centroids) < 2 {
+		return
+	}
+	sort.Slice(td.Centroids, func(i, j int) bool {
+		return td.Centroids[i].Mean < td.Centroids[j].Mean
+	})
+	merged := []*TDigestCentroid{{Mean: td.Centroids[0].Mean, Count: td.Centroids[0].Count}}
+	var soFar float64 // weight before the last merged centroid
+	for _, c := range td.Centroids[1:] {
+		last := merged[len(merged)-1]
+		q := (soFar + (last.Count+c.Count)/2) / td.Count
+		if last.Count+c.Count <= 4*td.Count*q*(1-q)/td.Compression {
+			last.Mean += (c.Mean - last.Mean) * c.Count / (last.Count + c.Count)
+			last.Count += c.Count
+			continue
+		}
+		soFar += last.Count
+		merged = append(merged, &TDigestCentroid{Mean: c.Mean, Count: c.Count})
+	}
+	td.Centroids = merged
+}
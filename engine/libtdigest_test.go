@@ -0,0 +1,76 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTDigestQuantile(t *testing.T) {
+	td := NewTDigest(tDigestCompression)
+	if v := td.Quantile(0.5); !math.IsNaN(v) {
+		t.Errorf("Expected NaN, received: %v", v)
+	}
+	for i := 1; i <= 100000; i++ {
+		td.Add(float64(i), 1)
+	}
+	if len(td.Centroids) > 10*tDigestCompression {
+		t.Errorf("digest not compressed, centroids: %d", len(td.Centroids))
+	}
+	for _, q := range []float64{0.5, 0.9, 0.95, 0.99, 0.999} {
+		if v := td.Quantile(q); math.Abs(v-q*100000) > 100 {
+			t.Errorf("quantile %v: expected around %v, received: %v", q, q*100000, v)
+		}
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	td1 := NewTDigest(tDigestCompression)
+	td2 := NewTDigest(tDigestCompression)
+	for i := 1; i <= 1000; i++ {
+		if i%2 == 0 {
+			td1.Add(float64(i), 1)
+		} else {
+			td2.Add(float64(i), 1)
+		}
+	}
+	td1.Merge(td2)
+	if td1.Count != 1000 {
+		t.Errorf("Expected 1000, received: %v", td1.Count)
+	}
+	if v := td1.Quantile(0.95); math.Abs(v-950) > 5 {
+		t.Errorf("Expected around 950, received: %v", v)
+	}
+}
+
+func TestTDigestRemove(t *testing.T) {
+	td := NewTDigest(tDigestCompression)
+	td.Add(1, 1)
+	td.Add(2, 2)
+	td.Add(10, 1)
+	td.Remove(10, 1)
+	if v := td.Quantile(1); v != 2 {
+		t.Errorf("Expected 2, received: %v", v)
+	}
+	td.Remove(2, 5)
+	if td.Count != 0 || len(td.Centroids) != 0 {
+		t.Errorf("Expected empty digest, received: %+v", td)
+	}
+}
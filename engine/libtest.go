@@ -304,6 +304,7 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.MetaSentryPeer:               {},
 		utils.CacheCapsEvents:              {},
 		utils.CacheReplicationHosts:        {},
+		utils.CacheSIPRegistrations:        {},
 		utils.CacheRadiusPackets:           {},
 	}
 }
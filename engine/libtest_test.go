@@ -84,6 +84,7 @@ func TestGetDefaultEmptyCacheStats(t *testing.T) {
 		utils.MetaSentryPeer,
 		utils.CacheCapsEvents,
 		utils.CacheReplicationHosts,
+		utils.CacheSIPRegistrations,
 		utils.CacheRadiusPackets,
 	}
 
@@ -0,0 +1,287 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	gocontext "context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the API spans
+const tracerName = "github.com/cgrates/cgrates"
+
+var (
+	// tracingEnabled is set once a TracerProvider is configured
+	tracingEnabled atomic.Bool
+
+	// tracePropagator encodes the span context as W3C trace context
+	tracePropagator = propagation.TraceContext{}
+)
+
+// TracingEnabled returns true if the API calls are traced
+func TracingEnabled() bool {
+	return tracingEnabled.Load()
+}
+
+// NewTracerProvider creates the TracerProvider based on the tracing config
+func NewTracerProvider(trCfg *config.TracingCfg, nodeID string) (tp *sdktrace.TracerProvider, err error) {
+	var exp sdktrace.SpanExporter
+	switch trCfg.Exporter {
+	case utils.MetaOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(trCfg.Endpoint)}
+		if trCfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		// the client connects lazily so the engine can start before the collector
+		exp, err = otlptracegrpc.New(gocontext.Background(), opts...)
+	case utils.MetaStdLog:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case utils.MetaFile:
+		var f io.Writer
+		if f, err = os.OpenFile(trCfg.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+			return
+		}
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		err = fmt.Errorf("unsupported tracing exporter: <%s>", trCfg.Exporter)
+	}
+	if err != nil {
+		return
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(trCfg.SamplerRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", utils.CGRateS),
+			attribute.String("service.instance.id", nodeID))),
+	), nil
+}
+
+// InitTracing starts tracing the API calls if enabled in config
+// the returned function flushes the spans and stops the exporter
+func InitTracing(cfg *config.CGRConfig) (shutdown func(), err error) {
+	shutdown = func() {}
+	if !cfg.CoreSCfg().Tracing.Enabled {
+		return
+	}
+	var tp *sdktrace.TracerProvider
+	if tp, err = NewTracerProvider(cfg.CoreSCfg().Tracing, cfg.GeneralCfg().NodeID); err != nil {
+		return
+	}
+	SetTracerProvider(tp)
+	shutdown = func() {
+		SetTracerProvider(nil)
+		if err := tp.Shutdown(gocontext.Background()); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed stopping the tracing exporter: %s",
+				utils.CoreS, err.Error()))
+		}
+	}
+	return
+}
+
+// SetTracerProvider changes the provider of the API spans, nil disables tracing
+func SetTracerProvider(tp trace.TracerProvider) {
+	tracingEnabled.Store(tp != nil)
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+	otel.SetTracerProvider(tp)
+}
+
+// StartRPCSpan starts the span of an API call
+// the parent is taken from ctx or, if missing, from the APIOpts of the arguments
+func StartRPCSpan(ctx *context.Context, method string, args any, kind trace.SpanKind) (*context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	parent := ctx.Context
+	if !trace.SpanContextFromContext(parent).IsValid() {
+		if opts := apiOptsFromArgs(args); opts != nil {
+			parent = tracePropagator.Extract(parent, apiOptsCarrier(opts))
+		}
+	}
+	srvName, mthdName, _ := strings.Cut(method, utils.NestingSep)
+	spanCtx, span := otel.Tracer(tracerName).Start(parent, method,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(
+			attribute.String("rpc.system", utils.CGRateSLwr),
+			attribute.String("rpc.service", srvName),
+			attribute.String("rpc.method", mthdName)))
+	return &context.Context{Context: spanCtx, Client: ctx.Client}, span
+}
+
+// InjectRPCSpan writes the span context of ctx in the APIOpts of the arguments
+// so it follows them to the next hop, creating the APIOpts if nil. Meant for
+// arguments not shared with anyone else, ie: decoded for the request being served
+func InjectRPCSpan(ctx *context.Context, args any) {
+	fld := apiOptsField(args)
+	if !fld.IsValid() || !fld.CanInterface() {
+		return
+	}
+	if fld.IsNil() {
+		if !fld.CanSet() {
+			return
+		}
+		fld.Set(reflect.ValueOf(make(map[string]any)))
+	}
+	tracePropagator.Inject(ctx, apiOptsCarrier(fld.Interface().(map[string]any)))
+}
+
+// EndRPCSpan ends the span of an API call recording the error if any
+func EndRPCSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// startConnSpan starts the client span of a ConnManager call
+// the returned arguments are a copy of args carrying the span context in their
+// APIOpts, the ones of the caller being left untouched since they can be shared
+// with concurrent calls (ie: *internal connections or broadcast pools)
+func startConnSpan(ctx *context.Context, connIDs []string, method string, args any) (*context.Context, any, func(error)) {
+	ctx, span := StartRPCSpan(ctx, method, args, trace.SpanKindClient)
+	span.SetAttributes(attribute.StringSlice("cgr.conn_ids", connIDs))
+	opts := maps.Clone(apiOptsFromArgs(args))
+	if opts == nil {
+		opts = make(map[string]any)
+	}
+	tracePropagator.Inject(ctx, apiOptsCarrier(opts))
+	if spanArgs, ok := withAPIOpts(args, opts); ok {
+		args = spanArgs
+	}
+	return ctx, args, func(err error) { EndRPCSpan(span, err) }
+}
+
+// apiOptsCarrier adapts the APIOpts to the W3C propagator
+// the trace context headers are stored with the * prefix (e.g. *traceparent)
+type apiOptsCarrier map[string]any
+
+func (c apiOptsCarrier) Get(key string) string {
+	if val, has := c[utils.Meta+key]; has {
+		return utils.IfaceAsString(val)
+	}
+	return utils.EmptyString
+}
+
+func (c apiOptsCarrier) Set(key, val string) {
+	c[utils.Meta+key] = val
+}
+
+func (c apiOptsCarrier) Keys() (keys []string) {
+	for key := range c {
+		if trimmed, has := strings.CutPrefix(key, utils.Meta); has {
+			keys = append(keys, trimmed)
+		}
+	}
+	return
+}
+
+// apiOptsField returns the APIOpts field of the API arguments, invalid if missing
+func apiOptsField(args any) (fld reflect.Value) {
+	v := reflect.ValueOf(args)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	sf, has := v.Type().FieldByName("APIOpts")
+	if !has || sf.Type != reflect.TypeOf(map[string]any(nil)) {
+		return
+	}
+	fld, _ = v.FieldByIndexErr(sf.Index)
+	return
+}
+
+// apiOptsFromArgs returns the APIOpts of the API arguments, nil if missing
+func apiOptsFromArgs(args any) map[string]any {
+	if fld := apiOptsField(args); fld.IsValid() && fld.CanInterface() {
+		return fld.Interface().(map[string]any)
+	}
+	return nil
+}
+
+// withAPIOpts returns a shallow copy of the API arguments having their APIOpts
+// replaced by opts, the structs embedded by pointer on the way to the APIOpts
+// being copied too so the arguments passed in stay untouched
+func withAPIOpts(args any, opts map[string]any) (_ any, ok bool) {
+	v := reflect.ValueOf(args)
+	byPtr := v.Kind() == reflect.Pointer
+	if byPtr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	sf, has := v.Type().FieldByName("APIOpts")
+	if !has || sf.Type != reflect.TypeOf(opts) {
+		return
+	}
+	cp := reflect.New(v.Type())
+	cp.Elem().Set(v)
+	fld := cp.Elem()
+	for _, idx := range sf.Index {
+		if fld.Kind() == reflect.Pointer { // embedded by pointer
+			if fld.IsNil() || !fld.CanSet() {
+				return
+			}
+			elem := reflect.New(fld.Type().Elem())
+			elem.Elem().Set(fld.Elem())
+			fld.Set(elem)
+			fld = elem.Elem()
+		}
+		fld = fld.Field(idx)
+	}
+	if !fld.CanSet() {
+		return
+	}
+	fld.Set(reflect.ValueOf(opts))
+	if byPtr {
+		return cp.Interface(), true
+	}
+	return cp.Elem().Interface(), true
+}
@@ -0,0 +1,146 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestAPIOptsFromArgs(t *testing.T) {
+	ev := &utils.CGREvent{Tenant: "cgrates.org"}
+	if opts := apiOptsFromArgs(ev); opts != nil || ev.APIOpts != nil {
+		t.Errorf("expected the nil APIOpts to be left nil, received: %v", opts)
+	}
+	ev.APIOpts = map[string]any{utils.OptsAPIKey: "key"}
+	if opts := apiOptsFromArgs(utils.CGREvent{APIOpts: ev.APIOpts}); opts[utils.OptsAPIKey] != "key" {
+		t.Errorf("expected the APIOpts of the event, received: %v", opts)
+	}
+	if opts := apiOptsFromArgs(&CGREventWithEeIDs{}); opts != nil {
+		t.Errorf("expected nil APIOpts for nil embedded event, received: %v", opts)
+	}
+	if opts := apiOptsFromArgs(&utils.TenantID{}); opts != nil {
+		t.Errorf("expected nil APIOpts for arguments without APIOpts, received: %v", opts)
+	}
+}
+
+func TestWithAPIOpts(t *testing.T) {
+	ev := &CGREventWithEeIDs{
+		EeIDs: []string{"ee1"},
+		CGREvent: &utils.CGREvent{
+			Tenant:  "cgrates.org",
+			APIOpts: map[string]any{utils.OptsAPIKey: "key"},
+		},
+	}
+	opts := map[string]any{utils.OptsAPIKey: "key", utils.OptsTraceParent: "tp"}
+	cp, ok := withAPIOpts(ev, opts)
+	if !ok {
+		t.Fatal("expected a copy of the arguments")
+	}
+	evCp := cp.(*CGREventWithEeIDs)
+	if evCp == ev || evCp.CGREvent == ev.CGREvent {
+		t.Error("expected the embedded event to be copied")
+	}
+	if evCp.Tenant != "cgrates.org" || !reflect.DeepEqual(evCp.EeIDs, ev.EeIDs) ||
+		!reflect.DeepEqual(evCp.APIOpts, opts) {
+		t.Errorf("unexpected copy: %s", utils.ToJSON(evCp))
+	}
+	if len(ev.APIOpts) != 1 {
+		t.Errorf("expected the APIOpts of the arguments untouched, received: %v", ev.APIOpts)
+	}
+	if cp, ok = withAPIOpts(utils.CGREvent{}, opts); !ok ||
+		!reflect.DeepEqual(cp.(utils.CGREvent).APIOpts, opts) {
+		t.Errorf("unexpected copy of the arguments passed by value: %v", cp)
+	}
+	if _, ok = withAPIOpts(&CGREventWithEeIDs{}, opts); ok {
+		t.Error("expected no copy for nil embedded event")
+	}
+	if _, ok = withAPIOpts(&utils.TenantID{}, opts); ok {
+		t.Error("expected no copy for arguments without APIOpts")
+	}
+}
+
+func TestCMCallTracing(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+	defer SetTracerProvider(nil)
+
+	Cache.Clear(nil)
+	defer Cache.Clear(nil)
+	cM := &ConnManager{cfg: config.NewDefaultCGRConfig()}
+	var traceParent any
+	Cache.SetWithoutReplicate(utils.CacheRPCConnections, "conn1", &ccMock{
+		calls: map[string]func(ctx *context.Context, args any, reply any) error{
+			utils.ChargerSv1ProcessEvent: func(_ *context.Context, args, reply any) error {
+				return cM.Call(context.TODO(), []string{"conn2"}, utils.AttributeSv1ProcessEvent, args, reply)
+			},
+		},
+	}, nil, true, utils.NonTransactional)
+	Cache.SetWithoutReplicate(utils.CacheRPCConnections, "conn2", &ccMock{
+		calls: map[string]func(ctx *context.Context, args any, reply any) error{
+			utils.AttributeSv1ProcessEvent: func(_ *context.Context, args, _ any) error {
+				traceParent = args.(*utils.CGREvent).APIOpts[utils.OptsTraceParent]
+				return utils.ErrNotFound
+			},
+		},
+	}, nil, true, utils.NonTransactional)
+
+	remoteParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ev := &utils.CGREvent{
+		Tenant:  "cgrates.org",
+		APIOpts: map[string]any{utils.OptsTraceParent: remoteParent},
+	}
+	var reply string
+	if err := cM.Call(context.TODO(), []string{"conn1"}, utils.ChargerSv1ProcessEvent, ev, &reply); err != utils.ErrNotFound {
+		t.Fatalf("expected error: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if len(ev.APIOpts) != 1 || ev.APIOpts[utils.OptsTraceParent] != remoteParent {
+		t.Errorf("expected the APIOpts of the caller untouched, received: %v", ev.APIOpts)
+	}
+	spans := exp.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, received: %d", len(spans))
+	}
+	inner, outer := spans[0], spans[1]
+	if outer.Name != utils.ChargerSv1ProcessEvent ||
+		inner.Name != utils.AttributeSv1ProcessEvent {
+		t.Errorf("unexpected span names: <%s> <%s>", outer.Name, inner.Name)
+	}
+	if outer.Parent.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" ||
+		outer.Parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("expected the remote parent, received: %+v", outer.Parent)
+	}
+	if inner.Parent.SpanID() != outer.SpanContext.SpanID() ||
+		inner.SpanContext.TraceID() != outer.SpanContext.TraceID() {
+		t.Errorf("expected <%s> to be the child of <%s>", inner.Name, outer.Name)
+	}
+	if expTP := "00-" + inner.SpanContext.TraceID().String() + "-" +
+		inner.SpanContext.SpanID().String() + "-01"; traceParent != expTP {
+		t.Errorf("expected traceparent: %q, received: %q", expTP, traceParent)
+	}
+	if inner.Status.Description != utils.ErrNotFound.Error() {
+		t.Errorf("expected the error in the span status, received: %+v", inner.Status)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// liveFeedBufferSize is the number of events queued for a slow subscriber before dropping
+const liveFeedBufferSize = 256
+
+// liveFeedQueueSize is the number of published events waiting to be evaluated before dropping
+const liveFeedQueueSize = 1024
+
+// LiveFeedSubscription selects the events pushed to a subscriber
+type LiveFeedSubscription struct {
+	Tenant     string
+	FilterIDs  []string // same syntax as the FilterS ones, checked against *req and *opts
+	EventTypes []string // <*session_init|*session_update|*session_terminate|*cdr|*threshold_hit|*account_update>, empty for all
+}
+
+// LiveFeedEvent is the event pushed to the subscribers
+type LiveFeedEvent struct {
+	Type   string
+	Tenant string
+	ID     string
+	Time   time.Time
+	Event  map[string]any
+	Opts   map[string]any
+}
+
+// NewLiveFeed returns a LiveFeed without subscribers
+// the published events are pushed to the subscribers once ListenAndServe is started
+func NewLiveFeed(cfg *config.CGRConfig, fltrS *FilterS) *LiveFeed {
+	return &LiveFeed{
+		cfg:     cfg,
+		fltrS:   fltrS,
+		subs:    make(map[uint64]*liveFeedSubscriber),
+		evQueue: make(chan *LiveFeedEvent, liveFeedQueueSize),
+	}
+}
+
+// LiveFeed pushes the engine events to the subscribers matching them
+type LiveFeed struct {
+	sync.RWMutex
+	cfg     *config.CGRConfig
+	fltrS   *FilterS
+	subs    map[uint64]*liveFeedSubscriber
+	subIdx  uint64
+	evQueue chan *LiveFeedEvent // published events waiting to be evaluated
+}
+
+type liveFeedSubscriber struct {
+	tenant    string
+	filterIDs []string
+	evTypes   utils.StringSet
+	evChan    chan []byte // JSON encoded LiveFeedEvent
+}
+
+// Subscribe registers a new subscriber
+// the events are received JSON encoded on the returned channel until Unsubscribe is called
+func (lf *LiveFeed) Subscribe(sub *LiveFeedSubscription) (subID uint64, evChan <-chan []byte, err error) {
+	tnt := sub.Tenant
+	if tnt == utils.EmptyString {
+		tnt = lf.cfg.GeneralCfg().DefaultTenant
+	}
+	for _, evType := range sub.EventTypes {
+		if !utils.LiveFeedEventTypes.Has(evType) {
+			return 0, nil, fmt.Errorf("unsupported event type: <%s>", evType)
+		}
+	}
+	// check the filters before accepting the subscription
+	if _, err = lf.fltrS.Pass(tnt, sub.FilterIDs, utils.MapStorage{}); err != nil {
+		return
+	}
+	s := &liveFeedSubscriber{
+		tenant:    tnt,
+		filterIDs: sub.FilterIDs,
+		evTypes:   utils.NewStringSet(sub.EventTypes),
+		evChan:    make(chan []byte, liveFeedBufferSize),
+	}
+	lf.Lock()
+	lf.subIdx++
+	subID = lf.subIdx
+	lf.subs[subID] = s
+	lf.Unlock()
+	return subID, s.evChan, nil
+}
+
+// Unsubscribe removes the subscriber and closes its channel
+func (lf *LiveFeed) Unsubscribe(subID uint64) {
+	lf.Lock()
+	if s, has := lf.subs[subID]; has {
+		delete(lf.subs, subID)
+		close(s.evChan)
+	}
+	lf.Unlock()
+}
+
+// Publish queues the event for the matching subscribers without blocking
+// the top level of the event is copied so the caller can modify it afterwards
+func (lf *LiveFeed) Publish(evType string, cgrEv *utils.CGREvent) {
+	lf.RLock()
+	noSubs := len(lf.subs) == 0
+	lf.RUnlock()
+	if noSubs {
+		return
+	}
+	tnt := cgrEv.Tenant
+	if tnt == utils.EmptyString {
+		tnt = lf.cfg.GeneralCfg().DefaultTenant
+	}
+	select {
+	case lf.evQueue <- &LiveFeedEvent{
+		Type:   evType,
+		Tenant: tnt,
+		ID:     cgrEv.ID,
+		Time:   time.Now(),
+		Event:  maps.Clone(cgrEv.Event),
+		Opts:   maps.Clone(cgrEv.APIOpts),
+	}:
+	default:
+		utils.Logger.Warning(fmt.Sprintf("<%s> dropped %s event: %s, the live feed queue is full",
+			utils.CoreS, evType, cgrEv.ID))
+	}
+}
+
+// ListenAndServe pushes the published events to the matching subscribers until stopChan is closed
+func (lf *LiveFeed) ListenAndServe(stopChan <-chan struct{}) {
+	for {
+		select {
+		case <-stopChan:
+			return
+		case ev := <-lf.evQueue:
+			lf.push(ev)
+		}
+	}
+}
+
+// push evaluates the subscribers against the event and sends it encoded to the matching ones
+func (lf *LiveFeed) push(ev *LiveFeedEvent) {
+	dP := utils.MapStorage{
+		utils.MetaReq:  ev.Event,
+		utils.MetaOpts: ev.Opts,
+	}
+	lf.RLock()
+	defer lf.RUnlock()
+	var evJSON []byte
+	for subID, s := range lf.subs {
+		if s.tenant != ev.Tenant ||
+			(s.evTypes.Size() != 0 && !s.evTypes.Has(ev.Type)) {
+			continue
+		}
+		if pass, err := lf.fltrS.Pass(ev.Tenant, s.filterIDs, dP); err != nil || !pass {
+			continue
+		}
+		if evJSON == nil {
+			var err error
+			if evJSON, err = json.Marshal(ev); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> failed encoding %s event: %s, error: %s",
+					utils.CoreS, ev.Type, ev.ID, err.Error()))
+				return
+			}
+		}
+		select {
+		case s.evChan <- evJSON:
+		default:
+			utils.Logger.Warning(fmt.Sprintf("<%s> dropped %s event: %s for slow live feed subscriber: %d",
+				utils.CoreS, ev.Type, ev.ID, subID))
+		}
+	}
+}
+
+// PublishLiveEvent sends the event to the live feed subscribers if the live feed is active
+func PublishLiveEvent(evType string, cgrEv *utils.CGREvent) {
+	if lf := liveFeed.Load(); lf != nil {
+		lf.Publish(evType, cgrEv)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestLiveFeedSubscribeErrors(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	lf := NewLiveFeed(cfg, NewFilterS(cfg, nil, dm))
+
+	expErr := "unsupported event type: <*resource_alloc>"
+	if _, _, err := lf.Subscribe(&LiveFeedSubscription{
+		EventTypes: []string{utils.MetaCDR, "*resource_alloc"},
+	}); err == nil || err.Error() != expErr {
+		t.Errorf("expected error: %s, received: %v", expErr, err)
+	}
+	expErr = "NOT_FOUND:FLTR_MISSING"
+	if _, _, err := lf.Subscribe(&LiveFeedSubscription{
+		FilterIDs: []string{"FLTR_MISSING"},
+	}); err == nil || err.Error() != expErr {
+		t.Errorf("expected error: %s, received: %v", expErr, err)
+	}
+}
+
+func TestLiveFeedPublish(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	lf := NewLiveFeed(cfg, NewFilterS(cfg, nil, dm))
+
+	// no subscribers, nothing to do
+	lf.Publish(utils.MetaCDR, &utils.CGREvent{Tenant: "cgrates.org", ID: "EV0"})
+	if len(lf.evQueue) != 0 {
+		t.Errorf("expected no queued events, received: %d", len(lf.evQueue))
+	}
+
+	id1, evChan1, err := lf.Subscribe(&LiveFeedSubscription{
+		FilterIDs:  []string{"*string:~*req.Account:1001"},
+		EventTypes: []string{utils.MetaSessionInit, utils.MetaSessionTerminate},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id2, evChan2, err := lf.Subscribe(&LiveFeedSubscription{Tenant: "itsyscom.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lf.Publish(utils.MetaSessionInit, &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "EV1",
+		Event:  map[string]any{utils.AccountField: "1002"},
+	})
+	lf.Publish(utils.MetaCDR, &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "EV2",
+		Event:  map[string]any{utils.AccountField: "1001"},
+	})
+	ev3 := &utils.CGREvent{
+		Tenant:  "cgrates.org",
+		ID:      "EV3",
+		Event:   map[string]any{utils.AccountField: "1001"},
+		APIOpts: map[string]any{utils.OptsSessionsTTL: "1s"},
+	}
+	lf.Publish(utils.MetaSessionInit, ev3)
+	ev3.Event[utils.AccountField] = "1002" // changed by the caller after publishing
+	lf.Publish(utils.MetaAccountUpdate, &utils.CGREvent{
+		Tenant: "itsyscom.com",
+		ID:     "EV4",
+	})
+	if len(evChan1) != 0 || len(evChan2) != 0 {
+		t.Fatal("expected the events to be only queued by Publish")
+	}
+	for len(lf.evQueue) != 0 {
+		lf.push(<-lf.evQueue)
+	}
+
+	if len(evChan1) != 1 {
+		t.Fatalf("expected 1 event, received: %d", len(evChan1))
+	}
+	var ev LiveFeedEvent
+	if err = json.Unmarshal(<-evChan1, &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Time.IsZero() {
+		t.Error("expected the event time to be set")
+	}
+	ev.Time = ev.Time.Truncate(0)
+	exp := LiveFeedEvent{
+		Type:   utils.MetaSessionInit,
+		Tenant: "cgrates.org",
+		ID:     "EV3",
+		Time:   ev.Time,
+		Event:  map[string]any{utils.AccountField: "1001"},
+		Opts:   map[string]any{utils.OptsSessionsTTL: "1s"},
+	}
+	if !reflect.DeepEqual(exp, ev) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(ev))
+	}
+	if len(evChan2) != 1 {
+		t.Fatalf("expected 1 event, received: %d", len(evChan2))
+	}
+	if err = json.Unmarshal(<-evChan2, &ev); err != nil {
+		t.Fatal(err)
+	} else if ev.ID != "EV4" || ev.Type != utils.MetaAccountUpdate {
+		t.Errorf("unexpected event: %s", utils.ToJSON(ev))
+	}
+
+	lf.Unsubscribe(id1)
+	if _, open := <-evChan1; open {
+		t.Error("expected the channel to be closed")
+	}
+	lf.Unsubscribe(id2)
+	lf.Unsubscribe(id2) // already removed
+	if len(lf.subs) != 0 {
+		t.Errorf("expected no subscribers, received: %d", len(lf.subs))
+	}
+}
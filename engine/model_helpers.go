@@ -2149,7 +2149,7 @@ func (tps RouteMdls) CSVHeader() (result []string) {
 		utils.Sorting, utils.SortingParameters, utils.RouteID, utils.RouteFilterIDs,
 		utils.RouteAccountIDs, utils.RouteRatingplanIDs, utils.RouteResourceIDs,
 		utils.RouteStatIDs, utils.RouteWeight, utils.RouteBlocker,
-		utils.RouteParameters, utils.Weight,
+		utils.RouteParameters, utils.Weight, utils.RouteRankingIDs, utils.RouteTrendIDs,
 	}
 }
 
@@ -2203,6 +2203,14 @@ func (tps RouteMdls) AsTPRouteProfile() (result []*utils.TPRouteProfile) {
 				statSplit := strings.Split(tp.RouteStatIDs, utils.InfieldSep)
 				sup.StatIDs = append(sup.StatIDs, statSplit...)
 			}
+			if tp.RouteRankingIDs != utils.EmptyString {
+				rankSplit := strings.Split(tp.RouteRankingIDs, utils.InfieldSep)
+				sup.RankingIDs = append(sup.RankingIDs, rankSplit...)
+			}
+			if tp.RouteTrendIDs != utils.EmptyString {
+				trendSplit := strings.Split(tp.RouteTrendIDs, utils.InfieldSep)
+				sup.TrendIDs = append(sup.TrendIDs, trendSplit...)
+			}
 			if tp.RouteAccountIDs != utils.EmptyString {
 				accSplit := strings.Split(tp.RouteAccountIDs, utils.InfieldSep)
 				sup.AccountIDs = append(sup.AccountIDs, accSplit...)
@@ -2318,6 +2326,18 @@ func APItoModelTPRoutes(st *utils.TPRouteProfile) (mdls RouteMdls) {
 			}
 			mdl.RouteStatIDs += val
 		}
+		for i, val := range supl.RankingIDs {
+			if i != 0 {
+				mdl.RouteRankingIDs += utils.InfieldSep
+			}
+			mdl.RouteRankingIDs += val
+		}
+		for i, val := range supl.TrendIDs {
+			if i != 0 {
+				mdl.RouteTrendIDs += utils.InfieldSep
+			}
+			mdl.RouteTrendIDs += val
+		}
 		mdl.RouteWeight = supl.Weight
 		mdl.RouteParameters = supl.RouteParameters
 		mdl.RouteBlocker = supl.Blocker
@@ -2355,6 +2375,8 @@ func APItoRouteProfile(tpRp *utils.TPRouteProfile, timezone string) (rp *RoutePr
 			FilterIDs:       route.FilterIDs,
 			ResourceIDs:     route.ResourceIDs,
 			StatIDs:         route.StatIDs,
+			RankingIDs:      route.RankingIDs,
+			TrendIDs:        route.TrendIDs,
 			RouteParameters: route.RouteParameters,
 		}
 	}
@@ -2381,6 +2403,8 @@ func RouteProfileToAPI(rp *RouteProfile) (tpRp *utils.TPRouteProfile) {
 			RatingPlanIDs:   route.RatingPlanIDs,
 			ResourceIDs:     route.ResourceIDs,
 			StatIDs:         route.StatIDs,
+			RankingIDs:      route.RankingIDs,
+			TrendIDs:        route.TrendIDs,
 			Weight:          route.Weight,
 			Blocker:         route.Blocker,
 			RouteParameters: route.RouteParameters,
@@ -2891,8 +2891,8 @@ func TestCsvHeader(t *testing.T) {
 		"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.Sorting, utils.SortingParameters, utils.RouteID, utils.RouteFilterIDs,
 		utils.RouteAccountIDs, utils.RouteRatingplanIDs, utils.RouteResourceIDs,
-		utils.RouteStatIDs, utils.RouteWeight, utils.RouteBlocker,
-		utils.RouteParameters, utils.Weight,
+		utils.RouteStatIDs, utils.RouteWeight, utils.RouteBlocker, utils.RouteParameters, utils.Weight,
+		utils.RouteRankingIDs, utils.RouteTrendIDs,
 	}
 	if rcv := tps.CSVHeader(); !reflect.DeepEqual(eOut, rcv) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eOut), utils.ToJSON(rcv))
@@ -4555,6 +4555,8 @@ func TestAPItoModelTPRoutesEmptySlice(t *testing.T) {
 					RatingPlanIDs:   []string{},
 					ResourceIDs:     []string{},
 					StatIDs:         []string{"Stat1", "Stat2"},
+					RankingIDs:      []string{"Rank1", "Rank2"},
+					TrendIDs:        []string{"Trend1", "Trend2"},
 					Weight:          10,
 					Blocker:         false,
 					RouteParameters: "SortingParam1",
@@ -4578,6 +4580,8 @@ func TestAPItoModelTPRoutesEmptySlice(t *testing.T) {
 			RouteRatingplanIDs: "",
 			RouteResourceIDs:   "",
 			RouteStatIDs:       "Stat1;Stat2",
+			RouteRankingIDs:    "Rank1;Rank2",
+			RouteTrendIDs:      "Trend1;Trend2",
 			RouteWeight:        10,
 			RouteBlocker:       false,
 			RouteParameters:    "SortingParam1",
@@ -4622,6 +4626,8 @@ func TestAPItoModelTPRoutesCase2(t *testing.T) {
 				RatingPlanIDs:   []string{"RPL_1", "RPL_2"},
 				ResourceIDs:     []string{"ResGroup1", "ResGroup2"},
 				StatIDs:         []string{"Stat1", "Stat2"},
+				RankingIDs:      []string{"Rank1", "Rank2"},
+				TrendIDs:        []string{"Trend1", "Trend2"},
 				Weight:          10,
 				Blocker:         false,
 				RouteParameters: "SortingParam1",
@@ -4643,6 +4649,8 @@ func TestAPItoModelTPRoutesCase2(t *testing.T) {
 		RouteRatingplanIDs: "RPL_1;RPL_2",
 		RouteResourceIDs:   "ResGroup1;ResGroup2",
 		RouteStatIDs:       "Stat1;Stat2",
+		RouteRankingIDs:    "Rank1;Rank2",
+		RouteTrendIDs:      "Trend1;Trend2",
 		RouteWeight:        10,
 		RouteBlocker:       false,
 		RouteParameters:    "SortingParam1",
@@ -468,6 +468,8 @@ type RouteMdl struct {
 	RouteBlocker       bool    `index:"13" re:".*"`
 	RouteParameters    string  `index:"14" re:".*"`
 	Weight             float64 `index:"15" re:".*"`
+	RouteRankingIDs    string  `index:"16" re:".*"`
+	RouteTrendIDs      string  `index:"17" re:".*"`
 	CreatedAt          time.Time
 }
 
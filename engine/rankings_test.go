@@ -100,6 +100,9 @@ func TestAsRankingSummary(t *testing.T) {
 	if rkSm.LastUpdate != ranking.LastUpdate {
 		t.Errorf("Expected LastUpdate to be '%v', got '%v'", ranking.LastUpdate, rkSm.LastUpdate)
 	}
+	if !reflect.DeepEqual(rkSm.SortedStatIDs, ranking.SortedStatIDs) {
+		t.Errorf("Expected SortedStatIDs to be %v, got %v", ranking.SortedStatIDs, rkSm.SortedStatIDs)
+	}
 
 }
 
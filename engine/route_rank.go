@@ -0,0 +1,57 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewRankRouteSorter(rS *RouteService) *RankRouteSorter {
+	return &RankRouteSorter{rS: rS,
+		sorting: utils.MetaRank}
+}
+
+// RankRouteSorter sorts routes based on their position within the rankings
+type RankRouteSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+func (rk *RankRouteSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting: rk.sorting,
+		Routes:  make([]*SortedRoute, 0)}
+	rankings := make(map[string]*RankingSummary)
+	for _, route := range routes {
+		srtSpl, pass, err := rk.rS.populateSortingData(ev, route, extraOpts)
+		if err != nil {
+			return nil, err
+		} else if !pass || srtSpl == nil {
+			continue
+		}
+		if pos, has := rk.rS.rankingPosition(route, ev.Tenant, rankings); has {
+			srtSpl.SortingData[utils.Rank] = pos
+			srtSpl.sortingDataF64[utils.Rank] = pos
+		}
+		sortedRoutes.Routes = append(sortedRoutes.Routes, srtSpl)
+	}
+	sortedRoutes.SortRank()
+	return
+}
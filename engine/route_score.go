@@ -0,0 +1,111 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewScoreRouteSorter(rS *RouteService) *ScoreRouteSorter {
+	return &ScoreRouteSorter{rS: rS,
+		sorting: utils.MetaScore}
+}
+
+// ScoreRouteSorter sorts routes based on a score combining cost, stats, resource usage and weight
+type ScoreRouteSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+func (sc *ScoreRouteSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	// sortingParameters are defined as Criterion with optional :Weight, 1 if missing
+	criteria := make([]string, len(extraOpts.sortingParameters))
+	weights := make([]float64, len(extraOpts.sortingParameters))
+	for i, param := range extraOpts.sortingParameters {
+		critWithWeight := strings.Split(param, utils.InInFieldSep)
+		criteria[i] = critWithWeight[0]
+		weights[i] = 1
+		if len(critWithWeight) == 2 {
+			if weights[i], err = strconv.ParseFloat(critWithWeight[1], 64); err != nil {
+				return nil, fmt.Errorf("invalid weight for criterion <%s>: %s", criteria[i], err.Error())
+			}
+		}
+	}
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting: sc.sorting,
+		Routes:  make([]*SortedRoute, 0)}
+	for _, route := range routes {
+		if srtSpl, pass, err := sc.rS.populateSortingData(ev, route, extraOpts); err != nil {
+			return nil, err
+		} else if pass && srtSpl != nil {
+			sortedRoutes.Routes = append(sortedRoutes.Routes, srtSpl)
+		}
+	}
+	scoreRoutes(sortedRoutes.Routes, criteria, weights)
+	sortedRoutes.SortScore()
+	return
+}
+
+// scoreRoutes populates the Score of each route as the weighted sum of its criteria
+// each criterion being normalized between 0 (worst route) and 1 (best route)
+// the routes missing a criterion or having it not available score 0 for it
+func scoreRoutes(routes []*SortedRoute, criteria []string, weights []float64) {
+	scores := make([]float64, len(routes))
+	for i, crit := range criteria {
+		var minVal, maxVal float64
+		var found bool
+		for _, route := range routes {
+			val, has := route.sortingDataF64[crit]
+			if !has || val == utils.StatsNA {
+				continue
+			}
+			if !found || val < minVal {
+				minVal = val
+			}
+			if !found || val > maxVal {
+				maxVal = val
+			}
+			found = true
+		}
+		for j, route := range routes {
+			val, has := route.sortingDataF64[crit]
+			if !has || val == utils.StatsNA { // routes missing the criterion are the worst for it
+				continue
+			}
+			norm := 1.0
+			if maxVal != minVal {
+				norm = (val - minVal) / (maxVal - minVal)
+				switch crit {
+				case utils.Cost, utils.ResourceUsage, utils.MetaPDD: // the smallest value is the best
+					norm = 1 - norm
+				}
+			}
+			scores[j] += weights[i] * norm
+		}
+	}
+	for i, route := range routes {
+		route.SortingData[utils.Score] = scores[i]
+		route.sortingDataF64[utils.Score] = scores[i]
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewTrendRouteSorter(rS *RouteService) *TrendRouteSorter {
+	return &TrendRouteSorter{rS: rS,
+		sorting: utils.MetaTrend}
+}
+
+// TrendRouteSorter sorts routes based on the trends of their metrics,
+// demoting the ones with the metrics degrading
+type TrendRouteSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+func (tr *TrendRouteSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	// sortingParameters are defined as MetricID with optional :Tolerance, the growth percentage ignored
+	metricIDs := make([]string, len(extraOpts.sortingParameters))
+	tolerances := make(map[string]float64)
+	for i, param := range extraOpts.sortingParameters {
+		metricWithTol := strings.Split(param, utils.InInFieldSep)
+		metricIDs[i] = metricWithTol[0]
+		if len(metricWithTol) == 2 {
+			if tolerances[metricIDs[i]], err = strconv.ParseFloat(metricWithTol[1], 64); err != nil {
+				return nil, fmt.Errorf("invalid tolerance for metric <%s>: %s", metricIDs[i], err.Error())
+			}
+		}
+	}
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting: tr.sorting,
+		Routes:  make([]*SortedRoute, 0)}
+	trends := make(map[string]*TrendSummary)
+	for _, route := range routes {
+		srtSpl, pass, err := tr.rS.populateSortingData(ev, route, extraOpts)
+		if err != nil {
+			return nil, err
+		} else if !pass || srtSpl == nil {
+			continue
+		}
+		growths := tr.rS.trendGrowths(route.TrendIDs, ev.Tenant, trends)
+		for _, metricID := range metricIDs {
+			srtSpl.sortingDataF64[metricID] = 0
+			growth, has := growths[metricID]
+			if !has {
+				continue
+			}
+			srtSpl.SortingData[metricID] = growth
+			if trendDegrading(metricID, growth, tolerances[metricID]) {
+				srtSpl.sortingDataF64[metricID] = 1
+			}
+		}
+		sortedRoutes.Routes = append(sortedRoutes.Routes, srtSpl)
+	}
+	sortedRoutes.SortTrend(metricIDs)
+	return
+}
+
+// trendDegrading checks if the growth of the metric goes the wrong way beyond the tolerance
+func trendDegrading(metricID string, growth, tolerance float64) bool {
+	if metricID == utils.MetaPDD { // in case of pdd the smallest value is the best
+		return growth > tolerance
+	}
+	return growth < -tolerance
+}
@@ -21,6 +21,7 @@ package engine
 import (
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -39,6 +40,8 @@ type Route struct {
 	RatingPlanIDs   []string // used when computing price
 	ResourceIDs     []string // queried in some strategies
 	StatIDs         []string // queried in some strategies
+	RankingIDs      []string // queried by the *rank strategy
+	TrendIDs        []string // queried by the *trend strategy
 	Weight          float64
 	Blocker         bool // do not process further route after this one
 	RouteParameters string
@@ -356,6 +359,99 @@ func (rpS *RouteService) statMetricsForLoadDistribution(statIDs []string, tenant
 	return
 }
 
+// rankingPosition returns the average position of the route within the rankings it references
+// rankings older than the configured stale age are ignored, has being false if the route was not ranked
+func (rpS *RouteService) rankingPosition(route *Route, tenant string,
+	rankings map[string]*RankingSummary) (pos float64, has bool) {
+	if len(rpS.cgrcfg.RouteSCfg().RankingSConns) == 0 {
+		return
+	}
+	var posSum float64
+	var posCnt int
+	for _, rankingID := range route.RankingIDs {
+		// check if we get an ID in the following form (RankingID:StatID)
+		rankingWithStat := strings.Split(rankingID, utils.InInFieldSep)
+		statID := route.ID
+		if len(rankingWithStat) == 2 {
+			statID = rankingWithStat[1]
+		}
+		rk, queried := rankings[rankingWithStat[0]]
+		if !queried { // query each ranking only once for all the routes
+			var rkSm RankingSummary
+			if err := rpS.connMgr.Call(context.TODO(), rpS.cgrcfg.RouteSCfg().RankingSConns,
+				utils.RankingSv1GetRankingSummary,
+				utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: tenant, ID: rankingWithStat[0]}},
+				&rkSm); err != nil {
+				if err.Error() != utils.ErrNotFound.Error() {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> error: %s getting ranking: %s", utils.RouteS, err.Error(), rankingWithStat[0]))
+				}
+			} else {
+				rk = &rkSm
+			}
+			rankings[rankingWithStat[0]] = rk
+		}
+		if rk == nil ||
+			(rpS.cgrcfg.RouteSCfg().RankingStaleAge != 0 &&
+				time.Since(rk.LastUpdate) > rpS.cgrcfg.RouteSCfg().RankingStaleAge) {
+			continue
+		}
+		if idx := slices.Index(rk.SortedStatIDs, statID); idx != -1 {
+			posSum += float64(idx + 1)
+			posCnt++
+		}
+	}
+	if posCnt == 0 {
+		return
+	}
+	return posSum / float64(posCnt), true
+}
+
+// trendGrowths will query a list of trendIDs and return the average growth of their last metrics
+func (rpS *RouteService) trendGrowths(trendIDs []string, tenant string,
+	trends map[string]*TrendSummary) (growths map[string]float64) {
+	growths = make(map[string]float64)
+	if len(rpS.cgrcfg.RouteSCfg().TrendSConns) == 0 {
+		return
+	}
+	provGrowths := make(map[string][]float64)
+	for _, trendID := range trendIDs {
+		tr, queried := trends[trendID]
+		if !queried { // query each trend only once for all the routes
+			var trSm TrendSummary
+			if err := rpS.connMgr.Call(context.TODO(), rpS.cgrcfg.RouteSCfg().TrendSConns,
+				utils.TrendSv1GetTrendSummary,
+				utils.TenantIDWithAPIOpts{TenantID: &utils.TenantID{Tenant: tenant, ID: trendID}},
+				&trSm); err != nil {
+				if err.Error() != utils.ErrNotFound.Error() {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> error: %s getting trend: %s", utils.RouteS, err.Error(), trendID))
+				}
+			} else {
+				tr = &trSm
+			}
+			trends[trendID] = tr
+		}
+		if tr == nil {
+			continue
+		}
+		for metricID, mWt := range tr.Metrics {
+			if mWt == nil || mWt.TrendLabel == utils.NotAvailable {
+				continue
+			}
+			provGrowths[metricID] = append(provGrowths[metricID], mWt.TrendGrowth)
+		}
+	}
+	for metricID, slice := range provGrowths {
+		sum := 0.0
+		for _, val := range slice {
+			sum += val
+		}
+		growths[metricID] = sum / float64(len(slice))
+	}
+	return
+}
+
 // resourceUsage returns sum of all resource usages out of list
 func (rpS *RouteService) resourceUsage(resIDs []string, tenant string) (tUsage float64, err error) {
 	if len(rpS.cgrcfg.RouteSCfg().ResourceSConns) != 0 {
@@ -450,15 +546,18 @@ func (rpS *RouteService) populateSortingData(ev *utils.CGREvent, route *Route,
 			//check if the route have the metric from sortingParameters
 			//in case that the metric don't exist
 			//we use 10000000 for *pdd and -1 for others
-			for _, metric := range extraOpts.sortingParameters {
-				if _, hasMetric := metricSupp[metric]; !hasMetric {
-					switch metric {
-					default:
-						sortedSpl.SortingData[metric] = -1.0
-						sortedSpl.sortingDataF64[metric] = -1.0
-					case utils.MetaPDD:
-						sortedSpl.SortingData[metric] = math.MaxFloat64
-						sortedSpl.sortingDataF64[metric] = math.MaxFloat64
+			//the other strategies do not have metrics as sortingParameters
+			if extraOpts.sortingStrategy == utils.MetaQOS {
+				for _, metric := range extraOpts.sortingParameters {
+					if _, hasMetric := metricSupp[metric]; !hasMetric {
+						switch metric {
+						default:
+							sortedSpl.SortingData[metric] = -1.0
+							sortedSpl.sortingDataF64[metric] = -1.0
+						case utils.MetaPDD:
+							sortedSpl.SortingData[metric] = math.MaxFloat64
+							sortedSpl.sortingDataF64[metric] = math.MaxFloat64
+						}
 					}
 				}
 			}
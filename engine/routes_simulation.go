@@ -0,0 +1,153 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ArgsSimulateRoutes are the arguments of the RouteSv1.SimulateRoutes API
+type ArgsSimulateRoutes struct {
+	Tenant               string
+	RouteProfileID       string        // stored RouteProfile to replay the CDRs against
+	RouteProfile         *RouteProfile // candidate RouteProfile, not yet stored, used instead of RouteProfileID
+	*utils.RPCCDRsFilter               // selects the CDRs to be replayed
+	APIOpts              map[string]any
+}
+
+// RouteSimulationCDR is the outcome of replaying one CDR against the RouteProfile
+type RouteSimulationCDR struct {
+	CGRID      string
+	RunID      string
+	RouteID    string   // route which would have been chosen
+	ActualCost float64  // cost of the CDR, -1 if it was not rated
+	RouteCost  *float64 // cost of the chosen route, nil if the route has no AccountIDs or RatingPlanIDs
+	Savings    *float64 // ActualCost - RouteCost, nil if any of them is missing
+	Error      string   // reason why no route was chosen
+}
+
+// RoutesSimulation is the reply of the RouteSv1.SimulateRoutes API
+type RoutesSimulation struct {
+	ProfileID  string
+	Sorting    string
+	CDRs       []*RouteSimulationCDR
+	RatedCDRs  int     // number of CDRs with both costs known, used within the totals below
+	ActualCost float64 // total ActualCost of the RatedCDRs
+	RouteCost  float64 // total RouteCost of the RatedCDRs
+	Savings    float64 // ActualCost - RouteCost, negative in case of loss
+}
+
+// V1SimulateRoutes replays the CDRs stored in StorDB against a RouteProfile,
+// reporting the route which would have been chosen and its cost compared with the actual one
+func (rpS *RouteService) V1SimulateRoutes(ctx *context.Context, args *ArgsSimulateRoutes, reply *RoutesSimulation) (err error) {
+	if args.RouteProfile == nil && args.RouteProfileID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.RouteProfileID)
+	}
+	if len(rpS.cgrcfg.RouteSCfg().CDRsConns) == 0 {
+		return utils.NewErrNotConnected(utils.CDRs)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = rpS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	rPrfl := args.RouteProfile
+	if rPrfl != nil {
+		if rPrfl.Tenant == utils.EmptyString {
+			rPrfl.Tenant = tnt
+		}
+		if err = rPrfl.Compile(); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	} else if rPrfl, err = rpS.dm.GetRouteProfile(tnt, args.RouteProfileID,
+		true, true, utils.NonTransactional); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	fltr := args.RPCCDRsFilter
+	if fltr == nil {
+		fltr = new(utils.RPCCDRsFilter)
+	}
+	var cdrs []*CDR
+	if err = rpS.connMgr.Call(ctx, rpS.cgrcfg.RouteSCfg().CDRsConns, utils.CDRsV1GetCDRs,
+		&utils.RPCCDRsFilterWithAPIOpts{
+			RPCCDRsFilter: fltr,
+			APIOpts:       args.APIOpts,
+			Tenant:        tnt,
+		}, &cdrs); err != nil {
+		return
+	}
+	sim := &RoutesSimulation{
+		ProfileID: rPrfl.ID,
+		Sorting:   rPrfl.Sorting,
+		CDRs:      make([]*RouteSimulationCDR, len(cdrs)),
+	}
+	for i, cdr := range cdrs {
+		sim.CDRs[i] = rpS.simulateRouteForCDR(rPrfl, cdr, args.APIOpts)
+		if sim.CDRs[i].Savings == nil {
+			continue
+		}
+		sim.RatedCDRs++
+		sim.ActualCost += sim.CDRs[i].ActualCost
+		sim.RouteCost += *sim.CDRs[i].RouteCost
+	}
+	sim.Savings = sim.ActualCost - sim.RouteCost
+	*reply = *sim
+	return
+}
+
+// simulateRouteForCDR sorts the routes of the profile for the event out of CDR, choosing the first one
+func (rpS *RouteService) simulateRouteForCDR(rPrfl *RouteProfile, cdr *CDR,
+	apiOpts map[string]any) (simCDR *RouteSimulationCDR) {
+	simCDR = &RouteSimulationCDR{
+		CGRID:      cdr.CGRID,
+		RunID:      cdr.RunID,
+		ActualCost: cdr.Cost,
+	}
+	ev := cdr.AsCGREvent()
+	for k, v := range apiOpts {
+		ev.APIOpts[k] = v
+	}
+	extraOpts, err := newOptsGetRoutes(ev, rpS.filterS, rpS.cgrcfg.RouteSCfg().Opts)
+	if err != nil {
+		simCDR.Error = err.Error()
+		return
+	}
+	sortedRoutes, err := rpS.sortedRoutesForProfile(rPrfl.Tenant, rPrfl, ev, utils.Paginator{}, extraOpts)
+	if err != nil {
+		simCDR.Error = err.Error()
+		return
+	}
+	if len(sortedRoutes.Routes) == 0 {
+		simCDR.Error = utils.ErrNotFound.Error()
+		return
+	}
+	simCDR.RouteID = sortedRoutes.Routes[0].RouteID
+	cost, has := sortedRoutes.Routes[0].sortingDataF64[utils.Cost]
+	if !has {
+		return
+	}
+	simCDR.RouteCost = utils.Float64Pointer(cost)
+	if cdr.Cost >= 0 {
+		simCDR.Savings = utils.Float64Pointer(cdr.Cost - cost)
+	}
+	return
+}
@@ -0,0 +1,121 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestRouteServiceV1SimulateRoutes(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().CDRsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)}
+	cfg.RouteSCfg().RALsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs)}
+	setupTime := time.Date(2024, 3, 3, 11, 39, 32, 0, time.UTC)
+	rpCosts := map[string]float64{
+		"RP_LOW":  0.3,
+		"RP_HIGH": 0.6,
+	}
+	clientConn := make(chan birpc.ClientConnector, 1)
+	clientConn <- &ccMock{
+		calls: map[string]func(ctx *context.Context, args, reply any) error{
+			utils.CDRsV1GetCDRs: func(ctx *context.Context, args, reply any) error {
+				if fltr := args.(*utils.RPCCDRsFilterWithAPIOpts); !reflect.DeepEqual(fltr.Accounts, []string{"1001"}) {
+					t.Errorf("Unexpected CDRs filter: %+v", fltr.RPCCDRsFilter)
+				}
+				*reply.(*[]*CDR) = []*CDR{
+					{CGRID: "CDR1", RunID: utils.MetaDefault, Tenant: "cgrates.org", Account: "1001",
+						Destination: "1002", SetupTime: setupTime, Usage: time.Minute, Cost: 0.5},
+					{CGRID: "CDR2", RunID: utils.MetaDefault, Tenant: "cgrates.org", Account: "1001",
+						Destination: "1003", SetupTime: setupTime, Usage: time.Minute, Cost: -1},
+				}
+				return nil
+			},
+			utils.ResponderGetCostOnRatingPlans: func(ctx *context.Context, args, reply any) error {
+				rpID := args.(*utils.GetCostOnRatingPlansArgs).RatingPlanIDs[0]
+				*reply.(*map[string]any) = map[string]any{
+					utils.Cost:         rpCosts[rpID],
+					utils.RatingPlanID: rpID,
+				}
+				return nil
+			},
+		},
+	}
+	connMgr := NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs): clientConn,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs): clientConn,
+	})
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	rpS := NewRouteService(dm, NewFilterS(cfg, connMgr, dm), cfg, connMgr)
+	args := &ArgsSimulateRoutes{
+		RouteProfile: &RouteProfile{
+			ID:      "RP_CANDIDATE",
+			Sorting: utils.MetaLC,
+			Routes: []*Route{
+				{ID: "route_high", RatingPlanIDs: []string{"RP_HIGH"}, Weight: 20},
+				{ID: "route_low", RatingPlanIDs: []string{"RP_LOW"}, Weight: 10},
+			},
+		},
+		RPCCDRsFilter: &utils.RPCCDRsFilter{Accounts: []string{"1001"}},
+	}
+	var reply RoutesSimulation
+	if err := rpS.V1SimulateRoutes(context.Background(), args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	exp := RoutesSimulation{
+		ProfileID: "RP_CANDIDATE",
+		Sorting:   utils.MetaLC,
+		CDRs: []*RouteSimulationCDR{
+			{CGRID: "CDR1", RunID: utils.MetaDefault, RouteID: "route_low", ActualCost: 0.5,
+				RouteCost: utils.Float64Pointer(0.3), Savings: utils.Float64Pointer(0.2)},
+			{CGRID: "CDR2", RunID: utils.MetaDefault, RouteID: "route_low", ActualCost: -1,
+				RouteCost: utils.Float64Pointer(0.3)},
+		},
+		RatedCDRs:  1,
+		ActualCost: 0.5,
+		RouteCost:  0.3,
+		Savings:    0.2,
+	}
+	if utils.ToJSON(exp) != utils.ToJSON(reply) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+
+	args.RouteProfile = nil
+	args.RouteProfileID = "RP_MISSING"
+	if err := rpS.V1SimulateRoutes(context.Background(), args, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	args.RouteProfileID = utils.EmptyString
+	if err := rpS.V1SimulateRoutes(context.Background(), args, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.RouteProfileID).Error() {
+		t.Errorf("Expected mandatory %s missing, received %v", utils.RouteProfileID, err)
+	}
+	cfg.RouteSCfg().CDRsConns = nil
+	args.RouteProfileID = "RP_MISSING"
+	if err := rpS.V1SimulateRoutes(context.Background(), args, &reply); err == nil ||
+		err.Error() != utils.NewErrNotConnected(utils.CDRs).Error() {
+		t.Errorf("Expected not connected error, received %v", err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"reflect"
 	"sort"
@@ -2644,3 +2645,207 @@ func TestSortedRoutesSortHighestCost(t *testing.T) {
 		}
 	}
 }
+
+func TestRouteServiceSortRoutesRank(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().RankingSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRankings)}
+	cfg.RouteSCfg().RankingStaleAge = time.Hour
+	var queried []string
+	clientConn := make(chan birpc.ClientConnector, 1)
+	clientConn <- &ccMock{
+		calls: map[string]func(ctx *context.Context, args, reply any) error{
+			utils.RankingSv1GetRankingSummary: func(ctx *context.Context, args, reply any) error {
+				arg := args.(utils.TenantIDWithAPIOpts)
+				queried = append(queried, arg.ID)
+				switch arg.ID {
+				case "RK_ASR":
+					*reply.(*RankingSummary) = RankingSummary{
+						Tenant:        arg.Tenant,
+						ID:            arg.ID,
+						LastUpdate:    time.Now(),
+						SortedStatIDs: []string{"route2", "STAT_ROUTE3", "route1"},
+					}
+				case "RK_STALE":
+					*reply.(*RankingSummary) = RankingSummary{
+						Tenant:        arg.Tenant,
+						ID:            arg.ID,
+						LastUpdate:    time.Now().Add(-2 * time.Hour),
+						SortedStatIDs: []string{"route4"},
+					}
+				default:
+					return utils.ErrNotFound
+				}
+				return nil
+			},
+		},
+	}
+	connMgr := NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRankings): clientConn,
+	})
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	rpS := NewRouteService(dm, NewFilterS(cfg, connMgr, dm), cfg, connMgr)
+	routes := map[string]*Route{
+		"route1": {ID: "route1", RankingIDs: []string{"RK_ASR"}, Weight: 40},
+		"route2": {ID: "route2", RankingIDs: []string{"RK_ASR"}, Weight: 10},
+		"route3": {ID: "route3", RankingIDs: []string{"RK_ASR:STAT_ROUTE3"}, Weight: 20},
+		"route4": {ID: "route4", RankingIDs: []string{"RK_STALE"}, Weight: 30},
+		"route5": {ID: "route5", RankingIDs: []string{"RK_MISSING"}, Weight: 5},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "RankEvent",
+		Event:  map[string]any{},
+	}
+	sortedRoutes, err := rpS.sorter.SortRoutes("RP_RANK", utils.MetaRank, routes, ev, &optsGetRoutes{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sortedRoutes.Sorting != utils.MetaRank {
+		t.Errorf("Expected sorting %q, received %q", utils.MetaRank, sortedRoutes.Sorting)
+	}
+	exp := []string{"route2", "route3", "route1", "route4", "route5"}
+	if rcv := sortedRoutes.RouteIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+	if rank := sortedRoutes.Routes[1].SortingData[utils.Rank]; rank != 2.0 {
+		t.Errorf("Expected rank 2, received %v", rank)
+	}
+	if _, has := sortedRoutes.Routes[3].SortingData[utils.Rank]; has {
+		t.Error("Expected no rank for the route within the stale ranking")
+	}
+	sort.Strings(queried)
+	if expQueried := []string{"RK_ASR", "RK_MISSING", "RK_STALE"}; !reflect.DeepEqual(expQueried, queried) {
+		t.Errorf("Expected rankings queried once: %+v, received %+v", expQueried, queried)
+	}
+}
+
+func TestRouteServiceSortRoutesTrend(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().TrendSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends)}
+	trendGrowths := map[string]map[string]float64{ // growth percentage of *asr and *pdd for each trend
+		"TR_ROUTE1": {utils.MetaASR: -12, utils.MetaPDD: 0},
+		"TR_ROUTE2": {utils.MetaASR: -3, utils.MetaPDD: 0},
+		"TR_ROUTE3": {utils.MetaASR: 8, utils.MetaPDD: 25},
+		"TR_ROUTE4": {utils.MetaASR: 2, utils.MetaPDD: -10},
+	}
+	clientConn := make(chan birpc.ClientConnector, 1)
+	clientConn <- &ccMock{
+		calls: map[string]func(ctx *context.Context, args, reply any) error{
+			utils.TrendSv1GetTrendSummary: func(ctx *context.Context, args, reply any) error {
+				arg := args.(utils.TenantIDWithAPIOpts)
+				growths, has := trendGrowths[arg.ID]
+				if !has {
+					return utils.ErrNotFound
+				}
+				trSm := TrendSummary{
+					Tenant:  arg.Tenant,
+					ID:      arg.ID,
+					Time:    time.Now(),
+					Metrics: make(map[string]*MetricWithTrend),
+				}
+				for metricID, growth := range growths {
+					trSm.Metrics[metricID] = &MetricWithTrend{ID: metricID, TrendGrowth: growth}
+				}
+				*reply.(*TrendSummary) = trSm
+				return nil
+			},
+		},
+	}
+	connMgr := NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTrends): clientConn,
+	})
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	rpS := NewRouteService(dm, NewFilterS(cfg, connMgr, dm), cfg, connMgr)
+	routes := map[string]*Route{
+		"route1": {ID: "route1", TrendIDs: []string{"TR_ROUTE1"}, Weight: 40},
+		"route2": {ID: "route2", TrendIDs: []string{"TR_ROUTE2"}, Weight: 30},
+		"route3": {ID: "route3", TrendIDs: []string{"TR_ROUTE3"}, Weight: 50},
+		"route4": {ID: "route4", TrendIDs: []string{"TR_ROUTE4"}, Weight: 10},
+		"route5": {ID: "route5", TrendIDs: []string{"TR_MISSING"}, Weight: 20},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TrendEvent",
+		Event:  map[string]any{},
+	}
+	// *asr falling by more than 5% demotes route1, *pdd raising demotes route3
+	sortedRoutes, err := rpS.sorter.SortRoutes("RP_TREND", utils.MetaTrend, routes, ev,
+		&optsGetRoutes{sortingParameters: []string{"*asr:5", utils.MetaPDD}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{"route2", "route5", "route4", "route3", "route1"}
+	if rcv := sortedRoutes.RouteIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+	if growth := sortedRoutes.Routes[4].SortingData[utils.MetaASR]; growth != -12.0 {
+		t.Errorf("Expected *asr growth -12, received %v", growth)
+	}
+
+	if _, err = rpS.sorter.SortRoutes("RP_TREND", utils.MetaTrend, routes, ev,
+		&optsGetRoutes{sortingParameters: []string{"*asr:five"}}); err == nil {
+		t.Error("Expected error for invalid tolerance")
+	}
+}
+
+func TestRouteServiceSortRoutesScore(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)}
+	statMetrics := map[string]map[string]float64{
+		"STAT_ROUTE1": {utils.MetaASR: 90, utils.MetaPDD: 4},
+		"STAT_ROUTE2": {utils.MetaASR: 60, utils.MetaPDD: 1},
+		"STAT_ROUTE3": {utils.MetaASR: 30, utils.MetaPDD: 2},
+	}
+	clientConn := make(chan birpc.ClientConnector, 1)
+	clientConn <- &ccMock{
+		calls: map[string]func(ctx *context.Context, args, reply any) error{
+			utils.StatSv1GetQueueFloatMetrics: func(ctx *context.Context, args, reply any) error {
+				metrics, has := statMetrics[args.(*utils.TenantIDWithAPIOpts).ID]
+				if !has {
+					return utils.ErrNotFound
+				}
+				*reply.(*map[string]float64) = metrics
+				return nil
+			},
+		},
+	}
+	connMgr := NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats): clientConn,
+	})
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	rpS := NewRouteService(dm, NewFilterS(cfg, connMgr, dm), cfg, connMgr)
+	routes := map[string]*Route{
+		"route1": {ID: "route1", StatIDs: []string{"STAT_ROUTE1"}, Weight: 10},
+		"route2": {ID: "route2", StatIDs: []string{"STAT_ROUTE2"}, Weight: 20},
+		"route3": {ID: "route3", StatIDs: []string{"STAT_ROUTE3"}, Weight: 30},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ScoreEvent",
+		Event:  map[string]any{},
+	}
+	// *asr counts twice the *pdd, weight only slightly
+	sortedRoutes, err := rpS.sorter.SortRoutes("RP_SCORE", utils.MetaScore, routes, ev,
+		&optsGetRoutes{
+			sortingParameters: []string{"*asr:2", utils.MetaPDD, "Weight:0.1"},
+			sortingStrategy:   utils.MetaScore,
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{"route2", "route1", "route3"}
+	if rcv := sortedRoutes.RouteIDs(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+	if score := sortedRoutes.Routes[0].SortingData[utils.Score].(float64); math.Abs(score-2.05) > 1e-9 {
+		t.Errorf("Expected score 2.05, received %v", score)
+	}
+	if _, has := sortedRoutes.Routes[0].SortingData["*asr:2"]; has {
+		t.Error("Expected no default metric populated out of sortingParameters")
+	}
+
+	if _, err = rpS.sorter.SortRoutes("RP_SCORE", utils.MetaScore, routes, ev,
+		&optsGetRoutes{sortingParameters: []string{"*asr:two"}}); err == nil {
+		t.Error("Expected error for invalid weight")
+	}
+}
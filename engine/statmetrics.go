@@ -20,6 +20,8 @@ package engine
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -44,16 +46,23 @@ type StatWithCompress struct {
 // cfg serves as general purpose container to pass config options to metric
 func NewStatMetric(metricID string, minItems int, filterIDs []string) (sm StatMetric, err error) {
 	metrics := map[string]func(int, string, []string) (StatMetric, error){
-		utils.MetaASR:      NewASR,
-		utils.MetaACD:      NewACD,
-		utils.MetaTCD:      NewTCD,
-		utils.MetaACC:      NewACC,
-		utils.MetaTCC:      NewTCC,
-		utils.MetaPDD:      NewPDD,
-		utils.MetaDDC:      NewDDC,
-		utils.MetaSum:      NewStatSum,
-		utils.MetaAverage:  NewStatAverage,
-		utils.MetaDistinct: NewStatDistinct,
+		utils.MetaASR:       NewASR,
+		utils.MetaACD:       NewACD,
+		utils.MetaTCD:       NewTCD,
+		utils.MetaACC:       NewACC,
+		utils.MetaTCC:       NewTCC,
+		utils.MetaPDD:       NewPDD,
+		utils.MetaDDC:       NewDDC,
+		utils.MetaSum:       NewStatSum,
+		utils.MetaAverage:   NewStatAverage,
+		utils.MetaDistinct:  NewStatDistinct,
+		utils.MetaP50:       percentileMetric(50),
+		utils.MetaP90:       percentileMetric(90),
+		utils.MetaP95:       percentileMetric(95),
+		utils.MetaP99:       percentileMetric(99),
+		utils.MetaHistogram: NewStatHistogram,
+		utils.MetaStdDev:    NewStatStdDev,
+		utils.MetaRate:      NewStatRate,
 	}
 	// split the metricID
 	// in case of *sum we have *sum#~*req.FieldName
@@ -1634,3 +1643,573 @@ func (dst *StatDistinct) GetCompressFactor(events map[string]int) map[string]int
 	}
 	return events
 }
+
+// percentileMetric returns the constructor of the metric computing the given percentile
+func percentileMetric(percentile float64) func(int, string, []string) (StatMetric, error) {
+	return func(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+		return NewStatPercentile(percentile, minItems, extraParams, filterIDs)
+	}
+}
+
+// NewStatPercentile returns the metric estimating the percentile of a field in the events
+func NewStatPercentile(percentile float64, minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatPercentile{Events: make(map[string][]float64), Digest: NewTDigest(tDigestCompression),
+		Percentile: percentile, MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
+}
+
+// StatPercentile implements the *p50, *p90, *p95 and *p99 metrics
+type StatPercentile struct {
+	FilterIDs  []string
+	Percentile float64
+	Digest     *TDigest
+	Count      int64
+	Events     map[string][]float64 // map[EventTenantID]values, needed to remove them from the Digest
+	MinItems   int
+	FieldName  string
+	val        *float64 // cached percentile value
+}
+
+// getValue returns the estimated percentile
+func (pct *StatPercentile) getValue(roundingDecimal int) float64 {
+	if pct.val == nil {
+		if (pct.MinItems > 0 && pct.Count < int64(pct.MinItems)) || (pct.Count == 0) {
+			pct.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			pct.val = utils.Float64Pointer(utils.Round(pct.Digest.Quantile(pct.Percentile/100),
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *pct.val
+}
+
+func (pct *StatPercentile) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := pct.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (pct *StatPercentile) GetValue(roundingDecimal int) (v any) {
+	return pct.getValue(roundingDecimal)
+}
+
+func (pct *StatPercentile) GetFloat64Value(roundingDecimal int) (v float64) {
+	return pct.getValue(roundingDecimal)
+}
+
+// getFieldVal accepts durations too so *p95#~*req.Usage works with string usages
+func (pct *StatPercentile) getFieldVal(ev utils.DataProvider) (val float64, err error) {
+	var ival any
+	if ival, err = utils.DPDynamicInterface(pct.FieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, pct.FieldName)
+		}
+		return
+	}
+	return utils.IfaceAsTFloat64(ival)
+}
+
+func (pct *StatPercentile) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	var val float64
+	if val, err = pct.getFieldVal(ev); err != nil {
+		return
+	}
+	pct.Events[evID] = append(pct.Events[evID], val)
+	pct.Digest.Add(val, 1)
+	pct.Count++
+	pct.val = nil
+	return
+}
+
+func (pct *StatPercentile) AddOneEvent(ev utils.DataProvider) (err error) {
+	var val float64
+	if val, err = pct.getFieldVal(ev); err != nil {
+		return
+	}
+	pct.Digest.Add(val, 1)
+	pct.Count++
+	pct.val = nil
+	return
+}
+
+func (pct *StatPercentile) RemEvent(evID string) {
+	vals, has := pct.Events[evID]
+	if !has {
+		return
+	}
+	pct.Digest.Remove(vals[len(vals)-1], 1)
+	pct.Count--
+	if len(vals) <= 1 {
+		delete(pct.Events, evID)
+	} else {
+		pct.Events[evID] = vals[:len(vals)-1]
+	}
+	pct.val = nil
+}
+
+func (pct *StatPercentile) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(pct)
+}
+
+func (pct *StatPercentile) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, &pct)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (pct *StatPercentile) GetFilterIDs() []string {
+	return pct.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (pct *StatPercentile) GetMinItems() (minIts int) { return pct.MinItems }
+
+// Compress is part of StatMetric interface
+// the events are kept as they are since collapsing them would lose the distribution
+func (pct *StatPercentile) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	for id := range pct.Events {
+		eventIDs = append(eventIDs, id)
+	}
+	return
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (pct *StatPercentile) GetCompressFactor(events map[string]int) map[string]int {
+	for id, vals := range pct.Events {
+		if _, has := events[id]; !has {
+			events[id] = len(vals)
+		}
+		if events[id] < len(vals) {
+			events[id] = len(vals)
+		}
+	}
+	return events
+}
+
+// NewStatHistogram returns the metric counting the values of a field into buckets
+// extraParams has the format: FieldName|Bound1|Bound2 with the bounds in ascending order
+func NewStatHistogram(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	params := strings.Split(extraParams, utils.PipeSep)
+	if len(params) < 2 {
+		return nil, fmt.Errorf("missing bounds for %s metric", utils.MetaHistogram)
+	}
+	hst := &StatHistogram{Events: make(map[string][]int),
+		Bounds:   make([]float64, len(params)-1),
+		Labels:   append(params[1:len(params):len(params)], utils.MetaInf),
+		Buckets:  make([]int64, len(params)),
+		MinItems: minItems, FieldName: params[0], FilterIDs: filterIDs}
+	for i, bnd := range params[1:] {
+		var err error
+		if hst.Bounds[i], err = utils.IfaceAsTFloat64(bnd); err != nil {
+			return nil, fmt.Errorf("invalid bound <%s> for %s metric: %v", bnd, utils.MetaHistogram, err)
+		}
+		if i != 0 && hst.Bounds[i] <= hst.Bounds[i-1] {
+			return nil, fmt.Errorf("bounds for %s metric not in ascending order", utils.MetaHistogram)
+		}
+	}
+	return hst, nil
+}
+
+// StatHistogram implements the *histogram metric
+// each bucket counts the values up to and including its bound, the last one the values over all bounds
+type StatHistogram struct {
+	FilterIDs []string
+	Bounds    []float64
+	Labels    []string // label for each bucket, as configured in the metric ID
+	Buckets   []int64
+	Count     int64
+	Events    map[string][]int // map[EventTenantID]bucket indexes
+	MinItems  int
+	FieldName string
+}
+
+func (hst *StatHistogram) hasValue() bool {
+	return hst.Count != 0 && hst.Count >= int64(hst.MinItems)
+}
+
+// GetStringValue returns the buckets as JSON
+func (hst *StatHistogram) GetStringValue(roundingDecimal int) (valStr string) {
+	if !hst.hasValue() {
+		return utils.NotAvailable
+	}
+	return utils.ToJSON(hst.GetValue(roundingDecimal))
+}
+
+// GetValue returns the number of values in each bucket, indexed by label
+func (hst *StatHistogram) GetValue(roundingDecimal int) (v any) {
+	if !hst.hasValue() {
+		return utils.StatsNA
+	}
+	bkts := make(map[string]int64, len(hst.Buckets))
+	for i, lbl := range hst.Labels {
+		bkts[lbl] = hst.Buckets[i]
+	}
+	return bkts
+}
+
+// GetFloat64Value returns the number of values in the histogram
+func (hst *StatHistogram) GetFloat64Value(roundingDecimal int) (v float64) {
+	if !hst.hasValue() {
+		return utils.StatsNA
+	}
+	return float64(hst.Count)
+}
+
+func (hst *StatHistogram) getBucket(ev utils.DataProvider) (bkt int, err error) {
+	var ival any
+	if ival, err = utils.DPDynamicInterface(hst.FieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, hst.FieldName)
+		}
+		return
+	}
+	var val float64
+	if val, err = utils.IfaceAsTFloat64(ival); err != nil {
+		return
+	}
+	return sort.SearchFloat64s(hst.Bounds, val), nil
+}
+
+func (hst *StatHistogram) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	var bkt int
+	if bkt, err = hst.getBucket(ev); err != nil {
+		return
+	}
+	hst.Events[evID] = append(hst.Events[evID], bkt)
+	hst.Buckets[bkt]++
+	hst.Count++
+	return
+}
+
+func (hst *StatHistogram) AddOneEvent(ev utils.DataProvider) (err error) {
+	var bkt int
+	if bkt, err = hst.getBucket(ev); err != nil {
+		return
+	}
+	hst.Buckets[bkt]++
+	hst.Count++
+	return
+}
+
+func (hst *StatHistogram) RemEvent(evID string) {
+	bkts, has := hst.Events[evID]
+	if !has {
+		return
+	}
+	hst.Buckets[bkts[len(bkts)-1]]--
+	hst.Count--
+	if len(bkts) <= 1 {
+		delete(hst.Events, evID)
+		return
+	}
+	hst.Events[evID] = bkts[:len(bkts)-1]
+}
+
+func (hst *StatHistogram) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(hst)
+}
+
+func (hst *StatHistogram) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, &hst)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (hst *StatHistogram) GetFilterIDs() []string {
+	return hst.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (hst *StatHistogram) GetMinItems() (minIts int) { return hst.MinItems }
+
+// Compress is part of StatMetric interface
+func (hst *StatHistogram) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	for id := range hst.Events {
+		eventIDs = append(eventIDs, id)
+	}
+	return
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (hst *StatHistogram) GetCompressFactor(events map[string]int) map[string]int {
+	for id, bkts := range hst.Events {
+		if _, has := events[id]; !has {
+			events[id] = len(bkts)
+		}
+		if events[id] < len(bkts) {
+			events[id] = len(bkts)
+		}
+	}
+	return events
+}
+
+// NewStatStdDev returns the metric computing the standard deviation of a field in the events
+func NewStatStdDev(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatStdDev{Events: make(map[string][]float64),
+		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
+}
+
+// StatStdDev implements the *stddev metric as population standard deviation
+type StatStdDev struct {
+	FilterIDs []string
+	Sum       float64
+	SumSq     float64 // sum of the squared values
+	Count     int64
+	Events    map[string][]float64 // map[EventTenantID]values
+	MinItems  int
+	FieldName string
+	val       *float64 // cached stddev value
+}
+
+// getValue returns the standard deviation
+func (std *StatStdDev) getValue(roundingDecimal int) float64 {
+	if std.val == nil {
+		if (std.MinItems > 0 && std.Count < int64(std.MinItems)) || (std.Count == 0) {
+			std.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			avg := std.Sum / float64(std.Count)
+			std.val = utils.Float64Pointer(utils.Round(math.Sqrt(math.Max(std.SumSq/float64(std.Count)-avg*avg, 0)),
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *std.val
+}
+
+func (std *StatStdDev) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := std.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (std *StatStdDev) GetValue(roundingDecimal int) (v any) {
+	return std.getValue(roundingDecimal)
+}
+
+func (std *StatStdDev) GetFloat64Value(roundingDecimal int) (v float64) {
+	return std.getValue(roundingDecimal)
+}
+
+func (std *StatStdDev) getFieldVal(ev utils.DataProvider) (val float64, err error) {
+	var ival any
+	if ival, err = utils.DPDynamicInterface(std.FieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, std.FieldName)
+		}
+		return
+	}
+	return utils.IfaceAsTFloat64(ival)
+}
+
+func (std *StatStdDev) addValue(val float64) {
+	std.Sum += val
+	std.SumSq += val * val
+	std.Count++
+	std.val = nil
+}
+
+func (std *StatStdDev) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	var val float64
+	if val, err = std.getFieldVal(ev); err != nil {
+		return
+	}
+	std.Events[evID] = append(std.Events[evID], val)
+	std.addValue(val)
+	return
+}
+
+func (std *StatStdDev) AddOneEvent(ev utils.DataProvider) (err error) {
+	var val float64
+	if val, err = std.getFieldVal(ev); err != nil {
+		return
+	}
+	std.addValue(val)
+	return
+}
+
+func (std *StatStdDev) RemEvent(evID string) {
+	vals, has := std.Events[evID]
+	if !has {
+		return
+	}
+	val := vals[len(vals)-1]
+	std.Sum -= val
+	std.SumSq -= val * val
+	std.Count--
+	if len(vals) <= 1 {
+		delete(std.Events, evID)
+	} else {
+		std.Events[evID] = vals[:len(vals)-1]
+	}
+	std.val = nil
+}
+
+func (std *StatStdDev) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(std)
+}
+
+func (std *StatStdDev) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, &std)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (std *StatStdDev) GetFilterIDs() []string {
+	return std.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (std *StatStdDev) GetMinItems() (minIts int) { return std.MinItems }
+
+// Compress is part of StatMetric interface
+// the events are kept as they are since collapsing them would lose the deviation
+func (std *StatStdDev) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	for id := range std.Events {
+		eventIDs = append(eventIDs, id)
+	}
+	return
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (std *StatStdDev) GetCompressFactor(events map[string]int) map[string]int {
+	for id, vals := range std.Events {
+		if _, has := events[id]; !has {
+			events[id] = len(vals)
+		}
+		if events[id] < len(vals) {
+			events[id] = len(vals)
+		}
+	}
+	return events
+}
+
+// NewStatRate returns the metric computing the number of events per time unit
+// extraParams is the time unit, defaulting to one minute
+func NewStatRate(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	unit := time.Minute
+	if extraParams != utils.EmptyString {
+		var err error
+		if unit, err = utils.ParseDurationWithNanosecs(extraParams); err != nil {
+			return nil, fmt.Errorf("invalid time unit <%s> for %s metric: %v", extraParams, utils.MetaRate, err)
+		}
+		if unit <= 0 {
+			return nil, fmt.Errorf("invalid time unit <%s> for %s metric", extraParams, utils.MetaRate)
+		}
+	}
+	return &StatRate{Events: make(map[string][]time.Time),
+		Unit: unit, MinItems: minItems, FilterIDs: filterIDs}, nil
+}
+
+// StatRate implements the *rate metric
+// the window is the time since the oldest event in the queue but not shorter than the Unit
+type StatRate struct {
+	FilterIDs  []string
+	Unit       time.Duration
+	Count      int64
+	Events     map[string][]time.Time // map[EventTenantID]time the event was added
+	FirstEvent time.Time              // time the first event was added on queues not storing the events
+	MinItems   int
+}
+
+// getValue returns the rate computed at the current time
+func (rt *StatRate) getValue(roundingDecimal int) float64 {
+	if (rt.MinItems > 0 && rt.Count < int64(rt.MinItems)) || (rt.Count == 0) {
+		return utils.StatsNA
+	}
+	window := rt.Unit
+	now := time.Now()
+	if !rt.FirstEvent.IsZero() {
+		if elapsed := now.Sub(rt.FirstEvent); elapsed > window {
+			window = elapsed
+		}
+	}
+	for _, tms := range rt.Events {
+		if elapsed := now.Sub(tms[0]); elapsed > window {
+			window = elapsed
+		}
+	}
+	return utils.Round(float64(rt.Count)*float64(rt.Unit)/float64(window),
+		roundingDecimal, utils.MetaRoundingMiddle)
+}
+
+func (rt *StatRate) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := rt.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (rt *StatRate) GetValue(roundingDecimal int) (v any) {
+	return rt.getValue(roundingDecimal)
+}
+
+func (rt *StatRate) GetFloat64Value(roundingDecimal int) (v float64) {
+	return rt.getValue(roundingDecimal)
+}
+
+func (rt *StatRate) AddEvent(evID string, _ utils.DataProvider) (err error) {
+	rt.Events[evID] = append(rt.Events[evID], time.Now())
+	rt.Count++
+	return
+}
+
+func (rt *StatRate) AddOneEvent(_ utils.DataProvider) (err error) {
+	if rt.FirstEvent.IsZero() {
+		rt.FirstEvent = time.Now()
+	}
+	rt.Count++
+	return
+}
+
+func (rt *StatRate) RemEvent(evID string) {
+	tms, has := rt.Events[evID]
+	if !has {
+		return
+	}
+	rt.Count--
+	if len(tms) <= 1 {
+		delete(rt.Events, evID)
+		return
+	}
+	rt.Events[evID] = tms[1:]
+}
+
+func (rt *StatRate) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(rt)
+}
+
+func (rt *StatRate) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, &rt)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (rt *StatRate) GetFilterIDs() []string {
+	return rt.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (rt *StatRate) GetMinItems() (minIts int) { return rt.MinItems }
+
+// Compress is part of StatMetric interface
+func (rt *StatRate) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	for id := range rt.Events {
+		eventIDs = append(eventIDs, id)
+	}
+	return
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (rt *StatRate) GetCompressFactor(events map[string]int) map[string]int {
+	for id, tms := range rt.Events {
+		if _, has := events[id]; !has {
+			events[id] = len(tms)
+		}
+		if events[id] < len(tms) {
+			events[id] = len(tms)
+		}
+	}
+	return events
+}
@@ -21,6 +21,7 @@ import (
 	"net"
 	"reflect"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -4321,3 +4322,294 @@ func TestStatMetricsStatASRAddEventErr3(t *testing.T) {
 		t.Errorf("\nExpecting <%+v>,\n Recevied <%+v>", utils.ErrAccountNotFound, err)
 	}
 }
+
+func TestStatPercentileGetFloat64Value(t *testing.T) {
+	p95, err := NewStatMetric("*p95#~*req.PDD", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rndDec := config.CgrConfig().GeneralCfg().RoundingDecimals
+	for i := 1; i <= 100; i++ {
+		ev := utils.MapStorage{utils.MetaReq: map[string]any{utils.PDD: time.Duration(i) * time.Second}}
+		if err := p95.AddEvent(utils.ConcatenatedKey("EVENT", strconv.Itoa(i)), ev); err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 {
+			if v := p95.GetFloat64Value(rndDec); v != utils.StatsNA {
+				t.Errorf("wrong p95 value: %v", v)
+			}
+			if v := p95.GetStringValue(rndDec); v != utils.NotAvailable {
+				t.Errorf("wrong p95 value: %v", v)
+			}
+		}
+	}
+	if v := p95.GetFloat64Value(rndDec); v != float64(95500*time.Millisecond) {
+		t.Errorf("wrong p95 value: %v", v)
+	}
+	for i := 91; i <= 100; i++ {
+		p95.RemEvent(utils.ConcatenatedKey("EVENT", strconv.Itoa(i)))
+	}
+	if v := p95.GetFloat64Value(rndDec); v != float64(86*time.Second) {
+		t.Errorf("wrong p95 value: %v", v)
+	}
+	p95.RemEvent("EVENT_NOT_FOUND")
+	if v := p95.GetStringValue(rndDec); v != "86000000000" {
+		t.Errorf("wrong p95 value: %v", v)
+	}
+	if err := p95.AddEvent("EVENT_ERR", utils.MapStorage{utils.MetaReq: map[string]any{}}); err == nil ||
+		err.Error() != "NOT_FOUND:~*req.PDD" {
+		t.Errorf("Expected error, received: %v", err)
+	}
+}
+
+func TestStatPercentileMarshal(t *testing.T) {
+	p99, _ := NewStatMetric("*p99#~*req.Usage", 0, []string{"*string:~*req.Account:1001"})
+	for i, usage := range []string{"10s", "20s", "1m", "30m"} {
+		if err := p99.AddEvent(utils.ConcatenatedKey("EVENT", strconv.Itoa(i)),
+			utils.MapStorage{utils.MetaReq: map[string]any{utils.Usage: usage}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, ms := range []Marshaler{new(JSONMarshaler), NewCodecMsgpackMarshaler()} {
+		b, err := p99.Marshal(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rcv, _ := NewStatMetric("*p99#~*req.Usage", 0, []string{})
+		if err := rcv.LoadMarshaled(ms, b); err != nil {
+			t.Fatal(err)
+		}
+		if rcv.GetFloat64Value(5) != p99.GetFloat64Value(5) {
+			t.Errorf("Expected: %v, received: %v", p99.GetFloat64Value(5), rcv.GetFloat64Value(5))
+		}
+		if !reflect.DeepEqual(p99, rcv) {
+			t.Errorf("Expected: %s\nReceived: %s", utils.ToJSON(p99), utils.ToJSON(rcv))
+		}
+	}
+}
+
+func TestStatPercentileCompress(t *testing.T) {
+	p50, _ := NewStatPercentile(50, 0, "~*req.Cost", []string{})
+	p50.AddEvent("EVENT_1", utils.MapStorage{utils.MetaReq: map[string]any{utils.Cost: 1.}})
+	p50.AddEvent("EVENT_1", utils.MapStorage{utils.MetaReq: map[string]any{utils.Cost: 3.}})
+	p50.AddEvent("EVENT_2", utils.MapStorage{utils.MetaReq: map[string]any{utils.Cost: 5.}})
+	ids := p50.Compress(2, "EVENT_3", 5)
+	sort.Strings(ids)
+	if exp := []string{"EVENT_1", "EVENT_2"}; !reflect.DeepEqual(exp, ids) {
+		t.Errorf("Expected: %v, received: %v", exp, ids)
+	}
+	exp := map[string]int{"EVENT_1": 2, "EVENT_2": 1}
+	if rcv := p50.GetCompressFactor(map[string]int{"EVENT_2": 2}); !reflect.DeepEqual(map[string]int{"EVENT_1": 2, "EVENT_2": 2}, rcv) {
+		t.Errorf("Expected: %v, received: %v", exp, rcv)
+	}
+	if rcv := p50.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %v, received: %v", exp, rcv)
+	}
+	p50.RemEvent("EVENT_1")
+	if v := p50.GetFloat64Value(5); v != 3 {
+		t.Errorf("wrong p50 value: %v", v)
+	}
+}
+
+func TestStatHistogram(t *testing.T) {
+	if _, err := NewStatMetric("*histogram#~*req.Usage", 0, []string{}); err == nil ||
+		err.Error() != "missing bounds for *histogram metric" {
+		t.Errorf("Expected error, received: %v", err)
+	}
+	if _, err := NewStatMetric("*histogram#~*req.Usage|30s|10s", 0, []string{}); err == nil ||
+		err.Error() != "bounds for *histogram metric not in ascending order" {
+		t.Errorf("Expected error, received: %v", err)
+	}
+	if _, err := NewStatMetric("*histogram#~*req.Usage|ten", 0, []string{}); err == nil {
+		t.Error("Expected error")
+	}
+	hst, err := NewStatMetric("*histogram#~*req.Usage|10s|1m", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, usage := range []any{5 * time.Second, "10s", "45s", 2 * time.Minute, time.Second} {
+		if err := hst.AddEvent(utils.ConcatenatedKey("EVENT", strconv.Itoa(i)),
+			utils.MapStorage{utils.MetaReq: map[string]any{utils.Usage: usage}}); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			if v := hst.GetValue(5); v != utils.StatsNA {
+				t.Errorf("wrong histogram value: %v", v)
+			}
+			if v := hst.GetStringValue(5); v != utils.NotAvailable {
+				t.Errorf("wrong histogram value: %v", v)
+			}
+		}
+	}
+	exp := map[string]int64{"10s": 3, "1m": 1, utils.MetaInf: 1}
+	if v := hst.GetValue(5); !reflect.DeepEqual(exp, v) {
+		t.Errorf("Expected: %v, received: %v", exp, v)
+	}
+	if v := hst.GetFloat64Value(5); v != 5 {
+		t.Errorf("wrong histogram value: %v", v)
+	}
+	hst.RemEvent("EVENT:3")
+	if v := hst.GetStringValue(5); v != `{"*inf":0,"10s":3,"1m":1}` {
+		t.Errorf("wrong histogram value: %v", v)
+	}
+	if ids := hst.Compress(2, "EVENT_5", 5); len(ids) != 4 {
+		t.Errorf("Expected 4 events, received: %v", ids)
+	}
+	for _, ms := range []Marshaler{new(JSONMarshaler), NewCodecMsgpackMarshaler()} {
+		b, err := hst.Marshal(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rcv, _ := NewStatMetric("*histogram#~*req.Usage|10s|1m", 2, []string{})
+		if err := rcv.LoadMarshaled(ms, b); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(hst, rcv) {
+			t.Errorf("Expected: %s\nReceived: %s", utils.ToJSON(hst), utils.ToJSON(rcv))
+		}
+	}
+}
+
+func TestStatStdDev(t *testing.T) {
+	std, err := NewStatMetric("*stddev#~*req.Cost", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, cost := range []any{2., "4", 4, 4., 5, 5., 7, "9"} {
+		if err := std.AddEvent(utils.ConcatenatedKey("EVENT", strconv.Itoa(i)),
+			utils.MapStorage{utils.MetaReq: map[string]any{utils.Cost: cost}}); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			if v := std.GetFloat64Value(5); v != utils.StatsNA {
+				t.Errorf("wrong stddev value: %v", v)
+			}
+			if v := std.GetStringValue(5); v != utils.NotAvailable {
+				t.Errorf("wrong stddev value: %v", v)
+			}
+		}
+	}
+	if v := std.GetFloat64Value(5); v != 2 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	std.RemEvent("EVENT:7")
+	std.RemEvent("EVENT:0")
+	if v := std.GetStringValue(5); v != "1.06719" {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	if err := std.AddEvent("EVENT_ERR", utils.MapStorage{utils.MetaReq: map[string]any{}}); err == nil ||
+		err.Error() != "NOT_FOUND:~*req.Cost" {
+		t.Errorf("Expected error, received: %v", err)
+	}
+	for _, ms := range []Marshaler{new(JSONMarshaler), NewCodecMsgpackMarshaler()} {
+		b, err := std.Marshal(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rcv, _ := NewStatMetric("*stddev#~*req.Cost", 2, []string{})
+		if err := rcv.LoadMarshaled(ms, b); err != nil {
+			t.Fatal(err)
+		}
+		if rcv.GetFloat64Value(5) != std.GetFloat64Value(5) {
+			t.Errorf("Expected: %v, received: %v", std.GetFloat64Value(5), rcv.GetFloat64Value(5))
+		}
+		if !reflect.DeepEqual(std, rcv) {
+			t.Errorf("Expected: %s\nReceived: %s", utils.ToJSON(std), utils.ToJSON(rcv))
+		}
+	}
+}
+
+func TestStatRate(t *testing.T) {
+	if _, err := NewStatMetric("*rate#one", 0, []string{}); err == nil {
+		t.Error("Expected error")
+	}
+	if _, err := NewStatMetric("*rate#-1s", 0, []string{}); err == nil ||
+		err.Error() != "invalid time unit <-1s> for *rate metric" {
+		t.Errorf("Expected error, received: %v", err)
+	}
+	if rt, err := NewStatMetric(utils.MetaRate, 0, []string{}); err != nil {
+		t.Error(err)
+	} else if rt.(*StatRate).Unit != time.Minute {
+		t.Errorf("Expected default unit, received: %v", rt.(*StatRate).Unit)
+	}
+	rt, err := NewStatMetric("*rate#1s", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt.AddEvent("EVENT_1", nil)
+	if v := rt.GetStringValue(5); v != utils.NotAvailable {
+		t.Errorf("wrong rate value: %v", v)
+	}
+	rt.AddEvent("EVENT_2", nil)
+	rt.AddEvent("EVENT_3", nil)
+	// events younger than the unit are counted over one unit
+	if v := rt.GetFloat64Value(5); v != 3 {
+		t.Errorf("wrong rate value: %v", v)
+	}
+	rt.(*StatRate).Events["EVENT_1"] = []time.Time{time.Now().Add(-time.Minute)}
+	if v := rt.GetFloat64Value(2); v != 0.05 {
+		t.Errorf("wrong rate value: %v", v)
+	}
+	rt.RemEvent("EVENT_1")
+	if v := rt.GetFloat64Value(5); v != 2 {
+		t.Errorf("wrong rate value: %v", v)
+	}
+	rt.AddEvent("EVENT_2", nil)
+	exp := map[string]int{"EVENT_2": 2, "EVENT_3": 1}
+	if rcv := rt.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %v, received: %v", exp, rcv)
+	}
+	for _, ms := range []Marshaler{new(JSONMarshaler), NewCodecMsgpackMarshaler()} {
+		b, err := rt.Marshal(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rcv, _ := NewStatMetric("*rate#1s", 2, []string{})
+		if err := rcv.LoadMarshaled(ms, b); err != nil {
+			t.Fatal(err)
+		}
+		if rcv.GetFloat64Value(5) != 3 {
+			t.Errorf("wrong rate value: %v", rcv.GetFloat64Value(5))
+		}
+	}
+}
+
+func TestStatRateOneEvent(t *testing.T) {
+	rt, err := NewStatMetric("*rate#1s", 0, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		rt.AddOneEvent(nil)
+	}
+	if v := rt.GetFloat64Value(5); v != 3 {
+		t.Errorf("wrong rate value: %v", v)
+	}
+	// the window grows with the time since the first event
+	rt.(*StatRate).FirstEvent = time.Now().Add(-time.Minute)
+	if v := rt.GetFloat64Value(2); v != 0.05 {
+		t.Errorf("wrong rate value: %v", v)
+	}
+
+	// queues with TTL -1 do not store the events
+	sq := &StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "SQ_RATE",
+		SQMetrics: map[string]StatMetric{"*rate#1s": &StatRate{Events: make(map[string][]time.Time), Unit: time.Second}},
+		ttl:       utils.DurationPointer(-1),
+	}
+	ev := &utils.CGREvent{Tenant: "cgrates.org", ID: "EVENT_1"}
+	for i := 0; i < 2; i++ {
+		if err := sq.ProcessEvent(ev.Tenant, ev.ID, nil, utils.MapStorage{utils.MetaReq: ev.Event}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sqRt := sq.SQMetrics["*rate#1s"].(*StatRate)
+	if len(sqRt.Events) != 0 || sqRt.FirstEvent.IsZero() {
+		t.Errorf("unexpected metric: %s", utils.ToJSON(sqRt))
+	}
+	sqRt.FirstEvent = sqRt.FirstEvent.Add(-10 * time.Second)
+	if v := sqRt.GetFloat64Value(1); v != 0.2 {
+		t.Errorf("wrong rate value: %v", v)
+	}
+}
@@ -40,7 +40,7 @@ type InternalDB struct {
 	indexedFieldsMutex  sync.RWMutex   // used for reload
 	cnter               *utils.Counter // used for OrderID for cdr
 	ms                  Marshaler
-	db                  *ltcache.TransCache
+	db                  *internalDBStore
 	isDataDB            bool
 }
 
@@ -61,11 +61,30 @@ func NewInternalDB(stringIndexedFields, prefixIndexedFields []string, isDataDB b
 		prefixIndexedFields: prefixIndexedFields,
 		cnter:               utils.NewCounter(time.Now().UnixNano(), 0),
 		ms:                  ms,
-		db:                  ltcache.NewTransCache(tcCfg),
+		db:                  newInternalDBStore(tcCfg),
 		isDataDB:            isDataDB,
 	}
 }
 
+// NewInternalDBWithDump constructs an InternalDB restoring its data out of the snapshot and the
+// write-ahead log within dumpPath and logging there the following changes
+func NewInternalDBWithDump(stringIndexedFields, prefixIndexedFields []string, isDataDB bool,
+	itmsCfg map[string]*config.ItemOpt, dumpPath string,
+	fsyncIntvl, snapshotIntvl time.Duration) (iDB *InternalDB, err error) {
+	iDB = NewInternalDB(stringIndexedFields, prefixIndexedFields, isDataDB, itmsCfg)
+	if dumpPath == utils.EmptyString {
+		return
+	}
+	name := utils.StorDB
+	if isDataDB {
+		name = utils.DataDB
+	}
+	if err = iDB.db.openDump(dumpPath, name, fsyncIntvl, snapshotIntvl); err != nil {
+		return nil, fmt.Errorf("cannot restore the %s out of <%s>: %s", name, dumpPath, err.Error())
+	}
+	return
+}
+
 // SetStringIndexedFields set the stringIndexedFields, used at StorDB reload (is thread safe)
 func (iDB *InternalDB) SetStringIndexedFields(stringIndexedFields []string) {
 	iDB.indexedFieldsMutex.Lock()
@@ -80,8 +99,15 @@ func (iDB *InternalDB) SetPrefixIndexedFields(prefixIndexedFields []string) {
 	iDB.indexedFieldsMutex.Unlock()
 }
 
-// Close only to implement Storage interface
-func (iDB *InternalDB) Close() {}
+// Close stops writing the changes to disk, if enabled
+func (iDB *InternalDB) Close() {
+	iDB.db.close()
+}
+
+// Snapshot compacts the write-ahead log into a new snapshot of the data, if the dump is enabled
+func (iDB *InternalDB) Snapshot() error {
+	return iDB.db.Snapshot()
+}
 
 // Flush clears the cache
 func (iDB *InternalDB) Flush(string) error {
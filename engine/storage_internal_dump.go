@@ -0,0 +1,567 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+)
+
+const (
+	idbVerbSet byte = iota
+	idbVerbRemove
+	idbVerbRemoveGroup
+	idbVerbClear
+
+	idbSnapshotExt  = ".snapshot"
+	idbWALExt       = ".wal"
+	idbTmpExt       = ".tmp"
+	idbRecordHdrLen = 8 // length of the record followed by its CRC32
+)
+
+var (
+	errIDBTruncatedRecord = errors.New("truncated record")
+	errIDBCorruptedRecord = errors.New("corrupted record")
+)
+
+// internalDBItemTypes are the types of the items stored within the partitions of the InternalDB,
+// only the partitions listed here being kept on disk
+var internalDBItemTypes = map[string]reflect.Type{
+	utils.CacheVersions:            reflect.TypeOf(Versions{}),
+	utils.CacheRatingPlans:         reflect.TypeOf(new(RatingPlan)),
+	utils.CacheRatingProfiles:      reflect.TypeOf(new(RatingProfile)),
+	utils.CacheDestinations:        reflect.TypeOf(new(Destination)),
+	utils.CacheReverseDestinations: reflect.TypeOf([]string{}),
+	utils.CacheActions:             reflect.TypeOf(Actions{}),
+	utils.CacheSharedGroups:        reflect.TypeOf(new(SharedGroup)),
+	utils.CacheActionTriggers:      reflect.TypeOf(ActionTriggers{}),
+	utils.CacheActionPlans:         reflect.TypeOf(new(ActionPlan)),
+	utils.CacheAccountActionPlans:  reflect.TypeOf([]string{}),
+	utils.CacheAccounts:            reflect.TypeOf(new(Account)),
+	utils.CacheResourceProfiles:    reflect.TypeOf(new(ResourceProfile)),
+	utils.CacheResources:           reflect.TypeOf(new(Resource)),
+	utils.CacheTimings:             reflect.TypeOf(new(utils.TPTiming)),
+	utils.CacheStatQueueProfiles:   reflect.TypeOf(new(StatQueueProfile)),
+	utils.CacheStatQueues:          reflect.TypeOf(new(StoredStatQueue)), // metrics are interfaces, kept as StoredStatQueue
+	utils.CacheTrendProfiles:       reflect.TypeOf(new(TrendProfile)),
+	utils.CacheTrends:              reflect.TypeOf(new(Trend)),
+	utils.CacheRankingProfiles:     reflect.TypeOf(new(RankingProfile)),
+	utils.CacheRankings:            reflect.TypeOf(new(Ranking)),
+	utils.CacheThresholdProfiles:   reflect.TypeOf(new(ThresholdProfile)),
+	utils.CacheThresholds:          reflect.TypeOf(new(Threshold)),
+	utils.CacheFilters:             reflect.TypeOf(new(Filter)),
+	utils.CacheRouteProfiles:       reflect.TypeOf(new(RouteProfile)),
+	utils.CacheAttributeProfiles:   reflect.TypeOf(new(AttributeProfile)),
+	utils.CacheChargerProfiles:     reflect.TypeOf(new(ChargerProfile)),
+	utils.CacheDispatcherProfiles:  reflect.TypeOf(new(DispatcherProfile)),
+	utils.CacheDispatcherHosts:     reflect.TypeOf(new(DispatcherHost)),
+	utils.CacheLoadIDs:             reflect.TypeOf(map[string]int64{}),
+	utils.CacheSessionsBackup:      reflect.TypeOf(new(StoredSession)),
+
+	utils.CacheTBLTPTimings:          reflect.TypeOf(new(utils.ApierTPTiming)),
+	utils.CacheTBLTPDestinations:     reflect.TypeOf(new(utils.TPDestination)),
+	utils.CacheTBLTPRates:            reflect.TypeOf(new(utils.TPRateRALs)),
+	utils.CacheTBLTPDestinationRates: reflect.TypeOf(new(utils.TPDestinationRate)),
+	utils.CacheTBLTPRatingPlans:      reflect.TypeOf(new(utils.TPRatingPlan)),
+	utils.CacheTBLTPRatingProfiles:   reflect.TypeOf(new(utils.TPRatingProfile)),
+	utils.CacheTBLTPSharedGroups:     reflect.TypeOf(new(utils.TPSharedGroups)),
+	utils.CacheTBLTPActions:          reflect.TypeOf(new(utils.TPActions)),
+	utils.CacheTBLTPActionPlans:      reflect.TypeOf(new(utils.TPActionPlan)),
+	utils.CacheTBLTPActionTriggers:   reflect.TypeOf(new(utils.TPActionTriggers)),
+	utils.CacheTBLTPAccountActions:   reflect.TypeOf(new(utils.TPAccountActions)),
+	utils.CacheTBLTPResources:        reflect.TypeOf(new(utils.TPResourceProfile)),
+	utils.CacheTBLTPStats:            reflect.TypeOf(new(utils.TPStatProfile)),
+	utils.CacheTBLTPTrends:           reflect.TypeOf(new(utils.TPTrendsProfile)),
+	utils.CacheTBLTPRankings:         reflect.TypeOf(new(utils.TPRankingProfile)),
+	utils.CacheTBLTPThresholds:       reflect.TypeOf(new(utils.TPThresholdProfile)),
+	utils.CacheTBLTPFilters:          reflect.TypeOf(new(utils.TPFilterProfile)),
+	utils.CacheTBLTPRoutes:           reflect.TypeOf(new(utils.TPRouteProfile)),
+	utils.CacheTBLTPAttributes:       reflect.TypeOf(new(utils.TPAttributeProfile)),
+	utils.CacheTBLTPChargers:         reflect.TypeOf(new(utils.TPChargerProfile)),
+	utils.CacheTBLTPDispatchers:      reflect.TypeOf(new(utils.TPDispatcherProfile)),
+	utils.CacheTBLTPDispatcherHosts:  reflect.TypeOf(new(utils.TPDispatcherHost)),
+	utils.CacheCDRsTBL:               reflect.TypeOf(new(CDR)),
+	utils.CacheSessionCostsTBL:       reflect.TypeOf(new(SMCost)),
+}
+
+func init() {
+	for idxCacheID := range utils.CacheIndexesToPrefix {
+		internalDBItemTypes[idxCacheID] = reflect.TypeOf(utils.StringSet{})
+	}
+}
+
+// internalDBRecord is one change of the InternalDB as written within the dump files
+type internalDBRecord struct {
+	Verb     byte
+	CacheID  string
+	ItemID   string
+	GroupIDs []string
+	Value    []byte
+}
+
+// internalDBStore holds the data of the InternalDB, optionally keeping it on disk as a snapshot
+// followed by the write-ahead log of the changes done after it
+type internalDBStore struct {
+	*ltcache.TransCache
+	partitions []string // partitions kept on disk
+
+	mu         sync.Mutex // serializes the writes together with their logging
+	ms         Marshaler
+	path       string                                  // path of the dump files without extension, empty when kept only in memory
+	records    map[string]map[string]*internalDBRecord // last records of the items, encoded when set and written within the snapshots
+	wal        *os.File
+	walSize    int64
+	fsyncIntvl time.Duration
+	unsynced   bool // WAL written since the last fsync
+	stopChan   chan struct{}
+}
+
+func newInternalDBStore(tcCfg map[string]*ltcache.CacheConfig) (s *internalDBStore) {
+	s = &internalDBStore{
+		TransCache: ltcache.NewTransCache(tcCfg),
+		ms:         NewCodecMsgpackMarshaler(),
+	}
+	for cacheID := range tcCfg {
+		if _, has := internalDBItemTypes[cacheID]; has {
+			s.partitions = append(s.partitions, cacheID)
+		}
+	}
+	return
+}
+
+// Set adds/edits an item, logging the change if the dump is enabled
+func (s *internalDBStore) Set(chID, itmID string, value any,
+	groupIDs []string, commit bool, transID string) {
+	if !s.logged(chID, commit) {
+		s.TransCache.Set(chID, itmID, value, groupIDs, commit, transID)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.TransCache.Set(chID, itmID, value, groupIDs, commit, transID)
+	if s.wal == nil { // closed
+		return
+	}
+	// encoded here, while the caller still owns the item, since the services keep changing it in place
+	rec := &internalDBRecord{Verb: idbVerbSet, CacheID: chID, ItemID: itmID, GroupIDs: groupIDs}
+	var err error
+	if rec.Value, err = s.encodeValue(chID, value); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: %s encoding item <%s> of partition <%s>",
+			utils.MetaInternal, err.Error(), itmID, chID))
+		return
+	}
+	s.setRecord(chID, itmID, rec)
+	s.logRecord(rec)
+}
+
+// Remove removes an item, logging the change if the dump is enabled
+func (s *internalDBStore) Remove(chID, itmID string, commit bool, transID string) {
+	if !s.logged(chID, commit) {
+		s.TransCache.Remove(chID, itmID, commit, transID)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.TransCache.Remove(chID, itmID, commit, transID)
+	s.setRecord(chID, itmID, nil)
+	if s.wal != nil {
+		s.logRecord(&internalDBRecord{Verb: idbVerbRemove, CacheID: chID, ItemID: itmID})
+	}
+}
+
+// RemoveGroup removes the items of a group, logging the change if the dump is enabled
+func (s *internalDBStore) RemoveGroup(chID, grpID string, commit bool, transID string) {
+	if !s.logged(chID, commit) {
+		s.TransCache.RemoveGroup(chID, grpID, commit, transID)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeGroup(chID, grpID)
+	if s.wal != nil {
+		s.logRecord(&internalDBRecord{Verb: idbVerbRemoveGroup, CacheID: chID, GroupIDs: []string{grpID}})
+	}
+}
+
+// Clear removes the items of the partitions, all of them for nil chIDs, logging the change if the dump is enabled
+func (s *internalDBStore) Clear(chIDs []string) {
+	if s.path == utils.EmptyString {
+		s.TransCache.Clear(chIDs)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clear(chIDs)
+	if s.wal != nil {
+		s.logRecord(&internalDBRecord{Verb: idbVerbClear, GroupIDs: chIDs})
+	}
+}
+
+// logged returns true if the changes on the partition are written to disk
+func (s *internalDBStore) logged(chID string, commit bool) bool {
+	if s.path == utils.EmptyString || !commit {
+		return false
+	}
+	_, has := internalDBItemTypes[chID]
+	return has
+}
+
+// setRecord keeps the last record of the item for the snapshots, nil removing it
+func (s *internalDBStore) setRecord(chID, itmID string, rec *internalDBRecord) {
+	if rec == nil {
+		delete(s.records[chID], itmID)
+		return
+	}
+	if _, has := s.records[chID]; !has {
+		s.records[chID] = make(map[string]*internalDBRecord)
+	}
+	s.records[chID][itmID] = rec
+}
+
+func (s *internalDBStore) removeGroup(chID, grpID string) {
+	for _, itmID := range s.TransCache.GetGroupItemIDs(chID, grpID) {
+		delete(s.records[chID], itmID)
+	}
+	s.TransCache.RemoveGroup(chID, grpID, true, utils.NonTransactional)
+}
+
+func (s *internalDBStore) clear(chIDs []string) {
+	if chIDs == nil {
+		s.records = make(map[string]map[string]*internalDBRecord)
+	}
+	for _, chID := range chIDs {
+		delete(s.records, chID)
+	}
+	s.TransCache.Clear(chIDs)
+}
+
+// encodeValue marshals the item, the StatQueues being converted to StoredStatQueue
+func (s *internalDBStore) encodeValue(chID string, value any) ([]byte, error) {
+	if sq, isSQ := value.(*StatQueue); isSQ {
+		ssq, err := NewStoredStatQueue(sq, s.ms)
+		if err != nil {
+			return nil, err
+		}
+		value = ssq
+	}
+	return s.ms.Marshal(value)
+}
+
+// decodeValue unmarshals the item based on the type of the partition
+func (s *internalDBStore) decodeValue(chID string, data []byte) (any, error) {
+	itmType, has := internalDBItemTypes[chID]
+	if !has {
+		return nil, fmt.Errorf("unsupported partition <%s>", chID)
+	}
+	value := reflect.New(itmType)
+	if err := s.ms.Unmarshal(data, value.Interface()); err != nil {
+		return nil, err
+	}
+	if ssq, isSSQ := value.Elem().Interface().(*StoredStatQueue); isSSQ {
+		return ssq.AsStatQueue(s.ms)
+	}
+	return value.Elem().Interface(), nil
+}
+
+// logRecord appends the record to the write-ahead log, syncing it if requested
+func (s *internalDBStore) logRecord(rec *internalDBRecord) {
+	n, err := s.writeRecord(s.wal, rec)
+	s.walSize += int64(n)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: %s writing item <%s> of partition <%s> to <%s>",
+			utils.MetaInternal, err.Error(), rec.ItemID, rec.CacheID, s.wal.Name()))
+		return
+	}
+	s.unsynced = true
+	if s.fsyncIntvl == 0 {
+		s.sync()
+	}
+}
+
+// writeRecord writes the record prefixed by its length and its CRC32
+func (s *internalDBStore) writeRecord(w io.Writer, rec *internalDBRecord) (n int, err error) {
+	var data []byte
+	if data, err = s.ms.Marshal(rec); err != nil {
+		return
+	}
+	buf := make([]byte, idbRecordHdrLen, idbRecordHdrLen+len(data))
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(buf[4:], crc32.ChecksumIEEE(data))
+	return w.Write(append(buf, data...))
+}
+
+// readRecords applies the records out of r, returning the offset after the last valid one
+func (s *internalDBStore) readRecords(r io.Reader, apply func(*internalDBRecord) error) (offset int64, err error) {
+	hdr := make([]byte, idbRecordHdrLen)
+	for {
+		if _, err = io.ReadFull(r, hdr); err != nil {
+			if err == io.EOF {
+				err = nil
+			} else if err == io.ErrUnexpectedEOF {
+				err = errIDBTruncatedRecord
+			}
+			return
+		}
+		data := make([]byte, binary.BigEndian.Uint32(hdr[:4]))
+		if _, err = io.ReadFull(r, data); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				err = errIDBTruncatedRecord
+			}
+			return
+		}
+		if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(hdr[4:]) {
+			return offset, errIDBCorruptedRecord
+		}
+		var rec internalDBRecord
+		if err = s.ms.Unmarshal(data, &rec); err != nil {
+			return offset, errIDBCorruptedRecord
+		}
+		if err = apply(&rec); err != nil {
+			return
+		}
+		offset += int64(idbRecordHdrLen + len(data))
+	}
+}
+
+// applyRecord replays the change out of the record
+func (s *internalDBStore) applyRecord(rec *internalDBRecord) (err error) {
+	switch rec.Verb {
+	case idbVerbSet:
+		var value any
+		if value, err = s.decodeValue(rec.CacheID, rec.Value); err != nil {
+			return fmt.Errorf("item <%s> of partition <%s>: %s", rec.ItemID, rec.CacheID, err.Error())
+		}
+		s.TransCache.Set(rec.CacheID, rec.ItemID, value, rec.GroupIDs, true, utils.NonTransactional)
+		s.setRecord(rec.CacheID, rec.ItemID, rec)
+	case idbVerbRemove:
+		s.TransCache.Remove(rec.CacheID, rec.ItemID, true, utils.NonTransactional)
+		s.setRecord(rec.CacheID, rec.ItemID, nil)
+	case idbVerbRemoveGroup:
+		for _, grpID := range rec.GroupIDs {
+			s.removeGroup(rec.CacheID, grpID)
+		}
+	case idbVerbClear:
+		s.clear(rec.GroupIDs)
+	default:
+		return fmt.Errorf("unsupported verb <%d>", rec.Verb)
+	}
+	return
+}
+
+// openDump restores the data out of the dump files named name within dumpPath and starts logging the changes
+func (s *internalDBStore) openDump(dumpPath, name string, fsyncIntvl, snapshotIntvl time.Duration) (err error) {
+	s.path = filepath.Join(dumpPath, name)
+	s.fsyncIntvl = fsyncIntvl
+	s.records = make(map[string]map[string]*internalDBRecord)
+	if err = s.restore(); err != nil {
+		return
+	}
+	if s.wal, err = os.OpenFile(s.path+idbWALExt, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return
+	}
+	if s.walSize != 0 { // compact what was restored
+		if err = s.Snapshot(); err != nil {
+			s.wal.Close()
+			return
+		}
+	}
+	s.stopChan = make(chan struct{})
+	go s.loop(fsyncIntvl, snapshotIntvl)
+	return
+}
+
+// restore loads the snapshot followed by the write-ahead log, dropping the incomplete records at the end of the log
+func (s *internalDBStore) restore() (err error) {
+	if _, err = s.restoreFile(s.path + idbSnapshotExt); err != nil {
+		if err == errIDBTruncatedRecord || err == errIDBCorruptedRecord {
+			err = fmt.Errorf("snapshot <%s>: %s", s.path+idbSnapshotExt, err.Error())
+		}
+		return
+	}
+	walPath := s.path + idbWALExt
+	if s.walSize, err = s.restoreFile(walPath); err != nil {
+		if err != errIDBTruncatedRecord && err != errIDBCorruptedRecord {
+			return
+		}
+		utils.Logger.Warning(fmt.Sprintf("<%s> %s at offset %d of <%s>, dropping the rest of the log",
+			utils.MetaInternal, err.Error(), s.walSize, walPath))
+		if err = os.Truncate(walPath, s.walSize); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// restoreFile applies the records out of the file, ignoring the missing one
+func (s *internalDBStore) restoreFile(path string) (offset int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	defer f.Close()
+	return s.readRecords(bufio.NewReader(f), s.applyRecord)
+}
+
+// Snapshot writes all the items into a new snapshot, emptying the write-ahead log
+func (s *internalDBStore) Snapshot() (err error) {
+	if s.path == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.InternalDBDumpPathCfg)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.wal == nil {
+		return utils.ErrNotFound
+	}
+	return s.snapshot()
+}
+
+// snapshot writes the records kept into a new snapshot, called with the lock held
+func (s *internalDBStore) snapshot() (err error) {
+	tmpPath := s.path + idbSnapshotExt + idbTmpExt
+	if err = s.writeSnapshot(tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return
+	}
+	if err = os.Rename(tmpPath, s.path+idbSnapshotExt); err != nil {
+		return
+	}
+	if dir, dirErr := os.Open(filepath.Dir(s.path)); dirErr == nil { // persist the rename
+		dir.Sync()
+		dir.Close()
+	}
+	if err = s.wal.Truncate(0); err != nil {
+		return
+	}
+	s.walSize = 0
+	s.unsynced = false
+	return
+}
+
+// writeSnapshot writes the records encoded when the items were set, never the live items
+func (s *internalDBStore) writeSnapshot(path string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	for _, chID := range s.partitions {
+		for _, itmID := range s.TransCache.GetItemIDs(chID, utils.EmptyString) {
+			rec, has := s.records[chID][itmID]
+			if !has {
+				continue
+			}
+			if _, err = s.writeRecord(w, rec); err != nil {
+				return
+			}
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return
+	}
+	return f.Sync()
+}
+
+// sync flushes the write-ahead log to disk, called with the lock held
+func (s *internalDBStore) sync() {
+	if !s.unsynced || s.wal == nil {
+		return
+	}
+	if err := s.wal.Sync(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: %s syncing <%s>",
+			utils.MetaInternal, err.Error(), s.wal.Name()))
+		return
+	}
+	s.unsynced = false
+}
+
+// loop syncs the write-ahead log and takes the snapshots at the configured intervals
+func (s *internalDBStore) loop(fsyncIntvl, snapshotIntvl time.Duration) {
+	var syncTick, snapshotTick <-chan time.Time
+	if fsyncIntvl > 0 {
+		tkr := time.NewTicker(fsyncIntvl)
+		defer tkr.Stop()
+		syncTick = tkr.C
+	}
+	if snapshotIntvl > 0 {
+		tkr := time.NewTicker(snapshotIntvl)
+		defer tkr.Stop()
+		snapshotTick = tkr.C
+	}
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-syncTick:
+			s.mu.Lock()
+			s.sync()
+			s.mu.Unlock()
+		case <-snapshotTick:
+			s.mu.Lock()
+			skip := s.wal == nil || s.walSize == 0
+			s.mu.Unlock()
+			if skip {
+				continue
+			}
+			if err := s.Snapshot(); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error: %s taking the snapshot of <%s>",
+					utils.MetaInternal, err.Error(), s.path))
+			}
+		}
+	}
+}
+
+// close stops logging the changes, compacting the write-ahead log into a last snapshot
+func (s *internalDBStore) close() {
+	if s.path == utils.EmptyString {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.wal == nil {
+		return
+	}
+	close(s.stopChan)
+	if s.walSize != 0 {
+		if err := s.snapshot(); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error: %s taking the snapshot of <%s>",
+				utils.MetaInternal, err.Error(), s.path))
+		}
+	}
+	if s.fsyncIntvl >= 0 { // whatever the snapshot left within the log
+		s.sync()
+	}
+	s.wal.Close()
+	s.wal = nil
+}
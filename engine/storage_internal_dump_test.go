@@ -0,0 +1,221 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestInternalDBDumpRestore(t *testing.T) {
+	dumpPath := t.TempDir()
+	itmsCfg := config.CgrConfig().DataDbCfg().Items
+	iDB, err := NewInternalDBWithDump(nil, nil, true, itmsCfg, dumpPath, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc := &Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {{ID: "B1", Value: 10, Weight: 10}},
+		},
+	}
+	if err = iDB.SetAccountDrv(acc); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Fatal(err)
+	}
+	sq := &StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "SQ1",
+		SQItems:   []SQItem{{EventID: "ev1"}},
+		SQMetrics: map[string]StatMetric{},
+	}
+	if err = iDB.SetStatQueueDrv(nil, sq); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.RemoveAccountDrv("cgrates.org:1002"); err != nil {
+		t.Fatal(err)
+	}
+	iDB.Close()
+
+	// reopen out of the write-ahead log only
+	if iDB, err = NewInternalDBWithDump(nil, nil, true, itmsCfg, dumpPath, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := iDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Fatal(err)
+	} else if acc.UpdateTime = rcv.UpdateTime; !reflect.DeepEqual(acc, rcv) { // not cloned
+		t.Errorf("expected %s, received %s", utils.ToJSON(acc), utils.ToJSON(rcv))
+	}
+	if _, err = iDB.GetAccountDrv("cgrates.org:1002"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if rcv, err := iDB.GetStatQueueDrv("cgrates.org", "SQ1"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(sq.SQItems, rcv.SQItems) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(sq.SQItems), utils.ToJSON(rcv.SQItems))
+	}
+	// the restored log was compacted into the snapshot
+	walPath := filepath.Join(dumpPath, utils.DataDB+idbWALExt)
+	if fi, err := os.Stat(walPath); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() != 0 {
+		t.Errorf("expected empty write-ahead log, received %d bytes", fi.Size())
+	}
+	if _, err = os.Stat(filepath.Join(dumpPath, utils.DataDB+idbSnapshotExt)); err != nil {
+		t.Fatal(err)
+	}
+
+	// a partially written record at the end of the log is dropped
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1003"}); err != nil {
+		t.Fatal(err)
+	}
+	snapshotPath := filepath.Join(dumpPath, utils.DataDB+idbSnapshotExt)
+	snapshot, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wal, err := os.ReadFile(walPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iDB.Close()
+	// crash while writing the last record, before the snapshot taken on close
+	if err = os.WriteFile(snapshotPath, snapshot, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(walPath, wal[:len(wal)-1], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if iDB, err = NewInternalDBWithDump(nil, nil, true, itmsCfg, dumpPath, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if _, err = iDB.GetAccountDrv("cgrates.org:1003"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err = iDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInternalDBDumpStorDB(t *testing.T) {
+	dumpPath := t.TempDir()
+	itmsCfg := config.CgrConfig().StorDbCfg().Items
+	iDB, err := NewInternalDBWithDump(nil, nil, false, itmsCfg, dumpPath, -1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdr := &CDR{
+		CGRID:       "cgrid1",
+		RunID:       utils.MetaDefault,
+		OriginID:    "orig1",
+		ToR:         utils.MetaVoice,
+		RequestType: utils.MetaPrepaid,
+		Tenant:      "cgrates.org",
+		Account:     "1001",
+		Usage:       time.Minute,
+		Cost:        1.2,
+	}
+	if err = iDB.SetCDR(cdr, false); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	iDB.Close()
+	if err = iDB.Snapshot(); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+
+	if iDB, err = NewInternalDBWithDump(nil, nil, false, itmsCfg, dumpPath, -1, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if cdrs, _, err := iDB.GetCDRs(&utils.CDRsFilter{Accounts: []string{"1001"}}, false); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 1 || cdrs[0].OriginID != "orig1" || cdrs[0].Cost != 1.2 {
+		t.Errorf("unexpected CDRs: %s", utils.ToJSON(cdrs))
+	}
+	// the removal drops the indexes kept as groups as well
+	if _, _, err = iDB.GetCDRs(&utils.CDRsFilter{Accounts: []string{"1001"}}, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = iDB.GetCDRs(&utils.CDRsFilter{OriginIDs: []string{"orig1"}}, false); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestInternalDBSnapshotNoDumpPath(t *testing.T) {
+	iDB := NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	if err := iDB.Snapshot(); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.InternalDBDumpPathCfg).Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestInternalDBSnapshotSetItems(t *testing.T) {
+	dumpPath := t.TempDir()
+	itmsCfg := config.CgrConfig().DataDbCfg().Items
+	iDB, err := NewInternalDBWithDump(nil, nil, true, itmsCfg, dumpPath, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	th := &Threshold{Tenant: "cgrates.org", ID: "TH1", Hits: 1}
+	if err = iDB.SetThresholdDrv(th); err != nil {
+		t.Fatal(err)
+	}
+	th.Hits = 2 // changed in place by the service without being stored
+	if err = iDB.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.SetThresholdDrv(&Threshold{Tenant: "cgrates.org", ID: "TH2", Hits: 3}); err != nil {
+		t.Fatal(err)
+	}
+	iDB.Close()
+	// the write-ahead log is compacted on close
+	if fi, err := os.Stat(filepath.Join(dumpPath, utils.DataDB+idbWALExt)); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() != 0 {
+		t.Errorf("expected the write-ahead log compacted, received %d bytes", fi.Size())
+	}
+
+	if iDB, err = NewInternalDBWithDump(nil, nil, true, itmsCfg, dumpPath, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if rcv, err := iDB.GetThresholdDrv("cgrates.org", "TH1"); err != nil {
+		t.Fatal(err)
+	} else if rcv.Hits != 1 {
+		t.Errorf("expected the stored hits, received %d", rcv.Hits)
+	}
+	if rcv, err := iDB.GetThresholdDrv("cgrates.org", "TH2"); err != nil {
+		t.Fatal(err)
+	} else if rcv.Hits != 3 {
+		t.Errorf("expected 3 hits, received %d", rcv.Hits)
+	}
+}
@@ -121,10 +121,10 @@ func (sqls *SQLStorage) IsDBEmpty() (resp bool, err error) {
 func (sqls *SQLStorage) GetTpIds(colName string) ([]string, error) {
 	var rows *sql.Rows
 	var err error
-	qryStr := fmt.Sprintf(" (SELECT tpid FROM %s)", colName)
+	qryStr := fmt.Sprintf("SELECT tpid FROM %s", colName)
 	if colName == "" {
 		qryStr = fmt.Sprintf(
-			"(SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s)",
+			"SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s UNION SELECT tpid FROM %s",
 			utils.TBLTPTimings,
 			utils.TBLTPDestinations,
 			utils.TBLTPRates,
@@ -923,7 +923,8 @@ func (sqls *SQLStorage) SetCDR(cdr *CDR, allowUpdate bool) error {
 	if saved.Error != nil {
 		tx.Rollback()
 		if !allowUpdate {
-			if strings.Contains(saved.Error.Error(), "1062") || strings.Contains(saved.Error.Error(), "duplicate key") { // returns 1062/pq when key is duplicated
+			if strings.Contains(saved.Error.Error(), "1062") || strings.Contains(saved.Error.Error(), "duplicate key") ||
+				strings.Contains(saved.Error.Error(), "UNIQUE constraint failed") { // returns 1062/pq/sqlite when key is duplicated
 				return utils.ErrExists
 			}
 			return saved.Error
@@ -0,0 +1,117 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteDSNOpts enables concurrent reads while writing and makes the writers wait for the lock instead of failing
+const sqliteDSNOpts = "?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_txlock=immediate"
+
+// NewSQLiteStorage returns the SQLite storDB kept in the dbPath file,
+// creating the tables out of the scripts within scriptsPath when the database is empty
+func NewSQLiteStorage(dbPath, scriptsPath string,
+	maxConn, maxIdleConn int, connMaxLifetime time.Duration) (*SQLStorage, error) {
+	db, err := gorm.Open(sqlite.Open(dbPath+sqliteDSNOpts), &gorm.Config{AllowGlobalUpdate: true})
+	if err != nil {
+		return nil, err
+	}
+	sqliteStor := new(SQLiteStorage)
+	if sqliteStor.Db, err = db.DB(); err != nil {
+		return nil, err
+	}
+	if err = sqliteStor.Db.Ping(); err != nil {
+		return nil, err
+	}
+	sqliteStor.Db.SetMaxIdleConns(maxIdleConn)
+	sqliteStor.Db.SetMaxOpenConns(maxConn)
+	sqliteStor.Db.SetConnMaxLifetime(connMaxLifetime)
+	sqliteStor.db = db
+	sqlStor := &SQLStorage{
+		Db:      sqliteStor.Db,
+		db:      sqliteStor.db,
+		StorDB:  sqliteStor,
+		SQLImpl: sqliteStor,
+	}
+	var empty bool
+	if empty, err = sqlStor.IsDBEmpty(); err != nil {
+		sqlStor.Close()
+		return nil, err
+	}
+	if empty {
+		if err = sqlStor.Flush(scriptsPath); err != nil {
+			sqlStor.Close()
+			return nil, fmt.Errorf("cannot create the tables out of <%s>: %s", scriptsPath, err.Error())
+		}
+	}
+	return sqlStor, nil
+}
+
+type SQLiteStorage struct {
+	SQLStorage
+}
+
+func (sqliteS *SQLiteStorage) SetVersions(vrs Versions, overwrite bool) (err error) {
+	tx := sqliteS.db.Begin()
+	if overwrite {
+		tx.Table(utils.TBLVersions).Delete(nil)
+	}
+	for key, val := range vrs {
+		vrModel := &TBLVersion{Item: key, Version: val}
+		if !overwrite {
+			if err = tx.Model(&TBLVersion{}).Where(
+				TBLVersion{Item: vrModel.Item}).Delete(TBLVersion{Version: val}).Error; err != nil {
+				tx.Rollback()
+				return
+			}
+		}
+		if err = tx.Save(vrModel).Error; err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+	tx.Commit()
+	return
+}
+
+func (sqliteS *SQLiteStorage) extraFieldsExistsQry(field string) string {
+	return fmt.Sprintf(" json_type(extra_fields, '$.\"%s\"') IS NOT NULL", field)
+}
+
+func (sqliteS *SQLiteStorage) extraFieldsValueQry(field, value string) string {
+	return fmt.Sprintf(" json_extract(extra_fields, '$.\"%s\"') = '%s'", field, value)
+}
+
+func (sqliteS *SQLiteStorage) notExtraFieldsExistsQry(field string) string {
+	return fmt.Sprintf(" json_type(extra_fields, '$.\"%s\"') IS NULL", field)
+}
+
+func (sqliteS *SQLiteStorage) notExtraFieldsValueQry(field, value string) string {
+	return fmt.Sprintf(" NOT (json_type(extra_fields, '$.\"%s\"') IS NOT NULL AND json_extract(extra_fields, '$.\"%s\"') = '%s')", field, field, value)
+}
+
+func (sqliteS *SQLiteStorage) GetStorageType() string {
+	return utils.MetaSQLite
+}
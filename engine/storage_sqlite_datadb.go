@@ -0,0 +1,1037 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// SQLite tables holding the DataDB, modeled after the redis data types
+// so they can share the same file with the StorDB tables
+const (
+	sqliteItemsTbl  = "datadb_items"  // plain keys
+	sqliteHashesTbl = "datadb_hashes" // hashes and sets(with empty values)
+	sqliteListsTbl  = "datadb_lists"  // lists ordered by id
+)
+
+var sqliteDataDBSchema = []string{
+	"CREATE TABLE IF NOT EXISTS " + sqliteItemsTbl + " (dbkey TEXT NOT NULL PRIMARY KEY, value BLOB NOT NULL)",
+	"CREATE TABLE IF NOT EXISTS " + sqliteHashesTbl + " (dbkey TEXT NOT NULL, field TEXT NOT NULL, value BLOB NOT NULL, PRIMARY KEY (dbkey, field))",
+	"CREATE TABLE IF NOT EXISTS " + sqliteListsTbl + " (id INTEGER PRIMARY KEY AUTOINCREMENT, dbkey TEXT NOT NULL, value BLOB NOT NULL)",
+	"CREATE INDEX IF NOT EXISTS " + sqliteListsTbl + "_idx ON " + sqliteListsTbl + " (dbkey, id)",
+}
+
+// SQLiteDataDB is the DataDB kept in a SQLite file
+type SQLiteDataDB struct {
+	db *sql.DB
+	ms Marshaler
+}
+
+// NewSQLiteDataDB returns the DataDB kept in the dbPath file, creating its tables if missing
+func NewSQLiteDataDB(dbPath, mrshlerStr string) (_ *SQLiteDataDB, err error) {
+	var ms Marshaler
+	if ms, err = NewMarshaler(mrshlerStr); err != nil {
+		return
+	}
+	var gormDB *gorm.DB
+	if gormDB, err = gorm.Open(sqlite.Open(dbPath+sqliteDSNOpts), &gorm.Config{}); err != nil {
+		return
+	}
+	var db *sql.DB
+	if db, err = gormDB.DB(); err != nil {
+		return
+	}
+	for _, qry := range sqliteDataDBSchema {
+		if _, err = db.Exec(qry); err != nil {
+			db.Close()
+			return
+		}
+	}
+	return &SQLiteDataDB{
+		db: db,
+		ms: ms,
+	}, nil
+}
+
+// globPrefix returns the GLOB pattern matching the keys starting with prefix
+func globPrefix(prefix string) string {
+	return strings.NewReplacer("[", "[[]", "*", "[*]", "?", "[?]").Replace(prefix) + "*"
+}
+
+// inTx runs f inside a transaction, rolling it back on error
+func (sqliteDB *SQLiteDataDB) inTx(f func(tx *sql.Tx) error) (err error) {
+	var tx *sql.Tx
+	if tx, err = sqliteDB.db.Begin(); err != nil {
+		return
+	}
+	if err = f(tx); err != nil {
+		tx.Rollback()
+		return
+	}
+	return tx.Commit()
+}
+
+func (sqliteDB *SQLiteDataDB) getValue(key string) (value []byte, err error) {
+	if err = sqliteDB.db.QueryRow("SELECT value FROM "+sqliteItemsTbl+" WHERE dbkey=?",
+		key).Scan(&value); err == sql.ErrNoRows {
+		err = utils.ErrNotFound
+	}
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) setValue(key string, value []byte) (err error) {
+	_, err = sqliteDB.db.Exec("INSERT OR REPLACE INTO "+sqliteItemsTbl+" (dbkey, value) VALUES (?, ?)",
+		key, value)
+	return
+}
+
+// getItem unmarshals the value of the key into rcv
+func (sqliteDB *SQLiteDataDB) getItem(key string, rcv any) (err error) {
+	var value []byte
+	if value, err = sqliteDB.getValue(key); err != nil {
+		return
+	}
+	return sqliteDB.ms.Unmarshal(value, rcv)
+}
+
+// setItem stores the marshaled item under key
+func (sqliteDB *SQLiteDataDB) setItem(key string, itm any) (err error) {
+	var value []byte
+	if value, err = sqliteDB.ms.Marshal(itm); err != nil {
+		return
+	}
+	return sqliteDB.setValue(key, value)
+}
+
+// delKey removes the key regardless of its type
+func (sqliteDB *SQLiteDataDB) delKey(key string) (err error) {
+	return sqliteDB.inTx(func(tx *sql.Tx) (err error) {
+		for _, tbl := range []string{sqliteItemsTbl, sqliteHashesTbl, sqliteListsTbl} {
+			if _, err = tx.Exec("DELETE FROM "+tbl+" WHERE dbkey=?", key); err != nil {
+				return
+			}
+		}
+		return
+	})
+}
+
+func (sqliteDB *SQLiteDataDB) queryStrings(qry string, args ...any) (vals []string, err error) {
+	var rows *sql.Rows
+	if rows, err = sqliteDB.db.Query(qry, args...); err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var val string
+		if err = rows.Scan(&val); err != nil {
+			return
+		}
+		vals = append(vals, val)
+	}
+	err = rows.Err()
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) hGetAll(key string) (mp map[string][]byte, err error) {
+	var rows *sql.Rows
+	if rows, err = sqliteDB.db.Query("SELECT field, value FROM "+sqliteHashesTbl+" WHERE dbkey=?",
+		key); err != nil {
+		return
+	}
+	defer rows.Close()
+	mp = make(map[string][]byte)
+	for rows.Next() {
+		var fld string
+		var val []byte
+		if err = rows.Scan(&fld, &val); err != nil {
+			return
+		}
+		mp[fld] = val
+	}
+	err = rows.Err()
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) hGet(key, fld string) (value []byte, err error) {
+	if err = sqliteDB.db.QueryRow("SELECT value FROM "+sqliteHashesTbl+" WHERE dbkey=? AND field=?",
+		key, fld).Scan(&value); err == sql.ErrNoRows {
+		err = utils.ErrNotFound
+	}
+	return
+}
+
+func hSetTx(tx *sql.Tx, key string, mp map[string][]byte) (err error) {
+	for fld, val := range mp {
+		if _, err = tx.Exec("INSERT OR REPLACE INTO "+sqliteHashesTbl+" (dbkey, field, value) VALUES (?, ?, ?)",
+			key, fld, val); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func hDelTx(tx *sql.Tx, key string, flds ...string) (err error) {
+	for _, fld := range flds {
+		if _, err = tx.Exec("DELETE FROM "+sqliteHashesTbl+" WHERE dbkey=? AND field=?",
+			key, fld); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) hSet(key string, mp map[string][]byte) (err error) {
+	return sqliteDB.inTx(func(tx *sql.Tx) error { return hSetTx(tx, key, mp) })
+}
+
+func (sqliteDB *SQLiteDataDB) hDel(key string, flds ...string) (err error) {
+	return sqliteDB.inTx(func(tx *sql.Tx) error { return hDelTx(tx, key, flds...) })
+}
+
+func (sqliteDB *SQLiteDataDB) Close() {
+	sqliteDB.db.Close()
+}
+
+func (sqliteDB *SQLiteDataDB) Flush(ignore string) (err error) {
+	return sqliteDB.inTx(func(tx *sql.Tx) (err error) {
+		for _, tbl := range []string{sqliteItemsTbl, sqliteHashesTbl, sqliteListsTbl} {
+			if _, err = tx.Exec("DELETE FROM " + tbl); err != nil {
+				return
+			}
+		}
+		return
+	})
+}
+
+func (sqliteDB *SQLiteDataDB) Marshaler() Marshaler {
+	return sqliteDB.ms
+}
+
+func (sqliteDB *SQLiteDataDB) SelectDatabase(dbName string) (err error) {
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) IsDBEmpty() (resp bool, err error) {
+	var keys []string
+	if keys, err = sqliteDB.GetKeysForPrefix(utils.EmptyString); err != nil {
+		return
+	}
+	return len(keys) == 0, nil
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveKeysForPrefix(prefix string) (err error) {
+	var keys []string
+	if keys, err = sqliteDB.GetKeysForPrefix(prefix); err != nil {
+		return
+	}
+	for _, key := range keys {
+		if err = sqliteDB.delKey(key); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) GetKeysForPrefix(prefix string) (keys []string, err error) {
+	glob := globPrefix(prefix)
+	if filterIndexesPrefixMap.Has(prefix) {
+		var rows *sql.Rows
+		if rows, err = sqliteDB.db.Query("SELECT dbkey, field FROM "+sqliteHashesTbl+" WHERE dbkey GLOB ?",
+			glob); err != nil {
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var key, fld string
+			if err = rows.Scan(&key, &fld); err != nil {
+				return
+			}
+			keys = append(keys, utils.ConcatenatedKey(key, fld))
+		}
+		err = rows.Err()
+		return
+	}
+	return sqliteDB.queryStrings("SELECT dbkey FROM "+sqliteItemsTbl+" WHERE dbkey GLOB ?"+
+		" UNION SELECT dbkey FROM "+sqliteHashesTbl+" WHERE dbkey GLOB ?"+
+		" UNION SELECT dbkey FROM "+sqliteListsTbl+" WHERE dbkey GLOB ?", glob, glob, glob)
+}
+
+// Used to check if specific subject is stored using prefix key attached to entity
+func (sqliteDB *SQLiteDataDB) HasDataDrv(category, subject, tenant string) (exists bool, err error) {
+	switch category {
+	case utils.DestinationPrefix, utils.RatingPlanPrefix, utils.RatingProfilePrefix,
+		utils.ActionPrefix, utils.ActionPlanPrefix, utils.AccountPrefix:
+	case utils.ResourcesPrefix, utils.ResourceProfilesPrefix, utils.StatQueuePrefix,
+		utils.StatQueueProfilePrefix, utils.ThresholdPrefix, utils.ThresholdProfilePrefix,
+		utils.FilterPrefix, utils.RouteProfilePrefix, utils.AttributeProfilePrefix,
+		utils.ChargerProfilePrefix, utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix:
+		subject = utils.ConcatenatedKey(tenant, subject)
+	default:
+		return false, errors.New("unsupported HasData category")
+	}
+	if _, err = sqliteDB.getValue(category + subject); err == utils.ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (sqliteDB *SQLiteDataDB) GetRatingPlanDrv(key string) (rp *RatingPlan, err error) {
+	err = sqliteDB.getItem(utils.RatingPlanPrefix+key, &rp)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetRatingPlanDrv(rp *RatingPlan) (err error) {
+	return sqliteDB.setItem(utils.RatingPlanPrefix+rp.Id, rp)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveRatingPlanDrv(key string) (err error) {
+	return sqliteDB.RemoveKeysForPrefix(utils.RatingPlanPrefix + key)
+}
+
+func (sqliteDB *SQLiteDataDB) GetRatingProfileDrv(key string) (rpf *RatingProfile, err error) {
+	err = sqliteDB.getItem(utils.RatingProfilePrefix+key, &rpf)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetRatingProfileDrv(rpf *RatingProfile) (err error) {
+	return sqliteDB.setItem(utils.RatingProfilePrefix+rpf.Id, rpf)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveRatingProfileDrv(key string) (err error) {
+	return sqliteDB.RemoveKeysForPrefix(utils.RatingProfilePrefix + key)
+}
+
+func (sqliteDB *SQLiteDataDB) GetDestinationDrv(key, transactionID string) (dest *Destination, err error) {
+	err = sqliteDB.getItem(utils.DestinationPrefix+key, &dest)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetDestinationDrv(dest *Destination, transactionID string) (err error) {
+	return sqliteDB.setItem(utils.DestinationPrefix+dest.Id, dest)
+}
+
+func (sqliteDB *SQLiteDataDB) GetReverseDestinationDrv(key, transactionID string) (ids []string, err error) {
+	if ids, err = sqliteDB.queryStrings("SELECT field FROM "+sqliteHashesTbl+" WHERE dbkey=?",
+		utils.ReverseDestinationPrefix+key); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = utils.ErrNotFound
+	}
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetReverseDestinationDrv(destID string, prefixes []string, transactionID string) (err error) {
+	return sqliteDB.inTx(func(tx *sql.Tx) (err error) {
+		for _, p := range prefixes {
+			if err = hSetTx(tx, utils.ReverseDestinationPrefix+p,
+				map[string][]byte{destID: {}}); err != nil {
+				return
+			}
+		}
+		return
+	})
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveDestinationDrv(destID, transactionID string) (err error) {
+	return sqliteDB.delKey(utils.DestinationPrefix + destID)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveReverseDestinationDrv(dstID, prfx, transactionID string) (err error) {
+	return sqliteDB.hDel(utils.ReverseDestinationPrefix+prfx, dstID)
+}
+
+func (sqliteDB *SQLiteDataDB) GetActionsDrv(key string) (as Actions, err error) {
+	err = sqliteDB.getItem(utils.ActionPrefix+key, &as)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetActionsDrv(key string, as Actions) (err error) {
+	return sqliteDB.setItem(utils.ActionPrefix+key, &as)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveActionsDrv(key string) (err error) {
+	return sqliteDB.delKey(utils.ActionPrefix + key)
+}
+
+func (sqliteDB *SQLiteDataDB) GetSharedGroupDrv(key string) (sg *SharedGroup, err error) {
+	err = sqliteDB.getItem(utils.SharedGroupPrefix+key, &sg)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetSharedGroupDrv(sg *SharedGroup) (err error) {
+	return sqliteDB.setItem(utils.SharedGroupPrefix+sg.Id, sg)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveSharedGroupDrv(id string) (err error) {
+	return sqliteDB.delKey(utils.SharedGroupPrefix + id)
+}
+
+func (sqliteDB *SQLiteDataDB) GetAccountDrv(key string) (ub *Account, err error) {
+	ub = &Account{ID: key}
+	if err = sqliteDB.getItem(utils.AccountPrefix+key, ub); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetAccountDrv(acc *Account) (err error) {
+	// never override existing account with an empty one
+	// UPDATE: if all balances expired and were cleaned it makes
+	// sense to write empty balance map
+	if len(acc.BalanceMap) == 0 {
+		var ac *Account
+		if ac, err = sqliteDB.GetAccountDrv(acc.ID); err == nil && !ac.allBalancesExpired() {
+			ac.ActionTriggers = acc.ActionTriggers
+			ac.UnitCounters = acc.UnitCounters
+			ac.AllowNegative = acc.AllowNegative
+			ac.Disabled = acc.Disabled
+			acc = ac
+		}
+	}
+	acc.UpdateTime = time.Now()
+	return sqliteDB.setItem(utils.AccountPrefix+acc.ID, acc)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveAccountDrv(key string) (err error) {
+	return sqliteDB.delKey(utils.AccountPrefix + key)
+}
+
+// Limit will only retrieve the last n items out of history, newest first
+func (sqliteDB *SQLiteDataDB) GetLoadHistory(limit int, skipCache bool,
+	transactionID string) (loadInsts []*utils.LoadInstance, err error) {
+	if limit == 0 {
+		return nil, nil
+	}
+	if !skipCache {
+		if x, ok := Cache.Get(utils.LoadInstKey, ""); ok {
+			if x != nil {
+				items := x.([]*utils.LoadInstance)
+				if len(items) < limit || limit == -1 {
+					return items, nil
+				}
+				return items[:limit], nil
+			}
+			return nil, utils.ErrNotFound
+		}
+	}
+	var rows *sql.Rows
+	if rows, err = sqliteDB.db.Query("SELECT value FROM "+sqliteListsTbl+" WHERE dbkey=? ORDER BY id DESC LIMIT ?",
+		utils.LoadInstKey, limit); err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var marshaled []byte
+		if err = rows.Scan(&marshaled); err != nil {
+			return
+		}
+		ldInst := new(utils.LoadInstance)
+		if err = sqliteDB.ms.Unmarshal(marshaled, ldInst); err != nil {
+			return
+		}
+		loadInsts = append(loadInsts, ldInst)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	cCommit := cacheCommit(transactionID)
+	if err = Cache.Remove(utils.LoadInstKey, "", cCommit, transactionID); err != nil {
+		return nil, err
+	}
+	if err = Cache.Set(utils.LoadInstKey, "", loadInsts, nil,
+		cCommit, transactionID); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Adds a single load instance to load history
+func (sqliteDB *SQLiteDataDB) AddLoadHistory(ldInst *utils.LoadInstance, loadHistSize int, transactionID string) (err error) {
+	if loadHistSize == 0 { // Load history disabled
+		return
+	}
+	var marshaled []byte
+	if marshaled, err = sqliteDB.ms.Marshal(&ldInst); err != nil {
+		return
+	}
+	err = guardian.Guardian.Guard(func() error { // Make sure we do it locked since other instance can modify history while we read it
+		return sqliteDB.inTx(func(tx *sql.Tx) (err error) {
+			if _, err = tx.Exec("INSERT INTO "+sqliteListsTbl+" (dbkey, value) VALUES (?, ?)",
+				utils.LoadInstKey, marshaled); err != nil {
+				return
+			}
+			// keep only the newest loadHistSize instances
+			_, err = tx.Exec("DELETE FROM "+sqliteListsTbl+" WHERE dbkey=? AND id NOT IN (SELECT id FROM "+
+				sqliteListsTbl+" WHERE dbkey=? ORDER BY id DESC LIMIT ?)",
+				utils.LoadInstKey, utils.LoadInstKey, loadHistSize)
+			return
+		})
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.LoadInstKey)
+
+	if errCh := Cache.Remove(utils.LoadInstKey, "",
+		cacheCommit(transactionID), transactionID); errCh != nil {
+		return errCh
+	}
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) GetActionTriggersDrv(key string) (atrs ActionTriggers, err error) {
+	err = sqliteDB.getItem(utils.ActionTriggerPrefix+key, &atrs)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetActionTriggersDrv(key string, atrs ActionTriggers) (err error) {
+	if len(atrs) == 0 {
+		// delete the key
+		return sqliteDB.delKey(utils.ActionTriggerPrefix + key)
+	}
+	return sqliteDB.setItem(utils.ActionTriggerPrefix+key, atrs)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveActionTriggersDrv(key string) (err error) {
+	return sqliteDB.delKey(utils.ActionTriggerPrefix + key)
+}
+
+func (sqliteDB *SQLiteDataDB) GetActionPlanDrv(key string) (ats *ActionPlan, err error) {
+	err = sqliteDB.getItem(utils.ActionPlanPrefix+key, &ats)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveActionPlanDrv(key string) (err error) {
+	return sqliteDB.delKey(utils.ActionPlanPrefix + key)
+}
+
+func (sqliteDB *SQLiteDataDB) SetActionPlanDrv(key string, ats *ActionPlan) (err error) {
+	return sqliteDB.setItem(utils.ActionPlanPrefix+key, ats)
+}
+
+func (sqliteDB *SQLiteDataDB) GetAllActionPlansDrv() (ats map[string]*ActionPlan, err error) {
+	var keys []string
+	if keys, err = sqliteDB.GetKeysForPrefix(utils.ActionPlanPrefix); err != nil {
+		return
+	}
+	if len(keys) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	ats = make(map[string]*ActionPlan, len(keys))
+	for _, key := range keys {
+		if ats[key[len(utils.ActionPlanPrefix):]], err = sqliteDB.GetActionPlanDrv(key[len(utils.ActionPlanPrefix):]); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) GetAccountActionPlansDrv(acntID string) (aPlIDs []string, err error) {
+	err = sqliteDB.getItem(utils.AccountActionPlansPrefix+acntID, &aPlIDs)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetAccountActionPlansDrv(acntID string, aPlIDs []string) (err error) {
+	return sqliteDB.setItem(utils.AccountActionPlansPrefix+acntID, aPlIDs)
+}
+
+func (sqliteDB *SQLiteDataDB) RemAccountActionPlansDrv(acntID string) (err error) {
+	return sqliteDB.delKey(utils.AccountActionPlansPrefix + acntID)
+}
+
+func (sqliteDB *SQLiteDataDB) PushTask(t *Task) (err error) {
+	var result []byte
+	if result, err = sqliteDB.ms.Marshal(t); err != nil {
+		return
+	}
+	_, err = sqliteDB.db.Exec("INSERT INTO "+sqliteListsTbl+" (dbkey, value) VALUES (?, ?)",
+		utils.TasksKey, result)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) PopTask() (t *Task, err error) {
+	var values []byte
+	if err = sqliteDB.inTx(func(tx *sql.Tx) (err error) {
+		var id int64
+		if err = tx.QueryRow("SELECT id, value FROM "+sqliteListsTbl+" WHERE dbkey=? ORDER BY id LIMIT 1",
+			utils.TasksKey).Scan(&id, &values); err != nil {
+			if err == sql.ErrNoRows {
+				err = utils.ErrNotFound
+			}
+			return
+		}
+		_, err = tx.Exec("DELETE FROM "+sqliteListsTbl+" WHERE id=?", id)
+		return
+	}); err != nil {
+		return
+	}
+	t = &Task{}
+	err = sqliteDB.ms.Unmarshal(values, t)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) GetResourceProfileDrv(tenant, id string) (rsp *ResourceProfile, err error) {
+	err = sqliteDB.getItem(utils.ResourceProfilesPrefix+utils.ConcatenatedKey(tenant, id), &rsp)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetResourceProfileDrv(rsp *ResourceProfile) (err error) {
+	return sqliteDB.setItem(utils.ResourceProfilesPrefix+rsp.TenantID(), rsp)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveResourceProfileDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.ResourceProfilesPrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetResourceDrv(tenant, id string) (r *Resource, err error) {
+	err = sqliteDB.getItem(utils.ResourcesPrefix+utils.ConcatenatedKey(tenant, id), &r)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetResourceDrv(r *Resource) (err error) {
+	return sqliteDB.setItem(utils.ResourcesPrefix+r.TenantID(), r)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveResourceDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.ResourcesPrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetTimingDrv(id string) (t *utils.TPTiming, err error) {
+	err = sqliteDB.getItem(utils.TimingsPrefix+id, &t)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetTimingDrv(t *utils.TPTiming) (err error) {
+	return sqliteDB.setItem(utils.TimingsPrefix+t.ID, t)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveTimingDrv(id string) (err error) {
+	return sqliteDB.delKey(utils.TimingsPrefix + id)
+}
+
+// getInt64Hash returns the int64 values out of the hash, only the fld one if not empty
+func (sqliteDB *SQLiteDataDB) getInt64Hash(key, fld string) (mp map[string]int64, err error) {
+	var rows *sql.Rows
+	if fld != utils.EmptyString {
+		rows, err = sqliteDB.db.Query("SELECT field, value FROM "+sqliteHashesTbl+" WHERE dbkey=? AND field=?",
+			key, fld)
+	} else {
+		rows, err = sqliteDB.db.Query("SELECT field, value FROM "+sqliteHashesTbl+" WHERE dbkey=?",
+			key)
+	}
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	mp = make(map[string]int64)
+	for rows.Next() {
+		var k string
+		var v int64
+		if err = rows.Scan(&k, &v); err != nil {
+			return
+		}
+		mp[k] = v
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	if len(mp) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) setInt64Hash(tx *sql.Tx, key string, mp map[string]int64) (err error) {
+	for k, v := range mp {
+		if _, err = tx.Exec("INSERT OR REPLACE INTO "+sqliteHashesTbl+" (dbkey, field, value) VALUES (?, ?, ?)",
+			key, k, v); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) GetVersions(itm string) (vrs Versions, err error) {
+	var mp map[string]int64
+	if mp, err = sqliteDB.getInt64Hash(utils.TBLVersions, itm); err != nil {
+		return
+	}
+	return Versions(mp), nil
+}
+
+func (sqliteDB *SQLiteDataDB) SetVersions(vrs Versions, overwrite bool) (err error) {
+	return sqliteDB.inTx(func(tx *sql.Tx) (err error) {
+		if overwrite {
+			if _, err = tx.Exec("DELETE FROM "+sqliteHashesTbl+" WHERE dbkey=?", utils.TBLVersions); err != nil {
+				return
+			}
+		}
+		return sqliteDB.setInt64Hash(tx, utils.TBLVersions, vrs)
+	})
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveVersions(vrs Versions) (err error) {
+	if len(vrs) != 0 {
+		flds := make([]string, 0, len(vrs))
+		for key := range vrs {
+			flds = append(flds, key)
+		}
+		return sqliteDB.hDel(utils.TBLVersions, flds...)
+	}
+	return sqliteDB.delKey(utils.TBLVersions)
+}
+
+// GetStatQueueProfileDrv retrieves a StatQueueProfile from dataDB
+func (sqliteDB *SQLiteDataDB) GetStatQueueProfileDrv(tenant string, id string) (sq *StatQueueProfile, err error) {
+	err = sqliteDB.getItem(utils.StatQueueProfilePrefix+utils.ConcatenatedKey(tenant, id), &sq)
+	return
+}
+
+// SetStatQueueProfileDrv stores a StatsQueue into DataDB
+func (sqliteDB *SQLiteDataDB) SetStatQueueProfileDrv(sq *StatQueueProfile) (err error) {
+	return sqliteDB.setItem(utils.StatQueueProfilePrefix+utils.ConcatenatedKey(sq.Tenant, sq.ID), sq)
+}
+
+// RemStatQueueProfileDrv removes a StatsQueue from dataDB
+func (sqliteDB *SQLiteDataDB) RemStatQueueProfileDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.StatQueueProfilePrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+// GetStatQueueDrv retrieves the stored metrics for a StatsQueue
+func (sqliteDB *SQLiteDataDB) GetStatQueueDrv(tenant, id string) (sq *StatQueue, err error) {
+	var ssq StoredStatQueue
+	if err = sqliteDB.getItem(utils.StatQueuePrefix+utils.ConcatenatedKey(tenant, id), &ssq); err != nil {
+		return
+	}
+	return ssq.AsStatQueue(sqliteDB.ms)
+}
+
+// SetStatQueueDrv stores the metrics for a StatsQueue
+func (sqliteDB *SQLiteDataDB) SetStatQueueDrv(ssq *StoredStatQueue, sq *StatQueue) (err error) {
+	if ssq == nil {
+		if ssq, err = NewStoredStatQueue(sq, sqliteDB.ms); err != nil {
+			return
+		}
+	}
+	return sqliteDB.setItem(utils.StatQueuePrefix+ssq.SqID(), ssq)
+}
+
+// RemStatQueueDrv removes a StatsQueue
+func (sqliteDB *SQLiteDataDB) RemStatQueueDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.StatQueuePrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) SetTrendProfileDrv(sg *TrendProfile) (err error) {
+	return sqliteDB.setItem(utils.TrendsProfilePrefix+utils.ConcatenatedKey(sg.Tenant, sg.ID), sg)
+}
+
+func (sqliteDB *SQLiteDataDB) GetTrendProfileDrv(tenant string, id string) (sg *TrendProfile, err error) {
+	err = sqliteDB.getItem(utils.TrendsProfilePrefix+utils.ConcatenatedKey(tenant, id), &sg)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) RemTrendProfileDrv(tenant string, id string) (err error) {
+	return sqliteDB.delKey(utils.TrendsProfilePrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetTrendDrv(tenant, id string) (tr *Trend, err error) {
+	err = sqliteDB.getItem(utils.TrendPrefix+utils.ConcatenatedKey(tenant, id), &tr)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetTrendDrv(r *Trend) (err error) {
+	return sqliteDB.setItem(utils.TrendPrefix+utils.ConcatenatedKey(r.Tenant, r.ID), r)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveTrendDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.TrendPrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) SetRankingProfileDrv(sg *RankingProfile) (err error) {
+	return sqliteDB.setItem(utils.RankingsProfilePrefix+utils.ConcatenatedKey(sg.Tenant, sg.ID), sg)
+}
+
+func (sqliteDB *SQLiteDataDB) GetRankingProfileDrv(tenant string, id string) (sg *RankingProfile, err error) {
+	err = sqliteDB.getItem(utils.RankingsProfilePrefix+utils.ConcatenatedKey(tenant, id), &sg)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) RemRankingProfileDrv(tenant string, id string) (err error) {
+	return sqliteDB.delKey(utils.RankingsProfilePrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetRankingDrv(tenant, id string) (rn *Ranking, err error) {
+	err = sqliteDB.getItem(utils.RankingPrefix+utils.ConcatenatedKey(tenant, id), &rn)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetRankingDrv(rn *Ranking) (err error) {
+	return sqliteDB.setItem(utils.RankingPrefix+utils.ConcatenatedKey(rn.Tenant, rn.ID), rn)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveRankingDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.RankingPrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+// GetThresholdProfileDrv retrieves a ThresholdProfile from dataDB
+func (sqliteDB *SQLiteDataDB) GetThresholdProfileDrv(tenant, ID string) (tp *ThresholdProfile, err error) {
+	err = sqliteDB.getItem(utils.ThresholdProfilePrefix+utils.ConcatenatedKey(tenant, ID), &tp)
+	return
+}
+
+// SetThresholdProfileDrv stores a ThresholdProfile into DataDB
+func (sqliteDB *SQLiteDataDB) SetThresholdProfileDrv(tp *ThresholdProfile) (err error) {
+	return sqliteDB.setItem(utils.ThresholdProfilePrefix+tp.TenantID(), tp)
+}
+
+// RemThresholdProfileDrv removes a ThresholdProfile from dataDB
+func (sqliteDB *SQLiteDataDB) RemThresholdProfileDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.ThresholdProfilePrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetThresholdDrv(tenant, id string) (r *Threshold, err error) {
+	err = sqliteDB.getItem(utils.ThresholdPrefix+utils.ConcatenatedKey(tenant, id), &r)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetThresholdDrv(r *Threshold) (err error) {
+	return sqliteDB.setItem(utils.ThresholdPrefix+utils.ConcatenatedKey(r.Tenant, r.ID), r)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveThresholdDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.ThresholdPrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetFilterDrv(tenant, id string) (r *Filter, err error) {
+	err = sqliteDB.getItem(utils.FilterPrefix+utils.ConcatenatedKey(tenant, id), &r)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetFilterDrv(r *Filter) (err error) {
+	return sqliteDB.setItem(utils.FilterPrefix+utils.ConcatenatedKey(r.Tenant, r.ID), r)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveFilterDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.FilterPrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetRouteProfileDrv(tenant, id string) (r *RouteProfile, err error) {
+	err = sqliteDB.getItem(utils.RouteProfilePrefix+utils.ConcatenatedKey(tenant, id), &r)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetRouteProfileDrv(r *RouteProfile) (err error) {
+	return sqliteDB.setItem(utils.RouteProfilePrefix+r.TenantID(), r)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveRouteProfileDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.RouteProfilePrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetAttributeProfileDrv(tenant, id string) (r *AttributeProfile, err error) {
+	err = sqliteDB.getItem(utils.AttributeProfilePrefix+utils.ConcatenatedKey(tenant, id), &r)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetAttributeProfileDrv(r *AttributeProfile) (err error) {
+	return sqliteDB.setItem(utils.AttributeProfilePrefix+r.TenantID(), r)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveAttributeProfileDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.AttributeProfilePrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetChargerProfileDrv(tenant, id string) (r *ChargerProfile, err error) {
+	err = sqliteDB.getItem(utils.ChargerProfilePrefix+utils.ConcatenatedKey(tenant, id), &r)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetChargerProfileDrv(r *ChargerProfile) (err error) {
+	return sqliteDB.setItem(utils.ChargerProfilePrefix+r.TenantID(), r)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveChargerProfileDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.ChargerProfilePrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetDispatcherProfileDrv(tenant, id string) (r *DispatcherProfile, err error) {
+	err = sqliteDB.getItem(utils.DispatcherProfilePrefix+utils.ConcatenatedKey(tenant, id), &r)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetDispatcherProfileDrv(r *DispatcherProfile) (err error) {
+	return sqliteDB.setItem(utils.DispatcherProfilePrefix+utils.ConcatenatedKey(r.Tenant, r.ID), r)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveDispatcherProfileDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.DispatcherProfilePrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetDispatcherHostDrv(tenant, id string) (r *DispatcherHost, err error) {
+	err = sqliteDB.getItem(utils.DispatcherHostPrefix+utils.ConcatenatedKey(tenant, id), &r)
+	return
+}
+
+func (sqliteDB *SQLiteDataDB) SetDispatcherHostDrv(r *DispatcherHost) (err error) {
+	return sqliteDB.setItem(utils.DispatcherHostPrefix+r.TenantID(), r)
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveDispatcherHostDrv(tenant, id string) (err error) {
+	return sqliteDB.delKey(utils.DispatcherHostPrefix + utils.ConcatenatedKey(tenant, id))
+}
+
+func (sqliteDB *SQLiteDataDB) GetStorageType() string {
+	return utils.MetaSQLite
+}
+
+func (sqliteDB *SQLiteDataDB) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[string]int64, err error) {
+	return sqliteDB.getInt64Hash(utils.LoadIDs, itemIDPrefix)
+}
+
+func (sqliteDB *SQLiteDataDB) SetLoadIDsDrv(loadIDs map[string]int64) error {
+	return sqliteDB.inTx(func(tx *sql.Tx) error {
+		return sqliteDB.setInt64Hash(tx, utils.LoadIDs, loadIDs)
+	})
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveLoadIDsDrv() (err error) {
+	return sqliteDB.delKey(utils.LoadIDs)
+}
+
+// GetIndexesDrv retrieves Indexes from dataDB
+func (sqliteDB *SQLiteDataDB) GetIndexesDrv(idxItmType, tntCtx, idxKey string) (indexes map[string]utils.StringSet, err error) {
+	dbKey := utils.CacheInstanceToPrefix[idxItmType] + tntCtx
+	var mp map[string][]byte
+	if len(idxKey) == 0 {
+		if mp, err = sqliteDB.hGetAll(dbKey); err != nil {
+			return
+		} else if len(mp) == 0 {
+			return nil, utils.ErrNotFound
+		}
+	} else {
+		var value []byte
+		if value, err = sqliteDB.hGet(dbKey, idxKey); err != nil {
+			return
+		}
+		mp = map[string][]byte{idxKey: value}
+	}
+	indexes = make(map[string]utils.StringSet)
+	for k, v := range mp {
+		var sm utils.StringSet
+		if err = sqliteDB.ms.Unmarshal(v, &sm); err != nil {
+			return
+		}
+		indexes[k] = sm
+	}
+	return
+}
+
+// SetIndexesDrv stores Indexes into DataDB
+func (sqliteDB *SQLiteDataDB) SetIndexesDrv(idxItmType, tntCtx string,
+	indexes map[string]utils.StringSet, commit bool, transactionID string) (err error) {
+	originKey := utils.CacheInstanceToPrefix[idxItmType] + tntCtx
+	dbKey := originKey
+	if transactionID != utils.EmptyString {
+		dbKey = "tmp_" + utils.ConcatenatedKey(dbKey, transactionID)
+	}
+	if commit && transactionID != utils.EmptyString {
+		return sqliteDB.inTx(func(tx *sql.Tx) (err error) {
+			if _, err = tx.Exec("DELETE FROM "+sqliteHashesTbl+" WHERE dbkey=?", originKey); err != nil {
+				return
+			}
+			_, err = tx.Exec("UPDATE "+sqliteHashesTbl+" SET dbkey=? WHERE dbkey=?", originKey, dbKey)
+			return
+		})
+	}
+	mp := make(map[string][]byte)
+	var deleteFlds []string
+	for key, strMp := range indexes {
+		if len(strMp) == 0 { // remove with no more elements inside
+			deleteFlds = append(deleteFlds, key)
+			continue
+		}
+		if mp[key], err = sqliteDB.ms.Marshal(strMp); err != nil {
+			return
+		}
+	}
+	return sqliteDB.inTx(func(tx *sql.Tx) (err error) {
+		if err = hDelTx(tx, dbKey, deleteFlds...); err != nil {
+			return
+		}
+		return hSetTx(tx, dbKey, mp)
+	})
+}
+
+func (sqliteDB *SQLiteDataDB) RemoveIndexesDrv(idxItmType, tntCtx, idxKey string) (err error) {
+	if idxKey == utils.EmptyString {
+		return sqliteDB.delKey(utils.CacheInstanceToPrefix[idxItmType] + tntCtx)
+	}
+	return sqliteDB.hDel(utils.CacheInstanceToPrefix[idxItmType]+tntCtx, idxKey)
+}
+
+// Will backup active sessions in DataDB
+func (sqliteDB *SQLiteDataDB) SetBackupSessionsDrv(nodeID string,
+	tnt string, storedSessions []*StoredSession) (err error) {
+	mp := make(map[string][]byte)
+	for _, sess := range storedSessions {
+		// Convert time.Time values inside EventStart and SRuns Events, to string type values
+		utils.MapIfaceTimeAsString(sess.EventStart)
+		for i := range sess.SRuns {
+			utils.MapIfaceTimeAsString(sess.SRuns[i].Event)
+		}
+		if mp[sess.CGRID], err = sqliteDB.ms.Marshal(sess); err != nil {
+			return
+		}
+	}
+	return sqliteDB.hSet(utils.SessionsBackupPrefix+utils.ConcatenatedKey(tnt, nodeID), mp)
+}
+
+// Will restore sessions that were active from dataDB backup
+func (sqliteDB *SQLiteDataDB) GetSessionsBackupDrv(nodeID, tnt string) (r []*StoredSession, err error) {
+	var mp map[string][]byte
+	if mp, err = sqliteDB.hGetAll(utils.SessionsBackupPrefix + utils.ConcatenatedKey(tnt,
+		nodeID)); err != nil {
+		return
+	} else if len(mp) == 0 {
+		return nil, utils.ErrNoBackupFound
+	}
+	for _, v := range mp {
+		var ss *StoredSession
+		if err = sqliteDB.ms.Unmarshal(v, &ss); err != nil {
+			return
+		}
+		r = append(r, ss)
+	}
+	return
+}
+
+// Will remove one or all sessions from dataDB backup
+func (sqliteDB *SQLiteDataDB) RemoveSessionsBackupDrv(nodeID, tnt, cgrid string) error {
+	if cgrid == utils.EmptyString {
+		return sqliteDB.delKey(utils.SessionsBackupPrefix + utils.ConcatenatedKey(tnt, nodeID))
+	}
+	return sqliteDB.hDel(utils.SessionsBackupPrefix+utils.ConcatenatedKey(tnt, nodeID), cgrid)
+}
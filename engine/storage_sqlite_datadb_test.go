@@ -0,0 +1,166 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSQLiteDataDB(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "cgrates.db")
+	sqliteDB, err := NewSQLiteDataDB(dbPath, utils.MsgPack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty, err := sqliteDB.IsDBEmpty(); err != nil {
+		t.Fatal(err)
+	} else if !empty {
+		t.Error("expected empty DataDB")
+	}
+	acc := &Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {{ID: "B1", Value: 10, Weight: 10}},
+		},
+	}
+	if err = sqliteDB.SetAccountDrv(acc); err != nil {
+		t.Fatal(err)
+	}
+	// empty accounts never override the stored balances
+	if err = sqliteDB.SetAccountDrv(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = sqliteDB.SetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*any",
+		map[string]utils.StringSet{
+			"*string:*req.Account:1001": utils.NewStringSet([]string{"ATTR1"}),
+			"*string:*req.Account:1002": utils.NewStringSet([]string{"ATTR2"}),
+		}, false, "tx1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sqliteDB.GetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*any",
+		utils.EmptyString); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err = sqliteDB.SetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*any",
+		nil, true, "tx1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = sqliteDB.SetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*any",
+		map[string]utils.StringSet{"*string:*req.Account:1002": {}}, true, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if err = sqliteDB.SetReverseDestinationDrv("DST_1002", []string{"1002", "10"}, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err = sqliteDB.SetVersions(CurrentDataDBVersions(), true); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = sqliteDB.AddLoadHistory(&utils.LoadInstance{LoadID: utils.IfaceAsString(i)}, 2,
+			utils.NonTransactional); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []string{"1", "2"} {
+		if err = sqliteDB.PushTask(&Task{Uuid: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sqliteDB.Close()
+
+	if sqliteDB, err = NewSQLiteDataDB(dbPath, utils.MsgPack); err != nil {
+		t.Fatal(err)
+	}
+	defer sqliteDB.Close()
+	if rcv, err := sqliteDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Error(err)
+	} else if acc.UpdateTime = rcv.UpdateTime; !reflect.DeepEqual(acc.BalanceMap, rcv.BalanceMap) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(acc), utils.ToJSON(rcv))
+	}
+	if has, err := sqliteDB.HasDataDrv(utils.AccountPrefix, "cgrates.org:1001", utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !has {
+		t.Error("expected the account to be found")
+	}
+	expIdx := map[string]utils.StringSet{
+		"*string:*req.Account:1001": utils.NewStringSet([]string{"ATTR1"}),
+	}
+	if rcv, err := sqliteDB.GetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*any",
+		utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expIdx, rcv) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expIdx), utils.ToJSON(rcv))
+	}
+	expKeys := []string{utils.AttributeFilterIndexes + "cgrates.org:*any:*string:*req.Account:1001"}
+	if rcv, err := sqliteDB.GetKeysForPrefix(utils.AttributeFilterIndexes); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expKeys, rcv) {
+		t.Errorf("expected %+v, received %+v", expKeys, rcv)
+	}
+	expKeys = []string{utils.ReverseDestinationPrefix + "10", utils.ReverseDestinationPrefix + "1002"}
+	if rcv, err := sqliteDB.GetKeysForPrefix(utils.ReverseDestinationPrefix); err != nil {
+		t.Error(err)
+	} else if sort.Strings(rcv); !reflect.DeepEqual(expKeys, rcv) {
+		t.Errorf("expected %+v, received %+v", expKeys, rcv)
+	}
+	if rcv, err := sqliteDB.GetReverseDestinationDrv("1002", utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{"DST_1002"}, rcv) {
+		t.Errorf("expected %+v, received %+v", []string{"DST_1002"}, rcv)
+	}
+	if rcv, err := sqliteDB.GetVersions(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(CurrentDataDBVersions(), rcv) {
+		t.Errorf("expected %+v, received %+v", CurrentDataDBVersions(), rcv)
+	}
+	if rcv, err := sqliteDB.GetLoadHistory(-1, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if len(rcv) != 2 || rcv[0].LoadID != "2" || rcv[1].LoadID != "1" {
+		t.Errorf("expected the newest two loads, received %s", utils.ToJSON(rcv))
+	}
+	for _, id := range []string{"1", "2"} {
+		if rcv, err := sqliteDB.PopTask(); err != nil {
+			t.Error(err)
+		} else if rcv.Uuid != id {
+			t.Errorf("expected %s, received %s", id, rcv.Uuid)
+		}
+	}
+	if _, err = sqliteDB.PopTask(); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err = sqliteDB.Flush(utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if empty, err := sqliteDB.IsDBEmpty(); err != nil {
+		t.Fatal(err)
+	} else if !empty {
+		t.Error("expected empty DataDB after flush")
+	}
+}
+
+func TestSQLiteDataDBGlobPrefix(t *testing.T) {
+	if rcv := globPrefix("acc_cgrates.org:*any[1]?"); rcv != "acc_cgrates.org:[*]any[[]1][?]*" {
+		t.Errorf("received %s", rcv)
+	}
+}
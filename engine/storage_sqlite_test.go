@@ -0,0 +1,122 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSQLiteStorDB(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "cgrates.db")
+	scriptsPath := "../data/storage/sqlite"
+	sqliteS, err := NewSQLiteStorage(dbPath, scriptsPath, 10, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if storType := sqliteS.GetStorageType(); storType != utils.MetaSQLite {
+		t.Errorf("expected %s, received %s", utils.MetaSQLite, storType)
+	}
+	cdr := &CDR{
+		CGRID:       utils.Sha1("sqlite_cdr"),
+		RunID:       utils.MetaDefault,
+		OrderID:     1,
+		OriginHost:  "127.0.0.1",
+		Source:      "test",
+		OriginID:    "sqlite_cdr",
+		ToR:         utils.MetaVoice,
+		RequestType: utils.MetaPrepaid,
+		Tenant:      "cgrates.org",
+		Category:    "call",
+		Account:     "1001",
+		Subject:     "1001",
+		Destination: "1002",
+		SetupTime:   time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+		AnswerTime:  time.Date(2026, 10, 18, 10, 0, 1, 0, time.UTC),
+		Usage:       time.Minute,
+		ExtraFields: map[string]string{"Hdr1": "val1"},
+		Cost:        1.01,
+	}
+	if err = sqliteS.SetCDR(cdr, false); err != nil {
+		t.Fatal(err)
+	}
+	if err = sqliteS.SetCDR(cdr, false); err != utils.ErrExists {
+		t.Errorf("expected %v, received %v", utils.ErrExists, err)
+	}
+	cdr.Cost = 2.02
+	if err = sqliteS.SetCDR(cdr, true); err != nil {
+		t.Fatal(err)
+	}
+	if err = sqliteS.SetTPTimings([]*utils.ApierTPTiming{{
+		TPid:      "TP_SQLITE",
+		ID:        "ALWAYS",
+		Years:     utils.MetaAny,
+		Months:    utils.MetaAny,
+		MonthDays: utils.MetaAny,
+		WeekDays:  utils.MetaAny,
+		Time:      "00:00:00",
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	vrs := CurrentDBVersions(utils.MetaSQLite, false)
+	if err = sqliteS.SetVersions(vrs, true); err != nil {
+		t.Fatal(err)
+	}
+	sqliteS.Close()
+
+	// the tables are not recreated when reopening the file
+	if sqliteS, err = NewSQLiteStorage(dbPath, scriptsPath, 10, 10, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer sqliteS.Close()
+	if rcv, err := sqliteS.GetVersions(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(vrs, rcv) {
+		t.Errorf("expected %+v, received %+v", vrs, rcv)
+	}
+	if rcv, err := sqliteS.GetTpIds(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{"TP_SQLITE"}, rcv) {
+		t.Errorf("expected %+v, received %+v", []string{"TP_SQLITE"}, rcv)
+	}
+	if rcv, _, err := sqliteS.GetCDRs(&utils.CDRsFilter{
+		ExtraFields: map[string]string{"Hdr1": "val1"},
+	}, false); err != nil {
+		t.Error(err)
+	} else if len(rcv) != 1 {
+		t.Errorf("expected one CDR, received %s", utils.ToJSON(rcv))
+	} else if rcv[0].Cost != 2.02 || rcv[0].Usage != time.Minute ||
+		!rcv[0].AnswerTime.Equal(cdr.AnswerTime) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(cdr), utils.ToJSON(rcv[0]))
+	}
+	if _, _, err := sqliteS.GetCDRs(&utils.CDRsFilter{
+		NotExtraFields: map[string]string{"Hdr1": "val1"},
+	}, false); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, _, err := sqliteS.GetCDRs(&utils.CDRsFilter{
+		ExtraFields: map[string]string{"Hdr2": utils.MetaExists},
+	}, false); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
@@ -20,6 +20,7 @@ package engine
 
 import (
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -55,7 +56,13 @@ func NewDataDBConn(dbType, host, port, name, user,
 	case utils.MetaMongo:
 		d, err = NewMongoStorage(opts.MongoConnScheme, host, port, name, user, pass, marshaler, utils.DataDB, nil, opts.MongoQueryTimeout)
 	case utils.MetaInternal:
-		d = NewInternalDB(nil, nil, true, itmsCfg)
+		var iDB *InternalDB
+		if iDB, err = NewInternalDBWithDump(nil, nil, true, itmsCfg, opts.InternalDBDumpPath,
+			opts.InternalDBFsyncInterval, opts.InternalDBSnapshotInterval); err == nil {
+			d = iDB
+		}
+	case utils.MetaSQLite:
+		d, err = NewSQLiteDataDB(name, marshaler)
 	default:
 		err = fmt.Errorf("unsupported db_type <%s>", dbType)
 	}
@@ -77,10 +84,17 @@ func NewStorDBConn(dbType, host, port, name, user, pass, marshaler string,
 		db, err = NewMySQLStorage(host, port, name, user, pass, opts.SQLMaxOpenConns, opts.SQLMaxIdleConns,
 			opts.SQLConnMaxLifetime, opts.MySQLLocation, opts.MySQLDSNParams)
 	case utils.MetaInternal:
-		db = NewInternalDB(stringIndexedFields, prefixIndexedFields, false, itmsCfg)
+		var iDB *InternalDB
+		if iDB, err = NewInternalDBWithDump(stringIndexedFields, prefixIndexedFields, false, itmsCfg,
+			opts.InternalDBDumpPath, opts.InternalDBFsyncInterval, opts.InternalDBSnapshotInterval); err == nil {
+			db = iDB
+		}
+	case utils.MetaSQLite:
+		db, err = NewSQLiteStorage(name, path.Join(config.CgrConfig().DataFolderPath, "storage", "sqlite"),
+			opts.SQLMaxOpenConns, opts.SQLMaxIdleConns, opts.SQLConnMaxLifetime)
 	default:
-		err = fmt.Errorf("unknown db '%s' valid options are [%s, %s, %s, %s]",
-			dbType, utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres, utils.MetaInternal)
+		err = fmt.Errorf("unknown db '%s' valid options are [%s, %s, %s, %s, %s]",
+			dbType, utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres, utils.MetaInternal, utils.MetaSQLite)
 	}
 	return
 }
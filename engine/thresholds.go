@@ -186,6 +186,12 @@ func (t *Threshold) ProcessEvent(args *utils.CGREvent, dm *DataManager, fltrS *F
 		}
 	}
 	t.Snooze = time.Now().Add(t.tPrfl.MinSleep)
+	PublishLiveEvent(utils.MetaThresholdHit, &utils.CGREvent{
+		Tenant:  t.Tenant,
+		ID:      t.ID,
+		Event:   args.Event,
+		APIOpts: args.APIOpts,
+	})
 	return
 }
 
@@ -1055,7 +1055,18 @@ func (tpr *TpReader) LoadAccountActions() (err error) {
 		if aa.ActionTriggersId != "" {
 			var exists bool
 			if aTriggers, exists = tpr.actionsTriggers[aa.ActionTriggersId]; !exists {
-				return fmt.Errorf("could not get action triggers for tag %q", aa.ActionTriggersId)
+				if tpr.dm.dataDB != nil {
+					if aTriggers, err = tpr.dm.GetActionTriggers(aa.ActionTriggersId, false, utils.NonTransactional); err != nil {
+						if err.Error() == utils.ErrNotFound.Error() {
+							return fmt.Errorf("could not get action triggers for tag %q", aa.ActionTriggersId)
+						}
+						return err
+					}
+					exists = true
+				}
+				if !exists {
+					return fmt.Errorf("could not get action triggers for tag %q", aa.ActionTriggersId)
+				}
 			}
 		}
 		ub := &Account{
@@ -1782,6 +1793,116 @@ func (tpr *TpReader) WriteToDatabase(verbose, disableReverse bool) (err error) {
 	return tpr.dm.SetLoadIDs(loadIDs)
 }
 
+// DryRun reports the changes the loaded data would bring to DataDB, without writing them
+func (tpr *TpReader) DryRun(lr *LoadReport, remove bool) {
+	report := lr.StoreItem
+	if remove {
+		report = func(ldrType, id string, _ any) { lr.RemoveItem(ldrType, id) }
+	}
+	for id, d := range tpr.destinations {
+		report(utils.MetaDestinations, id, d)
+	}
+	for id, t := range tpr.timings {
+		if !strings.HasPrefix(id, utils.Meta) { // the default timings are not loaded
+			report(utils.MetaTimings, id, t)
+		}
+	}
+	for id, rp := range tpr.ratingPlans {
+		report(utils.MetaRatingPlans, id, rp)
+	}
+	for id, rp := range tpr.ratingProfiles {
+		report(utils.MetaRatingProfiles, id, rp)
+	}
+	for id, sg := range tpr.sharedGroups {
+		report(utils.MetaSharedGroups, id, sg)
+	}
+	for id, as := range tpr.actions {
+		report(utils.MetaActions, id, as)
+	}
+	for id, atrs := range tpr.actionsTriggers {
+		report(utils.MetaActionTriggers, id, atrs)
+	}
+	for id, ap := range tpr.actionPlans {
+		report(utils.MetaActionPlans, id, ap)
+	}
+	for id, acc := range tpr.accountActions {
+		report(utils.MetaAccountActions, id, acc)
+	}
+	for tntID, tpFltr := range tpr.filters {
+		if fltr, err := APItoFilter(tpFltr, tpr.timezone); err != nil {
+			lr.AddError(utils.MetaFilters, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaFilters, tntID.TenantID(), fltr)
+		}
+	}
+	for tntID, tpRsp := range tpr.resProfiles {
+		if rsp, err := APItoResource(tpRsp, tpr.timezone); err != nil {
+			lr.AddError(utils.MetaResources, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaResources, tntID.TenantID(), rsp)
+		}
+	}
+	for tntID, tpSts := range tpr.sqProfiles {
+		if sts, err := APItoStats(tpSts, tpr.timezone); err != nil {
+			lr.AddError(utils.MetaStats, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaStats, tntID.TenantID(), sts)
+		}
+	}
+	for tntID, tpTr := range tpr.trProfiles {
+		if tr, err := APItoTrends(tpTr); err != nil {
+			lr.AddError(utils.MetaTrends, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaTrends, tntID.TenantID(), tr)
+		}
+	}
+	for tntID, tpRg := range tpr.rgProfiles {
+		if rg, err := APItoRanking(tpRg); err != nil {
+			lr.AddError(utils.MetaRankings, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaRankings, tntID.TenantID(), rg)
+		}
+	}
+	for tntID, tpTh := range tpr.thProfiles {
+		if th, err := APItoThresholdProfile(tpTh, tpr.timezone); err != nil {
+			lr.AddError(utils.MetaThresholds, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaThresholds, tntID.TenantID(), th)
+		}
+	}
+	for tntID, tpRpp := range tpr.routeProfiles {
+		if rpp, err := APItoRouteProfile(tpRpp, tpr.timezone); err != nil {
+			lr.AddError(utils.MetaRoutes, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaRoutes, tntID.TenantID(), rpp)
+		}
+	}
+	for tntID, tpAttr := range tpr.attributeProfiles {
+		if attr, err := APItoAttributeProfile(tpAttr, tpr.timezone); err != nil {
+			lr.AddError(utils.MetaAttributes, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaAttributes, tntID.TenantID(), attr)
+		}
+	}
+	for tntID, tpCpp := range tpr.chargerProfiles {
+		if cpp, err := APItoChargerProfile(tpCpp, tpr.timezone); err != nil {
+			lr.AddError(utils.MetaChargers, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaChargers, tntID.TenantID(), cpp)
+		}
+	}
+	for tntID, tpDsp := range tpr.dispatcherProfiles {
+		if dsp, err := APItoDispatcherProfile(tpDsp, tpr.timezone); err != nil {
+			lr.AddError(utils.MetaDispatchers, tntID.TenantID(), err)
+		} else {
+			report(utils.MetaDispatchers, tntID.TenantID(), dsp)
+		}
+	}
+	for tntID, tpDsh := range tpr.dispatcherHosts {
+		report(utils.MetaDispatcherHosts, tntID.TenantID(), APItoDispatcherHost(tpDsh))
+	}
+}
+
 func (tpr *TpReader) ShowStatistics() {
 	// destinations
 	destCount := len(tpr.destinations)
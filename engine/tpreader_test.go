@@ -1055,7 +1055,7 @@ func TestTPReaderLoadDestinationsFiltered(t *testing.T) {
 		TPid: "tpID",
 		ID:   "prefixes",
 	}, []string{"groupId"}, true, "tId")
-	db.db = tscache
+	db.db = &internalDBStore{TransCache: tscache}
 
 	tpr, err := NewTpReader(db, db, "itemId", "local", nil, nil, true)
 	if err != nil {
@@ -1208,7 +1208,7 @@ func TestTpReaderLoadAccountActions(t *testing.T) {
 		ActionPlanId: "actionplans",
 	}, []string{"groupId"}, true, "tId")
 	db := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
-	db.db = tscache
+	db.db = &internalDBStore{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "local", nil, nil, true)
 	if err != nil {
 		t.Error(err)
@@ -1407,7 +1407,7 @@ func TestTpReaderLoadTimingsErr(t *testing.T) {
 		ID:   duplicateId,
 	}, []string{"groupId"}, true, "tId")
 	db := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
-	db.db = tscache
+	db.db = &internalDBStore{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "local", nil, nil, true)
 	if err != nil {
 		t.Error(err)
@@ -1440,7 +1440,7 @@ func TestLoadDestinationRatesErr(t *testing.T) {
 		ID:   duplicateId,
 	}, []string{"groupId"}, true, "tId")
 	db := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
-	db.db = tscache
+	db.db = &internalDBStore{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "local", nil, nil, true)
 	if err != nil {
 		t.Error(err)
@@ -1523,7 +1523,7 @@ func TestLoadRatingProfilesFiltered(t *testing.T) {
 		},
 	)
 	db := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
-	db.db = tscache
+	db.db = &internalDBStore{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "local", nil, nil, true)
 	if err != nil {
 		t.Error(err)
@@ -1580,7 +1580,7 @@ func TestTpReaderLoadActionTriggers(t *testing.T) {
 		},
 	)
 	db := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
-	db.db = tscache
+	db.db = &internalDBStore{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "UTC", nil, nil, true)
 	if err != nil {
 		t.Error(err)
@@ -1665,7 +1665,7 @@ func TestTpReaderSetDestination(t *testing.T) {
 		},
 	)
 	db := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
-	db.db = tscache
+	db.db = &internalDBStore{TransCache: tscache}
 	tpr, err := NewTpReader(db, db, "*prf", "UTC", nil, nil, true)
 	if err != nil {
 		t.Error(err)
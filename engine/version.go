@@ -93,11 +93,13 @@ func CheckVersions(storage Storage) error {
 
 // relevant only for mongoDB
 func isDataDB(storage Storage) bool {
-	conv, ok := storage.(*MongoStorage)
-	if !ok {
-		return false
+	switch conv := storage.(type) {
+	case *MongoStorage:
+		return conv.IsDataDB()
+	case *SQLiteDataDB:
+		return true
 	}
-	return conv.IsDataDB()
+	return false
 }
 
 func setDBVersions(storage Storage, overwrite bool) (err error) {
@@ -124,7 +126,7 @@ func OverwriteDBVersions(storage Storage) (err error) {
 func (vers Versions) Compare(curent Versions, storType string, isDataDB bool) string {
 	var message map[string]string
 	switch storType {
-	case utils.MetaMongo:
+	case utils.MetaMongo, utils.MetaSQLite:
 		if isDataDB {
 			message = dataDBVers
 		} else {
@@ -218,7 +220,7 @@ func CurrentAllDBVersions() Versions {
 // CurrentDBVersions returns versions based on dbType
 func CurrentDBVersions(storType string, isDataDB bool) Versions {
 	switch storType {
-	case utils.MetaMongo:
+	case utils.MetaMongo, utils.MetaSQLite:
 		if isDataDB {
 			return CurrentDataDBVersions()
 		}
@@ -0,0 +1,211 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/linkedin/goavro/v2"
+)
+
+func NewAvroFileER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents, partialEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	srcPath := cfg.ERsCfg().Readers[cfgIdx].SourcePath
+	if strings.HasSuffix(srcPath, utils.Slash) {
+		srcPath = srcPath[:len(srcPath)-1]
+	}
+	avroEr := &AvroFileER{
+		cgrCfg:        cfg,
+		cfgIdx:        cfgIdx,
+		fltrS:         fltrS,
+		sourceDir:     srcPath,
+		rdrEvents:     rdrEvents,
+		partialEvents: partialEvents,
+		rdrError:      rdrErr,
+		rdrExit:       rdrExit,
+		conReqs:       make(chan struct{}, cfg.ERsCfg().Readers[cfgIdx].ConcurrentReqs),
+	}
+
+	return avroEr, nil
+}
+
+// AvroFileER implements EventReader interface for .avro object container files
+type AvroFileER struct {
+	cgrCfg        *config.CGRConfig
+	cfgIdx        int // index of config instance within ERsCfg.Readers
+	fltrS         *engine.FilterS
+	sourceDir     string        // path to the directory monitored by the reader for new events
+	rdrEvents     chan *erEvent // channel to dispatch the events created to
+	partialEvents chan *erEvent // channel to dispatch the partial events created to
+	rdrError      chan error
+	rdrExit       chan struct{}
+	conReqs       chan struct{} // limit number of opened files
+}
+
+func (rdr *AvroFileER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+func (rdr *AvroFileER) serveDefault() {
+	tm := time.NewTimer(0)
+	for {
+		// Not automated, process and sleep approach
+		select {
+		case <-rdr.rdrExit:
+			tm.Stop()
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring path <%s>",
+					utils.ERs, rdr.sourceDir))
+			return
+		case <-tm.C:
+		}
+		processReaderDir(rdr.sourceDir, utils.AvroSuffix, rdr.processFile)
+		tm.Reset(rdr.Config().RunDelay)
+	}
+}
+
+func (rdr *AvroFileER) Serve() (err error) {
+	switch rdr.Config().RunDelay {
+	case time.Duration(0): // 0 disables the automatic read, maybe done per API
+		return
+	case time.Duration(-1):
+
+		// Ensure that files already existing in the source path are processed
+		// before the reader starts listening for filesystem change events.
+		processReaderDir(rdr.sourceDir, utils.AvroSuffix, rdr.processFile)
+
+		return utils.WatchDir(rdr.sourceDir, rdr.processFile,
+			utils.ERs, rdr.rdrExit)
+	default:
+		go rdr.serveDefault()
+	}
+	return
+}
+
+// processFile is called for each file in a directory and dispatches erEvents from it
+func (rdr *AvroFileER) processFile(fName string) (err error) {
+	if cap(rdr.conReqs) != 0 { // 0 goes for no limit
+		rdr.conReqs <- struct{}{} // Queue here for maxOpenFiles
+		defer func() { <-rdr.conReqs }()
+	}
+	absPath := path.Join(rdr.sourceDir, fName)
+	utils.Logger.Info(
+		fmt.Sprintf("<%s> parsing <%s>", utils.ERs, absPath))
+	var file *os.File
+	if file, err = os.Open(absPath); err != nil {
+		return
+	}
+	defer file.Close()
+	var ocfR *goavro.OCFReader
+	if ocfR, err = goavro.NewOCFReader(file); err != nil {
+		return
+	}
+	// the records are decoded through the standard JSON codec
+	// in order to not have the union values wrapped in maps
+	var jsnCodec *goavro.Codec
+	if jsnCodec, err = goavro.NewCodecForStandardJSONFull(ocfR.Codec().Schema()); err != nil {
+		return
+	}
+	rowNr := 0 // This counts the rows in the file, not really number of CDRs
+	evsPosted := 0
+	timeStart := time.Now()
+	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{utils.MetaFileName: utils.NewLeafNode(fName), utils.MetaReaderID: utils.NewLeafNode(rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx].ID)}}
+	for ocfR.Scan() {
+		var record map[string]any
+		if record, err = avroRecord(ocfR, jsnCodec); err != nil {
+			return
+		}
+		rowNr++ // increment the rowNr after checking if it's not the end of file
+		reqVars.Map[utils.MetaFileLineNumber] = utils.NewLeafNode(rowNr)
+		agReq := agents.NewAgentRequest(
+			utils.MapStorage(record), reqVars,
+			nil, nil, nil, rdr.Config().Tenant,
+			rdr.cgrCfg.GeneralCfg().DefaultTenant,
+			utils.FirstNonEmpty(rdr.Config().Timezone,
+				rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+			rdr.fltrS, nil) // create an AgentRequest
+		if pass, err := rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+			agReq); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> reading file: <%s> row <%d>, ignoring due to filter error: <%s>",
+					utils.ERs, absPath, rowNr, err.Error()))
+			return err
+		} else if !pass {
+			continue
+		}
+		if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> reading file: <%s> row <%d>, ignoring due to error: <%s>",
+					utils.ERs, absPath, rowNr, err.Error()))
+			return
+		}
+		cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+		rdrEv := rdr.rdrEvents
+		if _, isPartial := cgrEv.APIOpts[utils.PartialOpt]; isPartial {
+			rdrEv = rdr.partialEvents
+		}
+		rdrEv <- &erEvent{
+			cgrEvent: cgrEv,
+			rdrCfg:   rdr.Config(),
+		}
+		evsPosted++
+	}
+	if err = ocfR.Err(); err != nil {
+		return
+	}
+	if rdr.Config().ProcessedPath != "" {
+		// Finished with file, move it to processed folder
+		outPath := path.Join(rdr.Config().ProcessedPath, fName)
+		if err = os.Rename(absPath, outPath); err != nil {
+			return
+		}
+	}
+
+	utils.Logger.Info(
+		fmt.Sprintf("%s finished processing file <%s>. Total records processed: %d, events posted: %d, run duration: %s",
+			utils.ERs, absPath, rowNr, evsPosted, time.Since(timeStart)))
+	return
+}
+
+// avroRecord reads the next record out of the container file
+func avroRecord(ocfR *goavro.OCFReader, jsnCodec *goavro.Codec) (record map[string]any, err error) {
+	var native any
+	if native, err = ocfR.Read(); err != nil {
+		return
+	}
+	var jsn []byte
+	if jsn, err = jsnCodec.TextualFromNative(nil, native); err != nil {
+		return
+	}
+	dec := json.NewDecoder(bytes.NewReader(jsn))
+	dec.UseNumber() // keep the long values as they are
+	err = dec.Decode(&record)
+	return
+}
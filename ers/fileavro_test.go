@@ -0,0 +1,69 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/linkedin/goavro/v2"
+)
+
+func TestAvroFileERProcessFile(t *testing.T) {
+	cfg := newTypedERCfg(t, utils.MetaFileAvro)
+	fName := "cdrs" + utils.AvroSuffix
+	f, err := os.Create(path.Join(cfg.ERsCfg().Readers[0].SourcePath, fName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ocfW, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W: f,
+		Schema: `{"type":"record","name":"cdr","fields":[` +
+			`{"name":"cgrid","type":"string"},` +
+			`{"name":"account","type":["null","string"],"default":null},` +
+			`{"name":"usage","type":"long"}]}`,
+		CompressionName: goavro.CompressionDeflateLabel,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfW.Append([]any{
+		map[string]any{"cgrid": "cgrid1", "account": goavro.Union("string", "1001"), "usage": int64(25000000000)},
+		map[string]any{"cgrid": "cgrid2", "account": nil, "usage": int64(1000000000)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rdrEvents := make(chan *erEvent, 2)
+	rdr, err := NewAvroFileER(cfg, 0, rdrEvents, nil, nil,
+		engine.NewFilterS(cfg, nil, nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rdr.(*AvroFileER).processFile(fName); err != nil {
+		t.Fatal(err)
+	}
+	checkTypedEvents(t, cfg, rdrEvents, fName)
+}
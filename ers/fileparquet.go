@@ -0,0 +1,239 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/schema"
+)
+
+// parquetBatchSize is the number of rows read at once from the parquet files
+const parquetBatchSize = 1000
+
+func NewParquetFileER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents, partialEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	srcPath := cfg.ERsCfg().Readers[cfgIdx].SourcePath
+	if strings.HasSuffix(srcPath, utils.Slash) {
+		srcPath = srcPath[:len(srcPath)-1]
+	}
+	pqtEr := &ParquetFileER{
+		cgrCfg:        cfg,
+		cfgIdx:        cfgIdx,
+		fltrS:         fltrS,
+		sourceDir:     srcPath,
+		rdrEvents:     rdrEvents,
+		partialEvents: partialEvents,
+		rdrError:      rdrErr,
+		rdrExit:       rdrExit,
+		conReqs:       make(chan struct{}, cfg.ERsCfg().Readers[cfgIdx].ConcurrentReqs),
+	}
+
+	return pqtEr, nil
+}
+
+// ParquetFileER implements EventReader interface for .parquet files
+type ParquetFileER struct {
+	cgrCfg        *config.CGRConfig
+	cfgIdx        int // index of config instance within ERsCfg.Readers
+	fltrS         *engine.FilterS
+	sourceDir     string        // path to the directory monitored by the reader for new events
+	rdrEvents     chan *erEvent // channel to dispatch the events created to
+	partialEvents chan *erEvent // channel to dispatch the partial events created to
+	rdrError      chan error
+	rdrExit       chan struct{}
+	conReqs       chan struct{} // limit number of opened files
+}
+
+func (rdr *ParquetFileER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+func (rdr *ParquetFileER) serveDefault() {
+	tm := time.NewTimer(0)
+	for {
+		// Not automated, process and sleep approach
+		select {
+		case <-rdr.rdrExit:
+			tm.Stop()
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring path <%s>",
+					utils.ERs, rdr.sourceDir))
+			return
+		case <-tm.C:
+		}
+		processReaderDir(rdr.sourceDir, utils.ParquetSuffix, rdr.processFile)
+		tm.Reset(rdr.Config().RunDelay)
+	}
+}
+
+func (rdr *ParquetFileER) Serve() (err error) {
+	switch rdr.Config().RunDelay {
+	case time.Duration(0): // 0 disables the automatic read, maybe done per API
+		return
+	case time.Duration(-1):
+
+		// Ensure that files already existing in the source path are processed
+		// before the reader starts listening for filesystem change events.
+		processReaderDir(rdr.sourceDir, utils.ParquetSuffix, rdr.processFile)
+
+		return utils.WatchDir(rdr.sourceDir, rdr.processFile,
+			utils.ERs, rdr.rdrExit)
+	default:
+		go rdr.serveDefault()
+	}
+	return
+}
+
+// processFile is called for each file in a directory and dispatches erEvents from it
+func (rdr *ParquetFileER) processFile(fName string) (err error) {
+	if cap(rdr.conReqs) != 0 { // 0 goes for no limit
+		rdr.conReqs <- struct{}{} // Queue here for maxOpenFiles
+		defer func() { <-rdr.conReqs }()
+	}
+	absPath := path.Join(rdr.sourceDir, fName)
+	utils.Logger.Info(
+		fmt.Sprintf("<%s> parsing <%s>", utils.ERs, absPath))
+	fr, err := local.NewLocalFileReader(absPath)
+	if err != nil {
+		return
+	}
+	defer fr.Close()
+	var pr *reader.ParquetReader
+	if pr, err = reader.NewParquetReader(fr, nil, 1); err != nil {
+		return
+	}
+	defer pr.ReadStop()
+	rowNr := 0 // This counts the rows in the file, not really number of CDRs
+	evsPosted := 0
+	timeStart := time.Now()
+	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{utils.MetaFileName: utils.NewLeafNode(fName), utils.MetaReaderID: utils.NewLeafNode(rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx].ID)}}
+	for nrRows := int(pr.GetNumRows()); rowNr < nrRows; {
+		var rows []any
+		if rows, err = pr.ReadByNumber(min(parquetBatchSize, nrRows-rowNr)); err != nil {
+			return
+		}
+		if len(rows) == 0 {
+			break
+		}
+		for _, row := range rows {
+			record, _ := parquetValue(pr.SchemaHandler, pr.SchemaHandler.GetRootInName(),
+				reflect.ValueOf(row)).(map[string]any)
+			rowNr++ // increment the rowNr after checking if it's not the end of file
+			reqVars.Map[utils.MetaFileLineNumber] = utils.NewLeafNode(rowNr)
+			agReq := agents.NewAgentRequest(
+				utils.MapStorage(record), reqVars,
+				nil, nil, nil, rdr.Config().Tenant,
+				rdr.cgrCfg.GeneralCfg().DefaultTenant,
+				utils.FirstNonEmpty(rdr.Config().Timezone,
+					rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+				rdr.fltrS, nil) // create an AgentRequest
+			if pass, err := rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+				agReq); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> reading file: <%s> row <%d>, ignoring due to filter error: <%s>",
+						utils.ERs, absPath, rowNr, err.Error()))
+				return err
+			} else if !pass {
+				continue
+			}
+			if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> reading file: <%s> row <%d>, ignoring due to error: <%s>",
+						utils.ERs, absPath, rowNr, err.Error()))
+				return
+			}
+			cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+			rdrEv := rdr.rdrEvents
+			if _, isPartial := cgrEv.APIOpts[utils.PartialOpt]; isPartial {
+				rdrEv = rdr.partialEvents
+			}
+			rdrEv <- &erEvent{
+				cgrEvent: cgrEv,
+				rdrCfg:   rdr.Config(),
+			}
+			evsPosted++
+		}
+	}
+	if rdr.Config().ProcessedPath != "" {
+		// Finished with file, move it to processed folder
+		outPath := path.Join(rdr.Config().ProcessedPath, fName)
+		if err = os.Rename(absPath, outPath); err != nil {
+			return
+		}
+	}
+
+	utils.Logger.Info(
+		fmt.Sprintf("%s finished processing file <%s>. Total records processed: %d, events posted: %d, run duration: %s",
+			utils.ERs, absPath, rowNr, evsPosted, time.Since(timeStart)))
+	return
+}
+
+// parquetValue converts the values built by the parquet reader to native ones
+// restoring the original column names for the nested structures
+func parquetValue(sh *schema.SchemaHandler, inPath string, v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return parquetValue(sh, inPath, v.Elem())
+	case reflect.Struct:
+		mp := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			name := v.Type().Field(i).Name
+			fldPath := inPath + common.PAR_GO_PATH_DELIMITER + name
+			if exPath, has := sh.InPathToExPath[fldPath]; has {
+				name = exPath[strings.LastIndex(exPath, common.PAR_GO_PATH_DELIMITER)+1:]
+			}
+			mp[name] = parquetValue(sh, fldPath, v.Field(i))
+		}
+		return mp
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+		sl := make([]any, v.Len())
+		for i := range sl {
+			sl[i] = parquetValue(sh, inPath, v.Index(i))
+		}
+		return sl
+	case reflect.Map:
+		mp := make(map[string]any, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			mp[utils.IfaceAsString(parquetValue(sh, inPath, it.Key()))] = parquetValue(sh, inPath, it.Value())
+		}
+		return mp
+	default:
+		return v.Interface()
+	}
+}
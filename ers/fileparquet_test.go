@@ -0,0 +1,145 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type testParquetCDR struct {
+	CGRID   string  `parquet:"name=cgrid, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Account *string `parquet:"name=account, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Usage   int64   `parquet:"name=usage, type=INT64"`
+}
+
+// newTypedERCfg returns a reader configuration populating the CGRID, Account and Usage
+// out of the lowercase columns/fields of the read records
+func newTypedERCfg(t *testing.T, rdrType string) *config.CGRConfig {
+	cfg := config.NewDefaultCGRConfig()
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.ID = "TypedReader"
+	rdrCfg.Type = rdrType
+	rdrCfg.SourcePath = t.TempDir()
+	rdrCfg.ProcessedPath = t.TempDir()
+	rdrCfg.Filters = []string{"*notempty:~*req.usage:"}
+	rdrCfg.Fields = []*config.FCTemplate{
+		{Tag: "CGRID", Path: "*cgreq.CGRID", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.cgrid", utils.InfieldSep)},
+		{Tag: "Account", Path: "*cgreq.Account", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.account", utils.InfieldSep)},
+		{Tag: "Usage", Path: "*cgreq.Usage", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.usage", utils.InfieldSep)},
+		{Tag: "Line", Path: "*cgreq.Line", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*vars.*fileLineNumber", utils.InfieldSep)},
+	}
+	for _, fld := range rdrCfg.Fields {
+		fld.ComputePath()
+	}
+	return cfg
+}
+
+// checkTypedEvents verifies the events read out of the file written by the tests
+func checkTypedEvents(t *testing.T, cfg *config.CGRConfig, rdrEvents chan *erEvent, fName string) {
+	t.Helper()
+	exp := []map[string]any{
+		{utils.CGRID: "cgrid1", utils.AccountField: "1001", utils.Usage: "25000000000", "Line": "1"},
+		{utils.CGRID: "cgrid2", utils.AccountField: "", utils.Usage: "1000000000", "Line": "2"},
+	}
+	for _, expEv := range exp {
+		select {
+		case ev := <-rdrEvents:
+			if utils.ToJSON(ev.cgrEvent.Event) != utils.ToJSON(expEv) {
+				t.Errorf("expected: %s, received: %s", utils.ToJSON(expEv), utils.ToJSON(ev.cgrEvent.Event))
+			}
+		default:
+			t.Fatal("expected an event to be read")
+		}
+	}
+	if _, err := os.Stat(path.Join(cfg.ERsCfg().Readers[0].ProcessedPath, fName)); err != nil {
+		t.Errorf("expected the file to be moved in the processed path, received: %v", err)
+	}
+	if _, err := os.Stat(path.Join(cfg.ERsCfg().Readers[0].SourcePath, fName)); !os.IsNotExist(err) {
+		t.Errorf("expected the file to be removed from the source path, received: %v", err)
+	}
+}
+
+func TestParquetFileERProcessFile(t *testing.T) {
+	cfg := newTypedERCfg(t, utils.MetaFileParquet)
+	fName := "cdrs" + utils.ParquetSuffix
+	fw, err := local.NewLocalFileWriter(path.Join(cfg.ERsCfg().Readers[0].SourcePath, fName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pw, err := writer.NewParquetWriter(fw, new(testParquetCDR), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range []*testParquetCDR{
+		{CGRID: "cgrid1", Account: utils.StringPointer("1001"), Usage: 25000000000},
+		{CGRID: "cgrid2", Usage: 1000000000},
+	} {
+		if err = pw.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = pw.WriteStop(); err != nil {
+		t.Fatal(err)
+	}
+	if err = fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rdrEvents := make(chan *erEvent, 2)
+	rdr, err := NewParquetFileER(cfg, 0, rdrEvents, nil, nil,
+		engine.NewFilterS(cfg, nil, nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rdr.(*ParquetFileER).processFile(fName); err != nil {
+		t.Fatal(err)
+	}
+	checkTypedEvents(t, cfg, rdrEvents, fName)
+}
+
+func TestParquetFileERProcessFileErr(t *testing.T) {
+	cfg := newTypedERCfg(t, utils.MetaFileParquet)
+	fName := "invalid" + utils.ParquetSuffix
+	if err := os.WriteFile(path.Join(cfg.ERsCfg().Readers[0].SourcePath, fName),
+		[]byte("not a parquet file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rdr, err := NewParquetFileER(cfg, 0, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rdr.(*ParquetFileER).processFile(fName); err == nil {
+		t.Error("expected an error when reading an invalid file")
+	}
+	if _, err = os.Stat(path.Join(cfg.ERsCfg().Readers[0].SourcePath, fName)); err != nil {
+		t.Errorf("expected the file to not be moved, received: %v", err)
+	}
+}
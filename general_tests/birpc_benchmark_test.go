@@ -186,10 +186,10 @@ cgrates.org,RES_RPC,,,1h,1,,false,false,10,
 
 	// Create and populate Routes.csv
 	if err := writeFile(utils.RoutesCsv, `
-#Tenant,ID,FilterIDs,ActivationInterval,Sorting,SortingParameters,RouteID,RouteFilterIDs,RouteAccountIDs,RouteRatingPlanIDs,RouteResourceIDs,RouteStatIDs,RouteWeight,RouteBlocker,RouteParameters,Weight
-cgrates.org,ROUTE_RPC,,,*weight,,,,,,,,,,,10
-cgrates.org,ROUTE_RPC,,,,,route1,,,,,,20,,,
-cgrates.org,ROUTE_RPC,,,,,route2,,,,,,10,,,
+#Tenant,ID,FilterIDs,ActivationInterval,Sorting,SortingParameters,RouteID,RouteFilterIDs,RouteAccountIDs,RouteRatingPlanIDs,RouteResourceIDs,RouteStatIDs,RouteWeight,RouteBlocker,RouteParameters,Weight,RouteRankingIDs,RouteTrendIDs
+cgrates.org,ROUTE_RPC,,,*weight,,,,,,,,,,,10,,
+cgrates.org,ROUTE_RPC,,,,,route1,,,,,,20,,,,,
+cgrates.org,ROUTE_RPC,,,,,route2,,,,,,10,,,,,
 `); err != nil {
 		b.Fatal(err)
 	}
@@ -19,12 +19,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package loaders
 
 import (
-	"encoding/csv"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -35,10 +35,10 @@ import (
 type openedCSVFile struct {
 	fileName string
 	rdr      io.ReadCloser // keep reference so we can close it when done
-	csvRdr   *csv.Reader
+	csvRdr   recordReader
 }
 
-func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
+func NewLoader(dm *engine.DataManager, storDB engine.StorDB, cfg *config.LoaderSCfg,
 	timezone string, cachingDlay time.Duration, filterS *engine.FilterS,
 	connMgr *engine.ConnManager, cacheConns []string) (ldr *Loader) {
 	ldr = &Loader{
@@ -51,12 +51,19 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 		lockFilepath:  cfg.GetLockFilePath(),
 		fieldSep:      cfg.FieldSeparator,
 		runDelay:      cfg.RunDelay,
+		pullURL:       cfg.PullURL,
+		pullInterval:  cfg.PullInterval,
+		pulled:        make(map[string]*pulledObject),
 		cachingDelay:  cachingDlay,
 		dataTpls:      make(map[string][]*config.FCTemplate),
 		flagsTpls:     make(map[string]utils.FlagsWithParams),
 		rdrs:          make(map[string]map[string]*openedCSVFile),
 		bufLoaderData: make(map[string][]LoaderData),
+		tpRates:       make(map[string]*utils.TPRateRALs),
+		tpDstRates:    make(map[string]*utils.TPDestinationRate),
+		tpRatingPlans: make(map[string]*utils.TPRatingPlan),
 		dm:            dm,
+		storDB:        storDB,
 		timezone:      timezone,
 		filterS:       filterS,
 		connMgr:       connMgr,
@@ -95,16 +102,26 @@ type Loader struct {
 	lockFilepath  string
 	fieldSep      string
 	runDelay      time.Duration
+	pullURL       string
+	pullInterval  time.Duration
+	pulled        map[string]*pulledObject // objects pulled so far, indexed on their URL
 	cachingDelay  time.Duration
 	dataTpls      map[string][]*config.FCTemplate      // map[loaderType]*config.FCTemplate
 	flagsTpls     map[string]utils.FlagsWithParams     //map[loaderType]utils.FlagsWithParams
 	rdrs          map[string]map[string]*openedCSVFile // map[loaderType]map[fileName]*openedCSVFile for common incremental read
 	bufLoaderData map[string][]LoaderData              // cache of data read, indexed on tenantID
+	tpRates       map[string]*utils.TPRateRALs         // rates loaded so far, needed to build the rating plans
+	tpDstRates    map[string]*utils.TPDestinationRate  // destination rates loaded so far, needed to build the rating plans
+	tpRatingPlans map[string]*utils.TPRatingPlan       // rating plans loaded so far, rebuilt when their rates change
+	ratingTPsRead bool                                 // the rating TPs were read out of storDB
 	dm            *engine.DataManager
+	storDB        engine.StorDB // keeps the rating TPs across restarts, nil keeping them in memory only
 	timezone      string
 	filterS       *engine.FilterS
 	connMgr       *engine.ConnManager
 	cacheConns    []string
+	report        *engine.LoadReport // filled instead of writing into DataDB on dry runs
+	processMux    sync.Mutex         // serializes the processing of tpInDir, dry runs included
 }
 
 func (ldr *Loader) ListenAndServe(stopChan chan struct{}) (err error) {
@@ -112,13 +129,23 @@ func (ldr *Loader) ListenAndServe(stopChan chan struct{}) (err error) {
 	return ldr.serve(stopChan)
 }
 
+// setStorDB replaces the StorDB keeping the rating TPs, read again on their next use
+func (ldr *Loader) setStorDB(storDB engine.StorDB) {
+	ldr.processMux.Lock()
+	ldr.storDB = storDB
+	ldr.ratingTPsRead = false
+	ldr.processMux.Unlock()
+}
+
 // ProcessFolder will process the content in the folder with locking
 func (ldr *Loader) ProcessFolder(caching, loadOption string, stopOnError bool) (err error) {
+	ldr.processMux.Lock()
+	defer ldr.processMux.Unlock()
 	if err = ldr.lockFolder(); err != nil {
 		return
 	}
 	defer ldr.unlockFolder()
-	for ldrType := range ldr.rdrs {
+	for _, ldrType := range ldr.loaderTypes() {
 		if err = ldr.processFiles(ldrType, caching, loadOption); err != nil {
 			if stopOnError {
 				return
@@ -195,15 +222,11 @@ func (ldr *Loader) moveFiles() (err error) {
 
 func (ldr *Loader) processFiles(loaderType, caching, loadOption string) (err error) {
 	for fName := range ldr.rdrs[loaderType] {
-		var rdr *os.File
-		if rdr, err = os.Open(path.Join(ldr.tpInDir, fName)); err != nil {
+		var oFile *openedCSVFile
+		if oFile, err = ldr.openFile(fName); err != nil {
 			return err
 		}
-		csvReader := csv.NewReader(rdr)
-		csvReader.Comma = rune(ldr.fieldSep[0])
-		csvReader.Comment = '#'
-		ldr.rdrs[loaderType][fName] = &openedCSVFile{
-			fileName: fName, rdr: rdr, csvRdr: csvReader}
+		ldr.rdrs[loaderType][fName] = oFile
 		defer ldr.unreferenceFile(loaderType, fName)
 	}
 	// based on load option will store or remove the content
@@ -236,6 +259,8 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> reading line: %d, error: %s",
 						utils.LoaderS, ldr.ldrID, lineNr, err.Error()))
+				ldr.reportError(loaderType, utils.EmptyString,
+					fmt.Errorf("file: %s, reading line: %d, error: %s", fName, lineNr, err.Error()))
 			}
 			if hasErrors { // if any of the readers will give errors, we ignore the line
 				continue
@@ -246,6 +271,8 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
 						utils.LoaderS, ldr.ldrID, lineNr, err.Error()))
+				ldr.reportError(loaderType, utils.EmptyString,
+					fmt.Errorf("file: %s, line: %d, error: %s", fName, lineNr, err.Error()))
 				hasErrors = true
 				continue
 			}
@@ -264,7 +291,10 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 			}
 			if err = ldr.storeLoadedData(loaderType,
 				map[string][]LoaderData{prevTntID: ldr.bufLoaderData[prevTntID]}, caching); err != nil {
-				return
+				if !ldr.reportError(loaderType, prevTntID, err) {
+					return
+				}
+				err = nil // the dry run continues with the next items
 			}
 			delete(ldr.bufLoaderData, prevTntID)
 		}
@@ -277,7 +307,10 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 	}
 	if err = ldr.storeLoadedData(loaderType,
 		map[string][]LoaderData{tntID: ldr.bufLoaderData[tntID]}, caching); err != nil {
-		return
+		if !ldr.reportError(loaderType, tntID, err) {
+			return
+		}
+		err = nil
 	}
 	delete(ldr.bufLoaderData, tntID)
 	return
@@ -308,6 +341,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: AttributeProfile: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(apf)))
+					ldr.reportItem(utils.MetaAttributes, apf.TenantID(), apf)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -338,6 +372,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: ResourceProfile: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(res)))
+					ldr.reportItem(utils.MetaResources, res.TenantID(), res)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -368,6 +403,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: Filter: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(fltrPrf)))
+					ldr.reportItem(utils.MetaFilters, fltrPrf.TenantID(), fltrPrf)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -397,6 +433,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: StatsQueueProfile: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(stsPrf)))
+					ldr.reportItem(utils.MetaStats, stsPrf.TenantID(), stsPrf)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -426,6 +463,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: TrendProfile: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(trsPrf)))
+					ldr.reportItem(utils.MetaTrends, trsPrf.TenantID(), trsPrf)
 					continue
 				}
 
@@ -454,6 +492,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: RankingProfile: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(rgsPrf)))
+					ldr.reportItem(utils.MetaRankings, rgsPrf.TenantID(), rgsPrf)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -483,6 +522,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: ThresholdProfile: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(thPrf)))
+					ldr.reportItem(utils.MetaThresholds, thPrf.TenantID(), thPrf)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -514,6 +554,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: RouteProfile: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(spPrf)))
+					ldr.reportItem(utils.MetaRoutes, spPrf.TenantID(), spPrf)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -544,6 +585,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: ChargerProfile: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(cpp)))
+					ldr.reportItem(utils.MetaChargers, cpp.TenantID(), cpp)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -573,6 +615,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: DispatcherProfile: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(dsp)))
+					ldr.reportItem(utils.MetaDispatchers, dsp.TenantID(), dsp)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -602,6 +645,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: DispatcherHost: %s",
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(dsp)))
+					ldr.reportItem(utils.MetaDispatcherHosts, dsp.TenantID(), dsp)
 					continue
 				}
 				// get IDs so we can reload in cache
@@ -612,6 +656,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				cacheArgs[utils.CacheDispatcherHosts] = ids
 			}
 		}
+	case utils.MetaDestinations, utils.MetaRates, utils.MetaDestinationRates,
+		utils.MetaRatingPlans, utils.MetaRatingProfiles, utils.MetaActions,
+		utils.MetaActionTriggers, utils.MetaActionPlans, utils.MetaAccountActions:
+		if cacheArgs, err = ldr.storeRatingData(loaderType, lds); err != nil {
+			return
+		}
 	}
 	// delay if needed before cache reload
 	if ldr.cachingDelay != 0 {
@@ -644,6 +694,8 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> reading line: %d, error: %s",
 						utils.LoaderS, ldr.ldrID, lineNr, err.Error()))
+				ldr.reportError(loaderType, utils.EmptyString,
+					fmt.Errorf("file: %s, reading line: %d, error: %s", fName, lineNr, err.Error()))
 			}
 			if hasErrors { // if any of the readers will give errors, we ignore the line
 				continue
@@ -654,6 +706,8 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
 						utils.LoaderS, ldr.ldrID, lineNr, err.Error()))
+				ldr.reportError(loaderType, utils.EmptyString,
+					fmt.Errorf("file: %s, line: %d, error: %s", fName, lineNr, err.Error()))
 				hasErrors = true
 				continue
 			}
@@ -705,6 +759,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: AttributeProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaAttributes, tntID)
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
@@ -724,6 +779,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: ResourceProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaResources, tntID)
 
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
@@ -743,6 +799,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: Filter: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaFilters, tntID)
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
@@ -761,6 +818,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: StatsQueueProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaStats, tntID)
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
@@ -780,6 +838,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: RankingProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaRankings, tntID)
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
@@ -798,6 +857,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: ThresholdProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaThresholds, tntID)
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
@@ -817,6 +877,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: RouteProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaRoutes, tntID)
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
@@ -835,6 +896,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: ChargerProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaChargers, tntID)
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
@@ -853,6 +915,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: DispatcherProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaDispatchers, tntID)
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
@@ -870,6 +933,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: DispatcherHostID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
+				ldr.reportRemoval(utils.MetaDispatcherHosts, tntID)
 			} else {
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
@@ -881,6 +945,12 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				cacheArgs[utils.CacheDispatcherHosts] = ids
 			}
 		}
+	case utils.MetaDestinations, utils.MetaRates, utils.MetaDestinationRates,
+		utils.MetaRatingPlans, utils.MetaRatingProfiles, utils.MetaActions,
+		utils.MetaActionTriggers, utils.MetaActionPlans, utils.MetaAccountActions:
+		if cacheArgs, err = ldr.removeRatingData(loaderType, lds); err != nil {
+			return
+		}
 	}
 	// delay if needed before cache reload
 	if ldr.cachingDelay != 0 {
@@ -894,6 +964,9 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 }
 
 func (ldr *Loader) serve(stopChan chan struct{}) (err error) {
+	if ldr.pullURL != utils.EmptyString && ldr.pullInterval > 0 {
+		go ldr.handlePull(stopChan)
+	}
 	switch ldr.runDelay {
 	case time.Duration(0): // 0 disables the automatic read, maybe done per API
 		return
@@ -923,6 +996,11 @@ func (ldr *Loader) handleFolder(stopChan chan struct{}) {
 }
 
 func (ldr *Loader) processFile(itmID string) (err error) {
+	ldr.processMux.Lock()
+	defer ldr.processMux.Unlock()
+	if strings.ToLower(path.Ext(itmID)) == utils.XLSXSuffix {
+		return ldr.processWorkbook(itmID)
+	}
 	loaderType := ldr.getLdrType(itmID)
 	if len(loaderType) == 0 {
 		return
@@ -934,15 +1012,9 @@ func (ldr *Loader) processFile(itmID string) (err error) {
 	if ldr.rdrs[loaderType][itmID] != nil {
 		ldr.unreferenceFile(loaderType, itmID)
 	}
-	var rdr *os.File
-	if rdr, err = os.Open(path.Join(ldr.tpInDir, itmID)); err != nil {
+	if ldr.rdrs[loaderType][itmID], err = ldr.openFile(itmID); err != nil {
 		return
 	}
-	csvReader := csv.NewReader(rdr)
-	csvReader.Comma = rune(ldr.fieldSep[0])
-	csvReader.Comment = '#'
-	ldr.rdrs[loaderType][itmID] = &openedCSVFile{
-		fileName: itmID, rdr: rdr, csvRdr: csvReader}
 	if !ldr.allFilesPresent(loaderType) {
 		return
 	}
@@ -0,0 +1,134 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"errors"
+	"os"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// DryRunFolder processes the folder the same way as ProcessFolder, reporting
+// the changes instead of writing them to DataDB and leaving the files in place.
+// The files dropped meanwhile are processed for real only once the dry run is over
+func (ldr *Loader) DryRunFolder(loadOption string, stopOnError bool) (report *engine.LoadReport, err error) {
+	ldr.processMux.Lock()
+	defer ldr.processMux.Unlock()
+	if err = ldr.lockFolder(); err != nil {
+		return
+	}
+	defer ldr.unlockFolder()
+	dryRun := ldr.dryRun
+	ldr.dryRun, ldr.report = true, engine.NewLoadReport(ldr.dm)
+	defer func() { ldr.dryRun, ldr.report = dryRun, nil }()
+	for _, ldrType := range ldr.loaderTypes() {
+		if err = ldr.processFiles(ldrType, utils.MetaNone, loadOption); err != nil {
+			if stopOnError {
+				return nil, err
+			}
+			if !errors.Is(err, os.ErrNotExist) { // not all the loader types need to be present
+				ldr.report.AddError(ldrType, utils.EmptyString, err)
+			}
+			err = nil
+		}
+	}
+	ldr.report.Complete()
+	return ldr.report, nil
+}
+
+// reportItem adds the item to the dry run report, if one was requested
+func (ldr *Loader) reportItem(ldrType, id string, item any) {
+	if ldr.report == nil {
+		return
+	}
+	switch ldrType {
+	case utils.MetaRates, utils.MetaDestinationRates: // kept by the loader only
+		ldr.report.AddDiff(ldrType, id, ldr.bufferedItem(ldrType, id), item)
+	default:
+		ldr.report.StoreItem(ldrType, id, item)
+	}
+}
+
+// reportRemoval adds the removal of the item to the dry run report, if one was requested
+func (ldr *Loader) reportRemoval(ldrType, id string) {
+	if ldr.report == nil {
+		return
+	}
+	switch ldrType {
+	case utils.MetaRates, utils.MetaDestinationRates:
+		oldItem := ldr.bufferedItem(ldrType, id)
+		if oldItem == nil {
+			ldr.report.AddError(ldrType, id, utils.ErrNotFound)
+			return
+		}
+		ldr.report.AddDiff(ldrType, id, oldItem, nil)
+	default:
+		ldr.report.RemoveItem(ldrType, id)
+	}
+}
+
+// reportError adds the error to the dry run report, returning false if none was requested
+func (ldr *Loader) reportError(ldrType, id string, err error) bool {
+	if ldr.report == nil {
+		return false
+	}
+	ldr.report.AddError(ldrType, id, err)
+	return true
+}
+
+// bufferedItem returns the rate or destination rate kept by the loader
+func (ldr *Loader) bufferedItem(ldrType, id string) (item any) {
+	switch ldrType {
+	case utils.MetaRates:
+		if tpRt, has := ldr.tpRates[id]; has {
+			return tpRt
+		}
+	case utils.MetaDestinationRates:
+		if tpDr, has := ldr.tpDstRates[id]; has {
+			return tpDr
+		}
+	}
+	return
+}
+
+// reportRatingItem loads one rating item the same way as when writing it,
+// adding the result to the dry run report
+func (ldr *Loader) reportRatingItem(ldrType, id string, set func(*engine.InternalDB) error,
+	loads ...func(*engine.TpReader) error) {
+	if ldr.report == nil {
+		return
+	}
+	lr := newRatingLoadReader()
+	err := set(lr)
+	var tpr *engine.TpReader
+	if err == nil {
+		tpr, err = engine.NewTpReader(ldr.dm.DataDB(), lr, ldr.ldrID, ldr.timezone,
+			nil, nil, false)
+	}
+	for i := 0; err == nil && i < len(loads); i++ {
+		err = loads[i](tpr)
+	}
+	if err != nil {
+		ldr.report.AddError(ldrType, id, err)
+		return
+	}
+	tpr.DryRun(ldr.report, false)
+}
@@ -0,0 +1,162 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestLoaderDryRunFolder(t *testing.T) {
+	ldr := newSourcesTestLoader(t, "Attributes.json")
+	ldr.tpOutDir = t.TempDir()
+	if err := ldr.dm.SetAttributeProfile(&engine.AttributeProfile{
+		Tenant:   "cgrates.org",
+		ID:       "ATTR_1",
+		Contexts: []string{utils.MetaSessionS},
+		Weight:   10,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	fPath := path.Join(ldr.tpInDir, "Attributes.json")
+	if err := os.WriteFile(fPath, []byte(`[
+		["cgrates.org", "ATTR_1", "*sessions", 20],
+		["cgrates.org", "ATTR_2", "*sessions", 10],
+		["cgrates.org", "ATTR_3", "*sessions", "high"]
+	]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := ldr.DryRunFolder(utils.MetaStore, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expDiffs := []*engine.LoadDiff{
+		{Type: utils.MetaAttributes, ID: "cgrates.org:ATTR_1", Status: utils.MetaUpdated,
+			Fields: map[string]*engine.FieldDiff{"Weight": {Old: float64(10), New: float64(20)}}},
+		{Type: utils.MetaAttributes, ID: "cgrates.org:ATTR_2", Status: utils.MetaCreated},
+	}
+	if !reflect.DeepEqual(expDiffs, report.Diffs) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expDiffs), utils.ToJSON(report.Diffs))
+	}
+	if len(report.Errors) != 1 || report.Errors[0].ID != "cgrates.org:ATTR_3" {
+		t.Errorf("expected the invalid weight reported, received %s", utils.ToJSON(report.Errors))
+	}
+	// nothing is written and the files are left in place
+	checkSourcesAttribute(t, ldr, "ATTR_1", 10)
+	if _, err := ldr.dm.GetAttributeProfile("cgrates.org", "ATTR_2", false, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := os.Stat(fPath); err != nil {
+		t.Error(err)
+	}
+	if ldr.dryRun || ldr.report != nil {
+		t.Error("expected the loader restored after the dry run")
+	}
+
+	if err := os.WriteFile(fPath, []byte(`[
+		["cgrates.org", "ATTR_1", "", ""],
+		["cgrates.org", "ATTR_3", "", ""]
+	]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if report, err = ldr.DryRunFolder(utils.MetaRemove, false); err != nil {
+		t.Fatal(err)
+	}
+	expDiffs = []*engine.LoadDiff{
+		{Type: utils.MetaAttributes, ID: "cgrates.org:ATTR_1", Status: utils.MetaRemoved},
+	}
+	if !reflect.DeepEqual(expDiffs, report.Diffs) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expDiffs), utils.ToJSON(report.Diffs))
+	}
+	expErrs := []*engine.LoadError{
+		{Type: utils.MetaAttributes, ID: "cgrates.org:ATTR_3", Error: utils.ErrNotFound.Error()},
+	}
+	if !reflect.DeepEqual(expErrs, report.Errors) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expErrs), utils.ToJSON(report.Errors))
+	}
+	checkSourcesAttribute(t, ldr, "ATTR_1", 10)
+}
+
+func TestLoaderDryRunConcurrentFile(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		ldr := newSourcesTestLoader(t, "Attributes.json")
+		ldr.tpOutDir = t.TempDir()
+		if err := os.WriteFile(path.Join(ldr.tpInDir, "Attributes.json"),
+			[]byte(`[["cgrates.org", "ATTR_1", "*sessions", 20]]`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := ldr.DryRunFolder(utils.MetaStore, false); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() { // file dropped during the dry run
+			defer wg.Done()
+			if err := ldr.processFile("Attributes.json"); err != nil {
+				t.Error(err)
+			}
+		}()
+		wg.Wait()
+		// the file dropped is always processed for real
+		checkSourcesAttribute(t, ldr, "ATTR_1", 20)
+		if _, err := os.Stat(path.Join(ldr.tpOutDir, "Attributes.json")); err != nil {
+			t.Error(err)
+		}
+		if ldr.dryRun || ldr.report != nil {
+			t.Error("expected the loader restored after the dry run")
+		}
+	}
+}
+
+func TestLoaderDryRunRatingData(t *testing.T) {
+	ldr := newRatingTestLoader("TestLoaderDryRunRatingData", nil)
+	ldr.dryRun, ldr.report = true, engine.NewLoadReport(ldr.dm)
+	processRatingContent(t, ldr, utils.MetaRates, `
+RT_1CNT,0,0.01,60s,1s,0s
+`, false)
+	processRatingContent(t, ldr, utils.MetaAccountActions, `
+cgrates.org,1001,AP_MISSING,,false,false
+`, false)
+	ldr.report.Complete()
+	expDiffs := []*engine.LoadDiff{
+		{Type: utils.MetaRates, ID: "RT_1CNT", Status: utils.MetaCreated},
+	}
+	if !reflect.DeepEqual(expDiffs, ldr.report.Diffs) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expDiffs), utils.ToJSON(ldr.report.Diffs))
+	}
+	if len(ldr.report.Errors) != 1 || ldr.report.Errors[0].ID != "cgrates.org:1001" {
+		t.Errorf("expected the missing action plan reported, received %s", utils.ToJSON(ldr.report.Errors))
+	}
+	if len(ldr.tpRates) != 0 {
+		t.Errorf("expected no rates kept, received %s", utils.ToJSON(ldr.tpRates))
+	}
+	if _, err := ldr.dm.GetAccount("cgrates.org:1001"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
@@ -0,0 +1,782 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ratingLoaderTypes are the loader types of the rating data, listed in the
+// order they depend on each other
+var ratingLoaderTypes = []string{utils.MetaDestinations, utils.MetaRates,
+	utils.MetaDestinationRates, utils.MetaRatingPlans, utils.MetaRatingProfiles,
+	utils.MetaActions, utils.MetaActionTriggers, utils.MetaActionPlans,
+	utils.MetaAccountActions}
+
+// loaderTypes returns the configured loader types, the rating ones first so
+// their dependencies are stored before them when processing a folder
+func (ldr *Loader) loaderTypes() (ldrTypes []string) {
+	ldrTypes = make([]string, 0, len(ldr.rdrs))
+	for _, ldrType := range ratingLoaderTypes {
+		if _, has := ldr.rdrs[ldrType]; has {
+			ldrTypes = append(ldrTypes, ldrType)
+		}
+	}
+	for ldrType := range ldr.rdrs {
+		if !slices.Contains(ratingLoaderTypes, ldrType) {
+			ldrTypes = append(ldrTypes, ldrType)
+		}
+	}
+	return
+}
+
+// storeRatingData stores the rating data of one batch, returning the cache
+// partitions with the IDs to be reloaded
+func (ldr *Loader) storeRatingData(loaderType string,
+	lds map[string][]LoaderData) (cacheArgs map[string][]string, err error) {
+	var lDataSet []LoaderData
+	for _, lData := range lds {
+		lDataSet = append(lDataSet, lData...)
+	}
+	cacheArgs = make(map[string][]string)
+	if len(lDataSet) == 0 {
+		return
+	}
+	switch loaderType {
+	case utils.MetaDestinations:
+		dstModels := make(engine.DestinationMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			if err = utils.UpdateStructWithIfaceMap(&dstModels[i], ld); err != nil {
+				return
+			}
+		}
+		for _, tpDst := range dstModels.AsTPDestinations() {
+			dst := engine.NewDestinationFromTPDestination(tpDst)
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: Destination: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(dst)))
+				ldr.reportItem(utils.MetaDestinations, dst.Id, dst)
+				continue
+			}
+			var oldDst *engine.Destination
+			if oldDst, err = ldr.dm.GetDestination(dst.Id, false, false,
+				utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+				return
+			}
+			if err = ldr.dm.SetDestination(dst, utils.NonTransactional); err != nil {
+				return
+			}
+			if err = ldr.dm.UpdateReverseDestination(oldDst, dst, utils.NonTransactional); err != nil {
+				return
+			}
+			cacheArgs[utils.CacheDestinations] = append(cacheArgs[utils.CacheDestinations], dst.Id)
+			if oldDst != nil { // the removed prefixes need reloading too
+				cacheArgs[utils.CacheReverseDestinations] = append(cacheArgs[utils.CacheReverseDestinations],
+					oldDst.Prefixes...)
+			}
+			cacheArgs[utils.CacheReverseDestinations] = append(cacheArgs[utils.CacheReverseDestinations],
+				dst.Prefixes...)
+		}
+		err = ldr.setLoadIDs(cacheArgs)
+	case utils.MetaRates:
+		rtModels := make(engine.RateMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			if err = utils.UpdateStructWithIfaceMap(&rtModels[i], ld); err != nil {
+				return
+			}
+			rtModels[i].Tpid = ldr.ldrID
+		}
+		var tpRts []*utils.TPRateRALs
+		if tpRts, err = rtModels.AsTPRates(); err != nil {
+			return
+		}
+		if err = ldr.readRatingTPs(); err != nil {
+			return
+		}
+		if !ldr.dryRun && ldr.storDB != nil {
+			if err = ldr.storDB.SetTPRates(tpRts); err != nil {
+				return
+			}
+		}
+		drIDs := make(utils.StringSet)
+		for _, tpRt := range tpRts {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: Rate: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(tpRt)))
+				ldr.reportItem(utils.MetaRates, tpRt.ID, tpRt)
+				continue
+			}
+			ldr.tpRates[tpRt.ID] = tpRt
+			for drID, tpDr := range ldr.tpDstRates {
+				for _, dr := range tpDr.DestinationRates {
+					if dr.RateId == tpRt.ID {
+						drIDs.Add(drID)
+					}
+				}
+			}
+		}
+		if err = ldr.warnUnknownRatingPlans(); err != nil {
+			return
+		}
+		// rebuild the rating plans using the changed rates
+		if cacheArgs, err = ldr.setRatingPlans(ldr.ratingPlansWithDestinationRates(drIDs)); err != nil {
+			return
+		}
+		err = ldr.setLoadIDs(cacheArgs)
+	case utils.MetaDestinationRates:
+		drModels := make(engine.DestinationRateMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			if err = utils.UpdateStructWithIfaceMap(&drModels[i], ld); err != nil {
+				return
+			}
+			drModels[i].Tpid = ldr.ldrID
+		}
+		tpDrs := drModels.AsTPDestinationRates()
+		if err = ldr.readRatingTPs(); err != nil {
+			return
+		}
+		if !ldr.dryRun && ldr.storDB != nil {
+			if err = ldr.storDB.SetTPDestinationRates(tpDrs); err != nil {
+				return
+			}
+		}
+		drIDs := make(utils.StringSet)
+		for _, tpDr := range tpDrs {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: DestinationRate: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(tpDr)))
+				ldr.reportItem(utils.MetaDestinationRates, tpDr.ID, tpDr)
+				continue
+			}
+			ldr.tpDstRates[tpDr.ID] = tpDr
+			drIDs.Add(tpDr.ID)
+		}
+		if err = ldr.warnUnknownRatingPlans(); err != nil {
+			return
+		}
+		if cacheArgs, err = ldr.setRatingPlans(ldr.ratingPlansWithDestinationRates(drIDs)); err != nil {
+			return
+		}
+		err = ldr.setLoadIDs(cacheArgs)
+	case utils.MetaRatingPlans:
+		rpModels := make(engine.RatingPlanMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			if err = utils.UpdateStructWithIfaceMap(&rpModels[i], ld); err != nil {
+				return
+			}
+			rpModels[i].Tpid = ldr.ldrID
+		}
+		tpRpls := rpModels.AsTPRatingPlans()
+		if err = ldr.readRatingTPs(); err != nil {
+			return
+		}
+		if ldr.dryRun {
+			for _, tpRpl := range tpRpls {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: RatingPlan: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(tpRpl)))
+				ldr.reportRatingItem(utils.MetaRatingPlans, tpRpl.ID,
+					func(lr *engine.InternalDB) error { return ldr.setRatingPlanTPs(lr, tpRpl) },
+					(*engine.TpReader).LoadRates, (*engine.TpReader).LoadDestinationRates,
+					(*engine.TpReader).LoadRatingPlans)
+			}
+			return
+		}
+		if cacheArgs, err = ldr.setRatingPlans(tpRpls); err != nil {
+			return
+		}
+		err = ldr.setLoadIDs(cacheArgs)
+	case utils.MetaRatingProfiles:
+		rpfModels := make(engine.RatingProfileMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			if err = utils.UpdateStructWithIfaceMap(&rpfModels[i], ld); err != nil {
+				return
+			}
+			rpfModels[i].Tpid = ldr.ldrID
+		}
+		tpRpfs := rpfModels.AsTPRatingProfiles()
+		if ldr.dryRun {
+			for _, tpRpf := range tpRpfs {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: RatingProfile: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(tpRpf)))
+				ldr.reportRatingItem(utils.MetaRatingProfiles, tpRpf.KeyId(),
+					func(lr *engine.InternalDB) error {
+						return lr.SetTPRatingProfiles([]*utils.TPRatingProfile{tpRpf})
+					}, (*engine.TpReader).LoadRatingProfiles)
+			}
+			return
+		}
+		lr := newRatingLoadReader()
+		if err = lr.SetTPRatingProfiles(tpRpfs); err != nil {
+			return
+		}
+		return ldr.writeRatingData(lr, (*engine.TpReader).LoadRatingProfiles,
+			utils.CacheRatingProfiles)
+	case utils.MetaActions:
+		actModels := make(engine.ActionMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			if err = utils.UpdateStructWithIfaceMap(&actModels[i], ld); err != nil {
+				return
+			}
+			actModels[i].Tpid = ldr.ldrID
+		}
+		tpActs := actModels.AsTPActions()
+		if ldr.dryRun {
+			for _, tpAct := range tpActs {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: Actions: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(tpAct)))
+				ldr.reportRatingItem(utils.MetaActions, tpAct.ID,
+					func(lr *engine.InternalDB) error {
+						return lr.SetTPActions([]*utils.TPActions{tpAct})
+					}, (*engine.TpReader).LoadActions)
+			}
+			return
+		}
+		lr := newRatingLoadReader()
+		if err = lr.SetTPActions(tpActs); err != nil {
+			return
+		}
+		return ldr.writeRatingData(lr, (*engine.TpReader).LoadActions,
+			utils.CacheActions)
+	case utils.MetaActionTriggers:
+		atrModels := make(engine.ActionTriggerMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			if err = utils.UpdateStructWithIfaceMap(&atrModels[i], ld); err != nil {
+				return
+			}
+			atrModels[i].Tpid = ldr.ldrID
+		}
+		tpAtrs := atrModels.AsTPActionTriggers()
+		if ldr.dryRun {
+			for _, tpAtr := range tpAtrs {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: ActionTriggers: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(tpAtr)))
+				ldr.reportRatingItem(utils.MetaActionTriggers, tpAtr.ID,
+					func(lr *engine.InternalDB) error {
+						return lr.SetTPActionTriggers([]*utils.TPActionTriggers{tpAtr})
+					}, (*engine.TpReader).LoadActionTriggers)
+			}
+			return
+		}
+		lr := newRatingLoadReader()
+		if err = lr.SetTPActionTriggers(tpAtrs); err != nil {
+			return
+		}
+		return ldr.writeRatingData(lr, (*engine.TpReader).LoadActionTriggers,
+			utils.CacheActionTriggers)
+	case utils.MetaActionPlans:
+		apModels := make(engine.ActionPlanMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			if err = utils.UpdateStructWithIfaceMap(&apModels[i], ld); err != nil {
+				return
+			}
+			apModels[i].Tpid = ldr.ldrID
+		}
+		tpAPs := apModels.AsTPActionPlans()
+		if ldr.dryRun {
+			for _, tpAP := range tpAPs {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: ActionPlan: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(tpAP)))
+				ldr.reportRatingItem(utils.MetaActionPlans, tpAP.ID,
+					func(lr *engine.InternalDB) error {
+						return lr.SetTPActionPlans([]*utils.TPActionPlan{tpAP})
+					}, (*engine.TpReader).LoadActionPlans)
+			}
+			return
+		}
+		lr := newRatingLoadReader()
+		if err = lr.SetTPActionPlans(tpAPs); err != nil {
+			return
+		}
+		return ldr.writeRatingData(lr, (*engine.TpReader).LoadActionPlans,
+			utils.CacheActionPlans)
+	case utils.MetaAccountActions:
+		aaModels := make(engine.AccountActionMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			if err = utils.UpdateStructWithIfaceMap(&aaModels[i], ld); err != nil {
+				return
+			}
+			aaModels[i].Tpid = ldr.ldrID
+		}
+		tpAAs := aaModels.AsTPAccountActions()
+		if ldr.dryRun {
+			for _, tpAA := range tpAAs {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: AccountActions: %s",
+						utils.LoaderS, ldr.ldrID, utils.ToJSON(tpAA)))
+				ldr.reportRatingItem(utils.MetaAccountActions, tpAA.KeyId(),
+					func(lr *engine.InternalDB) error {
+						return lr.SetTPAccountActions([]*utils.TPAccountActions{tpAA})
+					}, (*engine.TpReader).LoadAccountActions)
+			}
+			return
+		}
+		lr := newRatingLoadReader()
+		if err = lr.SetTPAccountActions(tpAAs); err != nil {
+			return
+		}
+		return ldr.writeRatingData(lr, (*engine.TpReader).LoadAccountActions,
+			utils.CacheActionPlans, utils.CacheAccountActionPlans)
+	}
+	return
+}
+
+// newRatingLoadReader returns the in-memory StorDB holding the TPs of one batch
+func newRatingLoadReader() *engine.InternalDB {
+	return engine.NewInternalDB(nil, nil, false, config.CgrConfig().StorDbCfg().Items)
+}
+
+// writeRatingData loads the TPs out of lr and writes them to DataDB the same
+// way cgr-loader does, returning the loaded IDs for the given cache partitions
+func (ldr *Loader) writeRatingData(lr engine.LoadReader, load func(*engine.TpReader) error,
+	cacheIDs ...string) (cacheArgs map[string][]string, err error) {
+	var tpr *engine.TpReader
+	if tpr, err = engine.NewTpReader(ldr.dm.DataDB(), lr, ldr.ldrID, ldr.timezone,
+		nil, nil, false); err != nil {
+		return
+	}
+	if err = load(tpr); err != nil {
+		return
+	}
+	if err = tpr.WriteToDatabase(false, false); err != nil {
+		return
+	}
+	cacheArgs = make(map[string][]string)
+	for _, cacheID := range cacheIDs {
+		var ids []string
+		if ids, err = tpr.GetLoadedIds(utils.CacheInstanceToPrefix[cacheID]); err != nil {
+			return
+		}
+		if len(ids) != 0 {
+			cacheArgs[cacheID] = ids
+		}
+	}
+	return
+}
+
+// ratingPlansWithDestinationRates returns the loaded rating plans using any of the drIDs
+func (ldr *Loader) ratingPlansWithDestinationRates(drIDs utils.StringSet) (tpRpls []*utils.TPRatingPlan) {
+	if len(drIDs) == 0 {
+		return
+	}
+	for _, tpRpl := range ldr.tpRatingPlans {
+		for _, rpb := range tpRpl.RatingPlanBindings {
+			if drIDs.Has(rpb.DestinationRatesId) {
+				tpRpls = append(tpRpls, tpRpl)
+				break
+			}
+		}
+	}
+	return
+}
+
+// setRatingPlans builds the rating plans out of the rates and destination
+// rates loaded so far and writes them to DataDB, keeping their TPs within
+// StorDB. The destinations are expected in DataDB already
+func (ldr *Loader) setRatingPlans(tpRpls []*utils.TPRatingPlan) (cacheArgs map[string][]string, err error) {
+	cacheArgs = make(map[string][]string)
+	for _, tpRpl := range tpRpls {
+		lr := newRatingLoadReader()
+		if err = ldr.setRatingPlanTPs(lr, tpRpl); err != nil {
+			return
+		}
+		var tpr *engine.TpReader
+		if tpr, err = engine.NewTpReader(ldr.dm.DataDB(), lr, ldr.ldrID, ldr.timezone,
+			nil, nil, false); err != nil {
+			return
+		}
+		if _, err = tpr.LoadRatingPlansFiltered(tpRpl.ID); err != nil {
+			return
+		}
+		if ldr.storDB != nil {
+			if err = ldr.storDB.SetTPRatingPlans([]*utils.TPRatingPlan{tpRpl}); err != nil {
+				return
+			}
+		}
+		ldr.tpRatingPlans[tpRpl.ID] = tpRpl
+		cacheArgs[utils.CacheRatingPlans] = append(cacheArgs[utils.CacheRatingPlans], tpRpl.ID)
+	}
+	return
+}
+
+// readRatingTPs reads once the rating TPs kept within StorDB, loaded by the
+// loader before a restart
+func (ldr *Loader) readRatingTPs() (err error) {
+	if ldr.storDB == nil || ldr.ratingTPsRead {
+		return
+	}
+	var tpRts []*utils.TPRateRALs
+	if tpRts, err = ldr.storDB.GetTPRates(ldr.ldrID, utils.EmptyString); err != nil &&
+		err != utils.ErrNotFound {
+		return
+	}
+	var tpDrs []*utils.TPDestinationRate
+	if tpDrs, err = ldr.storDB.GetTPDestinationRates(ldr.ldrID, utils.EmptyString,
+		nil); err != nil && err != utils.ErrNotFound {
+		return
+	}
+	var tpRpls []*utils.TPRatingPlan
+	if tpRpls, err = ldr.storDB.GetTPRatingPlans(ldr.ldrID, utils.EmptyString,
+		nil); err != nil && err != utils.ErrNotFound {
+		return
+	}
+	for _, tpRt := range tpRts {
+		ldr.tpRates[tpRt.ID] = tpRt
+	}
+	for _, tpDr := range tpDrs {
+		ldr.tpDstRates[tpDr.ID] = tpDr
+	}
+	for _, tpRpl := range tpRpls {
+		ldr.tpRatingPlans[tpRpl.ID] = tpRpl
+	}
+	ldr.ratingTPsRead = true
+	return nil
+}
+
+// setRatingPlanTPs sets into lr the rating plan together with the rates and
+// destination rates it uses, out of the ones loaded so far. Without StorDB the
+// rates and destination rates are kept in memory only, hence they need loading
+// again after a restart before the rating plans using them
+func (ldr *Loader) setRatingPlanTPs(lr *engine.InternalDB, tpRpl *utils.TPRatingPlan) (err error) {
+	if err = lr.SetTPRatingPlans([]*utils.TPRatingPlan{tpRpl}); err != nil {
+		return
+	}
+	var tpDrs []*utils.TPDestinationRate
+	var tpRts []*utils.TPRateRALs
+	for _, rpb := range tpRpl.RatingPlanBindings {
+		tpDr, has := ldr.tpDstRates[rpb.DestinationRatesId]
+		if !has {
+			return utils.ErrPrefixNotFound(fmt.Sprintf("destination rate <%s> of rating plan <%s> not loaded",
+				rpb.DestinationRatesId, tpRpl.ID))
+		}
+		tpDrs = append(tpDrs, tpDr)
+		for _, dr := range tpDr.DestinationRates {
+			tpRt, has := ldr.tpRates[dr.RateId]
+			if !has {
+				return utils.ErrPrefixNotFound(fmt.Sprintf("rate <%s> of destination rate <%s> not loaded",
+					dr.RateId, tpDr.ID))
+			}
+			tpRts = append(tpRts, tpRt)
+		}
+	}
+	if err = lr.SetTPDestinationRates(tpDrs); err != nil {
+		return
+	}
+	return lr.SetTPRates(tpRts)
+}
+
+// warnUnknownRatingPlans logs the rating plans within DataDB not loaded by the loader,
+// which are not rebuilt when their rates or destination rates change
+func (ldr *Loader) warnUnknownRatingPlans() (err error) {
+	if ldr.dryRun {
+		return
+	}
+	var keys []string
+	if keys, err = ldr.dm.DataDB().GetKeysForPrefix(utils.RatingPlanPrefix); err != nil {
+		return
+	}
+	var rplIDs []string
+	for _, key := range keys {
+		if rplID := strings.TrimPrefix(key, utils.RatingPlanPrefix); ldr.tpRatingPlans[rplID] == nil {
+			rplIDs = append(rplIDs, rplID)
+		}
+	}
+	if len(rplIDs) != 0 {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s-%s> rating plans %s not loaded by the loader are not rebuilt with the changed rates, load them again after their rates",
+				utils.LoaderS, ldr.ldrID, rplIDs))
+	}
+	return
+}
+
+// setLoadIDs updates the load IDs of the cache partitions we have written into
+func (ldr *Loader) setLoadIDs(cacheArgs map[string][]string) (err error) {
+	if len(cacheArgs) == 0 {
+		return
+	}
+	loadID := time.Now().UnixNano()
+	loadIDs := make(map[string]int64, len(cacheArgs))
+	for cacheID := range cacheArgs {
+		loadIDs[cacheID] = loadID
+	}
+	return ldr.dm.SetLoadIDs(loadIDs)
+}
+
+// removeRatingData removes the rating data of one batch, returning the
+// cache partitions with the IDs to be reloaded
+func (ldr *Loader) removeRatingData(loaderType string,
+	lds map[string][]LoaderData) (cacheArgs map[string][]string, err error) {
+	cacheArgs = make(map[string][]string)
+	// only the fields identifying the data are needed
+	var ids []string
+	for _, lDataSet := range lds {
+		for _, ld := range lDataSet {
+			var id string
+			switch loaderType {
+			case utils.MetaRatingProfiles:
+				id = utils.ConcatenatedKey(utils.MetaOut, utils.IfaceAsString(ld[utils.Tenant]),
+					utils.IfaceAsString(ld[utils.Category]), utils.IfaceAsString(ld[utils.Subject]))
+			case utils.MetaAccountActions:
+				id = utils.ConcatenatedKey(utils.IfaceAsString(ld[utils.Tenant]),
+					utils.IfaceAsString(ld[utils.AccountField]))
+			default:
+				id = utils.IfaceAsString(ld[utils.Tag])
+			}
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	if ldr.dryRun {
+		for _, id := range ids {
+			utils.Logger.Info(
+				fmt.Sprintf("<%s-%s> DRY_RUN: %s ID: %s",
+					utils.LoaderS, ldr.ldrID, loaderType, id))
+			ldr.reportRemoval(loaderType, id)
+		}
+		return
+	}
+	switch loaderType {
+	case utils.MetaDestinations:
+		for _, id := range ids {
+			var oldDst *engine.Destination
+			if oldDst, err = ldr.dm.GetDestination(id, false, false,
+				utils.NonTransactional); err != nil {
+				return
+			}
+			if err = ldr.dm.RemoveDestination(id, utils.NonTransactional); err != nil {
+				return
+			}
+			cacheArgs[utils.CacheDestinations] = append(cacheArgs[utils.CacheDestinations], id)
+			cacheArgs[utils.CacheReverseDestinations] = append(cacheArgs[utils.CacheReverseDestinations],
+				oldDst.Prefixes...)
+		}
+	case utils.MetaRates, utils.MetaDestinationRates: // kept only for building the rating plans
+		if err = ldr.readRatingTPs(); err != nil {
+			return
+		}
+		cacheArgs, err = ldr.removeRatingTPs(loaderType, ids)
+	case utils.MetaRatingPlans:
+		if err = ldr.readRatingTPs(); err != nil {
+			return
+		}
+		for _, id := range ids {
+			if err = ldr.removeRatingPlan(id); err != nil {
+				return
+			}
+		}
+		cacheArgs[utils.CacheRatingPlans] = ids
+	case utils.MetaRatingProfiles:
+		for _, id := range ids {
+			if err = ldr.dm.RemoveRatingProfile(id); err != nil {
+				return
+			}
+		}
+		cacheArgs[utils.CacheRatingProfiles] = ids
+	case utils.MetaActions:
+		for _, id := range ids {
+			if err = ldr.dm.RemoveActions(id); err != nil {
+				return
+			}
+		}
+		cacheArgs[utils.CacheActions] = ids
+	case utils.MetaActionTriggers:
+		for _, id := range ids {
+			if err = ldr.dm.RemoveActionTriggers(id, utils.NonTransactional); err != nil {
+				return
+			}
+		}
+		cacheArgs[utils.CacheActionTriggers] = ids
+	case utils.MetaActionPlans:
+		err = guardian.Guardian.Guard(func() (err error) {
+			for _, id := range ids {
+				var ap *engine.ActionPlan
+				if ap, err = ldr.dm.GetActionPlan(id, false, false,
+					utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+					return
+				}
+				if err = ldr.dm.RemoveActionPlan(id, utils.NonTransactional); err != nil {
+					return
+				}
+				if ap == nil {
+					continue
+				}
+				for acntID := range ap.AccountIDs {
+					if err = ldr.dm.RemAccountActionPlans(acntID, []string{id}); err != nil {
+						return
+					}
+					cacheArgs[utils.CacheAccountActionPlans] = append(cacheArgs[utils.CacheAccountActionPlans], acntID)
+				}
+			}
+			return
+		}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.ActionPlanPrefix)
+		cacheArgs[utils.CacheActionPlans] = ids
+	case utils.MetaAccountActions:
+		err = guardian.Guardian.Guard(func() (err error) {
+			for _, acntID := range ids {
+				// remove the account out of its action plans before removing it
+				var apIDs []string
+				if apIDs, err = ldr.dm.GetAccountActionPlans(acntID, false, false,
+					utils.NonTransactional); err != nil && err != utils.ErrNotFound {
+					return
+				}
+				for _, apID := range apIDs {
+					var ap *engine.ActionPlan
+					if ap, err = ldr.dm.GetActionPlan(apID, false, false,
+						utils.NonTransactional); err != nil {
+						if err == utils.ErrNotFound {
+							continue
+						}
+						return
+					}
+					delete(ap.AccountIDs, acntID)
+					if err = ldr.dm.SetActionPlan(apID, ap, true, utils.NonTransactional); err != nil {
+						return
+					}
+					cacheArgs[utils.CacheActionPlans] = append(cacheArgs[utils.CacheActionPlans], apID)
+				}
+				if len(apIDs) != 0 {
+					if err = ldr.dm.RemAccountActionPlans(acntID, nil); err != nil {
+						return
+					}
+					cacheArgs[utils.CacheAccountActionPlans] = append(cacheArgs[utils.CacheAccountActionPlans], acntID)
+				}
+				if err = ldr.dm.RemoveAccount(acntID); err != nil {
+					return
+				}
+			}
+			return
+		}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.ActionPlanPrefix)
+	}
+	if err != nil {
+		return
+	}
+	err = ldr.setLoadIDs(cacheArgs)
+	return
+}
+
+// removeRatingTPs removes the rates or the destination rates, rebuilding the
+// rating plans using them without them: the destination rates drop the rows
+// of the removed rates and the rating plans the bindings of the removed
+// destination rates, the ones left empty being removed as well
+func (ldr *Loader) removeRatingTPs(loaderType string,
+	ids []string) (cacheArgs map[string][]string, err error) {
+	rmDrIDs := make(utils.StringSet) // removed destination rates
+	chDrIDs := make(utils.StringSet) // changed destination rates
+	if loaderType == utils.MetaDestinationRates {
+		rmDrIDs.AddSlice(ids)
+	} else {
+		for _, id := range ids {
+			if err = ldr.remRatingTP(utils.TBLTPRates, id); err != nil {
+				return
+			}
+			delete(ldr.tpRates, id)
+		}
+		rmRtIDs := utils.NewStringSet(ids)
+		for drID, tpDr := range ldr.tpDstRates {
+			drs := slices.DeleteFunc(slices.Clone(tpDr.DestinationRates),
+				func(dr *utils.DestinationRate) bool { return rmRtIDs.Has(dr.RateId) })
+			switch len(drs) {
+			case len(tpDr.DestinationRates):
+				continue
+			case 0:
+				rmDrIDs.Add(drID)
+				continue
+			}
+			tpDr = &utils.TPDestinationRate{TPid: tpDr.TPid, ID: tpDr.ID, DestinationRates: drs}
+			if ldr.storDB != nil {
+				if err = ldr.storDB.SetTPDestinationRates([]*utils.TPDestinationRate{tpDr}); err != nil {
+					return
+				}
+			}
+			ldr.tpDstRates[drID] = tpDr
+			chDrIDs.Add(drID)
+		}
+	}
+	for drID := range rmDrIDs {
+		if err = ldr.remRatingTP(utils.TBLTPDestinationRates, drID); err != nil {
+			return
+		}
+		delete(ldr.tpDstRates, drID)
+	}
+	var tpRpls []*utils.TPRatingPlan
+	var rmRplIDs []string
+	for rplID, tpRpl := range ldr.tpRatingPlans {
+		rpbs := slices.DeleteFunc(slices.Clone(tpRpl.RatingPlanBindings),
+			func(rpb *utils.TPRatingPlanBinding) bool { return rmDrIDs.Has(rpb.DestinationRatesId) })
+		switch {
+		case len(rpbs) == 0:
+			rmRplIDs = append(rmRplIDs, rplID)
+		case len(rpbs) != len(tpRpl.RatingPlanBindings):
+			tpRpls = append(tpRpls, &utils.TPRatingPlan{TPid: tpRpl.TPid, ID: tpRpl.ID,
+				RatingPlanBindings: rpbs})
+		case slices.ContainsFunc(rpbs, func(rpb *utils.TPRatingPlanBinding) bool {
+			return chDrIDs.Has(rpb.DestinationRatesId)
+		}):
+			tpRpls = append(tpRpls, tpRpl)
+		}
+	}
+	for _, rplID := range rmRplIDs {
+		if err = ldr.removeRatingPlan(rplID); err != nil {
+			return
+		}
+	}
+	if cacheArgs, err = ldr.setRatingPlans(tpRpls); err != nil {
+		return
+	}
+	if len(rmRplIDs) != 0 {
+		cacheArgs[utils.CacheRatingPlans] = append(cacheArgs[utils.CacheRatingPlans], rmRplIDs...)
+	}
+	return
+}
+
+// removeRatingPlan removes the rating plan out of DataDB together with its TP
+func (ldr *Loader) removeRatingPlan(id string) (err error) {
+	if err = ldr.dm.RemoveRatingPlan(id, utils.NonTransactional); err != nil {
+		return
+	}
+	if err = ldr.remRatingTP(utils.TBLTPRatingPlans, id); err != nil {
+		return
+	}
+	delete(ldr.tpRatingPlans, id)
+	return
+}
+
+// remRatingTP removes the rating TP with the given ID out of StorDB
+func (ldr *Loader) remRatingTP(table, id string) (err error) {
+	if ldr.storDB == nil {
+		return
+	}
+	return ldr.storDB.RemTpData(table, ldr.ldrID, map[string]string{utils.TagCfg: id})
+}
@@ -0,0 +1,373 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"encoding/csv"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newRatingTestLoader(ldrID string, storDB engine.StorDB) *Loader {
+	ldr := NewLoader(engine.NewDataManager(
+		engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items),
+		config.CgrConfig().CacheCfg(), nil), storDB,
+		&config.LoaderSCfg{ID: ldrID, Tenant: "cgrates.org"}, "UTC", 0, nil, nil, nil)
+	tpls := map[string][]string{
+		utils.MetaDestinations:     {"Tag", "Prefix"},
+		utils.MetaRates:            {"Tag", "ConnectFee", "Rate", "RateUnit", "RateIncrement", "GroupIntervalStart"},
+		utils.MetaDestinationRates: {"Tag", "DestinationsTag", "RatesTag", "RoundingMethod", "RoundingDecimals", "MaxCost", "MaxCostStrategy"},
+		utils.MetaRatingPlans:      {"Tag", "DestratesTag", "TimingTag", "Weight"},
+		utils.MetaRatingProfiles:   {"Tenant", "Category", "Subject", "ActivationTime", "RatingPlanTag", "FallbackSubjects"},
+		utils.MetaActions:          {"Tag", "Action", "ExtraParameters", "Filters", "BalanceTag", "BalanceType", "Categories", "DestinationTags", "RatingSubject", "SharedGroups", "ExpiryTime", "TimingTags", "Units", "BalanceWeight", "BalanceBlocker", "BalanceDisabled", "Weight"},
+		utils.MetaActionPlans:      {"Tag", "ActionsTag", "TimingTag", "Weight"},
+		utils.MetaAccountActions:   {"Tenant", "Account", "ActionPlanTag", "ActionTriggersTag", "AllowNegative", "Disabled"},
+	}
+	for ldrType, flds := range tpls {
+		for i, fld := range flds {
+			ldr.dataTpls[ldrType] = append(ldr.dataTpls[ldrType], &config.FCTemplate{
+				Path:  fld,
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req."+strconv.Itoa(i), utils.InfieldSep),
+			})
+		}
+	}
+	return ldr
+}
+
+func processRatingContent(t *testing.T, ldr *Loader, ldrType, content string, remove bool) {
+	t.Helper()
+	rdr := io.NopCloser(strings.NewReader(content))
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		ldrType: {
+			"Rating.csv": &openedCSVFile{fileName: "Rating.csv",
+				rdr: rdr, csvRdr: csvRdr}},
+	}
+	process := ldr.processContent
+	if remove {
+		process = ldr.removeContent
+	}
+	if err := process(ldrType, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoaderProcessRatingPlans(t *testing.T) {
+	ldr := newRatingTestLoader("TestLoaderProcessRatingPlans", nil)
+	// the rating plan is built once all of its parts are loaded
+	processRatingContent(t, ldr, utils.MetaRates, `
+#Tag,ConnectFee,Rate,RateUnit,RateIncrement,GroupIntervalStart
+RT_1CNT,0,0.01,60s,1s,0s
+`, false)
+	processRatingContent(t, ldr, utils.MetaDestinations, `
+#Tag,Prefix
+DST_1002,1002
+DST_1002,+491002
+`, false)
+	processRatingContent(t, ldr, utils.MetaDestinationRates, `
+#Tag,DestinationsTag,RatesTag,RoundingMethod,RoundingDecimals,MaxCost,MaxCostStrategy
+DR_1002,DST_1002,RT_1CNT,*up,4,0,
+`, false)
+	processRatingContent(t, ldr, utils.MetaRatingPlans, `
+#Tag,DestratesTag,TimingTag,Weight
+RP_1002,DR_1002,*any,10
+`, false)
+	processRatingContent(t, ldr, utils.MetaRatingProfiles, `
+#Tenant,Category,Subject,ActivationTime,RatingPlanTag,FallbackSubjects
+cgrates.org,call,1001,2014-01-14T00:00:00Z,RP_1002,
+`, false)
+
+	if rcv, err := ldr.dm.GetReverseDestination("+491002", true, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{"DST_1002"}, rcv) {
+		t.Errorf("expected %+v, received %+v", []string{"DST_1002"}, rcv)
+	}
+	rateValue := func() (val float64) {
+		rp, err := ldr.dm.GetRatingPlan("RP_1002", true, utils.NonTransactional)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, has := rp.DestinationRates["DST_1002"]; !has {
+			t.Errorf("expected rates for DST_1002, received %s", utils.ToJSON(rp))
+		}
+		for _, rating := range rp.Ratings {
+			val = rating.Rates[0].Value
+		}
+		return
+	}
+	if val := rateValue(); val != 0.01 {
+		t.Errorf("expected rate 0.01, received %v", val)
+	}
+	if rpf, err := ldr.dm.GetRatingProfile("*out:cgrates.org:call:1001", true,
+		utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if len(rpf.RatingPlanActivations) != 1 ||
+		rpf.RatingPlanActivations[0].RatingPlanId != "RP_1002" {
+		t.Errorf("unexpected rating profile %s", utils.ToJSON(rpf))
+	}
+
+	// changing the rate rebuilds the rating plan using it
+	processRatingContent(t, ldr, utils.MetaRates, `
+RT_1CNT,0,0.02,60s,1s,0s
+`, false)
+	if val := rateValue(); val != 0.02 {
+		t.Errorf("expected rate 0.02, received %v", val)
+	}
+
+	processRatingContent(t, ldr, utils.MetaRatingProfiles, `
+cgrates.org,call,1001
+`, true)
+	if _, err := ldr.dm.GetRatingProfile("*out:cgrates.org:call:1001", true,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	processRatingContent(t, ldr, utils.MetaRatingPlans, `
+RP_1002
+`, true)
+	if _, err := ldr.dm.GetRatingPlan("RP_1002", true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if len(ldr.tpRatingPlans) != 0 {
+		t.Errorf("expected no rating plans kept, received %s", utils.ToJSON(ldr.tpRatingPlans))
+	}
+	processRatingContent(t, ldr, utils.MetaDestinations, `
+DST_1002
+`, true)
+	if _, err := ldr.dm.GetReverseDestination("+491002", true, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestLoaderProcessRatingPlansAfterRestart(t *testing.T) {
+	ldr := newRatingTestLoader("TestLoaderProcessRatingPlansAfterRestart", nil)
+	processRatingContent(t, ldr, utils.MetaDestinations, `
+DST_1002,1002
+`, false)
+	processRatingContent(t, ldr, utils.MetaRates, `
+RT_1CNT,0,0.01,60s,1s,0s
+`, false)
+	processRatingContent(t, ldr, utils.MetaDestinationRates, `
+DR_1002,DST_1002,RT_1CNT,*up,4,0,
+`, false)
+	processRatingContent(t, ldr, utils.MetaRatingPlans, `
+RP_1002,DR_1002,*any,10
+`, false)
+
+	// without StorDB a new loader on the same DataDB does not know the rates loaded before
+	rldr := newRatingTestLoader("TestLoaderProcessRatingPlansAfterRestart", nil)
+	rldr.dm = ldr.dm
+	loadRatingPlan := func() error {
+		rdr := io.NopCloser(strings.NewReader("RP_1002,DR_1002,*any,20\n"))
+		rldr.rdrs = map[string]map[string]*openedCSVFile{
+			utils.MetaRatingPlans: {
+				"RatingPlans.csv": &openedCSVFile{fileName: "RatingPlans.csv",
+					rdr: rdr, csvRdr: csv.NewReader(rdr)}},
+		}
+		return rldr.processContent(utils.MetaRatingPlans, utils.EmptyString)
+	}
+	expErr := "NOT_FOUND:destination rate <DR_1002> of rating plan <RP_1002> not loaded"
+	if err := loadRatingPlan(); err == nil || err.Error() != expErr {
+		t.Errorf("expected error %q, received %v", expErr, err)
+	}
+	processRatingContent(t, rldr, utils.MetaDestinationRates, `
+DR_1002,DST_1002,RT_1CNT,*up,4,0,
+`, false)
+	expErr = "NOT_FOUND:rate <RT_1CNT> of destination rate <DR_1002> not loaded"
+	if err := loadRatingPlan(); err == nil || err.Error() != expErr {
+		t.Errorf("expected error %q, received %v", expErr, err)
+	}
+	processRatingContent(t, rldr, utils.MetaRates, `
+RT_1CNT,0,0.02,60s,1s,0s
+`, false)
+	if err := loadRatingPlan(); err != nil {
+		t.Fatal(err)
+	}
+	if rp, err := rldr.dm.GetRatingPlan("RP_1002", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if len(rp.DestinationRates["DST_1002"]) != 1 || rp.DestinationRates["DST_1002"][0].Weight != 20 {
+		t.Errorf("unexpected rating plan %s", utils.ToJSON(rp))
+	}
+}
+
+func TestLoaderProcessRatingPlansStorDB(t *testing.T) {
+	storDB := engine.NewInternalDB(nil, nil, false, config.CgrConfig().StorDbCfg().Items)
+	ldr := newRatingTestLoader("TestLoaderProcessRatingPlansStorDB", storDB)
+	processRatingContent(t, ldr, utils.MetaDestinations, `
+DST_1002,1002
+DST_1003,1003
+`, false)
+	processRatingContent(t, ldr, utils.MetaRates, `
+RT_1CNT,0,0.01,60s,1s,0s
+RT_2CNT,0,0.02,60s,1s,0s
+`, false)
+	processRatingContent(t, ldr, utils.MetaDestinationRates, `
+DR_1002,DST_1002,RT_1CNT,*up,4,0,
+DR_1002,DST_1003,RT_2CNT,*up,4,0,
+DR_1003,DST_1003,RT_2CNT,*up,4,0,
+`, false)
+	processRatingContent(t, ldr, utils.MetaRatingPlans, `
+RP_1002,DR_1002,*any,10
+RP_1003,DR_1003,*any,10
+`, false)
+
+	// a new loader on the same DataDB and StorDB rebuilds the rating plans
+	// loaded before when their rates change
+	rldr := newRatingTestLoader("TestLoaderProcessRatingPlansStorDB", storDB)
+	rldr.dm = ldr.dm
+	processRatingContent(t, rldr, utils.MetaRates, `
+RT_1CNT,0,0.03,60s,1s,0s
+`, false)
+	rp, err := rldr.dm.GetRatingPlan("RP_1002", true, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rateVals []float64
+	for _, rating := range rp.Ratings {
+		rateVals = append(rateVals, rating.Rates[0].Value)
+	}
+	sort.Float64s(rateVals)
+	if exp := []float64{0.02, 0.03}; !reflect.DeepEqual(exp, rateVals) {
+		t.Errorf("expected rates %v, received %v", exp, rateVals)
+	}
+
+	// removing a rate drops it out of the destination rates, rebuilding their
+	// rating plans, the ones left empty being removed
+	processRatingContent(t, rldr, utils.MetaRates, `
+RT_2CNT
+`, true)
+	if rp, err = rldr.dm.GetRatingPlan("RP_1002", true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if _, has := rp.DestinationRates["DST_1003"]; has || len(rp.DestinationRates) != 1 {
+		t.Errorf("expected rates for DST_1002 only, received %s", utils.ToJSON(rp))
+	}
+	if _, err = rldr.dm.GetRatingPlan("RP_1003", true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if tpDrs, err := storDB.GetTPDestinationRates(rldr.ldrID, utils.EmptyString, nil); err != nil {
+		t.Error(err)
+	} else if len(tpDrs) != 1 || len(tpDrs[0].DestinationRates) != 1 ||
+		tpDrs[0].DestinationRates[0].RateId != "RT_1CNT" {
+		t.Errorf("unexpected destination rates %s", utils.ToJSON(tpDrs))
+	}
+	if tpRpls, err := storDB.GetTPRatingPlans(rldr.ldrID, utils.EmptyString, nil); err != nil {
+		t.Error(err)
+	} else if len(tpRpls) != 1 || tpRpls[0].ID != "RP_1002" {
+		t.Errorf("unexpected rating plans %s", utils.ToJSON(tpRpls))
+	}
+
+	// removing a destination rate removes the rating plans left without bindings
+	processRatingContent(t, rldr, utils.MetaDestinationRates, `
+DR_1002
+`, true)
+	if _, err = rldr.dm.GetRatingPlan("RP_1002", true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := storDB.GetTPRatingPlans(rldr.ldrID, utils.EmptyString, nil); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if tpRts, err := storDB.GetTPRates(rldr.ldrID, utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if len(tpRts) != 1 || tpRts[0].ID != "RT_1CNT" {
+		t.Errorf("unexpected rates %s", utils.ToJSON(tpRts))
+	}
+}
+
+func TestLoaderProcessAccountActions(t *testing.T) {
+	ldr := newRatingTestLoader("TestLoaderProcessAccountActions", nil)
+	processRatingContent(t, ldr, utils.MetaActions, `
+#Tag,Action,ExtraParameters,Filters,BalanceTag,BalanceType,Categories,DestinationTags,RatingSubject,SharedGroups,ExpiryTime,TimingTags,Units,BalanceWeight,BalanceBlocker,BalanceDisabled,Weight
+ACT_TOPUP,*topup_reset,,,MONETARY,*monetary,,*any,,,*unlimited,,10,10,false,false,10
+`, false)
+	processRatingContent(t, ldr, utils.MetaActionPlans, `
+#Tag,ActionsTag,TimingTag,Weight
+AP_MONTHLY,ACT_TOPUP,*monthly,10
+`, false)
+	processRatingContent(t, ldr, utils.MetaAccountActions, `
+#Tenant,Account,ActionPlanTag,ActionTriggersTag,AllowNegative,Disabled
+cgrates.org,1001,AP_MONTHLY,,false,false
+cgrates.org,1002,AP_MONTHLY,,false,false
+`, false)
+
+	if acts, err := ldr.dm.GetActions("ACT_TOPUP", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if len(acts) != 1 || acts[0].ActionType != utils.MetaTopUpReset {
+		t.Errorf("unexpected actions %s", utils.ToJSON(acts))
+	}
+	if _, err := ldr.dm.GetAccount("cgrates.org:1001"); err != nil {
+		t.Error(err)
+	}
+	expAcnts := []string{"cgrates.org:1001", "cgrates.org:1002"}
+	ap, err := ldr.dm.GetActionPlan("AP_MONTHLY", false, false, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcvAcnts := ap.AccountIDs.Slice()
+	sort.Strings(rcvAcnts)
+	if !reflect.DeepEqual(expAcnts, rcvAcnts) {
+		t.Errorf("expected %+v, received %+v", expAcnts, rcvAcnts)
+	}
+	if rcv, err := ldr.dm.GetAccountActionPlans("cgrates.org:1001", false, false,
+		utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{"AP_MONTHLY"}, rcv) {
+		t.Errorf("expected %+v, received %+v", []string{"AP_MONTHLY"}, rcv)
+	}
+
+	// removing the account takes it out of its action plans
+	processRatingContent(t, ldr, utils.MetaAccountActions, `
+cgrates.org,1001
+`, true)
+	if _, err := ldr.dm.GetAccount("cgrates.org:1001"); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if ap, err := ldr.dm.GetActionPlan("AP_MONTHLY", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcv := ap.AccountIDs.Slice(); !reflect.DeepEqual([]string{"cgrates.org:1002"}, rcv) {
+		t.Errorf("expected %+v, received %+v", []string{"cgrates.org:1002"}, rcv)
+	}
+	if _, err := ldr.dm.GetAccountActionPlans("cgrates.org:1001", false, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestLoaderLoaderTypes(t *testing.T) {
+	ldr := &Loader{rdrs: map[string]map[string]*openedCSVFile{
+		utils.MetaAttributes:     nil,
+		utils.MetaAccountActions: nil,
+		utils.MetaRatingPlans:    nil,
+		utils.MetaDestinations:   nil,
+	}}
+	exp := []string{utils.MetaDestinations, utils.MetaRatingPlans,
+		utils.MetaAccountActions, utils.MetaAttributes}
+	if rcv := ldr.loaderTypes(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected %+v, received %+v", exp, rcv)
+	}
+}
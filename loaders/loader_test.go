@@ -68,11 +68,11 @@ cgrates.org,FLTR_DST_DE,*destinations,~*req.Destination,DST_DE,2014-07-29T15:00:
 cgrates.org,FLTR_DST_NL,*destinations,~*req.Destination,DST_NL,2014-07-29T15:00:00Z
 `
 	RoutesCSVContent = `
-#Tenant[0],ID[1],FilterIDs[2],ActivationInterval[3],Sorting[4],SortingParameters[5],RouteID[6],RouteFilterIDs[7],RouteAccountIDs[8],RouteRatingPlanIDs[9],RouteResourceIDs[10],RouteStatIDs[11],RouteWeight[12],RouteBlocker[13],RouteParameters[14],Weight[15]
-cgrates.org,RoutePrf1,*string:~*req.Account:dan,2014-07-29T15:00:00Z,*lc,,route1,FLTR_ACNT_dan,Account1;Account1_1,RPL_1,ResGroup1,Stat1,10,true,param1,20
-cgrates.org,RoutePrf1,,,,,route1,,,RPL_2,ResGroup2,,10,,,
-cgrates.org,RoutePrf1,,,,,route1,FLTR_DST_DE,Account2,RPL_3,ResGroup3,Stat2,10,,,
-cgrates.org,RoutePrf1,,,,,route1,,,,ResGroup4,Stat3,10,,,
+#Tenant[0],ID[1],FilterIDs[2],ActivationInterval[3],Sorting[4],SortingParameters[5],RouteID[6],RouteFilterIDs[7],RouteAccountIDs[8],RouteRatingPlanIDs[9],RouteResourceIDs[10],RouteStatIDs[11],RouteWeight[12],RouteBlocker[13],RouteParameters[14],Weight[15],RouteRankingIDs[16],RouteTrendIDs[17]
+cgrates.org,RoutePrf1,*string:~*req.Account:dan,2014-07-29T15:00:00Z,*lc,,route1,FLTR_ACNT_dan,Account1;Account1_1,RPL_1,ResGroup1,Stat1,10,true,param1,20,Rank1,Trend1
+cgrates.org,RoutePrf1,,,,,route1,,,RPL_2,ResGroup2,,10,,,,,
+cgrates.org,RoutePrf1,,,,,route1,FLTR_DST_DE,Account2,RPL_3,ResGroup3,Stat2,10,,,,Rank2,Trend2
+cgrates.org,RoutePrf1,,,,,route1,,,,ResGroup4,Stat3,10,,,,,
 `
 	AttributesCSVContent = `
 #Tenant,ID,Contexts,FilterIDs,ActivationInterval,AttributeFilterIDs,Path,Type,Value,Blocker,Weight
@@ -859,6 +859,12 @@ func TestLoaderProcessRoutes(t *testing.T) {
 			{Path: "Weight",
 				Type:  utils.MetaComposed,
 				Value: config.NewRSRParsersMustCompile("~*req.15", utils.InfieldSep)},
+			{Path: "RouteRankingIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.16", utils.InfieldSep)},
+			{Path: "RouteTrendIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.17", utils.InfieldSep)},
 		},
 	}
 	rdr := io.NopCloser(strings.NewReader(RoutesCSVContent))
@@ -893,6 +899,8 @@ func TestLoaderProcessRoutes(t *testing.T) {
 				RatingPlanIDs:   []string{"RPL_1"},
 				ResourceIDs:     []string{"ResGroup1"},
 				StatIDs:         []string{"Stat1"},
+				RankingIDs:      []string{"Rank1"},
+				TrendIDs:        []string{"Trend1"},
 				Weight:          10,
 				Blocker:         true,
 				RouteParameters: "param1",
@@ -904,6 +912,8 @@ func TestLoaderProcessRoutes(t *testing.T) {
 				RatingPlanIDs:   []string{"RPL_3"},
 				ResourceIDs:     []string{"ResGroup3"},
 				StatIDs:         []string{"Stat2"},
+				RankingIDs:      []string{"Rank2"},
+				TrendIDs:        []string{"Trend2"},
 				Weight:          10,
 				Blocker:         false,
 				RouteParameters: utils.EmptyString,
@@ -1490,7 +1500,7 @@ func TestNewLoaderWithMultiFiles(t *testing.T) {
 			Type:  utils.MetaString,
 			Value: config.NewRSRParsersMustCompile("10", utils.InfieldSep)},
 	}
-	ldr := NewLoader(engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil), ldrCfg, "", 0, nil, nil, nil)
+	ldr := NewLoader(engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil), nil, ldrCfg, "", 0, nil, nil, nil)
 
 	openRdrs := make(utils.StringSet)
 	for _, rdr := range ldr.rdrs {
@@ -3142,7 +3152,7 @@ func TestStoreLoadedDataAttributes(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"Attributes": {
 			{
@@ -3191,7 +3201,7 @@ func TestStoreLoadedDataResources(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"Resources": {
 			{
@@ -3239,7 +3249,7 @@ func TestStoreLoadedDataFilters(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"Filters": {
 			{
@@ -3288,7 +3298,7 @@ func TestStoreLoadedDataStats(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"StatsQueue": {
 			{
@@ -3337,7 +3347,7 @@ func TestStoreLoadedDataThresholds(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"Thresholds": {
 			{
@@ -3385,7 +3395,7 @@ func TestStoreLoadedDataRoutes(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"Routes": {
 			{
@@ -3433,7 +3443,7 @@ func TestStoreLoadedDataChargers(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"Chargers": {
 			{
@@ -3481,7 +3491,7 @@ func TestStoreLoadedDataDispatchers(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"Dispatchers": {
 			{
@@ -3529,7 +3539,7 @@ func TestStoreLoadedDataDispatcherHosts(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", 0, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"DispatcherHosts": {
 			{
@@ -3576,7 +3586,7 @@ func TestStoreLoadedDataWithDelay(t *testing.T) {
 	cacheConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
 	loaderCfg := config.CgrConfig().LoaderCfg()
 	fltrS := engine.NewFilterS(cfg, connMgr, dm)
-	ldr := NewLoader(dm, loaderCfg[0], "", cfg.GeneralCfg().CachingDelay, fltrS, connMgr, cacheConns)
+	ldr := NewLoader(dm, nil, loaderCfg[0], "", cfg.GeneralCfg().CachingDelay, fltrS, connMgr, cacheConns)
 	lds := map[string][]LoaderData{
 		"DispatcherHosts": {
 			{
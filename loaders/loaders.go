@@ -30,13 +30,19 @@ import (
 	"github.com/cgrates/cgrates/utils"
 )
 
-func NewLoaderService(dm *engine.DataManager, ldrsCfg []*config.LoaderSCfg,
+func NewLoaderService(dm *engine.DataManager, storDBChan chan engine.StorDB, ldrsCfg []*config.LoaderSCfg,
 	timezone string, cachingDlay time.Duration, filterS *engine.FilterS,
 	connMgr *engine.ConnManager) (ldrS *LoaderService) {
-	ldrS = &LoaderService{ldrs: make(map[string]*Loader)}
+	ldrS = &LoaderService{
+		ldrs:       make(map[string]*Loader),
+		storDBChan: storDBChan,
+	}
+	if storDBChan != nil {
+		ldrS.storDB = <-storDBChan
+	}
 	for _, ldrCfg := range ldrsCfg {
 		if ldrCfg.Enabled {
-			ldrS.ldrs[ldrCfg.ID] = NewLoader(dm, ldrCfg, timezone, cachingDlay, filterS, connMgr, ldrCfg.CacheSConns)
+			ldrS.ldrs[ldrCfg.ID] = NewLoader(dm, ldrS.storDB, ldrCfg, timezone, cachingDlay, filterS, connMgr, ldrCfg.CacheSConns)
 		}
 	}
 	return
@@ -45,7 +51,9 @@ func NewLoaderService(dm *engine.DataManager, ldrsCfg []*config.LoaderSCfg,
 // LoaderService is the Loader service handling independent Loaders
 type LoaderService struct {
 	sync.RWMutex
-	ldrs map[string]*Loader
+	ldrs       map[string]*Loader
+	storDB     engine.StorDB      // keeps the rating TPs of the loaders, nil if not needed
+	storDBChan chan engine.StorDB // receives the StorDB on its reloads
 }
 
 // Enabled returns true if at least one loader is enabled
@@ -60,9 +68,32 @@ func (ldrS *LoaderService) ListenAndServe(stopChan chan struct{}) (err error) {
 			return
 		}
 	}
+	if ldrS.storDBChan != nil {
+		go ldrS.listenStorDB(stopChan)
+	}
 	return
 }
 
+// listenStorDB passes the StorDB to the loaders on its reloads
+func (ldrS *LoaderService) listenStorDB(stopChan chan struct{}) {
+	for {
+		select {
+		case <-stopChan:
+			return
+		case storDB, ok := <-ldrS.storDBChan:
+			if !ok { // the chanel was closed by the shutdown of stordbService
+				return
+			}
+			ldrS.Lock()
+			ldrS.storDB = storDB
+			for _, ldr := range ldrS.ldrs {
+				ldr.setStorDB(storDB)
+			}
+			ldrS.Unlock()
+		}
+	}
+}
+
 type ArgsProcessFolder struct {
 	LoaderID    string
 	ForceLock   bool
@@ -136,6 +167,47 @@ func (ldrS *LoaderService) V1Remove(ctx *context.Context, args *ArgsProcessFolde
 	return
 }
 
+// ArgsDryRun are the arguments of LoaderSv1.DryRun
+type ArgsDryRun struct {
+	ArgsProcessFolder
+	LoadOption string // <*store|*remove>, defaults to *store
+}
+
+// V1DryRun parses the files within the loader folder, reporting the changes
+// they would bring to DataDB without writing them or moving the files
+func (ldrS *LoaderService) V1DryRun(ctx *context.Context, args *ArgsDryRun,
+	rply *engine.LoadReport) (err error) {
+	ldrS.RLock()
+	defer ldrS.RUnlock()
+	if args.LoaderID == "" {
+		args.LoaderID = utils.MetaDefault
+	}
+	ldr, has := ldrS.ldrs[args.LoaderID]
+	if !has {
+		return fmt.Errorf("UNKNOWN_LOADER: %s", args.LoaderID)
+	}
+	loadOption := utils.FirstNonEmpty(args.LoadOption, utils.MetaStore)
+	if loadOption != utils.MetaStore && loadOption != utils.MetaRemove {
+		return fmt.Errorf("UNSUPPORTED_LOAD_OPTION: %s", loadOption)
+	}
+	if locked, err := ldr.isFolderLocked(); err != nil {
+		return utils.NewErrServerError(err)
+	} else if locked {
+		if !args.ForceLock {
+			return errors.New("ANOTHER_LOADER_RUNNING")
+		}
+		if err := ldr.unlockFolder(); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
+	report, err := ldr.DryRunFolder(loadOption, args.StopOnError)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*rply = *report
+	return
+}
+
 // Reload recreates the loaders map thread safe
 func (ldrS *LoaderService) Reload(dm *engine.DataManager, ldrsCfg []*config.LoaderSCfg,
 	timezone string, cachingDlay time.Duration, filterS *engine.FilterS, connMgr *engine.ConnManager) {
@@ -143,7 +215,7 @@ func (ldrS *LoaderService) Reload(dm *engine.DataManager, ldrsCfg []*config.Load
 	ldrS.ldrs = make(map[string]*Loader)
 	for _, ldrCfg := range ldrsCfg {
 		if ldrCfg.Enabled {
-			ldrS.ldrs[ldrCfg.ID] = NewLoader(dm, ldrCfg, timezone, cachingDlay, filterS, connMgr, ldrCfg.CacheSConns)
+			ldrS.ldrs[ldrCfg.ID] = NewLoader(dm, ldrS.storDB, ldrCfg, timezone, cachingDlay, filterS, connMgr, ldrCfg.CacheSConns)
 		}
 	}
 	ldrS.Unlock()
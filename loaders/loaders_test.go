@@ -154,7 +154,7 @@ func TestNewLoaderService(t *testing.T) {
 		{ID: "loader3", Enabled: true},
 	}
 
-	ldrService := NewLoaderService(dm, ldrsCfg, timezone, cachingDlay, filterS, connMgr)
+	ldrService := NewLoaderService(dm, nil, ldrsCfg, timezone, cachingDlay, filterS, connMgr)
 
 	if len(ldrService.ldrs) != 2 {
 		t.Errorf("expected 2 loaders, got %d", len(ldrService.ldrs))
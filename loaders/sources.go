@@ -0,0 +1,527 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// recordReader returns the records of a loader file, one at a time
+type recordReader interface {
+	Read() (record []string, err error)
+}
+
+// recordsSlice is a recordReader over records already decoded
+type recordsSlice struct {
+	records [][]string
+}
+
+func (rs *recordsSlice) Read() (record []string, err error) {
+	if len(rs.records) == 0 {
+		return nil, io.EOF
+	}
+	record, rs.records = rs.records[0], rs.records[1:]
+	return
+}
+
+// newJSONRecords decodes a JSON array of rows, each row being an array of values
+func newJSONRecords(rdr io.Reader) (rs *recordsSlice, err error) {
+	dec := json.NewDecoder(rdr)
+	dec.UseNumber()
+	var rows [][]any
+	if err = dec.Decode(&rows); err != nil {
+		return
+	}
+	rs = &recordsSlice{records: make([][]string, len(rows))}
+	for i, row := range rows {
+		rs.records[i] = make([]string, len(row))
+		for j, val := range row {
+			rs.records[i][j] = utils.IfaceAsString(val)
+		}
+	}
+	return
+}
+
+// openFile opens the loader file out of tpInDir, decoding it based on its extension.
+// Missing files are searched as sheets within the workbooks in tpInDir
+func (ldr *Loader) openFile(fName string) (oFile *openedCSVFile, err error) {
+	var rdr *os.File
+	if rdr, err = os.Open(path.Join(ldr.tpInDir, fName)); err != nil {
+		if !os.IsNotExist(err) {
+			return
+		}
+		var records [][]string
+		var has bool
+		if records, has, err = ldr.sheetRecords(fName); err != nil {
+			return
+		}
+		if !has {
+			return nil, fmt.Errorf("open %s: %w", path.Join(ldr.tpInDir, fName), os.ErrNotExist)
+		}
+		return &openedCSVFile{fileName: fName, rdr: io.NopCloser(nil),
+			csvRdr: &recordsSlice{records: records}}, nil
+	}
+	if strings.ToLower(path.Ext(fName)) == utils.JSNSuffix {
+		var rs *recordsSlice
+		if rs, err = newJSONRecords(rdr); err != nil {
+			rdr.Close()
+			return nil, fmt.Errorf("cannot decode file <%s>, err: %s", fName, err.Error())
+		}
+		return &openedCSVFile{fileName: fName, rdr: rdr, csvRdr: rs}, nil
+	}
+	csvReader := csv.NewReader(rdr)
+	csvReader.Comma = rune(ldr.fieldSep[0])
+	csvReader.Comment = '#'
+	return &openedCSVFile{fileName: fName, rdr: rdr, csvRdr: csvReader}, nil
+}
+
+// sheetRecords returns the records of the first sheet named after the file, found within the workbooks in tpInDir
+func (ldr *Loader) sheetRecords(fName string) (records [][]string, has bool, err error) {
+	filesInDir, _ := os.ReadDir(ldr.tpInDir)
+	for _, file := range filesInDir {
+		if file.IsDir() || strings.ToLower(path.Ext(file.Name())) != utils.XLSXSuffix {
+			continue
+		}
+		var sheets map[string][][]string
+		if sheets, err = readXLSX(path.Join(ldr.tpInDir, file.Name())); err != nil {
+			return
+		}
+		if records, has = sheetForFile(sheets, fName); has {
+			return
+		}
+	}
+	return
+}
+
+// sheetForFile matches the sheet on the file name, with or without its extension
+func sheetForFile(sheets map[string][][]string, fName string) (records [][]string, has bool) {
+	if records, has = sheets[fName]; has {
+		return
+	}
+	records, has = sheets[strings.TrimSuffix(fName, path.Ext(fName))]
+	return
+}
+
+// processWorkbook loads the sheets of a workbook dropped into tpInDir, one loader type at a time
+func (ldr *Loader) processWorkbook(fName string) (err error) {
+	if err = ldr.lockFolder(); err != nil {
+		return
+	}
+	defer ldr.unlockFolder()
+	var sheets map[string][][]string
+	if sheets, err = readXLSX(path.Join(ldr.tpInDir, fName)); err != nil {
+		return
+	}
+	for _, ldrType := range ldr.loaderTypes() {
+		var found bool
+		for rdrName := range ldr.rdrs[ldrType] {
+			records, has := sheetForFile(sheets, rdrName)
+			if !has {
+				continue
+			}
+			if ldr.rdrs[ldrType][rdrName] != nil {
+				ldr.unreferenceFile(ldrType, rdrName)
+			}
+			ldr.rdrs[ldrType][rdrName] = &openedCSVFile{fileName: rdrName,
+				rdr: io.NopCloser(nil), csvRdr: &recordsSlice{records: records}}
+			found = true
+		}
+		if !found || !ldr.allFilesPresent(ldrType) {
+			continue
+		}
+		err = ldr.processContent(ldrType, config.CgrConfig().GeneralCfg().DefaultCaching)
+		for rdrName := range ldr.rdrs[ldrType] {
+			ldr.unreferenceFile(ldrType, rdrName)
+		}
+		if err != nil {
+			return
+		}
+	}
+	if ldr.tpOutDir == utils.EmptyString {
+		return
+	}
+	return os.Rename(path.Join(ldr.tpInDir, fName), path.Join(ldr.tpOutDir, fName))
+}
+
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (rt xlsxRichText) String() string {
+	if len(rt.Runs) == 0 {
+		return rt.Text
+	}
+	var sb strings.Builder
+	for _, run := range rt.Runs {
+		sb.WriteString(run.Text)
+	}
+	return sb.String()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationship struct {
+	ID     string `xml:"Id,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+type xlsxRelationships struct {
+	Relationships []xlsxRelationship `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX returns the records of each sheet within the workbook, indexed on sheet name.
+// Empty rows and the ones starting with # are skipped, as with the .csv files
+func readXLSX(fPath string) (sheets map[string][][]string, err error) {
+	var zr *zip.ReadCloser
+	if zr, err = zip.OpenReader(fPath); err != nil {
+		return
+	}
+	defer zr.Close()
+	var wb xlsxWorkbook
+	if err = decodeXLSXPart(zr, "xl/workbook.xml", &wb); err != nil {
+		return
+	}
+	var rels xlsxRelationships
+	if err = decodeXLSXPart(zr, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return
+	}
+	var sst xlsxSharedStrings
+	if err = decodeXLSXPart(zr, "xl/sharedStrings.xml", &sst); err != nil &&
+		err != utils.ErrNotFound { // missing when no text is shared
+		return
+	}
+	sheets = make(map[string][][]string)
+	for _, sheet := range wb.Sheets {
+		idx := slices.IndexFunc(rels.Relationships, func(rel xlsxRelationship) bool {
+			return rel.ID == sheet.RID
+		})
+		if idx == -1 {
+			return nil, fmt.Errorf("no target for sheet <%s>", sheet.Name)
+		}
+		target := rels.Relationships[idx].Target
+		if strings.HasPrefix(target, utils.Slash) {
+			target = target[1:]
+		} else {
+			target = "xl/" + target
+		}
+		var ws xlsxWorksheet
+		if err = decodeXLSXPart(zr, target, &ws); err != nil {
+			return
+		}
+		var records [][]string
+		for _, row := range ws.Rows {
+			var record []string
+			for i, cell := range row.Cells {
+				col := i
+				if cell.Ref != utils.EmptyString {
+					col = xlsxColumn(cell.Ref)
+				}
+				var val string
+				switch cell.Type {
+				case "s":
+					var sIdx int
+					if sIdx, err = strconv.Atoi(cell.Value); err != nil {
+						return
+					}
+					if sIdx < 0 || sIdx >= len(sst.Items) {
+						return nil, fmt.Errorf("invalid shared string index <%d> in sheet <%s>", sIdx, sheet.Name)
+					}
+					val = sst.Items[sIdx].String()
+				case "inlineStr":
+					val = cell.Inline.String()
+				case "b":
+					val = strconv.FormatBool(cell.Value == "1")
+				default:
+					val = cell.Value
+				}
+				for len(record) <= col {
+					record = append(record, utils.EmptyString)
+				}
+				record[col] = val
+			}
+			if len(record) == 0 ||
+				strings.HasPrefix(record[0], utils.HashtagSep) ||
+				!slices.ContainsFunc(record, func(val string) bool { return val != utils.EmptyString }) {
+				continue
+			}
+			records = append(records, record)
+		}
+		sheets[sheet.Name] = records
+	}
+	return sheets, nil
+}
+
+// decodeXLSXPart decodes one of the XML parts of the workbook, returning ErrNotFound if missing
+func decodeXLSXPart(zr *zip.ReadCloser, name string, v any) (err error) {
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		var rdr io.ReadCloser
+		if rdr, err = f.Open(); err != nil {
+			return
+		}
+		defer rdr.Close()
+		return xml.NewDecoder(rdr).Decode(v)
+	}
+	return utils.ErrNotFound
+}
+
+// xlsxColumn returns the zero based column out of the cell reference (ie: AB12)
+func xlsxColumn(ref string) (col int) {
+	for _, c := range ref {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		col = col*26 + int(c-'A') + 1
+	}
+	return col - 1
+}
+
+// pulledObject keeps the validators of an object pulled, so it is not pulled again while unchanged
+type pulledObject struct {
+	etag         string
+	lastModified string
+}
+
+// handlePull pulls the files out of pullURL at each pullInterval.
+// The files pulled are processed right away if the loader is not running on its own
+func (ldr *Loader) handlePull(stopChan chan struct{}) {
+	for {
+		if nPulled, err := ldr.pull(); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s-%s> cannot pull files from <%s>, err: %s",
+				utils.LoaderS, ldr.ldrID, ldr.redactedPullURL(), err.Error()))
+		} else if nPulled != 0 && ldr.runDelay == 0 {
+			ldr.ProcessFolder(config.CgrConfig().GeneralCfg().DefaultCaching, utils.MetaStore, false)
+		}
+		timer := time.NewTimer(ldr.pullInterval)
+		select {
+		case <-stopChan:
+			utils.Logger.Info(
+				fmt.Sprintf("<%s-%s> stop pulling from <%s>",
+					utils.LoaderS, ldr.ldrID, ldr.redactedPullURL()))
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// redactedPullURL returns the pullURL without the password, to be logged
+func (ldr *Loader) redactedPullURL() string {
+	u, err := url.Parse(ldr.pullURL)
+	if err != nil {
+		return utils.EmptyString
+	}
+	return u.Redacted()
+}
+
+// pull downloads the loader files changed since the last pull out of pullURL
+// into tpInDir, returning the number of files downloaded
+func (ldr *Loader) pull() (nPulled int, err error) {
+	var u *url.URL
+	if u, err = url.Parse(ldr.pullURL); err != nil {
+		return
+	}
+	if u.Scheme != utils.SchemeS3 {
+		return ldr.pullHTTP(u)
+	}
+	// the credentials are taken out of the default AWS chain (ie: environment, shared files or instance role)
+	cfg := aws.Config{}
+	qry := u.Query()
+	if endpoint := qry.Get("endpoint"); endpoint != utils.EmptyString {
+		cfg.Endpoint = aws.String(endpoint)
+		cfg.S3ForcePathStyle = aws.Bool(true)
+	}
+	if region := qry.Get("region"); region != utils.EmptyString {
+		cfg.Region = aws.String(region)
+	}
+	var sess *session.Session
+	if sess, err = session.NewSessionWithOptions(session.Options{Config: cfg}); err != nil {
+		return
+	}
+	return ldr.pullS3(s3.New(sess), u.Host, strings.TrimPrefix(u.Path, utils.Slash))
+}
+
+// fileNames returns the names of the files expected by the loader
+func (ldr *Loader) fileNames() (fNames []string) {
+	for _, rdrs := range ldr.rdrs {
+		for fName := range rdrs {
+			fNames = append(fNames, fName)
+		}
+	}
+	slices.Sort(fNames)
+	return
+}
+
+// pullHTTP downloads either the workbook pointed by the URL or each of the loader files found under it,
+// the ones pulled before being requested only if changed since
+func (ldr *Loader) pullHTTP(u *url.URL) (nPulled int, err error) {
+	client := &http.Client{Timeout: config.CgrConfig().GeneralCfg().ReplyTimeout}
+	fURLs := map[string]*url.URL{path.Base(u.Path): u}
+	if strings.ToLower(path.Ext(u.Path)) != utils.XLSXSuffix {
+		fURLs = make(map[string]*url.URL)
+		for _, fName := range ldr.fileNames() {
+			fURLs[fName] = u.JoinPath(fName)
+		}
+	}
+	for fName, fURL := range fURLs {
+		var req *http.Request
+		if req, err = http.NewRequest(http.MethodGet, fURL.String(), nil); err != nil {
+			return
+		}
+		if obj, has := ldr.pulled[fURL.String()]; has {
+			if obj.etag != utils.EmptyString {
+				req.Header.Set("If-None-Match", obj.etag)
+			}
+			if obj.lastModified != utils.EmptyString {
+				req.Header.Set("If-Modified-Since", obj.lastModified)
+			}
+		}
+		var resp *http.Response
+		if resp, err = client.Do(req); err != nil {
+			return
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			if err = ldr.storeFile(fName, resp.Body); err == nil {
+				ldr.pulled[fURL.String()] = &pulledObject{
+					etag:         resp.Header.Get("ETag"),
+					lastModified: resp.Header.Get("Last-Modified"),
+				}
+				nPulled++
+			}
+		case http.StatusNotModified: // unchanged since the last pull
+		case http.StatusNotFound: // file not provided by the source
+			delete(ldr.pulled, fURL.String())
+		default:
+			err = fmt.Errorf("unexpected status <%s> for <%s>", resp.Status, fURL.Redacted())
+		}
+		resp.Body.Close()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+type s3Client interface {
+	ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error
+	GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
+}
+
+// pullS3 downloads the loader files and the workbooks found under the prefix, leaving the objects in place.
+// The objects pulled before are downloaded again only if their ETag changed
+func (ldr *Loader) pullS3(scv s3Client, bucket, prefix string) (nPulled int, err error) {
+	fNames := utils.NewStringSet(ldr.fileNames())
+	etags := make(map[string]string) // ETags of the objects to download, indexed on key
+	if err = scv.ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: aws.String(bucket), Prefix: aws.String(prefix)},
+		func(lovo *s3.ListObjectsV2Output, _ bool) bool {
+			for _, objMeta := range lovo.Contents {
+				if objMeta.Key == nil {
+					continue
+				}
+				if fName := path.Base(*objMeta.Key); fNames.Has(fName) ||
+					strings.ToLower(path.Ext(fName)) == utils.XLSXSuffix {
+					etags[*objMeta.Key] = aws.StringValue(objMeta.ETag)
+				}
+			}
+			return true
+		}); err != nil {
+		return
+	}
+	for key, etag := range etags {
+		objURL := utils.SchemeS3 + "://" + bucket + utils.Slash + key
+		if obj, has := ldr.pulled[objURL]; has && etag != utils.EmptyString && obj.etag == etag {
+			continue // unchanged since the last pull
+		}
+		var obj *s3.GetObjectOutput
+		if obj, err = scv.GetObject(&s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)}); err != nil {
+			return
+		}
+		err = ldr.storeFile(path.Base(key), obj.Body)
+		obj.Body.Close()
+		if err != nil {
+			return
+		}
+		ldr.pulled[objURL] = &pulledObject{etag: etag}
+		nPulled++
+	}
+	return
+}
+
+// storeFile writes the file pulled into tpInDir, moving it in place only once complete
+func (ldr *Loader) storeFile(fName string, rdr io.Reader) (err error) {
+	var f *os.File
+	if f, err = os.CreateTemp(ldr.tpInDir, "."+fName+".*.part"); err != nil {
+		return
+	}
+	if _, err = io.Copy(f, rdr); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(f.Name())
+		return
+	}
+	return os.Rename(f.Name(), path.Join(ldr.tpInDir, fName))
+}
@@ -47,9 +47,11 @@ func NewMigratorDataDB(db_type, host, port, name, user, pass,
 		db = newMongoMigrator(dm)
 	case utils.MetaInternal:
 		db = newInternalMigrator(dm)
+	case utils.MetaSQLite:
+		db = newSQLiteMigrator(dm)
 	default:
-		err = fmt.Errorf("unknown db '%s' valid options are '%s' or '%s or '%s'",
-			db_type, utils.MetaRedis, utils.MetaMongo, utils.MetaInternal)
+		err = fmt.Errorf("unknown db '%s' valid options are '%s' or '%s or '%s' or '%s'",
+			db_type, utils.MetaRedis, utils.MetaMongo, utils.MetaInternal, utils.MetaSQLite)
 	}
 	return
 }
@@ -67,13 +69,13 @@ func NewMigratorStorDB(db_type, host, port, name, user, pass, marshaler string,
 		db = newMongoStorDBMigrator(storDb)
 	case utils.MetaMySQL:
 		db = newMigratorSQL(storDb)
-	case utils.MetaPostgres:
+	case utils.MetaPostgres, utils.MetaSQLite:
 		db = newMigratorSQL(storDb)
 	case utils.MetaInternal:
 		db = newInternalStorDBMigrator(storDb)
 	default:
-		err = fmt.Errorf("Unknown db '%s' valid options are [%s, %s, %s, %s]",
-			db_type, utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres, utils.MetaInternal)
+		err = fmt.Errorf("Unknown db '%s' valid options are [%s, %s, %s, %s, %s]",
+			db_type, utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres, utils.MetaInternal, utils.MetaSQLite)
 	}
 	return
 }
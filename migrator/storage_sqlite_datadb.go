@@ -0,0 +1,42 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package migrator
+
+import (
+	"github.com/cgrates/cgrates/engine"
+)
+
+// sqliteMigrator migrates the *sqlite DataDB. The backend never stored
+// the legacy (v1/v2) items so, same as for *internal, their getters
+// return utils.ErrNotImplemented
+type sqliteMigrator struct {
+	*internalMigrator
+	sqliteDB *engine.SQLiteDataDB
+}
+
+func newSQLiteMigrator(dm *engine.DataManager) (sqliteMig *sqliteMigrator) {
+	return &sqliteMigrator{
+		internalMigrator: &internalMigrator{dm: dm},
+		sqliteDB:         dm.DataDB().(*engine.SQLiteDataDB),
+	}
+}
+
+func (sqliteMig *sqliteMigrator) close() {
+	sqliteMig.sqliteDB.Close()
+}
@@ -72,7 +72,7 @@ func TestAsteriskAgentReload(t *testing.T) {
 		shdChan, cm, anz, srvDep)
 	astService := NewAsteriskAgent(cfg, shdChan, cm, srvDep)
 	srvMngr.AddServices(astService, sS,
-		NewLoaderService(cfg, db, filterSChan, server, make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db)
+		NewLoaderService(cfg, db, nil, filterSChan, server, make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db)
 	if err := srvMngr.StartServices(); err != nil {
 		t.Fatal(err)
 	}
@@ -145,7 +145,7 @@ func TestAsteriskAgentReload2(t *testing.T) {
 		shdChan, cm, anz, srvDep)
 	astSrv := NewAsteriskAgent(cfg, shdChan, cm, srvDep)
 	srvMngr.AddServices(astSrv, sS,
-		NewLoaderService(cfg, db, filterSChan, server, make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db)
+		NewLoaderService(cfg, db, nil, filterSChan, server, make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db)
 	if err := srvMngr.StartServices(); err != nil {
 		t.Fatal(err)
 	}
@@ -59,7 +59,7 @@ func TestChargerSReload(t *testing.T) {
 	chrS := NewChargerService(cfg, db, chS, filterSChan, server, make(chan birpc.ClientConnector, 1), nil, anz, srvDep)
 	engine.NewConnManager(cfg, nil)
 	srvMngr.AddServices(attrS, chrS,
-		NewLoaderService(cfg, db, filterSChan, server,
+		NewLoaderService(cfg, db, nil, filterSChan, server,
 			make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db)
 	if err := srvMngr.StartServices(); err != nil {
 		t.Error(err)
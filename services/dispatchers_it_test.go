@@ -61,7 +61,7 @@ func TestDispatcherSReload(t *testing.T) {
 		make(chan birpc.ClientConnector, 1), nil, anz, srvDep)
 	engine.NewConnManager(cfg, nil)
 	srvMngr.AddServices(attrS, srv,
-		NewLoaderService(cfg, db, filterSChan, server,
+		NewLoaderService(cfg, db, nil, filterSChan, server,
 			make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db)
 	if err := srvMngr.StartServices(); err != nil {
 		t.Error(err)
@@ -69,7 +69,7 @@ func TestEventExporterSReload(t *testing.T) {
 	ees := NewEventExporterService(cfg, filterSChan, engine.NewConnManager(cfg, nil),
 		server, make(chan birpc.ClientConnector, 2), anz, srvDep)
 	srvMngr.AddServices(ees, attrS,
-		NewLoaderService(cfg, db, filterSChan, server, make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db)
+		NewLoaderService(cfg, db, nil, filterSChan, server, make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db)
 	if err := srvMngr.StartServices(); err != nil {
 		t.Fatal(err)
 	}
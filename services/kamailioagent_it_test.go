@@ -77,7 +77,7 @@ func TestKamailioAgentReload(t *testing.T) {
 		shdChan, cm, anz, srvDep)
 	srv := NewKamailioAgent(cfg, shdChan, cm, srvDep)
 	srvMngr.AddServices(srv, sS,
-		NewLoaderService(cfg, db, filterSChan, server, make(chan birpc.ClientConnector, 1), cm, anz, srvDep), db)
+		NewLoaderService(cfg, db, nil, filterSChan, server, make(chan birpc.ClientConnector, 1), cm, anz, srvDep), db)
 	if err := srvMngr.StartServices(); err != nil {
 		t.Fatal(err)
 	}
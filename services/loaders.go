@@ -31,7 +31,7 @@ import (
 )
 
 // NewLoaderService returns the Loader Service
-func NewLoaderService(cfg *config.CGRConfig, dm *DataDBService,
+func NewLoaderService(cfg *config.CGRConfig, dm *DataDBService, storDB *StorDBService,
 	filterSChan chan *engine.FilterS, server *cores.Server,
 	internalLoaderSChan chan birpc.ClientConnector,
 	connMgr *engine.ConnManager, anz *AnalyzerService,
@@ -40,6 +40,7 @@ func NewLoaderService(cfg *config.CGRConfig, dm *DataDBService,
 		connChan:    internalLoaderSChan,
 		cfg:         cfg,
 		dm:          dm,
+		storDB:      storDB,
 		filterSChan: filterSChan,
 		server:      server,
 		connMgr:     connMgr,
@@ -54,6 +55,7 @@ type LoaderService struct {
 	sync.RWMutex
 	cfg         *config.CGRConfig
 	dm          *DataDBService
+	storDB      *StorDBService
 	filterSChan chan *engine.FilterS
 	server      *cores.Server
 	stopChan    chan struct{}
@@ -77,10 +79,16 @@ func (ldrs *LoaderService) Start() error {
 	datadb := <-dbchan
 	dbchan <- datadb
 
+	var storDBChan chan engine.StorDB
+	if ldrs.storDB != nil && ldrs.cfg.LoaderCfg().RatingTPsEnabled() { // the rating TPs are kept within StorDB
+		storDBChan = make(chan engine.StorDB, 1)
+		ldrs.storDB.RegisterSyncChan(storDBChan)
+	}
+
 	ldrs.Lock()
 	defer ldrs.Unlock()
 
-	ldrs.ldrs = loaders.NewLoaderService(datadb, ldrs.cfg.LoaderCfg(),
+	ldrs.ldrs = loaders.NewLoaderService(datadb, storDBChan, ldrs.cfg.LoaderCfg(),
 		ldrs.cfg.GeneralCfg().DefaultTimezone, ldrs.cfg.GeneralCfg().CachingDelay, filterS, ldrs.connMgr)
 
 	if !ldrs.ldrs.Enabled() {
@@ -79,7 +79,7 @@ func TestLoaderSReload(t *testing.T) {
 	db := NewDataDBService(cfg, nil, false, srvDep)
 	anz := NewAnalyzerService(cfg, server, filterSChan, shdChan, make(chan birpc.ClientConnector, 1), srvDep)
 	conMngr := engine.NewConnManager(cfg, nil)
-	srv := NewLoaderService(cfg, db, filterSChan,
+	srv := NewLoaderService(cfg, db, nil, filterSChan,
 		server, make(chan birpc.ClientConnector, 1),
 		conMngr, anz, srvDep)
 	srvMngr.AddServices(srv, db)
@@ -161,7 +161,7 @@ func TestLoaderSReload2(t *testing.T) {
 	db := NewDataDBService(cfg, nil, false, srvDep)
 	db.dbchan <- new(engine.DataManager)
 	anz := NewAnalyzerService(cfg, server, filterSChan, shdChan, make(chan birpc.ClientConnector, 1), srvDep)
-	srv := NewLoaderService(cfg, db, filterSChan,
+	srv := NewLoaderService(cfg, db, nil, filterSChan,
 		server, make(chan birpc.ClientConnector, 1),
 		nil, anz, srvDep)
 	err := srv.Start()
@@ -186,7 +186,7 @@ func TestLoaderSReload3(t *testing.T) {
 	db := NewDataDBService(cfg, nil, false, srvDep)
 	db.dbchan <- new(engine.DataManager)
 	anz := NewAnalyzerService(cfg, server, filterSChan, shdChan, make(chan birpc.ClientConnector, 1), srvDep)
-	srv := NewLoaderService(cfg, db, filterSChan,
+	srv := NewLoaderService(cfg, db, nil, filterSChan,
 		server, make(chan birpc.ClientConnector, 1),
 		nil, anz, srvDep)
 	err := srv.Start()
@@ -44,7 +44,7 @@ func TestLoaderSCoverage(t *testing.T) {
 	rpcInternal := map[string]chan birpc.ClientConnector{}
 	cM := engine.NewConnManager(cfg, rpcInternal)
 	anz := NewAnalyzerService(cfg, server, filterSChan, shdChan, make(chan birpc.ClientConnector, 1), srvDep)
-	srv := NewLoaderService(cfg, db,
+	srv := NewLoaderService(cfg, db, nil,
 		filterSChan, server, internalLoaderSChan,
 		cM, anz, srvDep)
 	if srv == nil {
@@ -53,7 +53,7 @@ func TestLoaderSCoverage(t *testing.T) {
 	if srv.IsRunning() {
 		t.Errorf("Expected service to be down")
 	}
-	srv.ldrs = loaders.NewLoaderService(&engine.DataManager{},
+	srv.ldrs = loaders.NewLoaderService(&engine.DataManager{}, nil,
 		[]*config.LoaderSCfg{{
 			ID:             "test_id",
 			Enabled:        true,
@@ -61,7 +61,7 @@ func TestDispatcherHReload(t *testing.T) {
 	connMngr := engine.NewConnManager(cfg, nil)
 	srv := NewRegistrarCService(cfg, server, connMngr, anz, srvDep)
 	srvMngr.AddServices(srv,
-		NewLoaderService(cfg, db, filterSChan, server,
+		NewLoaderService(cfg, db, nil, filterSChan, server,
 			make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db)
 	if err := srvMngr.StartServices(); err != nil {
 		t.Fatal(err)
@@ -110,7 +110,8 @@ func (db *StorDBService) Reload() (err error) {
 		}
 		mgo.SetTTL(db.cfg.StorDbCfg().Opts.MongoQueryTimeout)
 	} else if db.cfg.StorDbCfg().Type == utils.MetaPostgres ||
-		db.cfg.StorDbCfg().Type == utils.MetaMySQL {
+		db.cfg.StorDbCfg().Type == utils.MetaMySQL ||
+		db.cfg.StorDbCfg().Type == utils.MetaSQLite {
 		msql, canCast := db.db.(*engine.SQLStorage)
 		if !canCast {
 			return fmt.Errorf("can't conver StorDB of type %s to SQLStorage",
@@ -163,7 +164,8 @@ func (db *StorDBService) ServiceName() string {
 
 // ShouldRun returns if the service should be running
 func (db *StorDBService) ShouldRun() bool {
-	return db.cfg.RalsCfg().Enabled || db.cfg.CdrsCfg().Enabled || db.cfg.ApierCfg().Enabled
+	return db.cfg.RalsCfg().Enabled || db.cfg.CdrsCfg().Enabled || db.cfg.ApierCfg().Enabled ||
+		db.cfg.LoaderCfg().RatingTPsEnabled()
 }
 
 // RegisterSyncChan used by dependent subsystems to register a chanel to reload only the storDB(thread safe)
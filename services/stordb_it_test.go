@@ -62,7 +62,7 @@ func TestStorDBReload(t *testing.T) {
 	cdrS := NewCDRServer(cfg, db, stordb, filterSChan, server,
 		cdrsRPC, nil, anz, srvDep)
 	srvMngr.AddServices(cdrS, ralS, schS, chrS,
-		NewLoaderService(cfg, db, filterSChan, server,
+		NewLoaderService(cfg, db, nil, filterSChan, server,
 			make(chan birpc.ClientConnector, 1), nil, anz, srvDep), db, stordb)
 	if err := engine.InitStorDb(cfg); err != nil {
 		t.Fatal(err)
@@ -1623,11 +1623,22 @@ func (sS *SessionS) initSession(cgrEv *utils.CGREvent, clntConnID,
 		s.Lock() // avoid endsession before initialising
 		sS.initSessionDebitLoops(s)
 		sS.registerSession(s, false)
+		sS.publishSession(utils.MetaSessionInit, s)
 		s.Unlock()
 	}
 	return
 }
 
+// publishSession sends the session event to the live feed, the session needs to be locked
+func (sS *SessionS) publishSession(evType string, s *Session) {
+	engine.PublishLiveEvent(evType, &utils.CGREvent{
+		Tenant:  s.Tenant,
+		ID:      s.CGRID,
+		Event:   s.EventStart,
+		APIOpts: s.OptsStart,
+	})
+}
+
 // updateSession will reset terminator, perform debits and replicate sessions
 func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMsg bool) (maxUsage map[string]time.Duration, err error) {
 	if !isMsg {
@@ -1644,6 +1655,10 @@ func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMs
 		}
 		s.updateSRuns(updtEv, sS.cgrCfg.SessionSCfg().AlterableFields)
 		sS.setSTerminator(s, opts) // reset the terminator
+		// the session init is published by initSession
+		if updtEv != nil {
+			sS.publishSession(utils.MetaSessionUpdate, s)
+		}
 	}
 	s.Chargeable = opts.GetBoolOrDefault(utils.OptsChargeable, true)
 	s.UpdatedAt = time.Now()
@@ -1798,6 +1813,9 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 		sS.removeSsCGRIDs.Add(s.CGRID)
 		sS.removeSsCGRIDsMux.Unlock()
 	}
+	if !isMsg {
+		sS.publishSession(utils.MetaSessionTerminate, s)
+	}
 	return
 }
 
@@ -1116,6 +1116,8 @@ type TPRoute struct {
 	RatingPlanIDs   []string // used when computing price
 	ResourceIDs     []string // queried in some strategies
 	StatIDs         []string // queried in some strategies
+	RankingIDs      []string // queried by the *rank strategy
+	TrendIDs        []string // queried by the *trend strategy
 	Weight          float64
 	Blocker         bool
 	RouteParameters string
@@ -34,7 +34,7 @@ var (
 	extraDBPartition = NewStringSet([]string{CacheDispatchers,
 		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRadiusPackets, CacheRPCResponses, CacheClosedSessions,
 		CacheCDRIDs, CacheRPCConnections, CacheUCH, CacheSTIR, CacheEventCharges, MetaAPIBan, MetaSentryPeer,
-		CacheRatingProfilesTmp, CacheCapsEvents, CacheReplicationHosts})
+		CacheRatingProfilesTmp, CacheCapsEvents, CacheReplicationHosts, CacheSIPRegistrations})
 
 	DataDBPartitions = NewStringSet([]string{CacheDestinations, CacheReverseDestinations, CacheRatingPlans,
 		CacheRatingProfiles, CacheDispatcherProfiles, CacheDispatcherHosts, CacheChargerProfiles, CacheActions, CacheActionTriggers, CacheSharedGroups, CacheTimings,
@@ -216,6 +216,7 @@ const (
 	Tenant                   = "Tenant"
 	Category                 = "Category"
 	Contexts                 = "Contexts"
+	Tag                      = "Tag"
 	AccountField             = "Account"
 	BalancesFld              = "Balances"
 	Subject                  = "Subject"
@@ -255,6 +256,7 @@ const (
 	MetaMongo               = "*mongo"
 	MetaRedis               = "*redis"
 	MetaPostgres            = "*postgres"
+	MetaSQLite              = "*sqlite"
 	MetaInternal            = "*internal"
 	MetaLocalHost           = "*localhost"
 	MetaBiJSONLocalHost     = "*bijson_localhost"
@@ -377,6 +379,7 @@ const (
 	XML                      = "xml"
 	MetaGOB                  = "*gob"
 	MetaJSON                 = "*json"
+	MetaGRPC                 = "*grpc"
 	MetaMSGPACK              = "*msgpack"
 	MetaDateTime             = "*datetime"
 	MetaMaskedDestination    = "*masked_destination"
@@ -423,10 +426,13 @@ const (
 	MetaDC                   = "*dc"
 	MetaCaches               = "*caches"
 	MetaUCH                  = "*uch"
+	MetaSIPReg               = "*sipreg"
 	MetaGuardian             = "*guardians"
 	MetaEEs                  = "*ees"
 	MetaERs                  = "*ers"
 	MetaContinue             = "*continue"
+	MetaPeerRequest          = "*peer_request"
+	MetaPeerAnswer           = "*peer_answer"
 	Migrator                 = "migrator"
 	UnsupportedMigrationTask = "unsupported migration task"
 	NoStorDBConnection       = "not connected to StorDB"
@@ -437,6 +443,12 @@ const (
 	XMLSuffix                = ".xml"
 	CSVSuffix                = ".csv"
 	FWVSuffix                = ".fwv"
+	ParquetSuffix            = ".parquet"
+	AvroSuffix               = ".avro"
+	XLSXSuffix               = ".xlsx"
+	SchemeHTTP               = "http"
+	SchemeHTTPS              = "https"
+	SchemeS3                 = "s3"
 	ContentJSON              = "json"
 	ContentForm              = "form"
 	FileLockPrefix           = "file_"
@@ -446,7 +458,11 @@ const (
 	MetaVirt                 = "*virt"
 	MetaElastic              = "*els"
 	MetaFileFWV              = "*file_fwv"
+	MetaFileParquet          = "*file_parquet"
+	MetaFileAvro             = "*file_avro"
+	MetaAvro                 = "*avro"
 	MetaFile                 = "*file"
+	MetaOTLP                 = "*otlp"
 	Accounts                 = "Accounts"
 	AccountService           = "AccountS"
 	AccountS                 = "AccountS"
@@ -554,8 +570,11 @@ const (
 	ReverseDestinations      = "ReverseDestinations"
 	RatingPlan               = "RatingPlan"
 	RatingProfile            = "RatingProfile"
+	MetaRates                = "*rates"
+	MetaDestinationRates     = "*destination_rates"
 	MetaRatingPlans          = "*rating_plans"
 	MetaRatingProfiles       = "*rating_profiles"
+	MetaAccountActions       = "*account_actions"
 	MetaUsers                = "*users"
 	MetaSubscribers          = "*subscribers"
 	MetaDerivedChargersV     = "*derivedchargers"
@@ -567,6 +586,9 @@ const (
 	MetaQOS                  = "*qos"
 	MetaReas                 = "*reas"
 	MetaReds                 = "*reds"
+	MetaRank                 = "*rank"
+	MetaTrend                = "*trend"
+	MetaScore                = "*score"
 	Weight                   = "Weight"
 	Limit                    = "Limit"
 	UsageTTL                 = "UsageTTL"
@@ -627,6 +649,7 @@ const (
 	MetaCgrep                = "*cgrep"
 	CgrAcd                   = "cgr_acd"
 	ActivationIntervalString = "ActivationInterval"
+	Hits                     = "Hits"
 	MaxHits                  = "MaxHits"
 	MinHits                  = "MinHits"
 	Async                    = "Async"
@@ -635,6 +658,8 @@ const (
 	RouteAccountIDs          = "RouteAccountIDs"
 	RouteRatingplanIDs       = "RouteRatingplanIDs"
 	RouteStatIDs             = "RouteStatIDs"
+	RouteRankingIDs          = "RouteRankingIDs"
+	RouteTrendIDs            = "RouteTrendIDs"
 	RouteWeight              = "RouteWeight"
 	RouteParameters          = "RouteParameters"
 	RouteBlocker             = "RouteBlocker"
@@ -730,6 +755,10 @@ const (
 	Local                   = "local"
 	TCP                     = "tcp"
 	UDP                     = "udp"
+	SCTP                    = "sctp"
+	WS                      = "ws"
+	WSS                     = "wss"
+	SIPWsSubProto           = "sip"
 	VersionName             = "Version"
 	MetaTenant              = "*tenant"
 	ResourceUsage           = "ResourceUsage"
@@ -756,6 +785,10 @@ const (
 	MetaRemove              = "*remove"
 	MetaRemoveAll           = "*removeall"
 	MetaStore               = "*store"
+	MetaCreated             = "*created"
+	MetaUpdated             = "*updated"
+	MetaUnchanged           = "*unchanged"
+	MetaRemoved             = "*removed"
 	MetaClear               = "*clear"
 	MetaExport              = "*export"
 	MetaExporterID          = "*exporterID"
@@ -791,6 +824,8 @@ const (
 	EEs                     = "EEs"
 	Ratio                   = "Ratio"
 	Load                    = "Load"
+	Rank                    = "Rank"
+	Score                   = "Score"
 	Slash                   = "/"
 	UUID                    = "UUID"
 	Uuid                    = "Uuid"
@@ -857,6 +892,7 @@ const (
 	MetaMonthEnd            = "*month_end"
 	APIKey                  = "ApiKey"
 	RouteID                 = "RouteID"
+	RouteProfileID          = "RouteProfileID"
 	MetaMonthlyEstimated    = "*monthly_estimated"
 	MetaProcessedProfileIDs = "*processedProfileIDs"
 	MetaAttrPrfTenantID     = "*apTenantID"
@@ -900,6 +936,11 @@ const (
 	MetaTemplateID          = "*templateID"
 	MetaCdrLog              = "*cdrLog"
 	MetaCDR                 = "*cdr"
+	MetaSessionInit         = "*session_init"
+	MetaSessionUpdate       = "*session_update"
+	MetaSessionTerminate    = "*session_terminate"
+	MetaThresholdHit        = "*threshold_hit"
+	MetaAccountUpdate       = "*account_update"
 	MetaExporterIDs         = "*exporterIDs"
 	MetaAsync               = "*async"
 	MetaUsage               = "*usage"
@@ -972,20 +1013,21 @@ const (
 
 // CoreSv1.Status metrics
 const (
-	PID            = "pid"
-	NodeID         = "node_id"
-	GoVersion      = "go_version"
-	VersionLower   = "version"
-	Goroutines     = "goroutines"
-	OSThreadsInUse = "os_threads_in_use"
-	CAPSAllocated  = "caps_allocated"
-	CAPSPeak       = "caps_peak"
-	RunningSince   = "running_since"
-	OpenFiles      = "open_files"
-	CPUTime        = "cpu_time"
-	ActiveMemory   = "active_memory"
-	SystemMemory   = "system_memory"
-	ResidentMemory = "resident_memory"
+	PID             = "pid"
+	NodeID          = "node_id"
+	GoVersion       = "go_version"
+	VersionLower    = "version"
+	Goroutines      = "goroutines"
+	OSThreadsInUse  = "os_threads_in_use"
+	CAPSAllocated   = "caps_allocated"
+	CAPSPeak        = "caps_peak"
+	CAPSRateLimited = "caps_rate_limited"
+	RunningSince    = "running_since"
+	OpenFiles       = "open_files"
+	CPUTime         = "cpu_time"
+	ActiveMemory    = "active_memory"
+	SystemMemory    = "system_memory"
+	ResidentMemory  = "resident_memory"
 )
 
 // Migrator Action
@@ -1027,19 +1069,27 @@ const (
 
 // MetaMetrics
 const (
-	MetaASR      = "*asr"
-	MetaACD      = "*acd"
-	MetaTCD      = "*tcd"
-	MetaACC      = "*acc"
-	MetaTCC      = "*tcc"
-	MetaPDD      = "*pdd"
-	MetaDDC      = "*ddc"
-	MetaSum      = "*sum"
-	MetaAverage  = "*average"
-	MetaDistinct = "*distinct"
-	MetaRAR      = "*rar"
-	MetaDMR      = "*dmr"
-	MetaCoA      = "*coa"
+	MetaASR       = "*asr"
+	MetaACD       = "*acd"
+	MetaTCD       = "*tcd"
+	MetaACC       = "*acc"
+	MetaTCC       = "*tcc"
+	MetaPDD       = "*pdd"
+	MetaDDC       = "*ddc"
+	MetaSum       = "*sum"
+	MetaAverage   = "*average"
+	MetaDistinct  = "*distinct"
+	MetaP50       = "*p50"
+	MetaP90       = "*p90"
+	MetaP95       = "*p95"
+	MetaP99       = "*p99"
+	MetaHistogram = "*histogram"
+	MetaInf       = "*inf"
+	MetaStdDev    = "*stddev"
+	MetaRate      = "*rate"
+	MetaRAR       = "*rar"
+	MetaDMR       = "*dmr"
+	MetaCoA       = "*coa"
 )
 
 // Services
@@ -1419,6 +1469,8 @@ const (
 	APIerSv1SetStorDBVersions                 = "APIerSv1.SetStorDBVersions"
 	APIerSv1GetAccountActionPlan              = "APIerSv1.GetAccountActionPlan"
 	APIerSv1ComputeActionPlanIndexes          = "APIerSv1.ComputeActionPlanIndexes"
+	APIerSv1SnapshotDataDB                    = "APIerSv1.SnapshotDataDB"
+	APIerSv1SnapshotStorDB                    = "APIerSv1.SnapshotStorDB"
 	APIerSv1GetActions                        = "APIerSv1.GetActions"
 	APIerSv1GetActionPlan                     = "APIerSv1.GetActionPlan"
 	APIerSv1GetActionPlanIDs                  = "APIerSv1.GetActionPlanIDs"
@@ -1647,6 +1699,7 @@ const (
 	RouteSv1GetRoutes                = "RouteSv1.GetRoutes"
 	RouteSv1GetRoutesList            = "RouteSv1.GetRoutesList"
 	RouteSv1GetRouteProfilesForEvent = "RouteSv1.GetRouteProfilesForEvent"
+	RouteSv1SimulateRoutes           = "RouteSv1.SimulateRoutes"
 	RouteSv1Ping                     = "RouteSv1.Ping"
 	APIerSv1GetRouteProfile          = "APIerSv1.GetRouteProfile"
 	APIerSv1GetRouteProfileIDs       = "APIerSv1.GetRouteProfileIDs"
@@ -1729,6 +1782,7 @@ const (
 	APIerSv1GetRankingProfile    = "APIerSv1.GetRankingProfile"
 	APIerSv1GetRankingProfileIDs = "APIerSv1.GetRankingProfileIDs"
 	RankingSv1Ping               = "RankingSv1.Ping"
+	RankingSv1GetRankingSummary  = "RankingSv1.GetRankingSummary"
 )
 
 // ResourceS APIs
@@ -1838,6 +1892,7 @@ const (
 	LoaderSv1       = "LoaderSv1"
 	LoaderSv1Load   = "LoaderSv1.Load"
 	LoaderSv1Remove = "LoaderSv1.Remove"
+	LoaderSv1DryRun = "LoaderSv1.DryRun"
 	LoaderSv1Ping   = "LoaderSv1.Ping"
 )
 
@@ -2045,6 +2100,7 @@ const (
 	CacheCapsEvents              = "*caps_events"
 	CacheSessionsBackup          = "*sessions_backup"
 	CacheReplicationHosts        = "*replication_hosts"
+	CacheSIPRegistrations        = "*sip_registrations"
 
 	// storDB
 	CacheTBLTPTimings          = "*tp_timings"
@@ -2168,6 +2224,10 @@ const (
 	OptsCfg                = "opts"
 	Tenants                = "tenants"
 	MysqlLocation          = "mysqlLocation"
+
+	InternalDBDumpPathCfg         = "internalDBDumpPath"
+	InternalDBFsyncIntervalCfg    = "internalDBFsyncInterval"
+	InternalDBSnapshotIntervalCfg = "internalDBSnapshotInterval"
 )
 
 // DataDbCfg
@@ -2228,6 +2288,7 @@ const (
 	RPCJSONTLSListenCfg = "rpc_json_tls"
 	RPCGOBTLSListenCfg  = "rpc_gob_tls"
 	HTTPTLSListenCfg    = "http_tls"
+	GRPCListenCfg       = "grpc"
 )
 
 // HTTPCfg
@@ -2235,6 +2296,8 @@ const (
 	HTTPJsonRPCURLCfg        = "json_rpc_url"
 	RegistrarSURLCfg         = "registrars_url"
 	PrometheusURLCfg         = "prometheus_url"
+	LiveFeedURLCfg           = "live_feed_url"
+	WSLiveFeedURLCfg         = "ws_live_feed_url"
 	HTTPWSURLCfg             = "ws_url"
 	HTTPFreeswitchCDRsURLCfg = "freeswitch_cdrs_url"
 	HTTPCDRsURLCfg           = "http_cdrs"
@@ -2242,8 +2305,14 @@ const (
 	HTTPUseBasicAuthCfg      = "use_basic_auth"
 	HTTPAuthUsersCfg         = "auth_users"
 	HTTPClientOptsCfg        = "client_opts"
+	PrometheusOptsCfg        = "prometheus_opts"
 	ConfigsURL               = "configs_url"
 
+	PrometheusStatFiltersCfg      = "stat_filters"
+	PrometheusThresholdFiltersCfg = "threshold_filters"
+	PrometheusTrendFiltersCfg     = "trend_filters"
+	PrometheusSessionFiltersCfg   = "session_filters"
+
 	HTTPClientTLSClientConfigCfg       = "skipTlsVerify"
 	HTTPClientTLSHandshakeTimeoutCfg   = "tlsHandshakeTimeout"
 	HTTPClientDisableKeepAlivesCfg     = "disableKeepAlives"
@@ -2266,6 +2335,7 @@ const (
 	ResourceSConnsCfg = "resources_conns"
 	ApierSConnsCfg    = "apiers_conns"
 	TrendSConnsCfg    = "trends_conns"
+	RankingSConnsCfg  = "rankings_conns"
 )
 
 // RalsCfg
@@ -2385,10 +2455,26 @@ const (
 	ASRTemplateCfg       = "asr_template"
 	RARTemplateCfg       = "rar_template"
 	ForcedDisconnectCfg  = "forced_disconnect"
+	AllowedPeersCfg      = "allowed_peers"
+	PeersCfg             = "peers"
+	WatchdogIntervalCfg  = "watchdog_interval"
+	ReconnectIntervalCfg = "reconnect_interval"
+	AuthAppIDsCfg        = "auth_application_ids"
+	AcctAppIDsCfg        = "acct_application_ids"
+	RoutesCfg            = "routes"
+	RealmCfg             = "realm"
+	AppIDsCfg            = "application_ids"
+	RequestProcessorCfg  = "request_processor"
+	WeightCfg            = "weight"
 	TemplatesCfg         = "templates"
 	RequestProcessorsCfg = "request_processors"
 
 	JanusConnsCfg = "janus_conns"
+
+	// SIPAgentCfg
+	RegistrarCfg  = "registrar"
+	MaxExpiresCfg = "max_expires"
+
 	// RequestProcessor
 	RequestFieldsCfg = "request_fields"
 	ReplyFieldsCfg   = "reply_fields"
@@ -2439,9 +2525,12 @@ const (
 	LockFilePathCfg = "lockfile_path"
 	TpInDirCfg      = "tp_in_dir"
 	TpOutDirCfg     = "tp_out_dir"
+	PullURLCfg      = "pull_url"
+	PullIntervalCfg = "pull_interval"
 	DataCfg         = "data"
 
 	DefaultRatioCfg           = "default_ratio"
+	RankingStaleAgeCfg        = "ranking_stale_age"
 	ReadersCfg                = "readers"
 	ExportersCfg              = "exporters"
 	PoolSize                  = "poolSize"
@@ -2482,7 +2571,17 @@ const (
 	CapsCfg              = "caps"
 	CapsStrategyCfg      = "caps_strategy"
 	CapsStatsIntervalCfg = "caps_stats_interval"
+	CapsRateLimitsCfg    = "caps_rate_limits"
 	ShutdownTimeoutCfg   = "shutdown_timeout"
+	MethodsCfg           = "methods"
+	RateCfg              = "rate"
+	BurstCfg             = "burst"
+	TracingCfg           = "tracing"
+	ExporterCfg          = "exporter"
+	EndpointCfg          = "endpoint"
+	InsecureCfg          = "insecure"
+	FilePathCfg          = "file_path"
+	SamplerRatioCfg      = "sampler_ratio"
 
 	// AccountSCfg
 	MaxIterations = "max_iterations"
@@ -2656,6 +2755,10 @@ var (
 	MetaZeroLeft = "*zeroleft"
 )
 
+// LiveFeedEventTypes the events pushed to the live feed subscribers
+var LiveFeedEventTypes = NewStringSet([]string{MetaSessionInit, MetaSessionUpdate,
+	MetaSessionTerminate, MetaCDR, MetaThresholdHit, MetaAccountUpdate})
+
 // CGROptionsSet the possible cgr options
 var CGROptionsSet = NewStringSet([]string{OptsSessionsTTL,
 	OptsSessionsTTLMaxDelay, OptsSessionsTTLLastUsed, OptsSessionsTTLLastUsage, OptsSessionsTTLUsage,
@@ -2668,7 +2771,7 @@ var CGROptionsSet = NewStringSet([]string{OptsSessionsTTL,
 	OptsAttributesProfileIgnoreFilters, OptsStatsProfileIDs, OptsStatsProfileIgnoreFilters,
 	OptsThresholdsProfileIDs, OptsThresholdsProfileIgnoreFilters, OptsResourcesUsageID, OptsResourcesUsageTTL,
 	OptsResourcesUnits, OptsAttributeS, OptsThresholdS, OptsChargerS, OptsStatS, OptsRALs, OptsRerate,
-	OptsRefund})
+	OptsRefund, OptsTraceParent, OptsTraceState})
 
 // EventExporter metrics
 const (
@@ -2711,6 +2814,9 @@ const (
 	OptsAPIKey                   = "*apiKey"
 	OptsRouteID                  = "*routeID"
 	OptsDispatchersProfilesCount = "*dispatchersProfilesCount"
+	// Tracing, W3C trace context headers
+	OptsTraceParent = "*traceparent"
+	OptsTraceState  = "*tracestate"
 	// EEs
 	OptsEEsVerbose = "*eesVerbose"
 	// Resources
@@ -2811,6 +2917,10 @@ const (
 	CSVLazyQuotes       = "csvLazyQuotes"
 	HeaderDefineCharOpt = "csvHeaderDefineChar"
 
+	// fileParquet and fileAvro
+	ParquetCompressionOpt = "parquetCompression"
+	AvroCodecOpt          = "avroCodec"
+
 	// fileXML
 	XMLRootPathOpt = "xmlRootPath"
 
@@ -2831,13 +2941,16 @@ const (
 	KafkaDefaultGroupID = "cgrates"
 	KafkaDefaultMaxWait = time.Millisecond
 
-	KafkaTopic         = "kafkaTopic"
-	KafkaBatchSize     = "kafkaBatchSize"
-	KafkaTLS           = "kafkaTLS"
-	KafkaCAPath        = "kafkaCAPath"
-	KafkaSkipTLSVerify = "kafkaSkipTLSVerify"
-	KafkaGroupID       = "kafkaGroupID"
-	KafkaMaxWait       = "kafkaMaxWait"
+	KafkaTopic          = "kafkaTopic"
+	KafkaBatchSize      = "kafkaBatchSize"
+	KafkaTLS            = "kafkaTLS"
+	KafkaCAPath         = "kafkaCAPath"
+	KafkaSkipTLSVerify  = "kafkaSkipTLSVerify"
+	KafkaEncoding       = "kafkaEncoding"
+	KafkaSchemaRegistry = "kafkaSchemaRegistry"
+	KafkaSchemaSubject  = "kafkaSchemaSubject"
+	KafkaGroupID        = "kafkaGroupID"
+	KafkaMaxWait        = "kafkaMaxWait"
 
 	// partial
 	PartialOpt = "*partial"
@@ -78,6 +78,8 @@ var (
 	ErrServiceAlreadyRunning            = fmt.Errorf("service already running")
 	ErrMaxConcurrentRPCExceededNoCaps   = errors.New("max concurrent rpc exceeded") // on internal we return this error for concureq
 	ErrMaxConcurrentRPCExceeded         = errors.New("MAX_CONCURRENT_RPC_EXCEEDED") // but the codec will rewrite it with this one to be sure that we corectly dealocate the request
+	ErrRateLimitExceededNoCaps          = errors.New("rate limit exceeded")         // caps return this error for the requests over the rate limits
+	ErrRateLimitExceeded                = errors.New("RATE_LIMIT_EXCEEDED")         // the codec will rewrite it with this one so the same error coming from other conns still dealocates
 	ErrMaxIterationsReached             = errors.New("maximum iterations reached")
 	ErrNegative                         = errors.New("NEGATIVE")
 	ErrCastFailed                       = errors.New("CAST_FAILED")
@@ -125,6 +127,7 @@ var (
 		ErrIndexOutOfBounds.Error():                 ErrIndexOutOfBounds,
 		ErrWrongPath.Error():                        ErrWrongPath,
 		ErrDSPHostNotFound.Error():                  ErrDSPHostNotFound,
+		ErrRateLimitExceeded.Error():                ErrRateLimitExceeded,
 	}
 )
 
@@ -19,11 +19,15 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package utils
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 )
@@ -140,3 +144,45 @@ type serverResponse struct {
 	Result any              `json:"result"`
 	Error  any              `json:"error"`
 }
+
+// NewTLSConfig builds the TLS configuration of the listeners and of the clients out of the tls section.
+// The servers require the certificate and verify the clients against the CA based on the clientAuth
+// policy while the clients verify the servers against it, the system CAs being used when missing.
+// The certificates are loaded on each call so the renewed ones are picked up without a restart.
+func NewTLSConfig(crtPath, keyPath, caPath string, clientAuth int, serverName string,
+	isServer bool) (cfg *tls.Config, err error) {
+	cfg = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+	if isServer {
+		if crtPath == EmptyString {
+			return nil, errors.New("missing server certificate")
+		}
+		cfg.ClientAuth = tls.ClientAuthType(clientAuth)
+	}
+	if crtPath != EmptyString {
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(crtPath, keyPath); err != nil {
+			return nil, fmt.Errorf("load certificate error <%v>", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if caPath == EmptyString {
+		return
+	}
+	var ca []byte
+	if ca, err = os.ReadFile(caPath); err != nil {
+		return nil, fmt.Errorf("read CA error <%v>", err)
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(ca) {
+		return nil, errors.New("cannot append certificate authority")
+	}
+	if isServer {
+		cfg.ClientCAs = rootCAs
+	} else {
+		cfg.RootCAs = rootCAs
+	}
+	return
+}
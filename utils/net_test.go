@@ -20,8 +20,11 @@ package utils
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"net/http"
+	"os"
+	"path"
 	"reflect"
 	"strings"
 	"testing"
@@ -110,3 +113,31 @@ func TestWriteServerResponse(t *testing.T) {
 		t.Errorf("Expecting: <{\"id\":10,\"result\":\"OK\",\"error\":null}>, received: <%+v>", writer.String())
 	}
 }
+
+func TestNewTLSConfig(t *testing.T) {
+	if _, err := NewTLSConfig(EmptyString, EmptyString, EmptyString, 0, EmptyString, true); err == nil ||
+		err.Error() != "missing server certificate" {
+		t.Errorf("expected missing server certificate, received: %v", err)
+	}
+	if _, err := NewTLSConfig("/not/a/cert", "/not/a/key", EmptyString, 0, EmptyString, true); err == nil ||
+		!strings.HasPrefix(err.Error(), "load certificate error") {
+		t.Errorf("expected load certificate error, received: %v", err)
+	}
+	if _, err := NewTLSConfig(EmptyString, EmptyString, "/not/a/ca", 0, EmptyString, false); err == nil ||
+		!strings.HasPrefix(err.Error(), "read CA error") {
+		t.Errorf("expected read CA error, received: %v", err)
+	}
+	caPath := path.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caPath, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewTLSConfig(EmptyString, EmptyString, caPath, 0, EmptyString, false); err == nil ||
+		err.Error() != "cannot append certificate authority" {
+		t.Errorf("expected cannot append certificate authority, received: %v", err)
+	}
+	if cfg, err := NewTLSConfig(EmptyString, EmptyString, EmptyString, 0, "cgrates.org", false); err != nil {
+		t.Error(err)
+	} else if cfg.MinVersion != tls.VersionTLS12 || cfg.RootCAs != nil || len(cfg.Certificates) != 0 {
+		t.Errorf("expected the system CAs used by the client, received: %+v", cfg)
+	}
+}